@@ -10,6 +10,49 @@ object Auth : Comp({ namespace("ee.auth") }) {
             val last = propS()
         }
 
+        object ExternalIdentity : Basic() {
+            val issuer = propS()
+            val subject = propS()
+            val email = propS()
+            val linkedAt = propDT()
+        }
+
+        object ApiKey : Basic() {
+            val keyId = prop(n.UUID)
+            val name = propS()
+            val scopes = propListT(n.String)
+            val hash = propS().hidden()
+            val createdAt = propDT()
+            val expiresAt = propDT()
+            val lastUsedAt = propDT()
+        }
+
+        object Impersonation : Basic() {
+            val impersonationId = prop(n.UUID)
+            val actorId = prop(n.UUID)
+            val reason = propS()
+            val startedAt = propDT()
+            val expiresAt = propDT()
+        }
+
+        object Session : Basic() {
+            val sessionId = prop(n.UUID)
+            val device = propS()
+            val ip = propS()
+            val userAgent = propS()
+            val createdAt = propDT()
+            val lastSeenAt = propDT()
+        }
+
+        object RoleAssignment : Basic() {
+            val assignmentId = prop(n.UUID)
+            val role = propS()
+            val validFrom = propDT()
+            val validUntil = propDT()
+            val assignedBy = prop(n.UUID)
+            val grantRequestId = prop(n.UUID)
+        }
+
         object UserCredentials : Values() {
             val username = propS()
             val password = propS()
@@ -21,18 +64,95 @@ object Auth : Comp({ namespace("ee.auth") }) {
             val password = propS().hidden()
             val email = propS().unique()
             val roles = propListT(n.String)
+            val organizationId = prop(n.UUID)
+            val service = propB()
+            val breakGlass = propB()
+            val attributes = prop(n.Map)
 
             val sentDisabledConfirmation = propB().meta()
             val sentEnabledConfirmation = propB().meta()
             val disabled = propB().meta()
+            val disabledReason = propS().meta()
+            val enabledAt = propDT().meta()
+            val lastLoginAt = propDT().meta()
+            val sentInactivityWarning = propB().meta()
+            val forgotten = propB().meta()
+            val pendingEmail = propS().meta()
+            val emailChangeTokenHash = propS().meta().hidden()
+            val emailChangeExpiresAt = propDT().meta()
+            val identities = propListT(ExternalIdentity).meta()
+            val apiKeys = propListT(ApiKey).meta().hidden()
+            val impersonations = propListT(Impersonation).meta().hidden()
+            val sessions = propListT(Session).meta()
+            val roleAssignments = propListT(RoleAssignment).meta()
+            val pendingApproval = propB().meta()
+            val passwordChangedAt = propDT().meta()
+            val mustChangePassword = propB().meta()
+            val passwordHistory = propListT(n.String).meta().hidden()
+            val mergedInto = prop(n.UUID).meta()
+            val mergedAt = propDT().meta()
 
             val login = command(username, email, password)
-            val enable = updateBy(p(disabled) { value(false) })
-            val disable = updateBy(p(disabled) { value(true) })
+            val enable = updateBy(p(disabled) { value(false) }, p(disabledReason) { value("") })
+            val disable = updateBy(p(disabled) { value(true) }, disabledReason)
+            val warnInactivity = updateBy(p(sentInactivityWarning) { value(true) })
+            val accountInactivityWarned = event()
 
             val sendEnabledConfirmation = command()
             val sendDisabledConfirmation = command()
 
+            val linkIdentity = command(ExternalIdentity.issuer, ExternalIdentity.subject, ExternalIdentity.email)
+            val unlinkIdentity = command(ExternalIdentity.issuer, ExternalIdentity.subject)
+
+            val createApiKey = command(ApiKey.keyId, ApiKey.name, ApiKey.scopes, ApiKey.hash, ApiKey.expiresAt)
+            val revokeApiKey = command(ApiKey.keyId)
+            val useApiKey = command(ApiKey.keyId)
+
+            val impersonate = command(Impersonation.impersonationId, Impersonation.actorId, Impersonation.reason,
+                    Impersonation.expiresAt)
+            val endImpersonation = command(Impersonation.impersonationId, Impersonation.actorId)
+            val impersonationStarted = event(Impersonation.impersonationId, Impersonation.actorId,
+                    Impersonation.reason, Impersonation.expiresAt)
+            val impersonationEnded = event(Impersonation.impersonationId, Impersonation.actorId)
+
+            val startSession = command(Session.sessionId, Session.device, Session.ip, Session.userAgent)
+            val touchSession = command(Session.sessionId)
+            val revokeSession = command(Session.sessionId)
+            val revokeSessions = command()
+
+            val assignRole = command(RoleAssignment.assignmentId, RoleAssignment.role, RoleAssignment.validFrom,
+                    RoleAssignment.validUntil, RoleAssignment.assignedBy, RoleAssignment.grantRequestId)
+            val unassignRole = command(RoleAssignment.assignmentId)
+            val expireRole = command(RoleAssignment.assignmentId, RoleAssignment.role)
+            val accountRoleExpired = event(RoleAssignment.assignmentId, RoleAssignment.role)
+
+            val register = command(name, username, password, email, roles, organizationId, pendingApproval)
+            val accountRegistered = event(name, username, password, email, roles, organizationId, pendingApproval)
+            val approveRegistration = command(prop(n.UUID).name("approvedBy"))
+            val accountRegistrationApproved = event(prop(n.UUID).name("approvedBy"))
+            val rejectRegistration = command(prop(n.UUID).name("rejectedBy"), propS().name("reason"))
+            val accountRegistrationRejected = event(prop(n.UUID).name("rejectedBy"), propS().name("reason"))
+
+            val changePassword = command(propS().name("oldPassword"), password)
+            val accountPasswordChanged = event(password)
+
+            val restore = command()
+            val accountRestored = event()
+            val purge = command()
+            val accountPurged = event()
+            val forget = command()
+            val accountForgotten = event()
+            val requestEmailChange = command(pendingEmail, emailChangeTokenHash, emailChangeExpiresAt)
+            val accountEmailChangeRequested = event(pendingEmail, emailChangeTokenHash, emailChangeExpiresAt)
+            val confirmEmailChange = command(propS().name("token"), propS().name("email"))
+            val accountEmailChangeConfirmed = event(propS().name("email"))
+            val changeAttributes = updateBy(attributes)
+            val accountAttributesChanged = event(attributes)
+            val merge = command(prop(n.UUID).name("sourceId"), roles, roleAssignments, identities, apiKeys)
+            val accountMerged = event(prop(n.UUID).name("sourceId"), roles, roleAssignments, identities, apiKeys)
+            val mergeInto = command(prop(n.UUID).name("targetId"))
+            val accountMergedInto = event(prop(n.UUID).name("targetId"))
+
             object Handler : AggregateHandler({
                 defaultState(state {
                     name("Initial")
@@ -41,9 +161,39 @@ object Auth : Comp({ namespace("ee.auth") }) {
 
                     handle(eventOf(commandCreate())).ifTrue(disabled.yes()).to(Disabled)
                     handle(eventOf(commandCreate())).ifFalse(disabled.yes()).to(Enabled)
+
+                    execute(register).produce(accountRegistered)
+                    handle(accountRegistered).ifTrue(pendingApproval.yes()).to(PendingApproval)
+                    handle(accountRegistered).ifFalse(pendingApproval.yes()).to(Enabled)
                 })
             }) {
 
+                object PendingApproval : State({
+                    execute(approveRegistration).produce(accountRegistrationApproved)
+                    execute(rejectRegistration).produce(accountRegistrationRejected)
+
+                    handle(accountRegistrationApproved).to(Enabled)
+                    handle(accountRegistrationRejected).to(Purged)
+                })
+
+                object Deleted : State({
+                    execute(restore).produce(accountRestored)
+                    execute(purge).produce(accountPurged)
+                    execute(forget).produce(accountForgotten)
+
+                    handle(accountRestored).to(Disabled)
+                    handle(accountPurged).to(Purged)
+                    handle(accountForgotten)
+                })
+
+                object Purged : State()
+
+                object Merged : State({
+                    execute(forget).produce(accountForgotten)
+
+                    handle(accountForgotten)
+                })
+
                 object Exist : State({
                     virtual()
                     executeAndProduce(commandUpdate())
@@ -58,8 +208,32 @@ object Auth : Comp({ namespace("ee.auth") }) {
 
                     executeAndProduce(enable)
                     executeAndProduce(sendDisabledConfirmation)
+                    executeAndProduce(linkIdentity)
+                    executeAndProduce(unlinkIdentity)
+                    executeAndProduce(revokeApiKey)
+                    execute(endImpersonation).produce(impersonationEnded)
+                    executeAndProduce(revokeSession)
+                    executeAndProduce(revokeSessions)
+                    executeAndProduce(unassignRole)
+                    execute(expireRole).produce(accountRoleExpired)
+                    execute(forget).produce(accountForgotten)
+                    execute(changeAttributes).produce(accountAttributesChanged)
+                    execute(merge).produce(accountMerged)
+                    execute(mergeInto).produce(accountMergedInto)
 
                     handle(eventOf(enable)).to(Enabled).produce(sendEnabledConfirmation)
+                    handle(eventOf(linkIdentity))
+                    handle(eventOf(unlinkIdentity))
+                    handle(eventOf(revokeApiKey))
+                    handle(impersonationEnded)
+                    handle(eventOf(revokeSession))
+                    handle(eventOf(revokeSessions))
+                    handle(eventOf(unassignRole))
+                    handle(accountRoleExpired)
+                    handle(accountForgotten).to(Deleted)
+                    handle(accountAttributesChanged)
+                    handle(accountMerged)
+                    handle(accountMergedInto).to(Merged)
                 })
 
                 object Enabled : State({
@@ -68,9 +242,272 @@ object Auth : Comp({ namespace("ee.auth") }) {
                     executeAndProduce(commandDelete())
                     executeAndProduce(disable)
                     executeAndProduce(sendEnabledConfirmation)
+                    executeAndProduce(linkIdentity)
+                    executeAndProduce(unlinkIdentity)
+                    executeAndProduce(createApiKey)
+                    executeAndProduce(revokeApiKey)
+                    executeAndProduce(useApiKey)
+                    execute(impersonate).produce(impersonationStarted)
+                    execute(endImpersonation).produce(impersonationEnded)
+                    executeAndProduce(startSession)
+                    executeAndProduce(touchSession)
+                    executeAndProduce(revokeSession)
+                    executeAndProduce(revokeSessions)
+                    executeAndProduce(assignRole)
+                    executeAndProduce(unassignRole)
+                    execute(expireRole).produce(accountRoleExpired)
+                    execute(changePassword).produce(accountPasswordChanged)
+                    execute(warnInactivity).produce(accountInactivityWarned)
+                    execute(forget).produce(accountForgotten)
+                    execute(requestEmailChange).produce(accountEmailChangeRequested)
+                    execute(confirmEmailChange).produce(accountEmailChangeConfirmed)
+                    execute(changeAttributes).produce(accountAttributesChanged)
+                    execute(merge).produce(accountMerged)
+                    execute(mergeInto).produce(accountMergedInto)
 
                     handle(eventOf(disable)).to(Disabled).produce(sendDisabledConfirmation)
                     handle(eventOf(commandDelete())).to(Deleted)
+                    handle(eventOf(linkIdentity))
+                    handle(eventOf(unlinkIdentity))
+                    handle(eventOf(createApiKey))
+                    handle(eventOf(revokeApiKey))
+                    handle(eventOf(useApiKey))
+                    handle(impersonationStarted)
+                    handle(impersonationEnded)
+                    handle(eventOf(startSession))
+                    handle(eventOf(touchSession))
+                    handle(eventOf(revokeSession))
+                    handle(eventOf(revokeSessions))
+                    handle(eventOf(assignRole))
+                    handle(eventOf(unassignRole))
+                    handle(accountRoleExpired)
+                    handle(accountPasswordChanged)
+                    handle(accountInactivityWarned)
+                    handle(accountForgotten).to(Deleted)
+                    handle(accountEmailChangeRequested)
+                    handle(accountEmailChangeConfirmed)
+                    handle(accountAttributesChanged)
+                    handle(accountMerged)
+                    handle(accountMergedInto).to(Merged)
+                })
+            }
+        }
+
+        object Group : Entity() {
+            val name = propS().unique()
+            val description = propS()
+            val roles = propListT(n.String)
+
+            val members = propListT(n.UUID).meta()
+            val subgroups = propListT(n.UUID).meta()
+
+            val addMember = command(p("accountId", n.UUID))
+            val removeMember = command(p("accountId", n.UUID))
+            val addSubgroup = command(p("groupId", n.UUID))
+            val removeSubgroup = command(p("groupId", n.UUID))
+
+            object Handler : AggregateHandler({
+                defaultState(state {
+                    name("Initial")
+
+                    executeAndProduce(commandCreate())
+
+                    handle(eventOf(commandCreate())).to(Exist)
+                })
+            }) {
+
+                object Exist : State({
+                    executeAndProduce(commandUpdate())
+                    executeAndProduce(commandDelete())
+                    executeAndProduce(addMember)
+                    executeAndProduce(removeMember)
+                    executeAndProduce(addSubgroup)
+                    executeAndProduce(removeSubgroup)
+
+                    handle(eventOf(commandUpdate()))
+                    handle(eventOf(commandDelete())).to(Deleted)
+                    handle(eventOf(addMember))
+                    handle(eventOf(removeMember))
+                    handle(eventOf(addSubgroup))
+                    handle(eventOf(removeSubgroup))
+                })
+
+                object Deleted : State()
+            }
+        }
+
+        object Organization : Entity() {
+            val name = propS().unique()
+            val description = propS()
+
+            object Handler : AggregateHandler({
+                defaultState(state {
+                    name("Initial")
+
+                    executeAndProduce(commandCreate())
+
+                    handle(eventOf(commandCreate())).to(Exist)
+                })
+            }) {
+
+                object Exist : State({
+                    executeAndProduce(commandUpdate())
+                    executeAndProduce(commandDelete())
+
+                    handle(eventOf(commandUpdate()))
+                    handle(eventOf(commandDelete())).to(Deleted)
+                })
+
+                object Deleted : State()
+            }
+        }
+
+        object RoleGrantRequest : Entity() {
+            val accountId = prop(n.UUID)
+            val role = propS()
+            val reason = propS()
+            val validFrom = propDT()
+            val validUntil = propDT()
+            val requestedBy = prop(n.UUID)
+
+            val decidedBy = prop(n.UUID).meta()
+            val decidedAt = propDT().meta()
+            val comment = propS().meta()
+
+            val approve = command(decidedBy, comment, accountId, role, validFrom, validUntil)
+            val reject = command(decidedBy, comment)
+
+            object Handler : AggregateHandler({
+                defaultState(state {
+                    name("Initial")
+
+                    executeAndProduce(commandCreate())
+
+                    handle(eventOf(commandCreate())).to(Pending)
+                })
+            }) {
+
+                object Pending : State({
+                    executeAndProduce(commandUpdate())
+                    executeAndProduce(commandDelete())
+                    executeAndProduce(approve)
+                    executeAndProduce(reject)
+
+                    handle(eventOf(commandUpdate()))
+                    handle(eventOf(commandDelete())).to(Deleted)
+                    handle(eventOf(approve)).to(Approved)
+                    handle(eventOf(reject)).to(Rejected)
+                })
+
+                object Approved : State()
+                object Rejected : State()
+                object Deleted : State()
+            }
+        }
+
+        object Role : Entity() {
+            val name = propS().unique()
+            val description = propS()
+            val inherits = propListT(n.String)
+            val permissions = propListT(n.String)
+
+            object Handler : AggregateHandler({
+                defaultState(state {
+                    name("Initial")
+
+                    executeAndProduce(commandCreate())
+
+                    handle(eventOf(commandCreate())).to(Exist)
+                })
+            }) {
+
+                object Exist : State({
+                    executeAndProduce(commandUpdate())
+                    executeAndProduce(commandDelete())
+
+                    handle(eventOf(commandUpdate()))
+                    handle(eventOf(commandDelete())).to(Deleted)
+                })
+
+                object Deleted : State()
+            }
+        }
+
+        object RelationTuple : Entity() {
+            val namespace = propS()
+            val `object` = propS()
+            val relation = propS()
+            val subject = propS()
+
+            object Handler : AggregateHandler({
+                defaultState(state {
+                    name("Initial")
+
+                    executeAndProduce(commandCreate())
+
+                    handle(eventOf(commandCreate())).to(Exist)
+                })
+            }) {
+
+                object Exist : State({
+                    executeAndProduce(commandDelete())
+
+                    handle(eventOf(commandDelete())).to(Deleted)
+                })
+
+                object Deleted : State()
+            }
+        }
+
+        object Invitation : Entity() {
+            val email = propS()
+            val roles = propListT(n.String)
+            val organizationId = prop(n.UUID)
+            val tokenHash = propS().hidden()
+            val expiresAt = propDT()
+            val invitedBy = prop(n.UUID)
+
+            val sentAt = propDT().meta()
+            val acceptedAt = propDT().meta()
+            val accountId = prop(n.UUID).meta()
+
+            val resend = command(tokenHash, expiresAt)
+            val invitationResent = event(tokenHash, expiresAt)
+            val revoke = command()
+            val accept = command(accountId)
+
+            object Handler : AggregateHandler({
+                defaultState(state {
+                    name("Initial")
+
+                    executeAndProduce(commandCreate())
+
+                    handle(eventOf(commandCreate())).to(Pending)
+                })
+            }) {
+
+                object Pending : State({
+                    executeAndProduce(commandDelete())
+                    execute(resend).produce(invitationResent)
+                    executeAndProduce(revoke)
+                    executeAndProduce(accept)
+
+                    handle(eventOf(commandDelete())).to(Deleted)
+                    handle(invitationResent)
+                    handle(eventOf(revoke)).to(Revoked)
+                    handle(eventOf(accept)).to(Accepted)
+                })
+
+                object Accepted : State({
+                    executeAndProduce(commandDelete())
+
+                    handle(eventOf(commandDelete())).to(Deleted)
+                })
+
+                object Revoked : State({
+                    executeAndProduce(commandDelete())
+
+                    handle(eventOf(commandDelete())).to(Deleted)
                 })
 
                 object Deleted : State()
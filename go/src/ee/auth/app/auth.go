@@ -2,21 +2,38 @@ package app
 
 import (
 	"ee/auth"
-	"errors"
-	"github.com/go-ee/utils/crypt"
 	"github.com/go-ee/utils/eh/app"
-	"github.com/go-ee/utils/net"
-	"github.com/google/uuid"
 	"path/filepath"
+	"time"
 )
 
 type Auth struct {
 	*app.AppBase
+	FederationConfigFile string
+	ClientConfigFile     string
+	PermissionsFile      string
+	PoliciesFile         string
+	RelationsFile        string
+	ApprovalRoles        []string
+	TokenTtl             time.Duration
+	InvitationTtl        time.Duration
+	PasswordMaxAge       time.Duration
+	DormancyPeriod       time.Duration
+	DormancyWarning      time.Duration
+	DeletionRetention    time.Duration
+	EmailChangeTtl       time.Duration
+	AttributeClaims      []string
+	Registration         auth.RegistrationMode
+	Notifier             auth.Notifier
+	Tokens               *auth.Tokens
+	Sessions             *auth.Sessions
 }
 
 func NewAuth(appBase *app.AppBase) *Auth {
 	appBase.ProductName = "Auth"
-	return &Auth{AppBase: appBase}
+	return &Auth{AppBase: appBase, TokenTtl: time.Hour, InvitationTtl: 7 * 24 * time.Hour,
+		DormancyWarning: 7 * 24 * time.Hour, DeletionRetention: 30 * 24 * time.Hour, ApprovalRoles: []string{"admin"},
+		EmailChangeTtl: 24 * time.Hour, Registration: auth.RegistrationApproval, Notifier: &auth.LogNotifier{}}
 }
 
 func (o *Auth) Start() (err error) {
@@ -26,7 +43,19 @@ func (o *Auth) Start() (err error) {
 		return
 	}
 
+	var keys *auth.FileKeyStore
+	if keys, err = auth.NewFileKeyStore(filepath.Join(o.WorkingFolder, "keys")); err != nil {
+		return
+	}
+	personalData := &auth.PersonalData{Keys: keys}
+	authEngine.ActivateDataEncryption(personalData)
+
 	authEngine.ActivatePasswordEncryption()
+	authEngine.ImplementApiKeys()
+	authEngine.ImplementSessions()
+	authEngine.ImplementRoleAssignments()
+	authEngine.ImplementDormancy()
+	authEngine.ImplementSoftDelete(o.DeletionRetention)
 	var authRouter *auth.Router
 	if authRouter, err = auth.NewRouter("", o.NewContext, authEngine); err != nil {
 		return
@@ -35,8 +64,208 @@ func (o *Auth) Start() (err error) {
 		return
 	}
 
-	if o.Secure {
-		if o.Jwt, err = o.initJwtController(authRouter.AccountRouter.QueryHandler.QueryRepository); err != nil {
+	accounts := authRouter.AccountRouter.QueryHandler.QueryRepository
+	if err = auth.NewAccountQueryRouter(accounts).Setup(o.Router); err != nil {
+		return
+	}
+	authEngine.ImplementIdentityLinking(accounts)
+	passwords := auth.NewPasswordPolicy()
+	passwords.MaxAge = o.PasswordMaxAge
+	authEngine.ImplementRegistration(accounts, passwords)
+	authEngine.ImplementPasswordExpiry(passwords)
+	authEngine.ImplementPasswordHistory(passwords)
+	authEngine.ImplementEmailChange(accounts)
+
+	var attributeSchemas *auth.AttributeSchemas
+	if attributeSchemas, err = auth.NewAttributeSchemas(filepath.Join(o.WorkingFolder, "attributes.json")); err != nil {
+		return
+	}
+	authEngine.ImplementAttributes(attributeSchemas)
+	authEngine.ImplementMerge(accounts)
+
+	groups := authRouter.GroupRouter.QueryHandler.QueryRepository
+	authEngine.ImplementGroups(groups)
+
+	organizations := authRouter.OrganizationRouter.QueryHandler.QueryRepository
+	authEngine.ImplementOrganizations(accounts, organizations)
+
+	hierarchy := auth.NewRoleHierarchy(authRouter.RoleRouter.QueryHandler.QueryRepository)
+	authEngine.ImplementRoles(hierarchy)
+
+	var relationConfigs *auth.RelationConfigs
+	if o.RelationsFile != "" {
+		if relationConfigs, err = auth.LoadRelationConfigs(o.RelationsFile); err != nil {
+			return
+		}
+	}
+	relations := auth.NewRelationEngine(authRouter.RelationTupleRouter.QueryHandler.QueryRepository, relationConfigs)
+	authEngine.ImplementRelations(relations)
+
+	grantPolicy := auth.NewRoleGrantPolicy(o.ApprovalRoles, hierarchy)
+	grantRequests := authRouter.RoleGrantRequestRouter.QueryHandler.QueryRepository
+	if err = authEngine.ImplementRoleGrants(grantRequests, grantPolicy); err != nil {
+		return
+	}
+
+	invitations := authRouter.InvitationRouter.QueryHandler.QueryRepository
+	authEngine.ImplementInvitations(accounts, grantPolicy)
+	authEngine.ImplementErasure(personalData)
+
+	if o.Tokens, err = auth.NewTokensFromFolder(filepath.Join(o.WorkingFolder, "certs"), o.AppName, o.TokenTtl); err != nil {
+		return
+	}
+
+	o.Tokens.Roles = auth.NewGroupRoleResolver(groups)
+	o.Tokens.Hierarchy = hierarchy
+	o.Tokens.AttributeClaims = o.AttributeClaims
+	authEngine.ImplementImpersonation(o.Tokens)
+	o.Sessions = auth.NewSessions(o.NewContext, authEngine.CommandBus, accounts, o.Tokens)
+
+	authenticator := auth.NewAuthenticator(o.NewContext, authEngine.CommandBus, accounts, o.Tokens)
+	authenticator.Revocations = auth.NewMemoryRevocationStore()
+	authenticator.Passwords = passwords
+	authorizer := auth.NewAuthorizer()
+	if o.PoliciesFile != "" {
+		if authorizer.Policies, err = auth.NewPolicyEngine(o.PoliciesFile, accounts); err != nil {
+			return
+		}
+		authorizer.Policies.Start(10 * time.Second)
+
+		policyRouter := auth.NewPolicyRouter(authRouter.PathPrefix, authorizer.Policies, o.Tokens)
+		if err = policyRouter.Setup(o.Router); err != nil {
+			return
+		}
+	}
+	o.Router.Use(authenticator.Middleware, authorizer.Middleware,
+		auth.NewTenantGuard(authRouter.PathPrefix, accounts).Middleware,
+		auth.NewGrantGuard(accounts, groups).Middleware)
+
+	apiKeyRouter := auth.NewApiKeyRouter(authRouter.PathPrefix, o.NewContext, authEngine.CommandBus, accounts)
+	if err = apiKeyRouter.Setup(o.Router); err != nil {
+		return
+	}
+
+	impersonationRouter := auth.NewImpersonationRouter(authRouter.PathPrefix, o.NewContext, authEngine.CommandBus,
+		accounts, o.Tokens)
+	if err = impersonationRouter.Setup(o.Router); err != nil {
+		return
+	}
+
+	sessionRouter := auth.NewSessionRouter(authRouter.PathPrefix, o.NewContext, authEngine.CommandBus, accounts,
+		o.Sessions, passwords)
+	if err = sessionRouter.Setup(o.Router); err != nil {
+		return
+	}
+
+	profileRouter := auth.NewProfileRouter(authRouter.PathPrefix, o.NewContext, authEngine.CommandBus, accounts)
+	if err = profileRouter.Setup(o.Router); err != nil {
+		return
+	}
+
+	passwordRouter := auth.NewPasswordRouter(authRouter.PathPrefix, o.NewContext, authEngine.CommandBus)
+	if err = passwordRouter.Setup(o.Router); err != nil {
+		return
+	}
+
+	emailRouter := auth.NewEmailRouter(authRouter.PathPrefix, o.NewContext, authEngine.CommandBus, accounts,
+		o.Notifier, o.EmailChangeTtl)
+	if err = emailRouter.Setup(o.Router); err != nil {
+		return
+	}
+
+	attributeRouter := auth.NewAttributeRouter(authRouter.PathPrefix, o.NewContext, authEngine.CommandBus, accounts,
+		attributeSchemas)
+	if err = attributeRouter.Setup(o.Router); err != nil {
+		return
+	}
+
+	mergeRouter := auth.NewMergeRouter(authRouter.PathPrefix, o.NewContext, authEngine.CommandBus)
+	if err = mergeRouter.Setup(o.Router); err != nil {
+		return
+	}
+
+	exportRouter := auth.NewExportRouter(authRouter.PathPrefix, o.NewContext, accounts, authEngine.EventStore,
+		personalData)
+	if err = exportRouter.Setup(o.Router); err != nil {
+		return
+	}
+
+	roleAssignmentRouter := auth.NewRoleAssignmentRouter(authRouter.PathPrefix, o.NewContext, authEngine.CommandBus,
+		accounts, grantPolicy)
+	if err = roleAssignmentRouter.Setup(o.Router); err != nil {
+		return
+	}
+	roleGrantRouter := auth.NewRoleGrantRouter(authRouter.PathPrefix, o.NewContext, authEngine.CommandBus)
+	if err = roleGrantRouter.Setup(o.Router); err != nil {
+		return
+	}
+	roleTreeRouter := auth.NewRoleTreeRouter(authRouter.PathPrefix, hierarchy)
+	if err = roleTreeRouter.Setup(o.Router); err != nil {
+		return
+	}
+	auth.NewRoleExpiryScheduler(o.NewContext, authEngine.CommandBus, accounts, time.Minute).Start()
+	auth.NewImpersonationExpiryScheduler(o.NewContext, authEngine.CommandBus, accounts, time.Minute).Start()
+	if o.DormancyPeriod > 0 {
+		auth.NewDormancyScheduler(o.NewContext, authEngine.CommandBus, accounts, o.Notifier, o.DormancyPeriod,
+			o.DormancyWarning, time.Hour).Start()
+	}
+
+	relationRouter := auth.NewRelationRouter(authRouter.PathPrefix, o.NewContext, authEngine.CommandBus, relations)
+	if err = relationRouter.Setup(o.Router); err != nil {
+		return
+	}
+
+	tenantRouter := auth.NewTenantRouter(authRouter.PathPrefix, o.NewContext, authEngine.CommandBus, accounts,
+		organizations)
+	if err = tenantRouter.Setup(o.Router); err != nil {
+		return
+	}
+
+	inviteRouter := auth.NewInviteRouter(authRouter.PathPrefix, o.NewContext, authEngine.CommandBus,
+		invitations, o.Notifier, o.InvitationTtl)
+	if err = inviteRouter.Setup(o.Router); err != nil {
+		return
+	}
+
+	registrationRouter := auth.NewRegistrationRouter(authRouter.PathPrefix, o.NewContext, authEngine.CommandBus,
+		accounts, o.Registration)
+	if err = registrationRouter.Setup(o.Router); err != nil {
+		return
+	}
+
+	deviceRouter := auth.NewDeviceRouter(authRouter.PathPrefix, o.NewContext, accounts, o.Sessions)
+	if err = deviceRouter.Setup(o.Router); err != nil {
+		return
+	}
+
+	clients := &auth.ClientConfig{}
+	if o.ClientConfigFile != "" {
+		if clients, err = auth.LoadClientConfig(o.ClientConfigFile); err != nil {
+			return
+		}
+	}
+
+	oauthRouter := auth.NewOAuthRouter(authRouter.PathPrefix, o.NewContext, authEngine.CommandBus, authenticator,
+		authenticator.Revocations, clients)
+	if err = oauthRouter.Setup(o.Router); err != nil {
+		return
+	}
+
+	var catalog *auth.PermissionCatalog
+	if o.PermissionsFile != "" {
+		if catalog, err = auth.LoadPermissionCatalog(o.PermissionsFile); err != nil {
+			return
+		}
+	}
+
+	checkRouter := auth.NewCheckRouter(authRouter.PathPrefix, clients,
+		auth.NewAccessChecker(accounts, o.Tokens.Roles, hierarchy, catalog))
+	if err = checkRouter.Setup(o.Router); err != nil {
+		return
+	}
+
+	if o.FederationConfigFile != "" {
+		if err = o.setupFederation(authRouter, authEngine, accounts); err != nil {
 			return
 		}
 	}
@@ -45,19 +274,19 @@ func (o *Auth) Start() (err error) {
 	return
 }
 
-func (o *Auth) initJwtController(accounts *auth.AccountQueryRepository) (ret *net.JwtController, err error) {
-	return net.NewJwtControllerApp(
-		filepath.Join(o.WorkingFolder, "certs"), o.AppName,
-		func(credentials net.UserCredentials) (ret interface{}, err error) {
-			var account *auth.Account
-			id, _ := uuid.Parse(credentials.Username)
-			if account, err = accounts.FindById(id); err == nil {
-				if !crypt.HashAndEquals(credentials.Password, account.Password) {
-					err = errors.New("password mismatch")
-				} else {
-					ret = account
-				}
-			}
-			return
-		})
+func (o *Auth) setupFederation(authRouter *auth.Router, authEngine *auth.EsEngine,
+	accounts *auth.AccountQueryRepository) (err error) {
+
+	var config *auth.FederationConfig
+	if config, err = auth.LoadFederationConfig(o.FederationConfigFile); err != nil {
+		return
+	}
+
+	var federationRouter *auth.FederationRouter
+	if federationRouter, err = auth.NewFederationRouter(authRouter.PathPrefix, o.NewContext,
+		authEngine.CommandBus, accounts, o.Sessions, config); err != nil {
+		return
+	}
+	err = federationRouter.Setup(o.Router)
+	return
 }
@@ -17,10 +17,22 @@ type AccountAggregateEngine struct {
 	AggregateHandlers  *AccountAggregateHandlers
 }
 
+func (o *AccountAggregateEngine) RegisterForAssignedRole(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, AccountEventTypes().AccountAssignedRole())
+}
+
+func (o *AccountAggregateEngine) RegisterForAttributesChanged(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, AccountEventTypes().AccountAttributesChanged())
+}
+
 func (o *AccountAggregateEngine) RegisterForCreated(handler eventhorizon.EventHandler) error {
 	return o.RegisterForEvent(handler, AccountEventTypes().AccountCreated())
 }
 
+func (o *AccountAggregateEngine) RegisterForCreatedApiKey(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, AccountEventTypes().AccountCreatedApiKey())
+}
+
 func (o *AccountAggregateEngine) RegisterForDeleted(handler eventhorizon.EventHandler) error {
 	return o.RegisterForEvent(handler, AccountEventTypes().AccountDeleted())
 }
@@ -29,14 +41,82 @@ func (o *AccountAggregateEngine) RegisterForDisabled(handler eventhorizon.EventH
 	return o.RegisterForEvent(handler, AccountEventTypes().AccountDisabled())
 }
 
+func (o *AccountAggregateEngine) RegisterForEmailChangeConfirmed(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, AccountEventTypes().AccountEmailChangeConfirmed())
+}
+
+func (o *AccountAggregateEngine) RegisterForEmailChangeRequested(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, AccountEventTypes().AccountEmailChangeRequested())
+}
+
 func (o *AccountAggregateEngine) RegisterForEnabled(handler eventhorizon.EventHandler) error {
 	return o.RegisterForEvent(handler, AccountEventTypes().AccountEnabled())
 }
 
+func (o *AccountAggregateEngine) RegisterForForgotten(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, AccountEventTypes().AccountForgotten())
+}
+
+func (o *AccountAggregateEngine) RegisterForInactivityWarned(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, AccountEventTypes().AccountInactivityWarned())
+}
+
+func (o *AccountAggregateEngine) RegisterForLinkedIdentity(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, AccountEventTypes().AccountLinkedIdentity())
+}
+
 func (o *AccountAggregateEngine) RegisterForLogged(handler eventhorizon.EventHandler) error {
 	return o.RegisterForEvent(handler, AccountEventTypes().AccountLogged())
 }
 
+func (o *AccountAggregateEngine) RegisterForMerged(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, AccountEventTypes().AccountMerged())
+}
+
+func (o *AccountAggregateEngine) RegisterForMergedInto(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, AccountEventTypes().AccountMergedInto())
+}
+
+func (o *AccountAggregateEngine) RegisterForPasswordChanged(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, AccountEventTypes().AccountPasswordChanged())
+}
+
+func (o *AccountAggregateEngine) RegisterForPurged(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, AccountEventTypes().AccountPurged())
+}
+
+func (o *AccountAggregateEngine) RegisterForRegistered(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, AccountEventTypes().AccountRegistered())
+}
+
+func (o *AccountAggregateEngine) RegisterForRegistrationApproved(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, AccountEventTypes().AccountRegistrationApproved())
+}
+
+func (o *AccountAggregateEngine) RegisterForRegistrationRejected(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, AccountEventTypes().AccountRegistrationRejected())
+}
+
+func (o *AccountAggregateEngine) RegisterForRestored(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, AccountEventTypes().AccountRestored())
+}
+
+func (o *AccountAggregateEngine) RegisterForRevokedApiKey(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, AccountEventTypes().AccountRevokedApiKey())
+}
+
+func (o *AccountAggregateEngine) RegisterForRevokedSession(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, AccountEventTypes().AccountRevokedSession())
+}
+
+func (o *AccountAggregateEngine) RegisterForRevokedSessions(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, AccountEventTypes().AccountRevokedSessions())
+}
+
+func (o *AccountAggregateEngine) RegisterForRoleExpired(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, AccountEventTypes().AccountRoleExpired())
+}
+
 func (o *AccountAggregateEngine) RegisterForSentDisabledConfirmation(handler eventhorizon.EventHandler) error {
 	return o.RegisterForEvent(handler, AccountEventTypes().AccountSentDisabledConfirmation())
 }
@@ -45,10 +125,38 @@ func (o *AccountAggregateEngine) RegisterForSentEnabledConfirmation(handler even
 	return o.RegisterForEvent(handler, AccountEventTypes().AccountSentEnabledConfirmation())
 }
 
+func (o *AccountAggregateEngine) RegisterForStartedSession(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, AccountEventTypes().AccountStartedSession())
+}
+
+func (o *AccountAggregateEngine) RegisterForTouchedSession(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, AccountEventTypes().AccountTouchedSession())
+}
+
+func (o *AccountAggregateEngine) RegisterForUnassignedRole(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, AccountEventTypes().AccountUnassignedRole())
+}
+
+func (o *AccountAggregateEngine) RegisterForUnlinkedIdentity(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, AccountEventTypes().AccountUnlinkedIdentity())
+}
+
 func (o *AccountAggregateEngine) RegisterForUpdated(handler eventhorizon.EventHandler) error {
 	return o.RegisterForEvent(handler, AccountEventTypes().AccountUpdated())
 }
 
+func (o *AccountAggregateEngine) RegisterForUsedApiKey(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, AccountEventTypes().AccountUsedApiKey())
+}
+
+func (o *AccountAggregateEngine) RegisterForImpersonationEnded(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, AccountEventTypes().ImpersonationEnded())
+}
+
+func (o *AccountAggregateEngine) RegisterForImpersonationStarted(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, AccountEventTypes().ImpersonationStarted())
+}
+
 func (o *AccountAggregateEngine) RegisterAccountProjector(
 	projType string, listener AccountAggregateHandler, events []eventhorizon.EventType) (ret *AccountProjector, err error) {
 
@@ -87,7 +195,7 @@ func (o *AccountProjector) Project(
 	ctx context.Context, event eventhorizon.Event, entity eventhorizon.Entity) (ret eventhorizon.Entity, err error) {
 
 	if err = o.Apply(event, entity.(*Account)); err == nil {
-		if event.EventType() != AccountDeletedEvent {
+		if event.EventType() != AccountPurgedEvent && event.EventType() != AccountRegistrationRejectedEvent {
 			ret = entity
 		}
 	}
@@ -134,16 +242,692 @@ func (o *AccountAggregateEngine) Setup() (err error) {
 	return
 }
 
+const GroupAggregateType eventhorizon.AggregateType = "Group"
+
+type GroupAggregateEngine struct {
+	*eh.AggregateEngine
+	AggregateExecutors *GroupAggregateExecutors
+	AggregateHandlers  *GroupAggregateHandlers
+}
+
+func (o *GroupAggregateEngine) RegisterForAddedMember(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, GroupEventTypes().GroupAddedMember())
+}
+
+func (o *GroupAggregateEngine) RegisterForAddedSubgroup(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, GroupEventTypes().GroupAddedSubgroup())
+}
+
+func (o *GroupAggregateEngine) RegisterForCreated(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, GroupEventTypes().GroupCreated())
+}
+
+func (o *GroupAggregateEngine) RegisterForDeleted(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, GroupEventTypes().GroupDeleted())
+}
+
+func (o *GroupAggregateEngine) RegisterForRemovedMember(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, GroupEventTypes().GroupRemovedMember())
+}
+
+func (o *GroupAggregateEngine) RegisterForRemovedSubgroup(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, GroupEventTypes().GroupRemovedSubgroup())
+}
+
+func (o *GroupAggregateEngine) RegisterForUpdated(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, GroupEventTypes().GroupUpdated())
+}
+
+func (o *GroupAggregateEngine) RegisterGroupProjector(
+	projType string, listener GroupAggregateHandler, events []eventhorizon.EventType) (ret *GroupProjector, err error) {
+
+	var repo eventhorizon.ReadWriteRepo
+	if repo, err = o.Repos(projType, o.EntityFactory); err != nil {
+		return
+	}
+
+	ret = NewGroupProjector(projType, listener, repo)
+	proj := projector.NewEventHandler(ret, repo)
+	proj.SetEntityFactory(o.EntityFactory)
+	err = o.RegisterForEvents(proj, events)
+	return
+}
+
+type GroupProjector struct {
+	GroupAggregateHandler
+	projType projector.Type
+	Repo     eventhorizon.ReadRepo
+}
+
+func NewGroupProjector(projType string, eventHandler GroupAggregateHandler, repo eventhorizon.ReadRepo) (ret *GroupProjector) {
+	ret = &GroupProjector{
+		GroupAggregateHandler: eventHandler,
+		projType:              projector.Type(projType),
+		Repo:                  repo,
+	}
+	return
+}
+
+func (o *GroupProjector) ProjectorType() projector.Type {
+	return o.projType
+}
+
+func (o *GroupProjector) Project(
+	ctx context.Context, event eventhorizon.Event, entity eventhorizon.Entity) (ret eventhorizon.Entity, err error) {
+
+	if err = o.Apply(event, entity.(*Group)); err == nil {
+		if event.EventType() != GroupDeletedEvent {
+			ret = entity
+		}
+	}
+	return
+}
+
+func NewGroupAggregateEngine(middleware *eh.Middleware) (ret *GroupAggregateEngine) {
+
+	groupAggregateExecutors := NewGroupAggregateExecutorsFull()
+	groupAggregateHandlers := NewGroupAggregateHandlersFull()
+
+	entityFactory := func() eventhorizon.Entity { return NewGroupDefault() }
+	aggregateEngine := eh.NewAggregateEngine(middleware, GroupAggregateType,
+		func(id uuid.UUID) eventhorizon.Aggregate {
+			return &GroupAggregate{
+				AggregateBase:      events.NewAggregateBase(GroupAggregateType, id),
+				Group:              NewGroupDefault(),
+				AggregateExecutors: groupAggregateExecutors,
+				AggregateHandlers:  groupAggregateHandlers,
+			}
+		}, entityFactory,
+		GroupCommandTypes().Literals(), GroupEventTypes().Literals())
+
+	ret = &GroupAggregateEngine{
+		AggregateEngine:    aggregateEngine,
+		AggregateExecutors: groupAggregateExecutors,
+		AggregateHandlers:  groupAggregateHandlers,
+	}
+	return
+}
+
+func (o *GroupAggregateEngine) Setup() (err error) {
+	if err = o.AggregateEngine.Setup(); err != nil {
+		return
+	}
+
+	if err = o.AggregateExecutors.SetupCommandHandler(); err != nil {
+		return
+	}
+
+	if err = o.AggregateHandlers.SetupEventHandler(); err != nil {
+		return
+	}
+	return
+}
+
+const OrganizationAggregateType eventhorizon.AggregateType = "Organization"
+
+type OrganizationAggregateEngine struct {
+	*eh.AggregateEngine
+	AggregateExecutors *OrganizationAggregateExecutors
+	AggregateHandlers  *OrganizationAggregateHandlers
+}
+
+func (o *OrganizationAggregateEngine) RegisterForCreated(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, OrganizationEventTypes().OrganizationCreated())
+}
+
+func (o *OrganizationAggregateEngine) RegisterForDeleted(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, OrganizationEventTypes().OrganizationDeleted())
+}
+
+func (o *OrganizationAggregateEngine) RegisterForUpdated(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, OrganizationEventTypes().OrganizationUpdated())
+}
+
+func (o *OrganizationAggregateEngine) RegisterOrganizationProjector(
+	projType string, listener OrganizationAggregateHandler, events []eventhorizon.EventType) (ret *OrganizationProjector, err error) {
+
+	var repo eventhorizon.ReadWriteRepo
+	if repo, err = o.Repos(projType, o.EntityFactory); err != nil {
+		return
+	}
+
+	ret = NewOrganizationProjector(projType, listener, repo)
+	proj := projector.NewEventHandler(ret, repo)
+	proj.SetEntityFactory(o.EntityFactory)
+	err = o.RegisterForEvents(proj, events)
+	return
+}
+
+type OrganizationProjector struct {
+	OrganizationAggregateHandler
+	projType projector.Type
+	Repo     eventhorizon.ReadRepo
+}
+
+func NewOrganizationProjector(projType string, eventHandler OrganizationAggregateHandler, repo eventhorizon.ReadRepo) (ret *OrganizationProjector) {
+	ret = &OrganizationProjector{
+		OrganizationAggregateHandler: eventHandler,
+		projType:                     projector.Type(projType),
+		Repo:                         repo,
+	}
+	return
+}
+
+func (o *OrganizationProjector) ProjectorType() projector.Type {
+	return o.projType
+}
+
+func (o *OrganizationProjector) Project(
+	ctx context.Context, event eventhorizon.Event, entity eventhorizon.Entity) (ret eventhorizon.Entity, err error) {
+
+	if err = o.Apply(event, entity.(*Organization)); err == nil {
+		if event.EventType() != OrganizationDeletedEvent {
+			ret = entity
+		}
+	}
+	return
+}
+
+func NewOrganizationAggregateEngine(middleware *eh.Middleware) (ret *OrganizationAggregateEngine) {
+
+	organizationAggregateExecutors := NewOrganizationAggregateExecutorsFull()
+	organizationAggregateHandlers := NewOrganizationAggregateHandlersFull()
+
+	entityFactory := func() eventhorizon.Entity { return NewOrganizationDefault() }
+	aggregateEngine := eh.NewAggregateEngine(middleware, OrganizationAggregateType,
+		func(id uuid.UUID) eventhorizon.Aggregate {
+			return &OrganizationAggregate{
+				AggregateBase:      events.NewAggregateBase(OrganizationAggregateType, id),
+				Organization:       NewOrganizationDefault(),
+				AggregateExecutors: organizationAggregateExecutors,
+				AggregateHandlers:  organizationAggregateHandlers,
+			}
+		}, entityFactory,
+		OrganizationCommandTypes().Literals(), OrganizationEventTypes().Literals())
+
+	ret = &OrganizationAggregateEngine{
+		AggregateEngine:    aggregateEngine,
+		AggregateExecutors: organizationAggregateExecutors,
+		AggregateHandlers:  organizationAggregateHandlers,
+	}
+	return
+}
+
+func (o *OrganizationAggregateEngine) Setup() (err error) {
+	if err = o.AggregateEngine.Setup(); err != nil {
+		return
+	}
+
+	if err = o.AggregateExecutors.SetupCommandHandler(); err != nil {
+		return
+	}
+
+	if err = o.AggregateHandlers.SetupEventHandler(); err != nil {
+		return
+	}
+	return
+}
+
+const RoleGrantRequestAggregateType eventhorizon.AggregateType = "RoleGrantRequest"
+
+type RoleGrantRequestAggregateEngine struct {
+	*eh.AggregateEngine
+	AggregateExecutors *RoleGrantRequestAggregateExecutors
+	AggregateHandlers  *RoleGrantRequestAggregateHandlers
+}
+
+func (o *RoleGrantRequestAggregateEngine) RegisterForApproved(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, RoleGrantRequestEventTypes().RoleGrantRequestApproved())
+}
+
+func (o *RoleGrantRequestAggregateEngine) RegisterForCreated(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, RoleGrantRequestEventTypes().RoleGrantRequestCreated())
+}
+
+func (o *RoleGrantRequestAggregateEngine) RegisterForDeleted(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, RoleGrantRequestEventTypes().RoleGrantRequestDeleted())
+}
+
+func (o *RoleGrantRequestAggregateEngine) RegisterForRejected(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, RoleGrantRequestEventTypes().RoleGrantRequestRejected())
+}
+
+func (o *RoleGrantRequestAggregateEngine) RegisterForUpdated(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, RoleGrantRequestEventTypes().RoleGrantRequestUpdated())
+}
+
+func (o *RoleGrantRequestAggregateEngine) RegisterRoleGrantRequestProjector(
+	projType string, listener RoleGrantRequestAggregateHandler, events []eventhorizon.EventType) (ret *RoleGrantRequestProjector, err error) {
+
+	var repo eventhorizon.ReadWriteRepo
+	if repo, err = o.Repos(projType, o.EntityFactory); err != nil {
+		return
+	}
+
+	ret = NewRoleGrantRequestProjector(projType, listener, repo)
+	proj := projector.NewEventHandler(ret, repo)
+	proj.SetEntityFactory(o.EntityFactory)
+	err = o.RegisterForEvents(proj, events)
+	return
+}
+
+type RoleGrantRequestProjector struct {
+	RoleGrantRequestAggregateHandler
+	projType projector.Type
+	Repo     eventhorizon.ReadRepo
+}
+
+func NewRoleGrantRequestProjector(projType string, eventHandler RoleGrantRequestAggregateHandler, repo eventhorizon.ReadRepo) (ret *RoleGrantRequestProjector) {
+	ret = &RoleGrantRequestProjector{
+		RoleGrantRequestAggregateHandler: eventHandler,
+		projType:                         projector.Type(projType),
+		Repo:                             repo,
+	}
+	return
+}
+
+func (o *RoleGrantRequestProjector) ProjectorType() projector.Type {
+	return o.projType
+}
+
+func (o *RoleGrantRequestProjector) Project(
+	ctx context.Context, event eventhorizon.Event, entity eventhorizon.Entity) (ret eventhorizon.Entity, err error) {
+
+	if err = o.Apply(event, entity.(*RoleGrantRequest)); err == nil {
+		if event.EventType() != RoleGrantRequestDeletedEvent {
+			ret = entity
+		}
+	}
+	return
+}
+
+func NewRoleGrantRequestAggregateEngine(middleware *eh.Middleware) (ret *RoleGrantRequestAggregateEngine) {
+
+	roleGrantRequestAggregateExecutors := NewRoleGrantRequestAggregateExecutorsFull()
+	roleGrantRequestAggregateHandlers := NewRoleGrantRequestAggregateHandlersFull()
+
+	entityFactory := func() eventhorizon.Entity { return NewRoleGrantRequestDefault() }
+	aggregateEngine := eh.NewAggregateEngine(middleware, RoleGrantRequestAggregateType,
+		func(id uuid.UUID) eventhorizon.Aggregate {
+			return &RoleGrantRequestAggregate{
+				AggregateBase:      events.NewAggregateBase(RoleGrantRequestAggregateType, id),
+				RoleGrantRequest:   NewRoleGrantRequestDefault(),
+				AggregateExecutors: roleGrantRequestAggregateExecutors,
+				AggregateHandlers:  roleGrantRequestAggregateHandlers,
+			}
+		}, entityFactory,
+		RoleGrantRequestCommandTypes().Literals(), RoleGrantRequestEventTypes().Literals())
+
+	ret = &RoleGrantRequestAggregateEngine{
+		AggregateEngine:    aggregateEngine,
+		AggregateExecutors: roleGrantRequestAggregateExecutors,
+		AggregateHandlers:  roleGrantRequestAggregateHandlers,
+	}
+	return
+}
+
+func (o *RoleGrantRequestAggregateEngine) Setup() (err error) {
+	if err = o.AggregateEngine.Setup(); err != nil {
+		return
+	}
+
+	if err = o.AggregateExecutors.SetupCommandHandler(); err != nil {
+		return
+	}
+
+	if err = o.AggregateHandlers.SetupEventHandler(); err != nil {
+		return
+	}
+	return
+}
+
+const RoleAggregateType eventhorizon.AggregateType = "Role"
+
+type RoleAggregateEngine struct {
+	*eh.AggregateEngine
+	AggregateExecutors *RoleAggregateExecutors
+	AggregateHandlers  *RoleAggregateHandlers
+}
+
+func (o *RoleAggregateEngine) RegisterForCreated(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, RoleEventTypes().RoleCreated())
+}
+
+func (o *RoleAggregateEngine) RegisterForDeleted(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, RoleEventTypes().RoleDeleted())
+}
+
+func (o *RoleAggregateEngine) RegisterForUpdated(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, RoleEventTypes().RoleUpdated())
+}
+
+func (o *RoleAggregateEngine) RegisterRoleProjector(
+	projType string, listener RoleAggregateHandler, events []eventhorizon.EventType) (ret *RoleProjector, err error) {
+
+	var repo eventhorizon.ReadWriteRepo
+	if repo, err = o.Repos(projType, o.EntityFactory); err != nil {
+		return
+	}
+
+	ret = NewRoleProjector(projType, listener, repo)
+	proj := projector.NewEventHandler(ret, repo)
+	proj.SetEntityFactory(o.EntityFactory)
+	err = o.RegisterForEvents(proj, events)
+	return
+}
+
+type RoleProjector struct {
+	RoleAggregateHandler
+	projType projector.Type
+	Repo     eventhorizon.ReadRepo
+}
+
+func NewRoleProjector(projType string, eventHandler RoleAggregateHandler, repo eventhorizon.ReadRepo) (ret *RoleProjector) {
+	ret = &RoleProjector{
+		RoleAggregateHandler: eventHandler,
+		projType:             projector.Type(projType),
+		Repo:                 repo,
+	}
+	return
+}
+
+func (o *RoleProjector) ProjectorType() projector.Type {
+	return o.projType
+}
+
+func (o *RoleProjector) Project(
+	ctx context.Context, event eventhorizon.Event, entity eventhorizon.Entity) (ret eventhorizon.Entity, err error) {
+
+	if err = o.Apply(event, entity.(*Role)); err == nil {
+		if event.EventType() != RoleDeletedEvent {
+			ret = entity
+		}
+	}
+	return
+}
+
+func NewRoleAggregateEngine(middleware *eh.Middleware) (ret *RoleAggregateEngine) {
+
+	roleAggregateExecutors := NewRoleAggregateExecutorsFull()
+	roleAggregateHandlers := NewRoleAggregateHandlersFull()
+
+	entityFactory := func() eventhorizon.Entity { return NewRoleDefault() }
+	aggregateEngine := eh.NewAggregateEngine(middleware, RoleAggregateType,
+		func(id uuid.UUID) eventhorizon.Aggregate {
+			return &RoleAggregate{
+				AggregateBase:      events.NewAggregateBase(RoleAggregateType, id),
+				Role:               NewRoleDefault(),
+				AggregateExecutors: roleAggregateExecutors,
+				AggregateHandlers:  roleAggregateHandlers,
+			}
+		}, entityFactory,
+		RoleCommandTypes().Literals(), RoleEventTypes().Literals())
+
+	ret = &RoleAggregateEngine{
+		AggregateEngine:    aggregateEngine,
+		AggregateExecutors: roleAggregateExecutors,
+		AggregateHandlers:  roleAggregateHandlers,
+	}
+	return
+}
+
+func (o *RoleAggregateEngine) Setup() (err error) {
+	if err = o.AggregateEngine.Setup(); err != nil {
+		return
+	}
+
+	if err = o.AggregateExecutors.SetupCommandHandler(); err != nil {
+		return
+	}
+
+	if err = o.AggregateHandlers.SetupEventHandler(); err != nil {
+		return
+	}
+	return
+}
+
+const RelationTupleAggregateType eventhorizon.AggregateType = "RelationTuple"
+
+type RelationTupleAggregateEngine struct {
+	*eh.AggregateEngine
+	AggregateExecutors *RelationTupleAggregateExecutors
+	AggregateHandlers  *RelationTupleAggregateHandlers
+}
+
+func (o *RelationTupleAggregateEngine) RegisterForCreated(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, RelationTupleEventTypes().RelationTupleCreated())
+}
+
+func (o *RelationTupleAggregateEngine) RegisterForDeleted(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, RelationTupleEventTypes().RelationTupleDeleted())
+}
+
+func (o *RelationTupleAggregateEngine) RegisterRelationTupleProjector(
+	projType string, listener RelationTupleAggregateHandler, events []eventhorizon.EventType) (ret *RelationTupleProjector, err error) {
+
+	var repo eventhorizon.ReadWriteRepo
+	if repo, err = o.Repos(projType, o.EntityFactory); err != nil {
+		return
+	}
+
+	ret = NewRelationTupleProjector(projType, listener, repo)
+	proj := projector.NewEventHandler(ret, repo)
+	proj.SetEntityFactory(o.EntityFactory)
+	err = o.RegisterForEvents(proj, events)
+	return
+}
+
+type RelationTupleProjector struct {
+	RelationTupleAggregateHandler
+	projType projector.Type
+	Repo     eventhorizon.ReadRepo
+}
+
+func NewRelationTupleProjector(projType string, eventHandler RelationTupleAggregateHandler, repo eventhorizon.ReadRepo) (ret *RelationTupleProjector) {
+	ret = &RelationTupleProjector{
+		RelationTupleAggregateHandler: eventHandler,
+		projType:                      projector.Type(projType),
+		Repo:                          repo,
+	}
+	return
+}
+
+func (o *RelationTupleProjector) ProjectorType() projector.Type {
+	return o.projType
+}
+
+func (o *RelationTupleProjector) Project(
+	ctx context.Context, event eventhorizon.Event, entity eventhorizon.Entity) (ret eventhorizon.Entity, err error) {
+
+	if err = o.Apply(event, entity.(*RelationTuple)); err == nil {
+		if event.EventType() != RelationTupleDeletedEvent {
+			ret = entity
+		}
+	}
+	return
+}
+
+func NewRelationTupleAggregateEngine(middleware *eh.Middleware) (ret *RelationTupleAggregateEngine) {
+
+	relationTupleAggregateExecutors := NewRelationTupleAggregateExecutorsFull()
+	relationTupleAggregateHandlers := NewRelationTupleAggregateHandlersFull()
+
+	entityFactory := func() eventhorizon.Entity { return NewRelationTupleDefault() }
+	aggregateEngine := eh.NewAggregateEngine(middleware, RelationTupleAggregateType,
+		func(id uuid.UUID) eventhorizon.Aggregate {
+			return &RelationTupleAggregate{
+				AggregateBase:      events.NewAggregateBase(RelationTupleAggregateType, id),
+				RelationTuple:      NewRelationTupleDefault(),
+				AggregateExecutors: relationTupleAggregateExecutors,
+				AggregateHandlers:  relationTupleAggregateHandlers,
+			}
+		}, entityFactory,
+		RelationTupleCommandTypes().Literals(), RelationTupleEventTypes().Literals())
+
+	ret = &RelationTupleAggregateEngine{
+		AggregateEngine:    aggregateEngine,
+		AggregateExecutors: relationTupleAggregateExecutors,
+		AggregateHandlers:  relationTupleAggregateHandlers,
+	}
+	return
+}
+
+func (o *RelationTupleAggregateEngine) Setup() (err error) {
+	if err = o.AggregateEngine.Setup(); err != nil {
+		return
+	}
+
+	if err = o.AggregateExecutors.SetupCommandHandler(); err != nil {
+		return
+	}
+
+	if err = o.AggregateHandlers.SetupEventHandler(); err != nil {
+		return
+	}
+	return
+}
+
+const InvitationAggregateType eventhorizon.AggregateType = "Invitation"
+
+type InvitationAggregateEngine struct {
+	*eh.AggregateEngine
+	AggregateExecutors *InvitationAggregateExecutors
+	AggregateHandlers  *InvitationAggregateHandlers
+}
+
+func (o *InvitationAggregateEngine) RegisterForAccepted(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, InvitationEventTypes().InvitationAccepted())
+}
+
+func (o *InvitationAggregateEngine) RegisterForCreated(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, InvitationEventTypes().InvitationCreated())
+}
+
+func (o *InvitationAggregateEngine) RegisterForDeleted(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, InvitationEventTypes().InvitationDeleted())
+}
+
+func (o *InvitationAggregateEngine) RegisterForResent(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, InvitationEventTypes().InvitationResent())
+}
+
+func (o *InvitationAggregateEngine) RegisterForRevoked(handler eventhorizon.EventHandler) error {
+	return o.RegisterForEvent(handler, InvitationEventTypes().InvitationRevoked())
+}
+
+func (o *InvitationAggregateEngine) RegisterInvitationProjector(
+	projType string, listener InvitationAggregateHandler, events []eventhorizon.EventType) (ret *InvitationProjector, err error) {
+
+	var repo eventhorizon.ReadWriteRepo
+	if repo, err = o.Repos(projType, o.EntityFactory); err != nil {
+		return
+	}
+
+	ret = NewInvitationProjector(projType, listener, repo)
+	proj := projector.NewEventHandler(ret, repo)
+	proj.SetEntityFactory(o.EntityFactory)
+	err = o.RegisterForEvents(proj, events)
+	return
+}
+
+type InvitationProjector struct {
+	InvitationAggregateHandler
+	projType projector.Type
+	Repo     eventhorizon.ReadRepo
+}
+
+func NewInvitationProjector(projType string, eventHandler InvitationAggregateHandler, repo eventhorizon.ReadRepo) (ret *InvitationProjector) {
+	ret = &InvitationProjector{
+		InvitationAggregateHandler: eventHandler,
+		projType:                   projector.Type(projType),
+		Repo:                       repo,
+	}
+	return
+}
+
+func (o *InvitationProjector) ProjectorType() projector.Type {
+	return o.projType
+}
+
+func (o *InvitationProjector) Project(
+	ctx context.Context, event eventhorizon.Event, entity eventhorizon.Entity) (ret eventhorizon.Entity, err error) {
+
+	if err = o.Apply(event, entity.(*Invitation)); err == nil {
+		if event.EventType() != InvitationDeletedEvent {
+			ret = entity
+		}
+	}
+	return
+}
+
+func NewInvitationAggregateEngine(middleware *eh.Middleware) (ret *InvitationAggregateEngine) {
+
+	invitationAggregateExecutors := NewInvitationAggregateExecutorsFull()
+	invitationAggregateHandlers := NewInvitationAggregateHandlersFull()
+
+	entityFactory := func() eventhorizon.Entity { return NewInvitationDefault() }
+	aggregateEngine := eh.NewAggregateEngine(middleware, InvitationAggregateType,
+		func(id uuid.UUID) eventhorizon.Aggregate {
+			return &InvitationAggregate{
+				AggregateBase:      events.NewAggregateBase(InvitationAggregateType, id),
+				Invitation:         NewInvitationDefault(),
+				AggregateExecutors: invitationAggregateExecutors,
+				AggregateHandlers:  invitationAggregateHandlers,
+			}
+		}, entityFactory,
+		InvitationCommandTypes().Literals(), InvitationEventTypes().Literals())
+
+	ret = &InvitationAggregateEngine{
+		AggregateEngine:    aggregateEngine,
+		AggregateExecutors: invitationAggregateExecutors,
+		AggregateHandlers:  invitationAggregateHandlers,
+	}
+	return
+}
+
+func (o *InvitationAggregateEngine) Setup() (err error) {
+	if err = o.AggregateEngine.Setup(); err != nil {
+		return
+	}
+
+	if err = o.AggregateExecutors.SetupCommandHandler(); err != nil {
+		return
+	}
+
+	if err = o.AggregateHandlers.SetupEventHandler(); err != nil {
+		return
+	}
+	return
+}
+
 type EsEngine struct {
 	*eh.Middleware
-	Account *AccountAggregateEngine
+	Account          *AccountAggregateEngine
+	Group            *GroupAggregateEngine
+	Organization     *OrganizationAggregateEngine
+	RoleGrantRequest *RoleGrantRequestAggregateEngine
+	Role             *RoleAggregateEngine
+	RelationTuple    *RelationTupleAggregateEngine
+	Invitation       *InvitationAggregateEngine
 }
 
 func NewEsEngine(middleware *eh.Middleware) (ret *EsEngine) {
 	account := NewAccountAggregateEngine(middleware)
+	group := NewGroupAggregateEngine(middleware)
+	organization := NewOrganizationAggregateEngine(middleware)
+	roleGrantRequest := NewRoleGrantRequestAggregateEngine(middleware)
+	role := NewRoleAggregateEngine(middleware)
+	relationTuple := NewRelationTupleAggregateEngine(middleware)
+	invitation := NewInvitationAggregateEngine(middleware)
 	ret = &EsEngine{
-		Middleware: middleware,
-		Account:    account,
+		Middleware:       middleware,
+		Account:          account,
+		Group:            group,
+		Organization:     organization,
+		RoleGrantRequest: roleGrantRequest,
+		Role:             role,
+		RelationTuple:    relationTuple,
+		Invitation:       invitation,
 	}
 	return
 }
@@ -154,5 +938,29 @@ func (o *EsEngine) Setup() (err error) {
 		return
 	}
 
+	if err = o.Group.Setup(); err != nil {
+		return
+	}
+
+	if err = o.Organization.Setup(); err != nil {
+		return
+	}
+
+	if err = o.RoleGrantRequest.Setup(); err != nil {
+		return
+	}
+
+	if err = o.Role.Setup(); err != nil {
+		return
+	}
+
+	if err = o.RelationTuple.Setup(); err != nil {
+		return
+	}
+
+	if err = o.Invitation.Setup(); err != nil {
+		return
+	}
+
 	return
 }
@@ -48,10 +48,118 @@ func (o *AccountCommandType) IsDisableAccount() bool {
 	return o.name == _accountCommandTypes.DisableAccount().name
 }
 
+func (o *AccountCommandType) IsWarnInactivityAccount() bool {
+	return o.name == _accountCommandTypes.WarnInactivityAccount().name
+}
+
 func (o *AccountCommandType) IsUpdateAccount() bool {
 	return o.name == _accountCommandTypes.UpdateAccount().name
 }
 
+func (o *AccountCommandType) IsLinkIdentityAccount() bool {
+	return o.name == _accountCommandTypes.LinkIdentityAccount().name
+}
+
+func (o *AccountCommandType) IsUnlinkIdentityAccount() bool {
+	return o.name == _accountCommandTypes.UnlinkIdentityAccount().name
+}
+
+func (o *AccountCommandType) IsCreateApiKeyAccount() bool {
+	return o.name == _accountCommandTypes.CreateApiKeyAccount().name
+}
+
+func (o *AccountCommandType) IsRevokeApiKeyAccount() bool {
+	return o.name == _accountCommandTypes.RevokeApiKeyAccount().name
+}
+
+func (o *AccountCommandType) IsUseApiKeyAccount() bool {
+	return o.name == _accountCommandTypes.UseApiKeyAccount().name
+}
+
+func (o *AccountCommandType) IsImpersonateAccount() bool {
+	return o.name == _accountCommandTypes.ImpersonateAccount().name
+}
+
+func (o *AccountCommandType) IsEndImpersonationAccount() bool {
+	return o.name == _accountCommandTypes.EndImpersonationAccount().name
+}
+
+func (o *AccountCommandType) IsStartSessionAccount() bool {
+	return o.name == _accountCommandTypes.StartSessionAccount().name
+}
+
+func (o *AccountCommandType) IsTouchSessionAccount() bool {
+	return o.name == _accountCommandTypes.TouchSessionAccount().name
+}
+
+func (o *AccountCommandType) IsRevokeSessionAccount() bool {
+	return o.name == _accountCommandTypes.RevokeSessionAccount().name
+}
+
+func (o *AccountCommandType) IsRevokeSessionsAccount() bool {
+	return o.name == _accountCommandTypes.RevokeSessionsAccount().name
+}
+
+func (o *AccountCommandType) IsAssignRoleAccount() bool {
+	return o.name == _accountCommandTypes.AssignRoleAccount().name
+}
+
+func (o *AccountCommandType) IsUnassignRoleAccount() bool {
+	return o.name == _accountCommandTypes.UnassignRoleAccount().name
+}
+
+func (o *AccountCommandType) IsExpireRoleAccount() bool {
+	return o.name == _accountCommandTypes.ExpireRoleAccount().name
+}
+
+func (o *AccountCommandType) IsRegisterAccount() bool {
+	return o.name == _accountCommandTypes.RegisterAccount().name
+}
+
+func (o *AccountCommandType) IsApproveRegistrationAccount() bool {
+	return o.name == _accountCommandTypes.ApproveRegistrationAccount().name
+}
+
+func (o *AccountCommandType) IsRejectRegistrationAccount() bool {
+	return o.name == _accountCommandTypes.RejectRegistrationAccount().name
+}
+
+func (o *AccountCommandType) IsChangePasswordAccount() bool {
+	return o.name == _accountCommandTypes.ChangePasswordAccount().name
+}
+
+func (o *AccountCommandType) IsRestoreAccount() bool {
+	return o.name == _accountCommandTypes.RestoreAccount().name
+}
+
+func (o *AccountCommandType) IsPurgeAccount() bool {
+	return o.name == _accountCommandTypes.PurgeAccount().name
+}
+
+func (o *AccountCommandType) IsForgetAccount() bool {
+	return o.name == _accountCommandTypes.ForgetAccount().name
+}
+
+func (o *AccountCommandType) IsRequestEmailChangeAccount() bool {
+	return o.name == _accountCommandTypes.RequestEmailChangeAccount().name
+}
+
+func (o *AccountCommandType) IsConfirmEmailChangeAccount() bool {
+	return o.name == _accountCommandTypes.ConfirmEmailChangeAccount().name
+}
+
+func (o *AccountCommandType) IsChangeAttributesAccount() bool {
+	return o.name == _accountCommandTypes.ChangeAttributesAccount().name
+}
+
+func (o *AccountCommandType) IsMergeAccount() bool {
+	return o.name == _accountCommandTypes.MergeAccount().name
+}
+
+func (o *AccountCommandType) IsMergeIntoAccount() bool {
+	return o.name == _accountCommandTypes.MergeIntoAccount().name
+}
+
 func (o *AccountCommandType) MarshalJSON() (ret []byte, err error) {
 	ret = []byte(fmt.Sprintf("\"%v\"", o.name))
 	return
@@ -98,7 +206,34 @@ var _accountCommandTypes = &accountCommandTypes{values: []*AccountCommandType{
 	{name: "DeleteAccount", ordinal: 4},
 	{name: "EnableAccount", ordinal: 5},
 	{name: "DisableAccount", ordinal: 6},
-	{name: "UpdateAccount", ordinal: 7}},
+	{name: "WarnInactivityAccount", ordinal: 7},
+	{name: "UpdateAccount", ordinal: 8},
+	{name: "LinkIdentityAccount", ordinal: 9},
+	{name: "UnlinkIdentityAccount", ordinal: 10},
+	{name: "CreateApiKeyAccount", ordinal: 11},
+	{name: "RevokeApiKeyAccount", ordinal: 12},
+	{name: "UseApiKeyAccount", ordinal: 13},
+	{name: "ImpersonateAccount", ordinal: 14},
+	{name: "EndImpersonationAccount", ordinal: 15},
+	{name: "StartSessionAccount", ordinal: 16},
+	{name: "TouchSessionAccount", ordinal: 17},
+	{name: "RevokeSessionAccount", ordinal: 18},
+	{name: "RevokeSessionsAccount", ordinal: 19},
+	{name: "AssignRoleAccount", ordinal: 20},
+	{name: "UnassignRoleAccount", ordinal: 21},
+	{name: "ExpireRoleAccount", ordinal: 22},
+	{name: "RegisterAccount", ordinal: 23},
+	{name: "ApproveRegistrationAccount", ordinal: 24},
+	{name: "RejectRegistrationAccount", ordinal: 25},
+	{name: "ChangePasswordAccount", ordinal: 26},
+	{name: "RestoreAccount", ordinal: 27},
+	{name: "PurgeAccount", ordinal: 28},
+	{name: "ForgetAccount", ordinal: 29},
+	{name: "RequestEmailChangeAccount", ordinal: 30},
+	{name: "ConfirmEmailChangeAccount", ordinal: 31},
+	{name: "ChangeAttributesAccount", ordinal: 32},
+	{name: "MergeAccount", ordinal: 33},
+	{name: "MergeIntoAccount", ordinal: 34}},
 }
 
 func AccountCommandTypes() *accountCommandTypes {
@@ -137,10 +272,118 @@ func (o *accountCommandTypes) DisableAccount() *AccountCommandType {
 	return o.values[6]
 }
 
-func (o *accountCommandTypes) UpdateAccount() *AccountCommandType {
+func (o *accountCommandTypes) WarnInactivityAccount() *AccountCommandType {
 	return o.values[7]
 }
 
+func (o *accountCommandTypes) UpdateAccount() *AccountCommandType {
+	return o.values[8]
+}
+
+func (o *accountCommandTypes) LinkIdentityAccount() *AccountCommandType {
+	return o.values[9]
+}
+
+func (o *accountCommandTypes) UnlinkIdentityAccount() *AccountCommandType {
+	return o.values[10]
+}
+
+func (o *accountCommandTypes) CreateApiKeyAccount() *AccountCommandType {
+	return o.values[11]
+}
+
+func (o *accountCommandTypes) RevokeApiKeyAccount() *AccountCommandType {
+	return o.values[12]
+}
+
+func (o *accountCommandTypes) UseApiKeyAccount() *AccountCommandType {
+	return o.values[13]
+}
+
+func (o *accountCommandTypes) ImpersonateAccount() *AccountCommandType {
+	return o.values[14]
+}
+
+func (o *accountCommandTypes) EndImpersonationAccount() *AccountCommandType {
+	return o.values[15]
+}
+
+func (o *accountCommandTypes) StartSessionAccount() *AccountCommandType {
+	return o.values[16]
+}
+
+func (o *accountCommandTypes) TouchSessionAccount() *AccountCommandType {
+	return o.values[17]
+}
+
+func (o *accountCommandTypes) RevokeSessionAccount() *AccountCommandType {
+	return o.values[18]
+}
+
+func (o *accountCommandTypes) RevokeSessionsAccount() *AccountCommandType {
+	return o.values[19]
+}
+
+func (o *accountCommandTypes) AssignRoleAccount() *AccountCommandType {
+	return o.values[20]
+}
+
+func (o *accountCommandTypes) UnassignRoleAccount() *AccountCommandType {
+	return o.values[21]
+}
+
+func (o *accountCommandTypes) ExpireRoleAccount() *AccountCommandType {
+	return o.values[22]
+}
+
+func (o *accountCommandTypes) RegisterAccount() *AccountCommandType {
+	return o.values[23]
+}
+
+func (o *accountCommandTypes) ApproveRegistrationAccount() *AccountCommandType {
+	return o.values[24]
+}
+
+func (o *accountCommandTypes) RejectRegistrationAccount() *AccountCommandType {
+	return o.values[25]
+}
+
+func (o *accountCommandTypes) ChangePasswordAccount() *AccountCommandType {
+	return o.values[26]
+}
+
+func (o *accountCommandTypes) RestoreAccount() *AccountCommandType {
+	return o.values[27]
+}
+
+func (o *accountCommandTypes) PurgeAccount() *AccountCommandType {
+	return o.values[28]
+}
+
+func (o *accountCommandTypes) ForgetAccount() *AccountCommandType {
+	return o.values[29]
+}
+
+func (o *accountCommandTypes) RequestEmailChangeAccount() *AccountCommandType {
+	return o.values[30]
+}
+
+func (o *accountCommandTypes) ConfirmEmailChangeAccount() *AccountCommandType {
+	return o.values[31]
+}
+
+func (o *accountCommandTypes) ChangeAttributesAccount() *AccountCommandType {
+	return o.values[32]
+}
+
+func (o *accountCommandTypes) MergeAccount() *AccountCommandType {
+	return o.values[33]
+}
+
+func (o *accountCommandTypes) MergeIntoAccount() *AccountCommandType {
+	return o.values[34]
+}
+
 func (o *accountCommandTypes) ParseAccountCommandType(name string) (ret *AccountCommandType, ok bool) {
 	for _, lit := range o.Values() {
 		if strings.EqualFold(lit.Name(), name) {
@@ -160,3 +403,720 @@ func (o *accountCommandTypes) Literals() []enum.Literal {
 	}
 	return o.valuesAsLiterals
 }
+
+type GroupCommandType struct {
+	name    string
+	ordinal int
+}
+
+func (o *GroupCommandType) Name() string {
+	return o.name
+}
+
+func (o *GroupCommandType) Ordinal() int {
+	return o.ordinal
+}
+
+func (o *GroupCommandType) IsCreateGroup() bool {
+	return o.name == _groupCommandTypes.CreateGroup().name
+}
+
+func (o *GroupCommandType) IsUpdateGroup() bool {
+	return o.name == _groupCommandTypes.UpdateGroup().name
+}
+
+func (o *GroupCommandType) IsDeleteGroup() bool {
+	return o.name == _groupCommandTypes.DeleteGroup().name
+}
+
+func (o *GroupCommandType) IsAddMemberGroup() bool {
+	return o.name == _groupCommandTypes.AddMemberGroup().name
+}
+
+func (o *GroupCommandType) IsRemoveMemberGroup() bool {
+	return o.name == _groupCommandTypes.RemoveMemberGroup().name
+}
+
+func (o *GroupCommandType) IsAddSubgroupGroup() bool {
+	return o.name == _groupCommandTypes.AddSubgroupGroup().name
+}
+
+func (o *GroupCommandType) IsRemoveSubgroupGroup() bool {
+	return o.name == _groupCommandTypes.RemoveSubgroupGroup().name
+}
+
+func (o *GroupCommandType) MarshalJSON() (ret []byte, err error) {
+	ret = []byte(fmt.Sprintf("\"%v\"", o.name))
+	return
+}
+
+func (o *GroupCommandType) UnmarshalJSON(data []byte) (err error) {
+	name := string(data)
+	//remove quotes
+	name = name[1 : len(name)-1]
+	if v, ok := GroupCommandTypes().ParseGroupCommandType(name); ok {
+		*o = *v
+	} else {
+		err = fmt.Errorf("invalid GroupCommandType %q", name)
+	}
+	return
+}
+
+func (o *GroupCommandType) GetBSON() (ret interface{}, err error) {
+	return o.name, nil
+}
+
+func (o *GroupCommandType) SetBSON(raw bson.Raw) (err error) {
+	var lit string
+	if err = raw.Unmarshal(&lit); err == nil {
+		if v, ok := GroupCommandTypes().ParseGroupCommandType(lit); ok {
+			*o = *v
+		} else {
+			err = fmt.Errorf("invalid GroupCommandType %q", lit)
+		}
+	}
+	return
+}
+
+type groupCommandTypes struct {
+	values           []*GroupCommandType
+	valuesAsLiterals []enum.Literal
+}
+
+var _groupCommandTypes = &groupCommandTypes{values: []*GroupCommandType{
+	{name: "CreateGroup", ordinal: 0},
+	{name: "UpdateGroup", ordinal: 1},
+	{name: "DeleteGroup", ordinal: 2},
+	{name: "AddMemberGroup", ordinal: 3},
+	{name: "RemoveMemberGroup", ordinal: 4},
+	{name: "AddSubgroupGroup", ordinal: 5},
+	{name: "RemoveSubgroupGroup", ordinal: 6}},
+}
+
+func GroupCommandTypes() *groupCommandTypes {
+	return _groupCommandTypes
+}
+
+func (o *groupCommandTypes) Values() []*GroupCommandType {
+	return o.values
+}
+
+func (o *groupCommandTypes) CreateGroup() *GroupCommandType {
+	return o.values[0]
+}
+
+func (o *groupCommandTypes) UpdateGroup() *GroupCommandType {
+	return o.values[1]
+}
+
+func (o *groupCommandTypes) DeleteGroup() *GroupCommandType {
+	return o.values[2]
+}
+
+func (o *groupCommandTypes) AddMemberGroup() *GroupCommandType {
+	return o.values[3]
+}
+
+func (o *groupCommandTypes) RemoveMemberGroup() *GroupCommandType {
+	return o.values[4]
+}
+
+func (o *groupCommandTypes) AddSubgroupGroup() *GroupCommandType {
+	return o.values[5]
+}
+
+func (o *groupCommandTypes) RemoveSubgroupGroup() *GroupCommandType {
+	return o.values[6]
+}
+
+func (o *groupCommandTypes) ParseGroupCommandType(name string) (ret *GroupCommandType, ok bool) {
+	for _, lit := range o.Values() {
+		if strings.EqualFold(lit.Name(), name) {
+			return lit, true
+		}
+	}
+	return nil, false
+}
+
+// we have to convert the instances to Literal interface, because it is not a other way in Go
+func (o *groupCommandTypes) Literals() []enum.Literal {
+	if o.valuesAsLiterals == nil {
+		o.valuesAsLiterals = make([]enum.Literal, len(o.values))
+		for i, item := range o.values {
+			o.valuesAsLiterals[i] = item
+		}
+	}
+	return o.valuesAsLiterals
+}
+
+type OrganizationCommandType struct {
+	name    string
+	ordinal int
+}
+
+func (o *OrganizationCommandType) Name() string {
+	return o.name
+}
+
+func (o *OrganizationCommandType) Ordinal() int {
+	return o.ordinal
+}
+
+func (o *OrganizationCommandType) IsCreateOrganization() bool {
+	return o.name == _organizationCommandTypes.CreateOrganization().name
+}
+
+func (o *OrganizationCommandType) IsUpdateOrganization() bool {
+	return o.name == _organizationCommandTypes.UpdateOrganization().name
+}
+
+func (o *OrganizationCommandType) IsDeleteOrganization() bool {
+	return o.name == _organizationCommandTypes.DeleteOrganization().name
+}
+
+func (o *OrganizationCommandType) MarshalJSON() (ret []byte, err error) {
+	ret = []byte(fmt.Sprintf("\"%v\"", o.name))
+	return
+}
+
+func (o *OrganizationCommandType) UnmarshalJSON(data []byte) (err error) {
+	name := string(data)
+	//remove quotes
+	name = name[1 : len(name)-1]
+	if v, ok := OrganizationCommandTypes().ParseOrganizationCommandType(name); ok {
+		*o = *v
+	} else {
+		err = fmt.Errorf("invalid OrganizationCommandType %q", name)
+	}
+	return
+}
+
+func (o *OrganizationCommandType) GetBSON() (ret interface{}, err error) {
+	return o.name, nil
+}
+
+func (o *OrganizationCommandType) SetBSON(raw bson.Raw) (err error) {
+	var lit string
+	if err = raw.Unmarshal(&lit); err == nil {
+		if v, ok := OrganizationCommandTypes().ParseOrganizationCommandType(lit); ok {
+			*o = *v
+		} else {
+			err = fmt.Errorf("invalid OrganizationCommandType %q", lit)
+		}
+	}
+	return
+}
+
+type organizationCommandTypes struct {
+	values           []*OrganizationCommandType
+	valuesAsLiterals []enum.Literal
+}
+
+var _organizationCommandTypes = &organizationCommandTypes{values: []*OrganizationCommandType{
+	{name: "CreateOrganization", ordinal: 0},
+	{name: "UpdateOrganization", ordinal: 1},
+	{name: "DeleteOrganization", ordinal: 2}},
+}
+
+func OrganizationCommandTypes() *organizationCommandTypes {
+	return _organizationCommandTypes
+}
+
+func (o *organizationCommandTypes) Values() []*OrganizationCommandType {
+	return o.values
+}
+
+func (o *organizationCommandTypes) CreateOrganization() *OrganizationCommandType {
+	return o.values[0]
+}
+
+func (o *organizationCommandTypes) UpdateOrganization() *OrganizationCommandType {
+	return o.values[1]
+}
+
+func (o *organizationCommandTypes) DeleteOrganization() *OrganizationCommandType {
+	return o.values[2]
+}
+
+func (o *organizationCommandTypes) ParseOrganizationCommandType(name string) (ret *OrganizationCommandType, ok bool) {
+	for _, lit := range o.Values() {
+		if strings.EqualFold(lit.Name(), name) {
+			return lit, true
+		}
+	}
+	return nil, false
+}
+
+// we have to convert the instances to Literal interface, because it is not a other way in Go
+func (o *organizationCommandTypes) Literals() []enum.Literal {
+	if o.valuesAsLiterals == nil {
+		o.valuesAsLiterals = make([]enum.Literal, len(o.values))
+		for i, item := range o.values {
+			o.valuesAsLiterals[i] = item
+		}
+	}
+	return o.valuesAsLiterals
+}
+
+type RoleGrantRequestCommandType struct {
+	name    string
+	ordinal int
+}
+
+func (o *RoleGrantRequestCommandType) Name() string {
+	return o.name
+}
+
+func (o *RoleGrantRequestCommandType) Ordinal() int {
+	return o.ordinal
+}
+
+func (o *RoleGrantRequestCommandType) IsCreateRoleGrantRequest() bool {
+	return o.name == _roleGrantRequestCommandTypes.CreateRoleGrantRequest().name
+}
+
+func (o *RoleGrantRequestCommandType) IsUpdateRoleGrantRequest() bool {
+	return o.name == _roleGrantRequestCommandTypes.UpdateRoleGrantRequest().name
+}
+
+func (o *RoleGrantRequestCommandType) IsDeleteRoleGrantRequest() bool {
+	return o.name == _roleGrantRequestCommandTypes.DeleteRoleGrantRequest().name
+}
+
+func (o *RoleGrantRequestCommandType) IsApproveRoleGrantRequest() bool {
+	return o.name == _roleGrantRequestCommandTypes.ApproveRoleGrantRequest().name
+}
+
+func (o *RoleGrantRequestCommandType) IsRejectRoleGrantRequest() bool {
+	return o.name == _roleGrantRequestCommandTypes.RejectRoleGrantRequest().name
+}
+
+func (o *RoleGrantRequestCommandType) MarshalJSON() (ret []byte, err error) {
+	ret = []byte(fmt.Sprintf("\"%v\"", o.name))
+	return
+}
+
+func (o *RoleGrantRequestCommandType) UnmarshalJSON(data []byte) (err error) {
+	name := string(data)
+	//remove quotes
+	name = name[1 : len(name)-1]
+	if v, ok := RoleGrantRequestCommandTypes().ParseRoleGrantRequestCommandType(name); ok {
+		*o = *v
+	} else {
+		err = fmt.Errorf("invalid RoleGrantRequestCommandType %q", name)
+	}
+	return
+}
+
+func (o *RoleGrantRequestCommandType) GetBSON() (ret interface{}, err error) {
+	return o.name, nil
+}
+
+func (o *RoleGrantRequestCommandType) SetBSON(raw bson.Raw) (err error) {
+	var lit string
+	if err = raw.Unmarshal(&lit); err == nil {
+		if v, ok := RoleGrantRequestCommandTypes().ParseRoleGrantRequestCommandType(lit); ok {
+			*o = *v
+		} else {
+			err = fmt.Errorf("invalid RoleGrantRequestCommandType %q", lit)
+		}
+	}
+	return
+}
+
+type roleGrantRequestCommandTypes struct {
+	values           []*RoleGrantRequestCommandType
+	valuesAsLiterals []enum.Literal
+}
+
+var _roleGrantRequestCommandTypes = &roleGrantRequestCommandTypes{values: []*RoleGrantRequestCommandType{
+	{name: "CreateRoleGrantRequest", ordinal: 0},
+	{name: "UpdateRoleGrantRequest", ordinal: 1},
+	{name: "DeleteRoleGrantRequest", ordinal: 2},
+	{name: "ApproveRoleGrantRequest", ordinal: 3},
+	{name: "RejectRoleGrantRequest", ordinal: 4}},
+}
+
+func RoleGrantRequestCommandTypes() *roleGrantRequestCommandTypes {
+	return _roleGrantRequestCommandTypes
+}
+
+func (o *roleGrantRequestCommandTypes) Values() []*RoleGrantRequestCommandType {
+	return o.values
+}
+
+func (o *roleGrantRequestCommandTypes) CreateRoleGrantRequest() *RoleGrantRequestCommandType {
+	return o.values[0]
+}
+
+func (o *roleGrantRequestCommandTypes) UpdateRoleGrantRequest() *RoleGrantRequestCommandType {
+	return o.values[1]
+}
+
+func (o *roleGrantRequestCommandTypes) DeleteRoleGrantRequest() *RoleGrantRequestCommandType {
+	return o.values[2]
+}
+
+func (o *roleGrantRequestCommandTypes) ApproveRoleGrantRequest() *RoleGrantRequestCommandType {
+	return o.values[3]
+}
+
+func (o *roleGrantRequestCommandTypes) RejectRoleGrantRequest() *RoleGrantRequestCommandType {
+	return o.values[4]
+}
+
+func (o *roleGrantRequestCommandTypes) ParseRoleGrantRequestCommandType(name string) (ret *RoleGrantRequestCommandType, ok bool) {
+	for _, lit := range o.Values() {
+		if strings.EqualFold(lit.Name(), name) {
+			return lit, true
+		}
+	}
+	return nil, false
+}
+
+// we have to convert the instances to Literal interface, because it is not a other way in Go
+func (o *roleGrantRequestCommandTypes) Literals() []enum.Literal {
+	if o.valuesAsLiterals == nil {
+		o.valuesAsLiterals = make([]enum.Literal, len(o.values))
+		for i, item := range o.values {
+			o.valuesAsLiterals[i] = item
+		}
+	}
+	return o.valuesAsLiterals
+}
+
+type RoleCommandType struct {
+	name    string
+	ordinal int
+}
+
+func (o *RoleCommandType) Name() string {
+	return o.name
+}
+
+func (o *RoleCommandType) Ordinal() int {
+	return o.ordinal
+}
+
+func (o *RoleCommandType) IsCreateRole() bool {
+	return o.name == _roleCommandTypes.CreateRole().name
+}
+
+func (o *RoleCommandType) IsUpdateRole() bool {
+	return o.name == _roleCommandTypes.UpdateRole().name
+}
+
+func (o *RoleCommandType) IsDeleteRole() bool {
+	return o.name == _roleCommandTypes.DeleteRole().name
+}
+
+func (o *RoleCommandType) MarshalJSON() (ret []byte, err error) {
+	ret = []byte(fmt.Sprintf("\"%v\"", o.name))
+	return
+}
+
+func (o *RoleCommandType) UnmarshalJSON(data []byte) (err error) {
+	name := string(data)
+	//remove quotes
+	name = name[1 : len(name)-1]
+	if v, ok := RoleCommandTypes().ParseRoleCommandType(name); ok {
+		*o = *v
+	} else {
+		err = fmt.Errorf("invalid RoleCommandType %q", name)
+	}
+	return
+}
+
+func (o *RoleCommandType) GetBSON() (ret interface{}, err error) {
+	return o.name, nil
+}
+
+func (o *RoleCommandType) SetBSON(raw bson.Raw) (err error) {
+	var lit string
+	if err = raw.Unmarshal(&lit); err == nil {
+		if v, ok := RoleCommandTypes().ParseRoleCommandType(lit); ok {
+			*o = *v
+		} else {
+			err = fmt.Errorf("invalid RoleCommandType %q", lit)
+		}
+	}
+	return
+}
+
+type roleCommandTypes struct {
+	values           []*RoleCommandType
+	valuesAsLiterals []enum.Literal
+}
+
+var _roleCommandTypes = &roleCommandTypes{values: []*RoleCommandType{
+	{name: "CreateRole", ordinal: 0},
+	{name: "UpdateRole", ordinal: 1},
+	{name: "DeleteRole", ordinal: 2}},
+}
+
+func RoleCommandTypes() *roleCommandTypes {
+	return _roleCommandTypes
+}
+
+func (o *roleCommandTypes) Values() []*RoleCommandType {
+	return o.values
+}
+
+func (o *roleCommandTypes) CreateRole() *RoleCommandType {
+	return o.values[0]
+}
+
+func (o *roleCommandTypes) UpdateRole() *RoleCommandType {
+	return o.values[1]
+}
+
+func (o *roleCommandTypes) DeleteRole() *RoleCommandType {
+	return o.values[2]
+}
+
+func (o *roleCommandTypes) ParseRoleCommandType(name string) (ret *RoleCommandType, ok bool) {
+	for _, lit := range o.Values() {
+		if strings.EqualFold(lit.Name(), name) {
+			return lit, true
+		}
+	}
+	return nil, false
+}
+
+// we have to convert the instances to Literal interface, because it is not a other way in Go
+func (o *roleCommandTypes) Literals() []enum.Literal {
+	if o.valuesAsLiterals == nil {
+		o.valuesAsLiterals = make([]enum.Literal, len(o.values))
+		for i, item := range o.values {
+			o.valuesAsLiterals[i] = item
+		}
+	}
+	return o.valuesAsLiterals
+}
+
+type RelationTupleCommandType struct {
+	name    string
+	ordinal int
+}
+
+func (o *RelationTupleCommandType) Name() string {
+	return o.name
+}
+
+func (o *RelationTupleCommandType) Ordinal() int {
+	return o.ordinal
+}
+
+func (o *RelationTupleCommandType) IsCreateRelationTuple() bool {
+	return o.name == _relationTupleCommandTypes.CreateRelationTuple().name
+}
+
+func (o *RelationTupleCommandType) IsDeleteRelationTuple() bool {
+	return o.name == _relationTupleCommandTypes.DeleteRelationTuple().name
+}
+
+func (o *RelationTupleCommandType) MarshalJSON() (ret []byte, err error) {
+	ret = []byte(fmt.Sprintf("\"%v\"", o.name))
+	return
+}
+
+func (o *RelationTupleCommandType) UnmarshalJSON(data []byte) (err error) {
+	name := string(data)
+	//remove quotes
+	name = name[1 : len(name)-1]
+	if v, ok := RelationTupleCommandTypes().ParseRelationTupleCommandType(name); ok {
+		*o = *v
+	} else {
+		err = fmt.Errorf("invalid RelationTupleCommandType %q", name)
+	}
+	return
+}
+
+func (o *RelationTupleCommandType) GetBSON() (ret interface{}, err error) {
+	return o.name, nil
+}
+
+func (o *RelationTupleCommandType) SetBSON(raw bson.Raw) (err error) {
+	var lit string
+	if err = raw.Unmarshal(&lit); err == nil {
+		if v, ok := RelationTupleCommandTypes().ParseRelationTupleCommandType(lit); ok {
+			*o = *v
+		} else {
+			err = fmt.Errorf("invalid RelationTupleCommandType %q", lit)
+		}
+	}
+	return
+}
+
+type relationTupleCommandTypes struct {
+	values           []*RelationTupleCommandType
+	valuesAsLiterals []enum.Literal
+}
+
+var _relationTupleCommandTypes = &relationTupleCommandTypes{values: []*RelationTupleCommandType{
+	{name: "CreateRelationTuple", ordinal: 0},
+	{name: "DeleteRelationTuple", ordinal: 1}},
+}
+
+func RelationTupleCommandTypes() *relationTupleCommandTypes {
+	return _relationTupleCommandTypes
+}
+
+func (o *relationTupleCommandTypes) Values() []*RelationTupleCommandType {
+	return o.values
+}
+
+func (o *relationTupleCommandTypes) CreateRelationTuple() *RelationTupleCommandType {
+	return o.values[0]
+}
+
+func (o *relationTupleCommandTypes) DeleteRelationTuple() *RelationTupleCommandType {
+	return o.values[1]
+}
+
+func (o *relationTupleCommandTypes) ParseRelationTupleCommandType(name string) (ret *RelationTupleCommandType, ok bool) {
+	for _, lit := range o.Values() {
+		if strings.EqualFold(lit.Name(), name) {
+			return lit, true
+		}
+	}
+	return nil, false
+}
+
+// we have to convert the instances to Literal interface, because it is not a other way in Go
+func (o *relationTupleCommandTypes) Literals() []enum.Literal {
+	if o.valuesAsLiterals == nil {
+		o.valuesAsLiterals = make([]enum.Literal, len(o.values))
+		for i, item := range o.values {
+			o.valuesAsLiterals[i] = item
+		}
+	}
+	return o.valuesAsLiterals
+}
+
+type InvitationCommandType struct {
+	name    string
+	ordinal int
+}
+
+func (o *InvitationCommandType) Name() string {
+	return o.name
+}
+
+func (o *InvitationCommandType) Ordinal() int {
+	return o.ordinal
+}
+
+func (o *InvitationCommandType) IsCreateInvitation() bool {
+	return o.name == _invitationCommandTypes.CreateInvitation().name
+}
+
+func (o *InvitationCommandType) IsDeleteInvitation() bool {
+	return o.name == _invitationCommandTypes.DeleteInvitation().name
+}
+
+func (o *InvitationCommandType) IsResendInvitation() bool {
+	return o.name == _invitationCommandTypes.ResendInvitation().name
+}
+
+func (o *InvitationCommandType) IsRevokeInvitation() bool {
+	return o.name == _invitationCommandTypes.RevokeInvitation().name
+}
+
+func (o *InvitationCommandType) IsAcceptInvitation() bool {
+	return o.name == _invitationCommandTypes.AcceptInvitation().name
+}
+
+func (o *InvitationCommandType) MarshalJSON() (ret []byte, err error) {
+	ret = []byte(fmt.Sprintf("\"%v\"", o.name))
+	return
+}
+
+func (o *InvitationCommandType) UnmarshalJSON(data []byte) (err error) {
+	name := string(data)
+	//remove quotes
+	name = name[1 : len(name)-1]
+	if v, ok := InvitationCommandTypes().ParseInvitationCommandType(name); ok {
+		*o = *v
+	} else {
+		err = fmt.Errorf("invalid InvitationCommandType %q", name)
+	}
+	return
+}
+
+func (o *InvitationCommandType) GetBSON() (ret interface{}, err error) {
+	return o.name, nil
+}
+
+func (o *InvitationCommandType) SetBSON(raw bson.Raw) (err error) {
+	var lit string
+	if err = raw.Unmarshal(&lit); err == nil {
+		if v, ok := InvitationCommandTypes().ParseInvitationCommandType(lit); ok {
+			*o = *v
+		} else {
+			err = fmt.Errorf("invalid InvitationCommandType %q", lit)
+		}
+	}
+	return
+}
+
+type invitationCommandTypes struct {
+	values           []*InvitationCommandType
+	valuesAsLiterals []enum.Literal
+}
+
+var _invitationCommandTypes = &invitationCommandTypes{values: []*InvitationCommandType{
+	{name: "CreateInvitation", ordinal: 0},
+	{name: "DeleteInvitation", ordinal: 1},
+	{name: "ResendInvitation", ordinal: 2},
+	{name: "RevokeInvitation", ordinal: 3},
+	{name: "AcceptInvitation", ordinal: 4}},
+}
+
+func InvitationCommandTypes() *invitationCommandTypes {
+	return _invitationCommandTypes
+}
+
+func (o *invitationCommandTypes) Values() []*InvitationCommandType {
+	return o.values
+}
+
+func (o *invitationCommandTypes) CreateInvitation() *InvitationCommandType {
+	return o.values[0]
+}
+
+func (o *invitationCommandTypes) DeleteInvitation() *InvitationCommandType {
+	return o.values[1]
+}
+
+func (o *invitationCommandTypes) ResendInvitation() *InvitationCommandType {
+	return o.values[2]
+}
+
+func (o *invitationCommandTypes) RevokeInvitation() *InvitationCommandType {
+	return o.values[3]
+}
+
+func (o *invitationCommandTypes) AcceptInvitation() *InvitationCommandType {
+	return o.values[4]
+}
+
+func (o *invitationCommandTypes) ParseInvitationCommandType(name string) (ret *InvitationCommandType, ok bool) {
+	for _, lit := range o.Values() {
+		if strings.EqualFold(lit.Name(), name) {
+			return lit, true
+		}
+	}
+	return nil, false
+}
+
+// we have to convert the instances to Literal interface, because it is not a other way in Go
+func (o *invitationCommandTypes) Literals() []enum.Literal {
+	if o.valuesAsLiterals == nil {
+		o.valuesAsLiterals = make([]enum.Literal, len(o.values))
+		for i, item := range o.values {
+			o.valuesAsLiterals[i] = item
+		}
+	}
+	return o.valuesAsLiterals
+}
@@ -20,10 +20,22 @@ func (o *AccountEventType) Ordinal() int {
 	return o.ordinal
 }
 
+func (o *AccountEventType) IsAccountAssignedRole() bool {
+	return o.name == _accountEventTypes.AccountAssignedRole().name
+}
+
+func (o *AccountEventType) IsAccountAttributesChanged() bool {
+	return o.name == _accountEventTypes.AccountAttributesChanged().name
+}
+
 func (o *AccountEventType) IsAccountCreated() bool {
 	return o.name == _accountEventTypes.AccountCreated().name
 }
 
+func (o *AccountEventType) IsAccountCreatedApiKey() bool {
+	return o.name == _accountEventTypes.AccountCreatedApiKey().name
+}
+
 func (o *AccountEventType) IsAccountDeleted() bool {
 	return o.name == _accountEventTypes.AccountDeleted().name
 }
@@ -32,14 +44,82 @@ func (o *AccountEventType) IsAccountDisabled() bool {
 	return o.name == _accountEventTypes.AccountDisabled().name
 }
 
+func (o *AccountEventType) IsAccountEmailChangeConfirmed() bool {
+	return o.name == _accountEventTypes.AccountEmailChangeConfirmed().name
+}
+
+func (o *AccountEventType) IsAccountEmailChangeRequested() bool {
+	return o.name == _accountEventTypes.AccountEmailChangeRequested().name
+}
+
 func (o *AccountEventType) IsAccountEnabled() bool {
 	return o.name == _accountEventTypes.AccountEnabled().name
 }
 
+func (o *AccountEventType) IsAccountForgotten() bool {
+	return o.name == _accountEventTypes.AccountForgotten().name
+}
+
+func (o *AccountEventType) IsAccountInactivityWarned() bool {
+	return o.name == _accountEventTypes.AccountInactivityWarned().name
+}
+
+func (o *AccountEventType) IsAccountLinkedIdentity() bool {
+	return o.name == _accountEventTypes.AccountLinkedIdentity().name
+}
+
 func (o *AccountEventType) IsAccountLogged() bool {
 	return o.name == _accountEventTypes.AccountLogged().name
 }
 
+func (o *AccountEventType) IsAccountMerged() bool {
+	return o.name == _accountEventTypes.AccountMerged().name
+}
+
+func (o *AccountEventType) IsAccountMergedInto() bool {
+	return o.name == _accountEventTypes.AccountMergedInto().name
+}
+
+func (o *AccountEventType) IsAccountPasswordChanged() bool {
+	return o.name == _accountEventTypes.AccountPasswordChanged().name
+}
+
+func (o *AccountEventType) IsAccountPurged() bool {
+	return o.name == _accountEventTypes.AccountPurged().name
+}
+
+func (o *AccountEventType) IsAccountRegistered() bool {
+	return o.name == _accountEventTypes.AccountRegistered().name
+}
+
+func (o *AccountEventType) IsAccountRegistrationApproved() bool {
+	return o.name == _accountEventTypes.AccountRegistrationApproved().name
+}
+
+func (o *AccountEventType) IsAccountRegistrationRejected() bool {
+	return o.name == _accountEventTypes.AccountRegistrationRejected().name
+}
+
+func (o *AccountEventType) IsAccountRestored() bool {
+	return o.name == _accountEventTypes.AccountRestored().name
+}
+
+func (o *AccountEventType) IsAccountRevokedApiKey() bool {
+	return o.name == _accountEventTypes.AccountRevokedApiKey().name
+}
+
+func (o *AccountEventType) IsAccountRevokedSession() bool {
+	return o.name == _accountEventTypes.AccountRevokedSession().name
+}
+
+func (o *AccountEventType) IsAccountRevokedSessions() bool {
+	return o.name == _accountEventTypes.AccountRevokedSessions().name
+}
+
+func (o *AccountEventType) IsAccountRoleExpired() bool {
+	return o.name == _accountEventTypes.AccountRoleExpired().name
+}
+
 func (o *AccountEventType) IsAccountSentDisabledConfirmation() bool {
 	return o.name == _accountEventTypes.AccountSentDisabledConfirmation().name
 }
@@ -48,10 +128,38 @@ func (o *AccountEventType) IsAccountSentEnabledConfirmation() bool {
 	return o.name == _accountEventTypes.AccountSentEnabledConfirmation().name
 }
 
+func (o *AccountEventType) IsAccountStartedSession() bool {
+	return o.name == _accountEventTypes.AccountStartedSession().name
+}
+
+func (o *AccountEventType) IsAccountTouchedSession() bool {
+	return o.name == _accountEventTypes.AccountTouchedSession().name
+}
+
+func (o *AccountEventType) IsAccountUnassignedRole() bool {
+	return o.name == _accountEventTypes.AccountUnassignedRole().name
+}
+
+func (o *AccountEventType) IsAccountUnlinkedIdentity() bool {
+	return o.name == _accountEventTypes.AccountUnlinkedIdentity().name
+}
+
 func (o *AccountEventType) IsAccountUpdated() bool {
 	return o.name == _accountEventTypes.AccountUpdated().name
 }
 
+func (o *AccountEventType) IsAccountUsedApiKey() bool {
+	return o.name == _accountEventTypes.AccountUsedApiKey().name
+}
+
+func (o *AccountEventType) IsImpersonationEnded() bool {
+	return o.name == _accountEventTypes.ImpersonationEnded().name
+}
+
+func (o *AccountEventType) IsImpersonationStarted() bool {
+	return o.name == _accountEventTypes.ImpersonationStarted().name
+}
+
 func (o *AccountEventType) MarshalJSON() (ret []byte, err error) {
 	ret = []byte(fmt.Sprintf("\"%v\"", o.name))
 	return
@@ -91,14 +199,41 @@ type accountEventTypes struct {
 }
 
 var _accountEventTypes = &accountEventTypes{values: []*AccountEventType{
-	{name: "AccountCreated", ordinal: 0},
-	{name: "AccountDeleted", ordinal: 1},
-	{name: "AccountDisabled", ordinal: 2},
-	{name: "AccountEnabled", ordinal: 3},
-	{name: "AccountLogged", ordinal: 4},
-	{name: "AccountSentDisabledConfirmation", ordinal: 5},
-	{name: "AccountSentEnabledConfirmation", ordinal: 6},
-	{name: "AccountUpdated", ordinal: 7}},
+	{name: "AccountAssignedRole", ordinal: 0},
+	{name: "AccountAttributesChanged", ordinal: 1},
+	{name: "AccountCreated", ordinal: 2},
+	{name: "AccountCreatedApiKey", ordinal: 3},
+	{name: "AccountDeleted", ordinal: 4},
+	{name: "AccountDisabled", ordinal: 5},
+	{name: "AccountEmailChangeConfirmed", ordinal: 6},
+	{name: "AccountEmailChangeRequested", ordinal: 7},
+	{name: "AccountEnabled", ordinal: 8},
+	{name: "AccountForgotten", ordinal: 9},
+	{name: "AccountInactivityWarned", ordinal: 10},
+	{name: "AccountLinkedIdentity", ordinal: 11},
+	{name: "AccountLogged", ordinal: 12},
+	{name: "AccountMerged", ordinal: 13},
+	{name: "AccountMergedInto", ordinal: 14},
+	{name: "AccountPasswordChanged", ordinal: 15},
+	{name: "AccountPurged", ordinal: 16},
+	{name: "AccountRegistered", ordinal: 17},
+	{name: "AccountRegistrationApproved", ordinal: 18},
+	{name: "AccountRegistrationRejected", ordinal: 19},
+	{name: "AccountRestored", ordinal: 20},
+	{name: "AccountRevokedApiKey", ordinal: 21},
+	{name: "AccountRevokedSession", ordinal: 22},
+	{name: "AccountRevokedSessions", ordinal: 23},
+	{name: "AccountRoleExpired", ordinal: 24},
+	{name: "AccountSentDisabledConfirmation", ordinal: 25},
+	{name: "AccountSentEnabledConfirmation", ordinal: 26},
+	{name: "AccountStartedSession", ordinal: 27},
+	{name: "AccountTouchedSession", ordinal: 28},
+	{name: "AccountUnassignedRole", ordinal: 29},
+	{name: "AccountUnlinkedIdentity", ordinal: 30},
+	{name: "AccountUpdated", ordinal: 31},
+	{name: "AccountUsedApiKey", ordinal: 32},
+	{name: "ImpersonationEnded", ordinal: 33},
+	{name: "ImpersonationStarted", ordinal: 34}},
 }
 
 func AccountEventTypes() *accountEventTypes {
@@ -109,38 +244,146 @@ func (o *accountEventTypes) Values() []*AccountEventType {
 	return o.values
 }
 
-func (o *accountEventTypes) AccountCreated() *AccountEventType {
+func (o *accountEventTypes) AccountAssignedRole() *AccountEventType {
 	return o.values[0]
 }
 
-func (o *accountEventTypes) AccountDeleted() *AccountEventType {
+func (o *accountEventTypes) AccountAttributesChanged() *AccountEventType {
 	return o.values[1]
 }
 
-func (o *accountEventTypes) AccountDisabled() *AccountEventType {
+func (o *accountEventTypes) AccountCreated() *AccountEventType {
 	return o.values[2]
 }
 
-func (o *accountEventTypes) AccountEnabled() *AccountEventType {
+func (o *accountEventTypes) AccountCreatedApiKey() *AccountEventType {
 	return o.values[3]
 }
 
-func (o *accountEventTypes) AccountLogged() *AccountEventType {
+func (o *accountEventTypes) AccountDeleted() *AccountEventType {
 	return o.values[4]
 }
 
-func (o *accountEventTypes) AccountSentDisabledConfirmation() *AccountEventType {
+func (o *accountEventTypes) AccountDisabled() *AccountEventType {
 	return o.values[5]
 }
 
-func (o *accountEventTypes) AccountSentEnabledConfirmation() *AccountEventType {
+func (o *accountEventTypes) AccountEmailChangeConfirmed() *AccountEventType {
 	return o.values[6]
 }
 
-func (o *accountEventTypes) AccountUpdated() *AccountEventType {
+func (o *accountEventTypes) AccountEmailChangeRequested() *AccountEventType {
 	return o.values[7]
 }
 
+func (o *accountEventTypes) AccountEnabled() *AccountEventType {
+	return o.values[8]
+}
+
+func (o *accountEventTypes) AccountForgotten() *AccountEventType {
+	return o.values[9]
+}
+
+func (o *accountEventTypes) AccountInactivityWarned() *AccountEventType {
+	return o.values[10]
+}
+
+func (o *accountEventTypes) AccountLinkedIdentity() *AccountEventType {
+	return o.values[11]
+}
+
+func (o *accountEventTypes) AccountLogged() *AccountEventType {
+	return o.values[12]
+}
+
+func (o *accountEventTypes) AccountMerged() *AccountEventType {
+	return o.values[13]
+}
+
+func (o *accountEventTypes) AccountMergedInto() *AccountEventType {
+	return o.values[14]
+}
+
+func (o *accountEventTypes) AccountPasswordChanged() *AccountEventType {
+	return o.values[15]
+}
+
+func (o *accountEventTypes) AccountPurged() *AccountEventType {
+	return o.values[16]
+}
+
+func (o *accountEventTypes) AccountRegistered() *AccountEventType {
+	return o.values[17]
+}
+
+func (o *accountEventTypes) AccountRegistrationApproved() *AccountEventType {
+	return o.values[18]
+}
+
+func (o *accountEventTypes) AccountRegistrationRejected() *AccountEventType {
+	return o.values[19]
+}
+
+func (o *accountEventTypes) AccountRestored() *AccountEventType {
+	return o.values[20]
+}
+
+func (o *accountEventTypes) AccountRevokedApiKey() *AccountEventType {
+	return o.values[21]
+}
+
+func (o *accountEventTypes) AccountRevokedSession() *AccountEventType {
+	return o.values[22]
+}
+
+func (o *accountEventTypes) AccountRevokedSessions() *AccountEventType {
+	return o.values[23]
+}
+
+func (o *accountEventTypes) AccountRoleExpired() *AccountEventType {
+	return o.values[24]
+}
+
+func (o *accountEventTypes) AccountSentDisabledConfirmation() *AccountEventType {
+	return o.values[25]
+}
+
+func (o *accountEventTypes) AccountSentEnabledConfirmation() *AccountEventType {
+	return o.values[26]
+}
+
+func (o *accountEventTypes) AccountStartedSession() *AccountEventType {
+	return o.values[27]
+}
+
+func (o *accountEventTypes) AccountTouchedSession() *AccountEventType {
+	return o.values[28]
+}
+
+func (o *accountEventTypes) AccountUnassignedRole() *AccountEventType {
+	return o.values[29]
+}
+
+func (o *accountEventTypes) AccountUnlinkedIdentity() *AccountEventType {
+	return o.values[30]
+}
+
+func (o *accountEventTypes) AccountUpdated() *AccountEventType {
+	return o.values[31]
+}
+
+func (o *accountEventTypes) AccountUsedApiKey() *AccountEventType {
+	return o.values[32]
+}
+
+func (o *accountEventTypes) ImpersonationEnded() *AccountEventType {
+	return o.values[33]
+}
+
+func (o *accountEventTypes) ImpersonationStarted() *AccountEventType {
+	return o.values[34]
+}
+
 func (o *accountEventTypes) ParseAccountEventType(name string) (ret *AccountEventType, ok bool) {
 	for _, lit := range o.Values() {
 		if strings.EqualFold(lit.Name(), name) {
@@ -160,3 +403,720 @@ func (o *accountEventTypes) Literals() []enum.Literal {
 	}
 	return o.valuesAsLiterals
 }
+
+type GroupEventType struct {
+	name    string
+	ordinal int
+}
+
+func (o *GroupEventType) Name() string {
+	return o.name
+}
+
+func (o *GroupEventType) Ordinal() int {
+	return o.ordinal
+}
+
+func (o *GroupEventType) IsGroupAddedMember() bool {
+	return o.name == _groupEventTypes.GroupAddedMember().name
+}
+
+func (o *GroupEventType) IsGroupAddedSubgroup() bool {
+	return o.name == _groupEventTypes.GroupAddedSubgroup().name
+}
+
+func (o *GroupEventType) IsGroupCreated() bool {
+	return o.name == _groupEventTypes.GroupCreated().name
+}
+
+func (o *GroupEventType) IsGroupDeleted() bool {
+	return o.name == _groupEventTypes.GroupDeleted().name
+}
+
+func (o *GroupEventType) IsGroupRemovedMember() bool {
+	return o.name == _groupEventTypes.GroupRemovedMember().name
+}
+
+func (o *GroupEventType) IsGroupRemovedSubgroup() bool {
+	return o.name == _groupEventTypes.GroupRemovedSubgroup().name
+}
+
+func (o *GroupEventType) IsGroupUpdated() bool {
+	return o.name == _groupEventTypes.GroupUpdated().name
+}
+
+func (o *GroupEventType) MarshalJSON() (ret []byte, err error) {
+	ret = []byte(fmt.Sprintf("\"%v\"", o.name))
+	return
+}
+
+func (o *GroupEventType) UnmarshalJSON(data []byte) (err error) {
+	name := string(data)
+	//remove quotes
+	name = name[1 : len(name)-1]
+	if v, ok := GroupEventTypes().ParseGroupEventType(name); ok {
+		*o = *v
+	} else {
+		err = fmt.Errorf("invalid GroupEventType %q", name)
+	}
+	return
+}
+
+func (o *GroupEventType) GetBSON() (ret interface{}, err error) {
+	return o.name, nil
+}
+
+func (o *GroupEventType) SetBSON(raw bson.Raw) (err error) {
+	var lit string
+	if err = raw.Unmarshal(&lit); err == nil {
+		if v, ok := GroupEventTypes().ParseGroupEventType(lit); ok {
+			*o = *v
+		} else {
+			err = fmt.Errorf("invalid GroupEventType %q", lit)
+		}
+	}
+	return
+}
+
+type groupEventTypes struct {
+	values           []*GroupEventType
+	valuesAsLiterals []enum.Literal
+}
+
+var _groupEventTypes = &groupEventTypes{values: []*GroupEventType{
+	{name: "GroupAddedMember", ordinal: 0},
+	{name: "GroupAddedSubgroup", ordinal: 1},
+	{name: "GroupCreated", ordinal: 2},
+	{name: "GroupDeleted", ordinal: 3},
+	{name: "GroupRemovedMember", ordinal: 4},
+	{name: "GroupRemovedSubgroup", ordinal: 5},
+	{name: "GroupUpdated", ordinal: 6}},
+}
+
+func GroupEventTypes() *groupEventTypes {
+	return _groupEventTypes
+}
+
+func (o *groupEventTypes) Values() []*GroupEventType {
+	return o.values
+}
+
+func (o *groupEventTypes) GroupAddedMember() *GroupEventType {
+	return o.values[0]
+}
+
+func (o *groupEventTypes) GroupAddedSubgroup() *GroupEventType {
+	return o.values[1]
+}
+
+func (o *groupEventTypes) GroupCreated() *GroupEventType {
+	return o.values[2]
+}
+
+func (o *groupEventTypes) GroupDeleted() *GroupEventType {
+	return o.values[3]
+}
+
+func (o *groupEventTypes) GroupRemovedMember() *GroupEventType {
+	return o.values[4]
+}
+
+func (o *groupEventTypes) GroupRemovedSubgroup() *GroupEventType {
+	return o.values[5]
+}
+
+func (o *groupEventTypes) GroupUpdated() *GroupEventType {
+	return o.values[6]
+}
+
+func (o *groupEventTypes) ParseGroupEventType(name string) (ret *GroupEventType, ok bool) {
+	for _, lit := range o.Values() {
+		if strings.EqualFold(lit.Name(), name) {
+			return lit, true
+		}
+	}
+	return nil, false
+}
+
+// we have to convert the instances to Literal interface, because it is not a other way in Go
+func (o *groupEventTypes) Literals() []enum.Literal {
+	if o.valuesAsLiterals == nil {
+		o.valuesAsLiterals = make([]enum.Literal, len(o.values))
+		for i, item := range o.values {
+			o.valuesAsLiterals[i] = item
+		}
+	}
+	return o.valuesAsLiterals
+}
+
+type OrganizationEventType struct {
+	name    string
+	ordinal int
+}
+
+func (o *OrganizationEventType) Name() string {
+	return o.name
+}
+
+func (o *OrganizationEventType) Ordinal() int {
+	return o.ordinal
+}
+
+func (o *OrganizationEventType) IsOrganizationCreated() bool {
+	return o.name == _organizationEventTypes.OrganizationCreated().name
+}
+
+func (o *OrganizationEventType) IsOrganizationDeleted() bool {
+	return o.name == _organizationEventTypes.OrganizationDeleted().name
+}
+
+func (o *OrganizationEventType) IsOrganizationUpdated() bool {
+	return o.name == _organizationEventTypes.OrganizationUpdated().name
+}
+
+func (o *OrganizationEventType) MarshalJSON() (ret []byte, err error) {
+	ret = []byte(fmt.Sprintf("\"%v\"", o.name))
+	return
+}
+
+func (o *OrganizationEventType) UnmarshalJSON(data []byte) (err error) {
+	name := string(data)
+	//remove quotes
+	name = name[1 : len(name)-1]
+	if v, ok := OrganizationEventTypes().ParseOrganizationEventType(name); ok {
+		*o = *v
+	} else {
+		err = fmt.Errorf("invalid OrganizationEventType %q", name)
+	}
+	return
+}
+
+func (o *OrganizationEventType) GetBSON() (ret interface{}, err error) {
+	return o.name, nil
+}
+
+func (o *OrganizationEventType) SetBSON(raw bson.Raw) (err error) {
+	var lit string
+	if err = raw.Unmarshal(&lit); err == nil {
+		if v, ok := OrganizationEventTypes().ParseOrganizationEventType(lit); ok {
+			*o = *v
+		} else {
+			err = fmt.Errorf("invalid OrganizationEventType %q", lit)
+		}
+	}
+	return
+}
+
+type organizationEventTypes struct {
+	values           []*OrganizationEventType
+	valuesAsLiterals []enum.Literal
+}
+
+var _organizationEventTypes = &organizationEventTypes{values: []*OrganizationEventType{
+	{name: "OrganizationCreated", ordinal: 0},
+	{name: "OrganizationDeleted", ordinal: 1},
+	{name: "OrganizationUpdated", ordinal: 2}},
+}
+
+func OrganizationEventTypes() *organizationEventTypes {
+	return _organizationEventTypes
+}
+
+func (o *organizationEventTypes) Values() []*OrganizationEventType {
+	return o.values
+}
+
+func (o *organizationEventTypes) OrganizationCreated() *OrganizationEventType {
+	return o.values[0]
+}
+
+func (o *organizationEventTypes) OrganizationDeleted() *OrganizationEventType {
+	return o.values[1]
+}
+
+func (o *organizationEventTypes) OrganizationUpdated() *OrganizationEventType {
+	return o.values[2]
+}
+
+func (o *organizationEventTypes) ParseOrganizationEventType(name string) (ret *OrganizationEventType, ok bool) {
+	for _, lit := range o.Values() {
+		if strings.EqualFold(lit.Name(), name) {
+			return lit, true
+		}
+	}
+	return nil, false
+}
+
+// we have to convert the instances to Literal interface, because it is not a other way in Go
+func (o *organizationEventTypes) Literals() []enum.Literal {
+	if o.valuesAsLiterals == nil {
+		o.valuesAsLiterals = make([]enum.Literal, len(o.values))
+		for i, item := range o.values {
+			o.valuesAsLiterals[i] = item
+		}
+	}
+	return o.valuesAsLiterals
+}
+
+type RoleGrantRequestEventType struct {
+	name    string
+	ordinal int
+}
+
+func (o *RoleGrantRequestEventType) Name() string {
+	return o.name
+}
+
+func (o *RoleGrantRequestEventType) Ordinal() int {
+	return o.ordinal
+}
+
+func (o *RoleGrantRequestEventType) IsRoleGrantRequestApproved() bool {
+	return o.name == _roleGrantRequestEventTypes.RoleGrantRequestApproved().name
+}
+
+func (o *RoleGrantRequestEventType) IsRoleGrantRequestCreated() bool {
+	return o.name == _roleGrantRequestEventTypes.RoleGrantRequestCreated().name
+}
+
+func (o *RoleGrantRequestEventType) IsRoleGrantRequestDeleted() bool {
+	return o.name == _roleGrantRequestEventTypes.RoleGrantRequestDeleted().name
+}
+
+func (o *RoleGrantRequestEventType) IsRoleGrantRequestRejected() bool {
+	return o.name == _roleGrantRequestEventTypes.RoleGrantRequestRejected().name
+}
+
+func (o *RoleGrantRequestEventType) IsRoleGrantRequestUpdated() bool {
+	return o.name == _roleGrantRequestEventTypes.RoleGrantRequestUpdated().name
+}
+
+func (o *RoleGrantRequestEventType) MarshalJSON() (ret []byte, err error) {
+	ret = []byte(fmt.Sprintf("\"%v\"", o.name))
+	return
+}
+
+func (o *RoleGrantRequestEventType) UnmarshalJSON(data []byte) (err error) {
+	name := string(data)
+	//remove quotes
+	name = name[1 : len(name)-1]
+	if v, ok := RoleGrantRequestEventTypes().ParseRoleGrantRequestEventType(name); ok {
+		*o = *v
+	} else {
+		err = fmt.Errorf("invalid RoleGrantRequestEventType %q", name)
+	}
+	return
+}
+
+func (o *RoleGrantRequestEventType) GetBSON() (ret interface{}, err error) {
+	return o.name, nil
+}
+
+func (o *RoleGrantRequestEventType) SetBSON(raw bson.Raw) (err error) {
+	var lit string
+	if err = raw.Unmarshal(&lit); err == nil {
+		if v, ok := RoleGrantRequestEventTypes().ParseRoleGrantRequestEventType(lit); ok {
+			*o = *v
+		} else {
+			err = fmt.Errorf("invalid RoleGrantRequestEventType %q", lit)
+		}
+	}
+	return
+}
+
+type roleGrantRequestEventTypes struct {
+	values           []*RoleGrantRequestEventType
+	valuesAsLiterals []enum.Literal
+}
+
+var _roleGrantRequestEventTypes = &roleGrantRequestEventTypes{values: []*RoleGrantRequestEventType{
+	{name: "RoleGrantRequestApproved", ordinal: 0},
+	{name: "RoleGrantRequestCreated", ordinal: 1},
+	{name: "RoleGrantRequestDeleted", ordinal: 2},
+	{name: "RoleGrantRequestRejected", ordinal: 3},
+	{name: "RoleGrantRequestUpdated", ordinal: 4}},
+}
+
+func RoleGrantRequestEventTypes() *roleGrantRequestEventTypes {
+	return _roleGrantRequestEventTypes
+}
+
+func (o *roleGrantRequestEventTypes) Values() []*RoleGrantRequestEventType {
+	return o.values
+}
+
+func (o *roleGrantRequestEventTypes) RoleGrantRequestApproved() *RoleGrantRequestEventType {
+	return o.values[0]
+}
+
+func (o *roleGrantRequestEventTypes) RoleGrantRequestCreated() *RoleGrantRequestEventType {
+	return o.values[1]
+}
+
+func (o *roleGrantRequestEventTypes) RoleGrantRequestDeleted() *RoleGrantRequestEventType {
+	return o.values[2]
+}
+
+func (o *roleGrantRequestEventTypes) RoleGrantRequestRejected() *RoleGrantRequestEventType {
+	return o.values[3]
+}
+
+func (o *roleGrantRequestEventTypes) RoleGrantRequestUpdated() *RoleGrantRequestEventType {
+	return o.values[4]
+}
+
+func (o *roleGrantRequestEventTypes) ParseRoleGrantRequestEventType(name string) (ret *RoleGrantRequestEventType, ok bool) {
+	for _, lit := range o.Values() {
+		if strings.EqualFold(lit.Name(), name) {
+			return lit, true
+		}
+	}
+	return nil, false
+}
+
+// we have to convert the instances to Literal interface, because it is not a other way in Go
+func (o *roleGrantRequestEventTypes) Literals() []enum.Literal {
+	if o.valuesAsLiterals == nil {
+		o.valuesAsLiterals = make([]enum.Literal, len(o.values))
+		for i, item := range o.values {
+			o.valuesAsLiterals[i] = item
+		}
+	}
+	return o.valuesAsLiterals
+}
+
+type RoleEventType struct {
+	name    string
+	ordinal int
+}
+
+func (o *RoleEventType) Name() string {
+	return o.name
+}
+
+func (o *RoleEventType) Ordinal() int {
+	return o.ordinal
+}
+
+func (o *RoleEventType) IsRoleCreated() bool {
+	return o.name == _roleEventTypes.RoleCreated().name
+}
+
+func (o *RoleEventType) IsRoleDeleted() bool {
+	return o.name == _roleEventTypes.RoleDeleted().name
+}
+
+func (o *RoleEventType) IsRoleUpdated() bool {
+	return o.name == _roleEventTypes.RoleUpdated().name
+}
+
+func (o *RoleEventType) MarshalJSON() (ret []byte, err error) {
+	ret = []byte(fmt.Sprintf("\"%v\"", o.name))
+	return
+}
+
+func (o *RoleEventType) UnmarshalJSON(data []byte) (err error) {
+	name := string(data)
+	//remove quotes
+	name = name[1 : len(name)-1]
+	if v, ok := RoleEventTypes().ParseRoleEventType(name); ok {
+		*o = *v
+	} else {
+		err = fmt.Errorf("invalid RoleEventType %q", name)
+	}
+	return
+}
+
+func (o *RoleEventType) GetBSON() (ret interface{}, err error) {
+	return o.name, nil
+}
+
+func (o *RoleEventType) SetBSON(raw bson.Raw) (err error) {
+	var lit string
+	if err = raw.Unmarshal(&lit); err == nil {
+		if v, ok := RoleEventTypes().ParseRoleEventType(lit); ok {
+			*o = *v
+		} else {
+			err = fmt.Errorf("invalid RoleEventType %q", lit)
+		}
+	}
+	return
+}
+
+type roleEventTypes struct {
+	values           []*RoleEventType
+	valuesAsLiterals []enum.Literal
+}
+
+var _roleEventTypes = &roleEventTypes{values: []*RoleEventType{
+	{name: "RoleCreated", ordinal: 0},
+	{name: "RoleDeleted", ordinal: 1},
+	{name: "RoleUpdated", ordinal: 2}},
+}
+
+func RoleEventTypes() *roleEventTypes {
+	return _roleEventTypes
+}
+
+func (o *roleEventTypes) Values() []*RoleEventType {
+	return o.values
+}
+
+func (o *roleEventTypes) RoleCreated() *RoleEventType {
+	return o.values[0]
+}
+
+func (o *roleEventTypes) RoleDeleted() *RoleEventType {
+	return o.values[1]
+}
+
+func (o *roleEventTypes) RoleUpdated() *RoleEventType {
+	return o.values[2]
+}
+
+func (o *roleEventTypes) ParseRoleEventType(name string) (ret *RoleEventType, ok bool) {
+	for _, lit := range o.Values() {
+		if strings.EqualFold(lit.Name(), name) {
+			return lit, true
+		}
+	}
+	return nil, false
+}
+
+// we have to convert the instances to Literal interface, because it is not a other way in Go
+func (o *roleEventTypes) Literals() []enum.Literal {
+	if o.valuesAsLiterals == nil {
+		o.valuesAsLiterals = make([]enum.Literal, len(o.values))
+		for i, item := range o.values {
+			o.valuesAsLiterals[i] = item
+		}
+	}
+	return o.valuesAsLiterals
+}
+
+type RelationTupleEventType struct {
+	name    string
+	ordinal int
+}
+
+func (o *RelationTupleEventType) Name() string {
+	return o.name
+}
+
+func (o *RelationTupleEventType) Ordinal() int {
+	return o.ordinal
+}
+
+func (o *RelationTupleEventType) IsRelationTupleCreated() bool {
+	return o.name == _relationTupleEventTypes.RelationTupleCreated().name
+}
+
+func (o *RelationTupleEventType) IsRelationTupleDeleted() bool {
+	return o.name == _relationTupleEventTypes.RelationTupleDeleted().name
+}
+
+func (o *RelationTupleEventType) MarshalJSON() (ret []byte, err error) {
+	ret = []byte(fmt.Sprintf("\"%v\"", o.name))
+	return
+}
+
+func (o *RelationTupleEventType) UnmarshalJSON(data []byte) (err error) {
+	name := string(data)
+	//remove quotes
+	name = name[1 : len(name)-1]
+	if v, ok := RelationTupleEventTypes().ParseRelationTupleEventType(name); ok {
+		*o = *v
+	} else {
+		err = fmt.Errorf("invalid RelationTupleEventType %q", name)
+	}
+	return
+}
+
+func (o *RelationTupleEventType) GetBSON() (ret interface{}, err error) {
+	return o.name, nil
+}
+
+func (o *RelationTupleEventType) SetBSON(raw bson.Raw) (err error) {
+	var lit string
+	if err = raw.Unmarshal(&lit); err == nil {
+		if v, ok := RelationTupleEventTypes().ParseRelationTupleEventType(lit); ok {
+			*o = *v
+		} else {
+			err = fmt.Errorf("invalid RelationTupleEventType %q", lit)
+		}
+	}
+	return
+}
+
+type relationTupleEventTypes struct {
+	values           []*RelationTupleEventType
+	valuesAsLiterals []enum.Literal
+}
+
+var _relationTupleEventTypes = &relationTupleEventTypes{values: []*RelationTupleEventType{
+	{name: "RelationTupleCreated", ordinal: 0},
+	{name: "RelationTupleDeleted", ordinal: 1}},
+}
+
+func RelationTupleEventTypes() *relationTupleEventTypes {
+	return _relationTupleEventTypes
+}
+
+func (o *relationTupleEventTypes) Values() []*RelationTupleEventType {
+	return o.values
+}
+
+func (o *relationTupleEventTypes) RelationTupleCreated() *RelationTupleEventType {
+	return o.values[0]
+}
+
+func (o *relationTupleEventTypes) RelationTupleDeleted() *RelationTupleEventType {
+	return o.values[1]
+}
+
+func (o *relationTupleEventTypes) ParseRelationTupleEventType(name string) (ret *RelationTupleEventType, ok bool) {
+	for _, lit := range o.Values() {
+		if strings.EqualFold(lit.Name(), name) {
+			return lit, true
+		}
+	}
+	return nil, false
+}
+
+// we have to convert the instances to Literal interface, because it is not a other way in Go
+func (o *relationTupleEventTypes) Literals() []enum.Literal {
+	if o.valuesAsLiterals == nil {
+		o.valuesAsLiterals = make([]enum.Literal, len(o.values))
+		for i, item := range o.values {
+			o.valuesAsLiterals[i] = item
+		}
+	}
+	return o.valuesAsLiterals
+}
+
+type InvitationEventType struct {
+	name    string
+	ordinal int
+}
+
+func (o *InvitationEventType) Name() string {
+	return o.name
+}
+
+func (o *InvitationEventType) Ordinal() int {
+	return o.ordinal
+}
+
+func (o *InvitationEventType) IsInvitationAccepted() bool {
+	return o.name == _invitationEventTypes.InvitationAccepted().name
+}
+
+func (o *InvitationEventType) IsInvitationCreated() bool {
+	return o.name == _invitationEventTypes.InvitationCreated().name
+}
+
+func (o *InvitationEventType) IsInvitationDeleted() bool {
+	return o.name == _invitationEventTypes.InvitationDeleted().name
+}
+
+func (o *InvitationEventType) IsInvitationResent() bool {
+	return o.name == _invitationEventTypes.InvitationResent().name
+}
+
+func (o *InvitationEventType) IsInvitationRevoked() bool {
+	return o.name == _invitationEventTypes.InvitationRevoked().name
+}
+
+func (o *InvitationEventType) MarshalJSON() (ret []byte, err error) {
+	ret = []byte(fmt.Sprintf("\"%v\"", o.name))
+	return
+}
+
+func (o *InvitationEventType) UnmarshalJSON(data []byte) (err error) {
+	name := string(data)
+	//remove quotes
+	name = name[1 : len(name)-1]
+	if v, ok := InvitationEventTypes().ParseInvitationEventType(name); ok {
+		*o = *v
+	} else {
+		err = fmt.Errorf("invalid InvitationEventType %q", name)
+	}
+	return
+}
+
+func (o *InvitationEventType) GetBSON() (ret interface{}, err error) {
+	return o.name, nil
+}
+
+func (o *InvitationEventType) SetBSON(raw bson.Raw) (err error) {
+	var lit string
+	if err = raw.Unmarshal(&lit); err == nil {
+		if v, ok := InvitationEventTypes().ParseInvitationEventType(lit); ok {
+			*o = *v
+		} else {
+			err = fmt.Errorf("invalid InvitationEventType %q", lit)
+		}
+	}
+	return
+}
+
+type invitationEventTypes struct {
+	values           []*InvitationEventType
+	valuesAsLiterals []enum.Literal
+}
+
+var _invitationEventTypes = &invitationEventTypes{values: []*InvitationEventType{
+	{name: "InvitationAccepted", ordinal: 0},
+	{name: "InvitationCreated", ordinal: 1},
+	{name: "InvitationDeleted", ordinal: 2},
+	{name: "InvitationResent", ordinal: 3},
+	{name: "InvitationRevoked", ordinal: 4}},
+}
+
+func InvitationEventTypes() *invitationEventTypes {
+	return _invitationEventTypes
+}
+
+func (o *invitationEventTypes) Values() []*InvitationEventType {
+	return o.values
+}
+
+func (o *invitationEventTypes) InvitationAccepted() *InvitationEventType {
+	return o.values[0]
+}
+
+func (o *invitationEventTypes) InvitationCreated() *InvitationEventType {
+	return o.values[1]
+}
+
+func (o *invitationEventTypes) InvitationDeleted() *InvitationEventType {
+	return o.values[2]
+}
+
+func (o *invitationEventTypes) InvitationResent() *InvitationEventType {
+	return o.values[3]
+}
+
+func (o *invitationEventTypes) InvitationRevoked() *InvitationEventType {
+	return o.values[4]
+}
+
+func (o *invitationEventTypes) ParseInvitationEventType(name string) (ret *InvitationEventType, ok bool) {
+	for _, lit := range o.Values() {
+		if strings.EqualFold(lit.Name(), name) {
+			return lit, true
+		}
+	}
+	return nil, false
+}
+
+// we have to convert the instances to Literal interface, because it is not a other way in Go
+func (o *invitationEventTypes) Literals() []enum.Literal {
+	if o.valuesAsLiterals == nil {
+		o.valuesAsLiterals = make([]enum.Literal, len(o.values))
+		for i, item := range o.values {
+			o.valuesAsLiterals[i] = item
+		}
+	}
+	return o.valuesAsLiterals
+}
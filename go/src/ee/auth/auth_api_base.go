@@ -5,18 +5,49 @@ import (
 	"time"
 )
 
+type Accepted struct {
+}
+
+func NewAcceptedDefault() (ret *Accepted) {
+	ret = &Accepted{}
+	return
+}
+
 type Account struct {
-	Name                     *PersonName `json:"name,omitempty" eh:"optional"`
-	Username                 string      `json:"username,omitempty" eh:"optional"`
-	Password                 string      `json:"password,omitempty" eh:"optional"`
-	Email                    string      `json:"email,omitempty" eh:"optional"`
-	Roles                    []string    `json:"roles,omitempty" eh:"optional"`
-	SentDisabledConfirmation bool        `json:"sentDisabledConfirmation,omitempty" eh:"optional"`
-	SentEnabledConfirmation  bool        `json:"sentEnabledConfirmation,omitempty" eh:"optional"`
-	Disabled                 bool        `json:"disabled,omitempty" eh:"optional"`
-	Id                       uuid.UUID   `json:"id,omitempty" eh:"optional"`
-	AggregateState           string      `json:"aggregateState,omitempty" eh:"optional"`
-	DeletedAt                *time.Time  `json:"deletedAt,omitempty" eh:"optional"`
+	Name                     *PersonName            `json:"name,omitempty" eh:"optional"`
+	Username                 string                 `json:"username,omitempty" eh:"optional"`
+	Password                 string                 `json:"password,omitempty" eh:"optional"`
+	Email                    string                 `json:"email,omitempty" eh:"optional"`
+	Roles                    []string               `json:"roles,omitempty" eh:"optional"`
+	OrganizationId           uuid.UUID              `json:"organizationId,omitempty" eh:"optional"`
+	SentDisabledConfirmation bool                   `json:"sentDisabledConfirmation,omitempty" eh:"optional"`
+	SentEnabledConfirmation  bool                   `json:"sentEnabledConfirmation,omitempty" eh:"optional"`
+	Disabled                 bool                   `json:"disabled,omitempty" eh:"optional"`
+	DisabledReason           string                 `json:"disabledReason,omitempty" eh:"optional"`
+	EnabledAt                *time.Time             `json:"enabledAt,omitempty" eh:"optional"`
+	LastLoginAt              *time.Time             `json:"lastLoginAt,omitempty" eh:"optional"`
+	Service                  bool                   `json:"service,omitempty" eh:"optional"`
+	BreakGlass               bool                   `json:"breakGlass,omitempty" eh:"optional"`
+	Attributes               map[string]interface{} `json:"attributes,omitempty" eh:"optional"`
+	SentInactivityWarning    bool                   `json:"sentInactivityWarning,omitempty" eh:"optional"`
+	Forgotten                bool                   `json:"forgotten,omitempty" eh:"optional"`
+	PendingEmail             string                 `json:"pendingEmail,omitempty" eh:"optional"`
+	EmailChangeTokenHash     string                 `json:"emailChangeTokenHash,omitempty" eh:"optional"`
+	EmailChangeExpiresAt     *time.Time             `json:"emailChangeExpiresAt,omitempty" eh:"optional"`
+	Identities               []*ExternalIdentity    `json:"identities,omitempty" eh:"optional"`
+	ApiKeys                  []*ApiKey              `json:"apiKeys,omitempty" eh:"optional"`
+	Impersonations           []*Impersonation       `json:"impersonations,omitempty" eh:"optional"`
+	Sessions                 []*Session             `json:"sessions,omitempty" eh:"optional"`
+	RoleAssignments          []*RoleAssignment      `json:"roleAssignments,omitempty" eh:"optional"`
+	PendingApproval          bool                   `json:"pendingApproval,omitempty" eh:"optional"`
+	PasswordChangedAt        *time.Time             `json:"passwordChangedAt,omitempty" eh:"optional"`
+	MustChangePassword       bool                   `json:"mustChangePassword,omitempty" eh:"optional"`
+	PasswordHistory          []string               `json:"passwordHistory,omitempty" eh:"optional"`
+	MergedInto               uuid.UUID              `json:"mergedInto,omitempty" eh:"optional"`
+	MergedAt                 *time.Time             `json:"mergedAt,omitempty" eh:"optional"`
+	Id                       uuid.UUID              `json:"id,omitempty" eh:"optional"`
+	AggregateState           string                 `json:"aggregateState,omitempty" eh:"optional"`
+	DeletedAt                *time.Time             `json:"deletedAt,omitempty" eh:"optional"`
 }
 
 func NewAccountDefault() (ret *Account) {
@@ -28,9 +59,41 @@ func (o *Account) AddToRoles(item string) string {
 	o.Roles = append(o.Roles, item)
 	return item
 }
+func (o *Account) AddToIdentities(item *ExternalIdentity) *ExternalIdentity {
+	o.Identities = append(o.Identities, item)
+	return item
+}
+func (o *Account) AddToApiKeys(item *ApiKey) *ApiKey {
+	o.ApiKeys = append(o.ApiKeys, item)
+	return item
+}
+func (o *Account) AddToImpersonations(item *Impersonation) *Impersonation {
+	o.Impersonations = append(o.Impersonations, item)
+	return item
+}
+func (o *Account) AddToSessions(item *Session) *Session {
+	o.Sessions = append(o.Sessions, item)
+	return item
+}
+func (o *Account) AddToRoleAssignments(item *RoleAssignment) *RoleAssignment {
+	o.RoleAssignments = append(o.RoleAssignments, item)
+	return item
+}
+func (o *Account) AddToPasswordHistory(item string) string {
+	o.PasswordHistory = append(o.PasswordHistory, item)
+	return item
+}
 func (o *Account) EntityID() uuid.UUID { return o.Id }
 func (o *Account) Deleted() *time.Time { return o.DeletedAt }
 
+type Approved struct {
+}
+
+func NewApprovedDefault() (ret *Approved) {
+	ret = &Approved{}
+	return
+}
+
 type Deleted struct {
 }
 
@@ -76,6 +139,37 @@ func NewExistDefault() (ret *Exist) {
 	return
 }
 
+type Group struct {
+	Name           string      `json:"name,omitempty" eh:"optional"`
+	Description    string      `json:"description,omitempty" eh:"optional"`
+	Roles          []string    `json:"roles,omitempty" eh:"optional"`
+	Members        []uuid.UUID `json:"members,omitempty" eh:"optional"`
+	Subgroups      []uuid.UUID `json:"subgroups,omitempty" eh:"optional"`
+	Id             uuid.UUID   `json:"id,omitempty" eh:"optional"`
+	AggregateState string      `json:"aggregateState,omitempty" eh:"optional"`
+	DeletedAt      *time.Time  `json:"deletedAt,omitempty" eh:"optional"`
+}
+
+func NewGroupDefault() (ret *Group) {
+	ret = &Group{}
+	return
+}
+
+func (o *Group) AddToRoles(item string) string {
+	o.Roles = append(o.Roles, item)
+	return item
+}
+func (o *Group) AddToMembers(item uuid.UUID) uuid.UUID {
+	o.Members = append(o.Members, item)
+	return item
+}
+func (o *Group) AddToSubgroups(item uuid.UUID) uuid.UUID {
+	o.Subgroups = append(o.Subgroups, item)
+	return item
+}
+func (o *Group) EntityID() uuid.UUID { return o.Id }
+func (o *Group) Deleted() *time.Time { return o.DeletedAt }
+
 type AccountHandler struct {
 }
 
@@ -84,6 +178,54 @@ func NewAccountHandlerDefault() (ret *AccountHandler) {
 	return
 }
 
+type GroupHandler struct {
+}
+
+func NewGroupHandlerDefault() (ret *GroupHandler) {
+	ret = &GroupHandler{}
+	return
+}
+
+type OrganizationHandler struct {
+}
+
+func NewOrganizationHandlerDefault() (ret *OrganizationHandler) {
+	ret = &OrganizationHandler{}
+	return
+}
+
+type RoleGrantRequestHandler struct {
+}
+
+func NewRoleGrantRequestHandlerDefault() (ret *RoleGrantRequestHandler) {
+	ret = &RoleGrantRequestHandler{}
+	return
+}
+
+type RoleHandler struct {
+}
+
+func NewRoleHandlerDefault() (ret *RoleHandler) {
+	ret = &RoleHandler{}
+	return
+}
+
+type RelationTupleHandler struct {
+}
+
+func NewRelationTupleHandlerDefault() (ret *RelationTupleHandler) {
+	ret = &RelationTupleHandler{}
+	return
+}
+
+type InvitationHandler struct {
+}
+
+func NewInvitationHandlerDefault() (ret *InvitationHandler) {
+	ret = &InvitationHandler{}
+	return
+}
+
 type Initial struct {
 }
 
@@ -92,6 +234,164 @@ func NewInitialDefault() (ret *Initial) {
 	return
 }
 
+type Invitation struct {
+	Email          string     `json:"email,omitempty" eh:"optional"`
+	Roles          []string   `json:"roles,omitempty" eh:"optional"`
+	OrganizationId uuid.UUID  `json:"organizationId,omitempty" eh:"optional"`
+	TokenHash      string     `json:"tokenHash,omitempty" eh:"optional"`
+	ExpiresAt      *time.Time `json:"expiresAt,omitempty" eh:"optional"`
+	InvitedBy      uuid.UUID  `json:"invitedBy,omitempty" eh:"optional"`
+	SentAt         *time.Time `json:"sentAt,omitempty" eh:"optional"`
+	AcceptedAt     *time.Time `json:"acceptedAt,omitempty" eh:"optional"`
+	AccountId      uuid.UUID  `json:"accountId,omitempty" eh:"optional"`
+	Id             uuid.UUID  `json:"id,omitempty" eh:"optional"`
+	AggregateState string     `json:"aggregateState,omitempty" eh:"optional"`
+	DeletedAt      *time.Time `json:"deletedAt,omitempty" eh:"optional"`
+}
+
+func NewInvitationDefault() (ret *Invitation) {
+	ret = &Invitation{}
+	return
+}
+
+func (o *Invitation) AddToRoles(item string) string {
+	o.Roles = append(o.Roles, item)
+	return item
+}
+func (o *Invitation) EntityID() uuid.UUID { return o.Id }
+func (o *Invitation) Deleted() *time.Time { return o.DeletedAt }
+
+type Merged struct {
+}
+
+func NewMergedDefault() (ret *Merged) {
+	ret = &Merged{}
+	return
+}
+
+type Organization struct {
+	Name           string     `json:"name,omitempty" eh:"optional"`
+	Description    string     `json:"description,omitempty" eh:"optional"`
+	Id             uuid.UUID  `json:"id,omitempty" eh:"optional"`
+	AggregateState string     `json:"aggregateState,omitempty" eh:"optional"`
+	DeletedAt      *time.Time `json:"deletedAt,omitempty" eh:"optional"`
+}
+
+func NewOrganizationDefault() (ret *Organization) {
+	ret = &Organization{}
+	return
+}
+
+func (o *Organization) EntityID() uuid.UUID { return o.Id }
+func (o *Organization) Deleted() *time.Time { return o.DeletedAt }
+
+type Pending struct {
+}
+
+func NewPendingDefault() (ret *Pending) {
+	ret = &Pending{}
+	return
+}
+
+type PendingApproval struct {
+}
+
+func NewPendingApprovalDefault() (ret *PendingApproval) {
+	ret = &PendingApproval{}
+	return
+}
+
+type Purged struct {
+}
+
+func NewPurgedDefault() (ret *Purged) {
+	ret = &Purged{}
+	return
+}
+
+type Rejected struct {
+}
+
+func NewRejectedDefault() (ret *Rejected) {
+	ret = &Rejected{}
+	return
+}
+
+type RelationTuple struct {
+	Namespace      string     `json:"namespace,omitempty" eh:"optional"`
+	Object         string     `json:"object,omitempty" eh:"optional"`
+	Relation       string     `json:"relation,omitempty" eh:"optional"`
+	Subject        string     `json:"subject,omitempty" eh:"optional"`
+	Id             uuid.UUID  `json:"id,omitempty" eh:"optional"`
+	AggregateState string     `json:"aggregateState,omitempty" eh:"optional"`
+	DeletedAt      *time.Time `json:"deletedAt,omitempty" eh:"optional"`
+}
+
+func NewRelationTupleDefault() (ret *RelationTuple) {
+	ret = &RelationTuple{}
+	return
+}
+
+func (o *RelationTuple) EntityID() uuid.UUID { return o.Id }
+func (o *RelationTuple) Deleted() *time.Time { return o.DeletedAt }
+
+type Revoked struct {
+}
+
+func NewRevokedDefault() (ret *Revoked) {
+	ret = &Revoked{}
+	return
+}
+
+type Role struct {
+	Name           string     `json:"name,omitempty" eh:"optional"`
+	Description    string     `json:"description,omitempty" eh:"optional"`
+	Inherits       []string   `json:"inherits,omitempty" eh:"optional"`
+	Permissions    []string   `json:"permissions,omitempty" eh:"optional"`
+	Id             uuid.UUID  `json:"id,omitempty" eh:"optional"`
+	AggregateState string     `json:"aggregateState,omitempty" eh:"optional"`
+	DeletedAt      *time.Time `json:"deletedAt,omitempty" eh:"optional"`
+}
+
+func NewRoleDefault() (ret *Role) {
+	ret = &Role{}
+	return
+}
+
+func (o *Role) AddToInherits(item string) string {
+	o.Inherits = append(o.Inherits, item)
+	return item
+}
+func (o *Role) AddToPermissions(item string) string {
+	o.Permissions = append(o.Permissions, item)
+	return item
+}
+func (o *Role) EntityID() uuid.UUID { return o.Id }
+func (o *Role) Deleted() *time.Time { return o.DeletedAt }
+
+type RoleGrantRequest struct {
+	AccountId      uuid.UUID  `json:"accountId,omitempty" eh:"optional"`
+	Role           string     `json:"role,omitempty" eh:"optional"`
+	Reason         string     `json:"reason,omitempty" eh:"optional"`
+	ValidFrom      *time.Time `json:"validFrom,omitempty" eh:"optional"`
+	ValidUntil     *time.Time `json:"validUntil,omitempty" eh:"optional"`
+	RequestedBy    uuid.UUID  `json:"requestedBy,omitempty" eh:"optional"`
+	DecidedBy      uuid.UUID  `json:"decidedBy,omitempty" eh:"optional"`
+	DecidedAt      *time.Time `json:"decidedAt,omitempty" eh:"optional"`
+	Comment        string     `json:"comment,omitempty" eh:"optional"`
+	Id             uuid.UUID  `json:"id,omitempty" eh:"optional"`
+	AggregateState string     `json:"aggregateState,omitempty" eh:"optional"`
+	DeletedAt      *time.Time `json:"deletedAt,omitempty" eh:"optional"`
+}
+
+func NewRoleGrantRequestDefault() (ret *RoleGrantRequest) {
+	ret = &RoleGrantRequest{}
+	return
+}
+
+func (o *RoleGrantRequest) EntityID() uuid.UUID { return o.Id }
+func (o *RoleGrantRequest) Deleted() *time.Time { return o.DeletedAt }
+
 type UserCredentials struct {
 	Username string `json:"username,omitempty" eh:"optional"`
 	Password string `json:"password,omitempty" eh:"optional"`
@@ -111,3 +411,76 @@ func NewPersonNameDefault() (ret *PersonName) {
 	ret = &PersonName{}
 	return
 }
+
+type ExternalIdentity struct {
+	Issuer   string     `json:"issuer,omitempty" eh:"optional"`
+	Subject  string     `json:"subject,omitempty" eh:"optional"`
+	Email    string     `json:"email,omitempty" eh:"optional"`
+	LinkedAt *time.Time `json:"linkedAt,omitempty" eh:"optional"`
+}
+
+func NewExternalIdentityDefault() (ret *ExternalIdentity) {
+	ret = &ExternalIdentity{}
+	return
+}
+
+type ApiKey struct {
+	KeyId      uuid.UUID  `json:"keyId,omitempty" eh:"optional"`
+	Name       string     `json:"name,omitempty" eh:"optional"`
+	Scopes     []string   `json:"scopes,omitempty" eh:"optional"`
+	Hash       string     `json:"hash,omitempty" eh:"optional"`
+	CreatedAt  *time.Time `json:"createdAt,omitempty" eh:"optional"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty" eh:"optional"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty" eh:"optional"`
+}
+
+func NewApiKeyDefault() (ret *ApiKey) {
+	ret = &ApiKey{}
+	return
+}
+
+func (o *ApiKey) AddToScopes(item string) string {
+	o.Scopes = append(o.Scopes, item)
+	return item
+}
+
+type Impersonation struct {
+	ImpersonationId uuid.UUID  `json:"impersonationId,omitempty" eh:"optional"`
+	ActorId         uuid.UUID  `json:"actorId,omitempty" eh:"optional"`
+	Reason          string     `json:"reason,omitempty" eh:"optional"`
+	StartedAt       *time.Time `json:"startedAt,omitempty" eh:"optional"`
+	ExpiresAt       *time.Time `json:"expiresAt,omitempty" eh:"optional"`
+}
+
+func NewImpersonationDefault() (ret *Impersonation) {
+	ret = &Impersonation{}
+	return
+}
+
+type Session struct {
+	SessionId  uuid.UUID  `json:"sessionId,omitempty" eh:"optional"`
+	Device     string     `json:"device,omitempty" eh:"optional"`
+	Ip         string     `json:"ip,omitempty" eh:"optional"`
+	UserAgent  string     `json:"userAgent,omitempty" eh:"optional"`
+	CreatedAt  *time.Time `json:"createdAt,omitempty" eh:"optional"`
+	LastSeenAt *time.Time `json:"lastSeenAt,omitempty" eh:"optional"`
+}
+
+func NewSessionDefault() (ret *Session) {
+	ret = &Session{}
+	return
+}
+
+type RoleAssignment struct {
+	AssignmentId   uuid.UUID  `json:"assignmentId,omitempty" eh:"optional"`
+	Role           string     `json:"role,omitempty" eh:"optional"`
+	ValidFrom      *time.Time `json:"validFrom,omitempty" eh:"optional"`
+	ValidUntil     *time.Time `json:"validUntil,omitempty" eh:"optional"`
+	AssignedBy     uuid.UUID  `json:"assignedBy,omitempty" eh:"optional"`
+	GrantRequestId uuid.UUID  `json:"grantRequestId,omitempty" eh:"optional"`
+}
+
+func NewRoleAssignmentDefault() (ret *RoleAssignment) {
+	ret = &RoleAssignment{}
+	return
+}
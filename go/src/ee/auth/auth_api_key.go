@@ -0,0 +1,617 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-ee/utils"
+	"github.com/go-ee/utils/crypt"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/looplab/eventhorizon"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const apiKeyPrefix = "ak"
+
+// usage of a key is recorded at most once per interval to keep the event stream small
+const apiKeyUsageInterval = time.Minute
+
+var ErrUnauthenticated = errors.New("authentication required")
+
+func FormatApiKey(accountId uuid.UUID, keyId uuid.UUID, secret string) string {
+	return fmt.Sprintf("%v.%v.%v.%v", apiKeyPrefix, accountId, keyId, secret)
+}
+
+func IsApiKey(value string) bool {
+	return strings.HasPrefix(value, apiKeyPrefix+".")
+}
+
+func ParseApiKey(value string) (accountId uuid.UUID, keyId uuid.UUID, secret string, err error) {
+	parts := strings.SplitN(value, ".", 4)
+	if len(parts) != 4 || parts[0] != apiKeyPrefix || parts[3] == "" {
+		err = errors.New("malformed api key")
+		return
+	}
+	if accountId, err = uuid.Parse(parts[1]); err != nil {
+		return
+	}
+	if keyId, err = uuid.Parse(parts[2]); err != nil {
+		return
+	}
+	secret = parts[3]
+	return
+}
+
+func (o *ApiKey) IsExpired(now time.Time) bool {
+	return o.ExpiresAt != nil && !now.Before(*o.ExpiresAt)
+}
+
+func (o *ApiKey) HasScope(scope string) bool {
+	for _, item := range o.Scopes {
+		if item == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Public returns a copy without the secret hash
+func (o *ApiKey) Public() (ret *ApiKey) {
+	ret = &ApiKey{
+		KeyId:      o.KeyId,
+		Name:       o.Name,
+		Scopes:     o.Scopes,
+		CreatedAt:  o.CreatedAt,
+		ExpiresAt:  o.ExpiresAt,
+		LastUsedAt: o.LastUsedAt,
+	}
+	return
+}
+
+func (o *Account) FindApiKey(keyId uuid.UUID) (ret *ApiKey) {
+	for _, apiKey := range o.ApiKeys {
+		if apiKey.KeyId == keyId {
+			return apiKey
+		}
+	}
+	return
+}
+
+func (o *Account) FindApiKeyByName(name string) (ret *ApiKey) {
+	for _, apiKey := range o.ApiKeys {
+		if apiKey.Name == name {
+			return apiKey
+		}
+	}
+	return
+}
+
+func (o *Account) RemoveApiKey(keyId uuid.UUID) {
+	apiKeys := o.ApiKeys[:0]
+	for _, apiKey := range o.ApiKeys {
+		if apiKey.KeyId != keyId {
+			apiKeys = append(apiKeys, apiKey)
+		}
+	}
+	o.ApiKeys = apiKeys
+}
+
+func (o *EsEngine) ImplementApiKeys() {
+	o.Account.ImplementApiKeys()
+}
+
+func (o *AccountAggregateEngine) ImplementApiKeys() {
+	o.AggregateExecutors.Enabled.AddCreateApiKeyPreparer(
+		func(cmd *CreateApiKeyAccount, entity *Account) (err error) {
+			if cmd.KeyId == uuid.Nil || cmd.Name == "" || cmd.Hash == "" {
+				err = errors.New("key id, name and hash of the api key are required")
+			} else if entity.FindApiKey(cmd.KeyId) != nil {
+				err = fmt.Errorf("api key '%v' exists already", cmd.KeyId)
+			} else if entity.FindApiKeyByName(cmd.Name) != nil {
+				err = fmt.Errorf("api key with name '%v' exists already", cmd.Name)
+			} else if cmd.ExpiresAt != nil && !cmd.ExpiresAt.After(time.Now()) {
+				err = errors.New("expiry of the api key must be in the future")
+			}
+			return
+		})
+
+	o.AggregateExecutors.Enabled.AddUseApiKeyPreparer(
+		func(cmd *UseApiKeyAccount, entity *Account) (err error) {
+			if apiKey := entity.FindApiKey(cmd.KeyId); apiKey == nil {
+				err = fmt.Errorf("api key '%v' not found", cmd.KeyId)
+			} else if apiKey.IsExpired(time.Now()) {
+				err = fmt.Errorf("api key '%v' is expired", cmd.KeyId)
+			}
+			return
+		})
+
+	revokePreparer := func(cmd *RevokeApiKeyAccount, entity *Account) (err error) {
+		if entity.FindApiKey(cmd.KeyId) == nil {
+			err = fmt.Errorf("api key '%v' not found", cmd.KeyId)
+		}
+		return
+	}
+	o.AggregateExecutors.Enabled.AddRevokeApiKeyPreparer(revokePreparer)
+	o.AggregateExecutors.Disabled.AddRevokeApiKeyPreparer(revokePreparer)
+
+	o.AggregateHandlers.Enabled.CreatedApiKeyHandler =
+		func(event eventhorizon.Event, eventData *AccountCreatedApiKey, entity *Account) (err error) {
+			entity.AddToApiKeys(&ApiKey{
+				KeyId:     eventData.KeyId,
+				Name:      eventData.Name,
+				Scopes:    eventData.Scopes,
+				Hash:      eventData.Hash,
+				CreatedAt: utils.PtrTime(event.Timestamp()),
+				ExpiresAt: eventData.ExpiresAt,
+			})
+			return
+		}
+
+	o.AggregateHandlers.Enabled.UsedApiKeyHandler =
+		func(event eventhorizon.Event, eventData *AccountUsedApiKey, entity *Account) (err error) {
+			if apiKey := entity.FindApiKey(eventData.KeyId); apiKey != nil {
+				apiKey.LastUsedAt = utils.PtrTime(event.Timestamp())
+			}
+			return
+		}
+
+	revokedHandler := func(event eventhorizon.Event, eventData *AccountRevokedApiKey, entity *Account) (err error) {
+		entity.RemoveApiKey(eventData.KeyId)
+		return
+	}
+	o.AggregateHandlers.Enabled.RevokedApiKeyHandler = revokedHandler
+	o.AggregateHandlers.Disabled.RevokedApiKeyHandler = revokedHandler
+}
+
+type Principal struct {
+	AccountId       uuid.UUID
+	Username        string
+	Roles           []string
+	Permissions     []string
+	OrganizationId  uuid.UUID
+	SessionId       uuid.UUID
+	ApiKey          *ApiKey
+	Actor           *Principal
+	ImpersonationId uuid.UUID
+	// PasswordChangeOnly is set for tokens of expired passwords, they allow the password change only
+	PasswordChangeOnly bool
+}
+
+// HasScope is always true for interactive logins, api keys are limited to their scopes
+func (o *Principal) HasScope(scope string) bool {
+	return o.ApiKey == nil || o.ApiKey.HasScope(scope)
+}
+
+// HasPermission checks the roles of the principal, a role grants the permission of the same name,
+// and the permissions of the roles
+func (o *Principal) HasPermission(permission string) bool {
+	if !o.HasScope(permission) {
+		return false
+	}
+	for _, role := range o.Roles {
+		if role == permission {
+			return true
+		}
+	}
+	for _, pattern := range o.Permissions {
+		if matchPermission(pattern, permission) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *Principal) IsImpersonated() bool {
+	return o.Actor != nil
+}
+
+type principalContextKey struct{}
+
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+func PrincipalFrom(ctx context.Context) (ret *Principal) {
+	ret, _ = ctx.Value(principalContextKey{}).(*Principal)
+	return
+}
+
+// Authenticator resolves the principal of a request from an api key, a token issued by this service
+// or basic credentials, requests without credentials pass unchanged.
+type Authenticator struct {
+	Accounts    *AccountQueryRepository
+	CommandBus  eventhorizon.CommandHandler
+	Tokens      *Tokens
+	Revocations RevocationStore
+	// Passwords rejects expired passwords of basic authentication, they must be changed over a login first
+	Passwords *PasswordPolicy
+	// ClientRoutes authenticate oauth clients by their own, credentials are passed through
+	ClientRoutes map[string]bool
+	ctx          context.Context
+}
+
+func NewAuthenticator(newContext func(string) (ret context.Context), commandBus eventhorizon.CommandHandler,
+	accounts *AccountQueryRepository, tokens *Tokens) (ret *Authenticator) {
+	ret = &Authenticator{
+		Accounts:   accounts,
+		CommandBus: commandBus,
+		Tokens:     tokens,
+		ClientRoutes: map[string]bool{
+			"Introspect": true,
+			"Revoke":     true,
+			"Check":      true,
+			"CheckBatch": true,
+		},
+		ctx: newContext("authenticator"),
+	}
+	return
+}
+
+func (o *Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if route := mux.CurrentRoute(r); route != nil && o.ClientRoutes[route.GetName()] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		principal, err := o.Authenticate(r)
+		if err == nil && principal != nil && o.Tokens != nil {
+			principal.Permissions, err = o.Tokens.EffectivePermissions(principal.Roles)
+		}
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			writeError(w, http.StatusUnauthorized, "invalid_token", err)
+			return
+		}
+		if principal != nil {
+			r = r.WithContext(WithPrincipal(r.Context(), principal))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (o *Authenticator) Authenticate(r *http.Request) (ret *Principal, err error) {
+	if username, password, ok := r.BasicAuth(); ok {
+		ret, err = o.AuthenticatePassword(username, password)
+		return
+	}
+
+	authorization := r.Header.Get("Authorization")
+	if !strings.HasPrefix(authorization, "Bearer ") {
+		return
+	}
+
+	token := strings.TrimSpace(strings.TrimPrefix(authorization, "Bearer "))
+	if IsApiKey(token) {
+		ret, err = o.AuthenticateApiKey(token)
+	} else if o.Tokens == nil {
+		err = errors.New("invalid token")
+	} else {
+		// an invalid token is rejected, it is never downgraded to an anonymous request
+		var claims *TokenClaims
+		if claims, err = o.Tokens.Parse(token); err == nil {
+			ret, err = o.principalOfClaims(claims, true)
+		}
+	}
+	return
+}
+
+// VerifyToken validates a token of this service without recording the activity of its session
+func (o *Authenticator) VerifyToken(token string) (claims *TokenClaims, ret *Principal, err error) {
+	if claims, err = o.Tokens.Parse(token); err == nil {
+		ret, err = o.principalOfClaims(claims, false)
+	}
+	return
+}
+
+func (o *Authenticator) AuthenticatePassword(username string, password string) (ret *Principal, err error) {
+	var account *Account
+	if account, err = o.Accounts.FindByCredentials(username, password); err != nil {
+		return
+	}
+	if o.Passwords != nil && o.Passwords.IsExpired(account, time.Now()) {
+		err = errors.New("password is expired")
+		return
+	}
+
+	var roles []string
+	if roles, err = o.Tokens.EffectiveRoles(account); err == nil {
+		ret = &Principal{AccountId: account.Id, Username: account.Username, Roles: roles,
+			OrganizationId: account.OrganizationId}
+	}
+	return
+}
+
+func (o *Authenticator) AuthenticateApiKey(value string) (ret *Principal, err error) {
+	accountId, keyId, secret, parseErr := ParseApiKey(value)
+	if parseErr != nil {
+		err = parseErr
+		return
+	}
+
+	// keys of merged accounts are moved to the account they are merged into
+	var account *Account
+	if account, err = o.Accounts.FindById(accountId); err == nil && account != nil && account.IsMerged() {
+		account, err = o.Accounts.FindById(account.MergedInto)
+	}
+	if err != nil || account == nil {
+		err = errors.New("invalid api key")
+		return
+	}
+
+	apiKey := account.FindApiKey(keyId)
+	now := time.Now()
+	if apiKey == nil || !crypt.HashAndEquals(secret, apiKey.Hash) {
+		err = errors.New("invalid api key")
+	} else if apiKey.IsExpired(now) {
+		err = errors.New("api key is expired")
+	} else if account.Disabled {
+		err = errors.New("account is disabled")
+	} else {
+		var roles []string
+		if roles, err = o.Tokens.EffectiveRoles(account); err != nil {
+			return
+		}
+		ret = &Principal{AccountId: account.Id, Username: account.Username, Roles: roles,
+			OrganizationId: account.OrganizationId, ApiKey: apiKey.Public()}
+
+		if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyUsageInterval {
+			err = o.CommandBus.HandleCommand(o.ctx, &UseApiKeyAccount{Id: account.Id, KeyId: keyId})
+		}
+	}
+	return
+}
+
+func (o *Authenticator) principalOfClaims(claims *TokenClaims, touch bool) (ret *Principal, err error) {
+	var accountId, organizationId uuid.UUID
+	if accountId, err = claims.AccountId(); err != nil {
+		return
+	}
+	if organizationId, err = claims.OrganizationId(); err != nil {
+		return
+	}
+
+	if o.Revocations != nil {
+		var revoked bool
+		if revoked, err = o.Revocations.IsRevoked(claims.Id); err != nil {
+			return
+		} else if revoked {
+			err = errors.New("token is revoked")
+			return
+		}
+	}
+
+	principal := &Principal{AccountId: accountId, Username: claims.Username, Roles: claims.Roles,
+		OrganizationId: organizationId, PasswordChangeOnly: claims.Scope == ScopePasswordChange}
+	if claims.Actor != nil {
+		err = o.resolveImpersonation(principal, claims)
+	} else {
+		err = o.resolveSession(principal, claims, touch)
+	}
+	if err == nil {
+		ret = principal
+	}
+	return
+}
+
+// FindByUsername searches over all organizations, a username used in several organizations is ambiguous
+func (o *AccountQueryRepository) FindByUsername(username string) (ret *Account, err error) {
+	var accounts []*Account
+	if accounts, err = o.FindAll(); err != nil {
+		return
+	}
+	for _, account := range accounts {
+		if account.Username == username {
+			if ret != nil {
+				ret = nil
+				err = fmt.Errorf("username '%v' is ambiguous, the organization is required", username)
+				return
+			}
+			ret = account
+		}
+	}
+	if ret == nil {
+		err = fmt.Errorf("account '%v' not found", username)
+	}
+	return
+}
+
+// FindByCredentials resolves the account by id or username and verifies the password
+func (o *AccountQueryRepository) FindByCredentials(username string, password string) (ret *Account, err error) {
+	var account *Account
+	if id, parseErr := uuid.Parse(username); parseErr == nil {
+		account, err = o.FindById(id)
+	} else {
+		account, err = o.FindByUsername(username)
+	}
+
+	ret, err = verifyCredentials(account, err, password)
+	return
+}
+
+func verifyCredentials(account *Account, findErr error, password string) (ret *Account, err error) {
+	if findErr != nil || account == nil || account.DeletedAt != nil || account.Password == "" ||
+		!crypt.HashAndEquals(password, account.Password) {
+		err = errors.New("invalid credentials")
+	} else if err = verifyAccount(account); err == nil {
+		ret = account
+	}
+	return
+}
+
+// verifyAccount checks that the identified account may log in, whatever the way it was identified
+func verifyAccount(account *Account) (err error) {
+	if account.DeletedAt != nil {
+		err = errors.New("account is deleted")
+	} else if account.PendingApproval {
+		err = errors.New("account is pending approval")
+	} else if account.IsMerged() {
+		err = errors.New("account is merged into another account")
+	} else if account.Disabled {
+		err = errors.New("account is disabled")
+	}
+	return
+}
+
+type CreateApiKeyRequest struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes,omitempty"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+type CreatedApiKey struct {
+	*ApiKey
+	Key string `json:"key"`
+}
+
+// ApiKeyRouter serves the api keys of an account, a key is only readable once in the response of its creation.
+type ApiKeyRouter struct {
+	PathPrefix string
+	DefaultTtl time.Duration
+	Accounts   *AccountQueryRepository
+	CommandBus eventhorizon.CommandHandler
+	ctx        context.Context
+}
+
+func NewApiKeyRouter(pathPrefix string, newContext func(string) (ret context.Context),
+	commandBus eventhorizon.CommandHandler, accounts *AccountQueryRepository) (ret *ApiKeyRouter) {
+	ret = &ApiKeyRouter{
+		PathPrefix: pathPrefix + "/" + "account",
+		DefaultTtl: 90 * 24 * time.Hour,
+		Accounts:   accounts,
+		CommandBus: commandBus,
+		ctx:        newContext("apiKey"),
+	}
+	return
+}
+
+func (o *ApiKeyRouter) Setup(router *mux.Router) (err error) {
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefix).Path("/{id}/tokens").
+		Name("ApiKeyFindAll").
+		HandlerFunc(o.FindAll)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefix).Path("/{id}/tokens").
+		Name("CreateApiKey").
+		HandlerFunc(o.Create)
+	router.Methods(http.MethodDelete).PathPrefix(o.PathPrefix).Path("/{id}/tokens/{keyId}").
+		Name("RevokeApiKey").
+		HandlerFunc(o.Revoke)
+	return
+}
+
+func (o *ApiKeyRouter) FindAll(w http.ResponseWriter, r *http.Request) {
+	account, ok := o.ownAccount(w, r)
+	if !ok {
+		return
+	}
+
+	ret := make([]*ApiKey, 0, len(account.ApiKeys))
+	for _, apiKey := range account.ApiKeys {
+		ret = append(ret, apiKey.Public())
+	}
+	writeJSON(w, http.StatusOK, ret)
+}
+
+func (o *ApiKeyRouter) Create(w http.ResponseWriter, r *http.Request) {
+	account, ok := o.ownAccount(w, r)
+	if !ok {
+		return
+	}
+
+	if principal := PrincipalFrom(r.Context()); principal.ApiKey != nil {
+		writeError(w, http.StatusForbidden, "access_denied", errors.New("api keys can not create api keys"))
+		return
+	}
+
+	request := &CreateApiKeyRequest{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+
+	expiresAt := request.ExpiresAt
+	if expiresAt == nil && o.DefaultTtl > 0 {
+		expiresAt = utils.PtrTime(time.Now().Add(o.DefaultTtl))
+	}
+
+	secret, err := randomToken()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", err)
+		return
+	}
+
+	var hash string
+	if hash, err = crypt.Hash(secret); err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", err)
+		return
+	}
+
+	create := &CreateApiKeyAccount{
+		Id:        account.Id,
+		KeyId:     uuid.New(),
+		Name:      request.Name,
+		Scopes:    request.Scopes,
+		Hash:      hash,
+		ExpiresAt: expiresAt,
+	}
+	if err = o.CommandBus.HandleCommand(o.ctx, create); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, &CreatedApiKey{
+		ApiKey: &ApiKey{
+			KeyId:     create.KeyId,
+			Name:      create.Name,
+			Scopes:    create.Scopes,
+			CreatedAt: utils.PtrTime(time.Now()),
+			ExpiresAt: create.ExpiresAt,
+		},
+		Key: FormatApiKey(account.Id, create.KeyId, secret),
+	})
+}
+
+func (o *ApiKeyRouter) Revoke(w http.ResponseWriter, r *http.Request) {
+	account, ok := o.ownAccount(w, r)
+	if !ok {
+		return
+	}
+
+	keyId, err := uuid.Parse(mux.Vars(r)["keyId"])
+	if err != nil || account.FindApiKey(keyId) == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err = o.CommandBus.HandleCommand(o.ctx, &RevokeApiKeyAccount{Id: account.Id, KeyId: keyId}); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ownAccount loads the account of the path, api keys are managed by the account itself only
+func (o *ApiKeyRouter) ownAccount(w http.ResponseWriter, r *http.Request) (ret *Account, ok bool) {
+	principal := PrincipalFrom(r.Context())
+	if principal == nil {
+		w.Header().Set("WWW-Authenticate", `Basic realm="auth"`)
+		writeError(w, http.StatusUnauthorized, "unauthorized", ErrUnauthenticated)
+		return
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil || id != principal.AccountId {
+		writeError(w, http.StatusForbidden, "access_denied", errors.New("api keys of other accounts are not accessible"))
+		return
+	}
+
+	if ret, err = o.Accounts.FindById(id); err != nil || ret == nil {
+		http.NotFound(w, r)
+		return
+	}
+	ok = true
+	return
+}
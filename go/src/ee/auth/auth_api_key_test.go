@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// requests with a bearer token the service did not issue are rejected, not served as anonymous requests
+func TestAuthenticatorRejectsInvalidBearer(t *testing.T) {
+	signKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authenticator := NewAuthenticator(func(string) context.Context { return context.Background() }, nil, nil,
+		NewTokens("auth", time.Hour, signKey))
+	handler := authenticator.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	for _, item := range []struct {
+		authorization string
+		expected      int
+	}{
+		{"", http.StatusNoContent},
+		{"Bearer not-a-token", http.StatusUnauthorized},
+		{"Bearer eyJhbGciOiJub25lIn0.eyJzdWIiOiJhZG1pbiJ9.", http.StatusUnauthorized},
+	} {
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		if item.authorization != "" {
+			request.Header.Set("Authorization", item.authorization)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		if recorder.Code != item.expected {
+			t.Errorf("'%v' answered %v instead of %v", item.authorization, recorder.Code, item.expected)
+		}
+	}
+}
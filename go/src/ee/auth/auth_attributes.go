@@ -0,0 +1,432 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/looplab/eventhorizon"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"sync"
+)
+
+// AttributeSchema is the subset of JSON Schema supported for custom account attributes:
+// type, properties, required, additionalProperties, items, enum, pattern, length and range limits
+type AttributeSchema struct {
+	Type                 string                      `json:"type,omitempty"`
+	Description          string                      `json:"description,omitempty"`
+	Properties           map[string]*AttributeSchema `json:"properties,omitempty"`
+	Required             []string                    `json:"required,omitempty"`
+	AdditionalProperties *bool                       `json:"additionalProperties,omitempty"`
+	Items                *AttributeSchema            `json:"items,omitempty"`
+	Enum                 []interface{}               `json:"enum,omitempty"`
+	Pattern              string                      `json:"pattern,omitempty"`
+	MinLength            *int                        `json:"minLength,omitempty"`
+	MaxLength            *int                        `json:"maxLength,omitempty"`
+	Minimum              *float64                    `json:"minimum,omitempty"`
+	Maximum              *float64                    `json:"maximum,omitempty"`
+	pattern              *regexp.Regexp
+}
+
+// Compile checks the schema and prepares its patterns, the root must describe an object
+func (o *AttributeSchema) Compile() (err error) {
+	if o.Type != "object" {
+		err = errors.New("schema of the attributes must be of type 'object'")
+		return
+	}
+	err = o.compile("")
+	return
+}
+
+func (o *AttributeSchema) compile(path string) (err error) {
+	switch o.Type {
+	case "", "object", "array", "string", "number", "integer", "boolean":
+	default:
+		err = fmt.Errorf("unsupported type '%v' of '%v'", o.Type, path)
+		return
+	}
+	if o.Pattern != "" {
+		if o.pattern, err = regexp.Compile(o.Pattern); err != nil {
+			return
+		}
+	}
+	for name, property := range o.Properties {
+		if err = property.compile(attributePath(path, name)); err != nil {
+			return
+		}
+	}
+	if o.Items != nil {
+		err = o.Items.compile(path + "[]")
+	}
+	return
+}
+
+// Validate checks the value, it must be of the types of decoded json, e.g. numbers as float64
+func (o *AttributeSchema) Validate(path string, value interface{}) (err error) {
+	if err = o.validateType(path, value); err != nil {
+		return
+	}
+	if len(o.Enum) > 0 && !containsValue(o.Enum, value) {
+		err = fmt.Errorf("'%v' is not one of %v", path, o.Enum)
+		return
+	}
+
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		err = o.validateObject(path, typed)
+	case []interface{}:
+		if o.Items != nil {
+			for i, item := range typed {
+				if err = o.Items.Validate(fmt.Sprintf("%v[%v]", path, i), item); err != nil {
+					return
+				}
+			}
+		}
+	case string:
+		length := len([]rune(typed))
+		if o.MinLength != nil && length < *o.MinLength {
+			err = fmt.Errorf("'%v' is shorter than %v", path, *o.MinLength)
+		} else if o.MaxLength != nil && length > *o.MaxLength {
+			err = fmt.Errorf("'%v' is longer than %v", path, *o.MaxLength)
+		} else if o.pattern != nil && !o.pattern.MatchString(typed) {
+			err = fmt.Errorf("'%v' does not match '%v'", path, o.Pattern)
+		}
+	case float64:
+		if o.Minimum != nil && typed < *o.Minimum {
+			err = fmt.Errorf("'%v' is less than %v", path, *o.Minimum)
+		} else if o.Maximum != nil && typed > *o.Maximum {
+			err = fmt.Errorf("'%v' is greater than %v", path, *o.Maximum)
+		}
+	}
+	return
+}
+
+func (o *AttributeSchema) validateType(path string, value interface{}) (err error) {
+	var valid bool
+	switch o.Type {
+	case "":
+		valid = true
+	case "object":
+		_, valid = value.(map[string]interface{})
+	case "array":
+		_, valid = value.([]interface{})
+	case "string":
+		_, valid = value.(string)
+	case "boolean":
+		_, valid = value.(bool)
+	case "number":
+		_, valid = value.(float64)
+	case "integer":
+		number, isNumber := value.(float64)
+		valid = isNumber && number == float64(int64(number))
+	}
+	if !valid {
+		err = fmt.Errorf("'%v' must be of type '%v'", path, o.Type)
+	}
+	return
+}
+
+func (o *AttributeSchema) validateObject(path string, value map[string]interface{}) (err error) {
+	for _, name := range o.Required {
+		if _, ok := value[name]; !ok {
+			err = fmt.Errorf("'%v' is required", attributePath(path, name))
+			return
+		}
+	}
+
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if property := o.Properties[name]; property != nil {
+			err = property.Validate(attributePath(path, name), value[name])
+		} else if o.AdditionalProperties != nil && !*o.AdditionalProperties {
+			err = fmt.Errorf("'%v' is not defined", attributePath(path, name))
+		}
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+func attributePath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, item := range values {
+		if reflect.DeepEqual(normalizeAttribute(item), value) {
+			return true
+		}
+	}
+	return false
+}
+
+// AttributeSchemas keeps the schema of the custom attributes defined by the admins in a file,
+// without a schema accounts have no custom attributes
+type AttributeSchemas struct {
+	File   string
+	schema *AttributeSchema
+	mutex  sync.RWMutex
+}
+
+func NewAttributeSchemas(file string) (ret *AttributeSchemas, err error) {
+	ret = &AttributeSchemas{File: file}
+	err = ret.Load()
+	return
+}
+
+func (o *AttributeSchemas) Load() (err error) {
+	var data []byte
+	if data, err = ioutil.ReadFile(o.File); err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+
+	schema := &AttributeSchema{}
+	if err = json.Unmarshal(data, schema); err != nil {
+		return
+	}
+	if err = schema.Compile(); err != nil {
+		return
+	}
+
+	o.mutex.Lock()
+	o.schema = schema
+	o.mutex.Unlock()
+	return
+}
+
+func (o *AttributeSchemas) Schema() (ret *AttributeSchema) {
+	o.mutex.RLock()
+	ret = o.schema
+	o.mutex.RUnlock()
+	return
+}
+
+// Define replaces the schema, the attributes of existing accounts are validated again with their next change
+func (o *AttributeSchemas) Define(schema *AttributeSchema) (err error) {
+	if err = schema.Compile(); err != nil {
+		return
+	}
+
+	var data []byte
+	if data, err = json.MarshalIndent(schema, "", "  "); err != nil {
+		return
+	}
+	if err = os.MkdirAll(filepath.Dir(o.File), 0700); err != nil {
+		return
+	}
+	if err = ioutil.WriteFile(o.File, data, 0600); err != nil {
+		return
+	}
+
+	o.mutex.Lock()
+	o.schema = schema
+	o.mutex.Unlock()
+	return
+}
+
+// Validate checks the attributes against the schema, also missing attributes because they may be required
+func (o *AttributeSchemas) Validate(attributes map[string]interface{}) (err error) {
+	schema := o.Schema()
+	if schema == nil {
+		if len(attributes) > 0 {
+			err = errors.New("custom attributes are not defined")
+		}
+		return
+	}
+	if attributes == nil {
+		attributes = map[string]interface{}{}
+	}
+	err = schema.Validate("", normalizeAttribute(attributes))
+	return
+}
+
+func (o *EsEngine) ImplementAttributes(schemas *AttributeSchemas) {
+	o.Account.ImplementAttributes(schemas)
+}
+
+// ImplementAttributes validates the custom attributes against the schema of the admins,
+// an update without attributes keeps the current ones
+func (o *AccountAggregateEngine) ImplementAttributes(schemas *AttributeSchemas) {
+	o.AggregateExecutors.Initial.AddCreatePreparer(func(cmd *CreateAccount, entity *Account) (err error) {
+		err = schemas.Validate(cmd.Attributes)
+		return
+	})
+	o.AggregateExecutors.Exist.AddUpdatePreparer(func(cmd *UpdateAccount, entity *Account) (err error) {
+		if cmd.Attributes == nil {
+			cmd.Attributes = entity.Attributes
+		} else {
+			err = schemas.Validate(cmd.Attributes)
+		}
+		return
+	})
+
+	changeAttributesPreparer := func(cmd *ChangeAttributesAccount, entity *Account) (err error) {
+		err = schemas.Validate(cmd.Attributes)
+		return
+	}
+	o.AggregateExecutors.Enabled.AddChangeAttributesPreparer(changeAttributesPreparer)
+	o.AggregateExecutors.Disabled.AddChangeAttributesPreparer(changeAttributesPreparer)
+}
+
+// FindByAttribute finds the accounts with the value of the custom attribute
+func (o *AccountQueryRepository) FindByAttribute(name string, value interface{}) (ret []*Account, err error) {
+	var accounts []*Account
+	if accounts, err = o.FindAll(); err == nil {
+		ret = filterByAttribute(accounts, name, value)
+	}
+	return
+}
+
+func (o *TenantAccountQueryRepository) FindByAttribute(name string, value interface{}) (ret []*Account, err error) {
+	var accounts []*Account
+	if accounts, err = o.FindAll(); err == nil {
+		ret = filterByAttribute(accounts, name, value)
+	}
+	return
+}
+
+func filterByAttribute(accounts []*Account, name string, value interface{}) (ret []*Account) {
+	value = normalizeAttribute(value)
+	ret = []*Account{}
+	for _, account := range accounts {
+		if attribute, ok := account.Attributes[name]; ok && reflect.DeepEqual(normalizeAttribute(attribute), value) {
+			ret = append(ret, account)
+		}
+	}
+	return
+}
+
+// AttributeRouter serves the schema of the custom attributes, changes the attributes of accounts
+// and finds accounts by them, members of an organization find the accounts of their organization only
+type AttributeRouter struct {
+	PathPrefix string
+	Schemas    *AttributeSchemas
+	Accounts   *AccountQueryRepository
+	CommandBus eventhorizon.CommandHandler
+	ctx        context.Context
+}
+
+func NewAttributeRouter(pathPrefix string, newContext func(string) (ret context.Context),
+	commandBus eventhorizon.CommandHandler, accounts *AccountQueryRepository,
+	schemas *AttributeSchemas) (ret *AttributeRouter) {
+	ret = &AttributeRouter{
+		PathPrefix: pathPrefix,
+		Schemas:    schemas,
+		Accounts:   accounts,
+		CommandBus: commandBus,
+		ctx:        newContext("attribute"),
+	}
+	return
+}
+
+func (o *AttributeRouter) Setup(router *mux.Router) (err error) {
+	router.Methods(http.MethodGet).Path(o.PathPrefix + "/attribute/schema").
+		Name("AttributeSchema").
+		HandlerFunc(o.Schema)
+	router.Methods(http.MethodPut).Path(o.PathPrefix + "/attribute/schema").
+		Name("DefineAttributeSchema").
+		HandlerFunc(o.Define)
+	router.Methods(http.MethodGet).Path(o.PathPrefix + "/attribute/account").
+		Name("AccountFindByAttribute").
+		HandlerFunc(o.FindAccounts)
+	router.Methods(http.MethodPut).PathPrefix(o.PathPrefix + "/account").Path("/{id}/attributes").
+		Name("ChangeAttributes").
+		HandlerFunc(o.Change)
+	return
+}
+
+func (o *AttributeRouter) Schema(w http.ResponseWriter, r *http.Request) {
+	schema := o.Schemas.Schema()
+	if schema == nil {
+		http.NotFound(w, r)
+		return
+	}
+	writeJSON(w, http.StatusOK, schema)
+}
+
+func (o *AttributeRouter) Define(w http.ResponseWriter, r *http.Request) {
+	schema := &AttributeSchema{}
+	if err := json.NewDecoder(r.Body).Decode(schema); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+	if err := o.Schemas.Define(schema); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, schema)
+}
+
+// FindAccounts finds by the query parameters 'name' and 'value', the value is decoded as json if possible,
+// e.g. 42 and true, otherwise it is a string
+func (o *AttributeRouter) FindAccounts(w http.ResponseWriter, r *http.Request) {
+	principal := PrincipalFrom(r.Context())
+	if principal == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", ErrUnauthenticated)
+		return
+	}
+
+	query := r.URL.Query()
+	name := query.Get("name")
+	if name == "" {
+		writeError(w, http.StatusBadRequest, "invalid_request", errors.New("name of the attribute is required"))
+		return
+	}
+	var value interface{}
+	if err := json.Unmarshal([]byte(query.Get("value")), &value); err != nil {
+		value = query.Get("value")
+	}
+
+	var ret []*Account
+	var err error
+	if principal.OrganizationId != uuid.Nil && !principal.HasPermission(PermissionManageOrganizations) {
+		ret, err = o.Accounts.ForTenant(principal.OrganizationId).FindByAttribute(name, value)
+	} else {
+		ret, err = o.Accounts.FindByAttribute(name, value)
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, PublicAccounts(ret))
+}
+
+func (o *AttributeRouter) Change(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	attributes := map[string]interface{}{}
+	if err = json.NewDecoder(r.Body).Decode(&attributes); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+
+	if err = o.CommandBus.HandleCommand(o.ctx, &ChangeAttributesAccount{Id: id, Attributes: attributes}); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, attributes)
+}
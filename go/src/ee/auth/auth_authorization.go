@@ -0,0 +1,205 @@
+package auth
+
+import (
+	"errors"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+// Authorizer checks the principal of a request against the name of the matched route.
+type Authorizer struct {
+	// Permissions required by routes
+	Permissions map[string]string
+	// CredentialRoutes change credentials and are closed for impersonated principals
+	CredentialRoutes map[string]bool
+	// InternalRoutes are dispatched by the service itself only, e.g. to fill the principal into commands
+	InternalRoutes map[string]bool
+	// Policies decide before the permissions, an allow replaces the permission of the route
+	Policies *PolicyEngine
+}
+
+func NewAuthorizer() (ret *Authorizer) {
+	ret = &Authorizer{
+		Permissions: map[string]string{
+			"CreateGroup":               PermissionManageGroups,
+			"UpdateGroup":               PermissionManageGroups,
+			"DeleteGroup":               PermissionManageGroups,
+			"AddMemberGroup":            PermissionManageGroups,
+			"RemoveMemberGroup":         PermissionManageGroups,
+			"AddSubgroupGroup":          PermissionManageGroups,
+			"RemoveSubgroupGroup":       PermissionManageGroups,
+			"GroupFindById":             PermissionManageGroups,
+			"GroupCountById":            PermissionManageGroups,
+			"GroupExistById":            PermissionManageGroups,
+			"GroupFindAll":              PermissionManageGroups,
+			"GroupCountAll":             PermissionManageGroups,
+			"GroupExistAll":             PermissionManageGroups,
+			"CreateOrganization":        PermissionManageOrganizations,
+			"UpdateOrganization":        PermissionManageOrganizations,
+			"DeleteOrganization":        PermissionManageOrganizations,
+			"OrganizationFindById":      PermissionManageOrganizations,
+			"OrganizationCountById":     PermissionManageOrganizations,
+			"OrganizationExistById":     PermissionManageOrganizations,
+			"OrganizationFindAll":       PermissionManageOrganizations,
+			"OrganizationCountAll":      PermissionManageOrganizations,
+			"OrganizationExistAll":      PermissionManageOrganizations,
+			"AssignRoleAccount":         PermissionManageRoles,
+			"UnassignRoleAccount":       PermissionManageRoles,
+			"ExpireRoleAccount":         PermissionManageRoles,
+			"RoleAssignmentFindAll":     PermissionManageRoles,
+			"AssignRole":                PermissionManageRoles,
+			"UnassignRole":              PermissionManageRoles,
+			"RequestRoleGrant":          PermissionManageRoles,
+			"ApproveRoleGrant":          PermissionApproveRoles,
+			"RejectRoleGrant":           PermissionApproveRoles,
+			"RoleGrantRequestFindById":  PermissionApproveRoles,
+			"RoleGrantRequestCountById": PermissionApproveRoles,
+			"RoleGrantRequestExistById": PermissionApproveRoles,
+			"RoleGrantRequestFindAll":   PermissionApproveRoles,
+			"RoleGrantRequestCountAll":  PermissionApproveRoles,
+			"RoleGrantRequestExistAll":  PermissionApproveRoles,
+			"CreateRole":                PermissionManageRoles,
+			"UpdateRole":                PermissionManageRoles,
+			"DeleteRole":                PermissionManageRoles,
+			"PolicyFindAll":             PermissionManagePolicies,
+			"EvaluatePolicy":            PermissionManagePolicies,
+			"CreateRelationTuple":       PermissionManageRelations,
+			"DeleteRelationTuple":       PermissionManageRelations,
+			"RelationTupleFindById":     PermissionReadRelations,
+			"RelationTupleFindAll":      PermissionReadRelations,
+			"RelationTupleCountById":    PermissionReadRelations,
+			"RelationTupleExistById":    PermissionReadRelations,
+			"RelationTupleCountAll":     PermissionReadRelations,
+			"RelationTupleExistAll":     PermissionReadRelations,
+			"WriteRelation":             PermissionManageRelations,
+			"DeleteRelation":            PermissionManageRelations,
+			"CheckRelation":             PermissionReadRelations,
+			"ExpandRelation":            PermissionReadRelations,
+			"ListRelationObjects":       PermissionReadRelations,
+			"DeleteInvitation":          PermissionManageAccounts,
+			"InvitationFindById":        PermissionManageAccounts,
+			"InvitationFindAll":         PermissionManageAccounts,
+			"InvitationCountById":       PermissionManageAccounts,
+			"InvitationExistById":       PermissionManageAccounts,
+			"InvitationCountAll":        PermissionManageAccounts,
+			"InvitationExistAll":        PermissionManageAccounts,
+			"InvitationFindPending":     PermissionManageAccounts,
+			"Invite":                    PermissionManageAccounts,
+			"ResendInvite":              PermissionManageAccounts,
+			"RevokeInvite":              PermissionManageAccounts,
+			"RegistrationFindPending":   PermissionManageAccounts,
+			"ApproveRegistration":       PermissionManageAccounts,
+			"RejectRegistration":        PermissionManageAccounts,
+			"RestoreAccount":            PermissionManageAccounts,
+			"PurgeAccount":              PermissionManageAccounts,
+			"ForgetAccount":             PermissionManageAccounts,
+			"DefineAttributeSchema":     PermissionManageAccounts,
+			"AccountFindByAttribute":    PermissionManageAccounts,
+			"ChangeAttributes":          PermissionManageAccounts,
+			"MergeAccounts":             PermissionManageAccounts,
+			"UpdateAccount":             PermissionManageAccounts,
+			"EnableAccount":             PermissionManageAccounts,
+			"DisableAccount":            PermissionManageAccounts,
+			"DeleteAccount":             PermissionManageAccounts,
+		},
+		CredentialRoutes: map[string]bool{
+			"UpdateAccount":      true,
+			"UpdateProfile":      true,
+			"CreateApiKey":       true,
+			"RevokeApiKey":       true,
+			"ChangePassword":     true,
+			"RequestEmailChange": true,
+			"MergeAccounts":      true,
+		},
+		InternalRoutes: map[string]bool{
+			"CreateApiKeyAccount":        true,
+			"RevokeApiKeyAccount":        true,
+			"UseApiKeyAccount":           true,
+			"LinkIdentityAccount":        true,
+			"UnlinkIdentityAccount":      true,
+			"StartSessionAccount":        true,
+			"TouchSessionAccount":        true,
+			"RevokeSessionAccount":       true,
+			"RevokeSessionsAccount":      true,
+			"AssignRoleAccount":          true,
+			"CreateRoleGrantRequest":     true,
+			"UpdateRoleGrantRequest":     true,
+			"ApproveRoleGrantRequest":    true,
+			"RejectRoleGrantRequest":     true,
+			"DeleteRoleGrantRequest":     true,
+			"CreateInvitation":           true,
+			"ResendInvitation":           true,
+			"RevokeInvitation":           true,
+			"AcceptInvitation":           true,
+			"RegisterAccount":            true,
+			"ApproveRegistrationAccount": true,
+			"RejectRegistrationAccount":  true,
+			"ChangePasswordAccount":      true,
+			"WarnInactivityAccount":      true,
+			"RequestEmailChangeAccount":  true,
+			"ConfirmEmailChangeAccount":  true,
+			"ChangeAttributesAccount":    true,
+			"MergeAccount":               true,
+			"MergeIntoAccount":           true,
+			"ImpersonateAccount":         true,
+			"EndImpersonationAccount":    true,
+		},
+	}
+	return
+}
+
+func (o *Authorizer) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var routeName string
+		if route := mux.CurrentRoute(r); route != nil {
+			routeName = route.GetName()
+		}
+
+		if o.InternalRoutes[routeName] {
+			http.NotFound(w, r)
+			return
+		}
+
+		principal := PrincipalFrom(r.Context())
+		if principal != nil && principal.PasswordChangeOnly && routeName != "ChangePassword" {
+			writeError(w, http.StatusForbidden, "password_change_required",
+				errors.New("the password must be changed first"))
+			return
+		}
+
+		allowed := false
+		if o.Policies != nil && principal != nil {
+			decision := o.Policies.Authorize(r, principal, routeName)
+			if decision.Decision == PolicyDeny {
+				writeError(w, http.StatusForbidden, "access_denied",
+					errors.New("denied by policy "+decision.Policy))
+				return
+			}
+			allowed = decision.Decision == PolicyAllow
+		}
+
+		// an allow replaces the roles of the principal, api keys stay limited to their scopes
+		if permission, ok := o.Permissions[routeName]; ok && allowed {
+			if !principal.HasScope(permission) {
+				writeError(w, http.StatusForbidden, "access_denied", errors.New("missing scope "+permission))
+				return
+			}
+		} else if ok {
+			if principal == nil {
+				writeError(w, http.StatusUnauthorized, "unauthorized", ErrUnauthenticated)
+				return
+			}
+			if !principal.HasPermission(permission) {
+				writeError(w, http.StatusForbidden, "access_denied", errors.New("missing permission "+permission))
+				return
+			}
+		}
+
+		if principal != nil && principal.IsImpersonated() && o.CredentialRoutes[routeName] {
+			writeError(w, http.StatusForbidden, "access_denied",
+				errors.New("credentials can not be changed during impersonation"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
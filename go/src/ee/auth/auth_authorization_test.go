@@ -0,0 +1,169 @@
+package auth
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/looplab/eventhorizon"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func routeNames(t *testing.T) (ret map[string]bool) {
+	ret = map[string]bool{}
+	_ = setupRoutes(t).Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		ret[route.GetName()] = true
+		return nil
+	})
+	return
+}
+
+// a misspelled route name leaves the route open
+func TestAuthorizerRoutesExist(t *testing.T) {
+	names := routeNames(t)
+	authorizer := NewAuthorizer()
+	for name := range authorizer.Permissions {
+		if !names[name] {
+			t.Errorf("permission of unknown route '%v'", name)
+		}
+	}
+	for name := range authorizer.CredentialRoutes {
+		if !names[name] {
+			t.Errorf("unknown credential route '%v'", name)
+		}
+		if authorizer.InternalRoutes[name] {
+			t.Errorf("credential route '%v' is internal", name)
+		}
+	}
+	for name := range authorizer.InternalRoutes {
+		if !names[name] {
+			t.Errorf("unknown internal route '%v'", name)
+		}
+	}
+}
+
+// commands filled by the service from the principal or from other commands must not be reachable
+func TestAuthorizerGeneratedCommandsAreInternal(t *testing.T) {
+	authorizer := NewAuthorizer()
+	for _, name := range []string{
+		"CreateApiKeyAccount", "RevokeApiKeyAccount", "UseApiKeyAccount",
+		"LinkIdentityAccount", "UnlinkIdentityAccount",
+		"StartSessionAccount", "TouchSessionAccount", "RevokeSessionAccount", "RevokeSessionsAccount",
+		"ChangePasswordAccount", "RequestEmailChangeAccount", "ConfirmEmailChangeAccount",
+		"MergeAccount", "MergeIntoAccount", "ImpersonateAccount", "EndImpersonationAccount",
+	} {
+		if !authorizer.InternalRoutes[name] {
+			t.Errorf("route '%v' is not internal", name)
+		}
+	}
+}
+
+func authorize(authorizer *Authorizer, routeName string, principal *Principal) int {
+	router := mux.NewRouter()
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if principal != nil {
+				r = r.WithContext(WithPrincipal(r.Context(), principal))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}, authorizer.Middleware)
+	router.Path("/").Name(routeName).HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", nil))
+	return recorder.Code
+}
+
+func TestAuthorizerMiddleware(t *testing.T) {
+	authorizer := NewAuthorizer()
+	admin := &Principal{AccountId: uuid.New(), Roles: []string{PermissionManageGroups, PermissionManageAccounts}}
+	impersonated := &Principal{AccountId: uuid.New(), Actor: admin}
+
+	for _, item := range []struct {
+		route     string
+		principal *Principal
+		expected  int
+	}{
+		{"CreateApiKeyAccount", admin, http.StatusNotFound},
+		{"CreateGroup", nil, http.StatusUnauthorized},
+		{"CreateGroup", &Principal{AccountId: uuid.New()}, http.StatusForbidden},
+		{"CreateGroup", admin, http.StatusNoContent},
+		{"ChangePassword", impersonated, http.StatusForbidden},
+		{"ChangePassword", admin, http.StatusNoContent},
+		{"CreateGroup", &Principal{AccountId: uuid.New(), PasswordChangeOnly: true}, http.StatusForbidden},
+		{"UpdateAccount", nil, http.StatusUnauthorized},
+		{"UpdateAccount", &Principal{AccountId: uuid.New()}, http.StatusForbidden},
+		{"DisableAccount", &Principal{AccountId: uuid.New()}, http.StatusForbidden},
+		{"DeleteAccount", admin, http.StatusNoContent},
+	} {
+		if code := authorize(authorizer, item.route, item.principal); code != item.expected {
+			t.Errorf("route '%v' answered %v instead of %v", item.route, code, item.expected)
+		}
+	}
+}
+
+// an allowing policy replaces the permission of the route, not the scopes of api keys
+func TestAuthorizerPolicyAllowKeepsScopes(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryRepo(t, AccountAggregateType, func() eventhorizon.Entity { return NewAccountDefault() })
+	account := &Account{Id: uuid.New(), Username: "alice"}
+	if err := repo.Save(ctx, account); err != nil {
+		t.Fatal(err)
+	}
+
+	authorizer := NewAuthorizer()
+	authorizer.Policies = &PolicyEngine{Accounts: NewAccountQueryRepositoryFull(repo, ctx),
+		policies: &PolicySet{Policies: []*Policy{{Id: "groups", Effect: PolicyAllow, Routes: []string{"CreateGroup"}}}}}
+
+	for _, item := range []struct {
+		apiKey   *ApiKey
+		expected int
+	}{
+		{nil, http.StatusNoContent},
+		{&ApiKey{Scopes: []string{PermissionManageGroups}}, http.StatusNoContent},
+		{&ApiKey{Scopes: []string{PermissionReadRelations}}, http.StatusForbidden},
+	} {
+		principal := &Principal{AccountId: account.Id, ApiKey: item.apiKey}
+		if code := authorize(authorizer, "CreateGroup", principal); code != item.expected {
+			t.Errorf("api key %v answered %v instead of %v", item.apiKey, code, item.expected)
+		}
+	}
+}
+
+// the queries of the generated routers answer with data of all accounts and organizations
+func TestAuthorizerProtectsQueries(t *testing.T) {
+	authorizer := NewAuthorizer()
+	for _, item := range []struct {
+		routes     []string
+		permission string
+	}{
+		{[]string{"RoleGrantRequestFindById", "RoleGrantRequestCountById", "RoleGrantRequestExistById",
+			"RoleGrantRequestFindAll", "RoleGrantRequestCountAll", "RoleGrantRequestExistAll"}, PermissionApproveRoles},
+		{[]string{"GroupFindById", "GroupCountById", "GroupExistById",
+			"GroupFindAll", "GroupCountAll", "GroupExistAll"}, PermissionManageGroups},
+		{[]string{"OrganizationFindById", "OrganizationCountById", "OrganizationExistById",
+			"OrganizationFindAll", "OrganizationCountAll", "OrganizationExistAll"}, PermissionManageOrganizations},
+		{[]string{"InvitationFindById", "InvitationCountById", "InvitationExistById", "InvitationFindAll",
+			"InvitationCountAll", "InvitationExistAll", "InvitationFindPending", "Invite", "ResendInvite",
+			"RevokeInvite"}, PermissionManageAccounts},
+		{[]string{"RelationTupleFindById", "RelationTupleCountById", "RelationTupleExistById",
+			"RelationTupleFindAll", "RelationTupleCountAll", "RelationTupleExistAll"}, PermissionReadRelations},
+	} {
+		for _, route := range item.routes {
+			if code := authorize(authorizer, route, nil); code != http.StatusUnauthorized {
+				t.Errorf("route '%v' answered %v to anonymous callers", route, code)
+			}
+			if code := authorize(authorizer, route, &Principal{AccountId: uuid.New()}); code != http.StatusForbidden {
+				t.Errorf("route '%v' answered %v to principals without permission", route, code)
+			}
+			principal := &Principal{AccountId: uuid.New(), Roles: []string{item.permission}}
+			if code := authorize(authorizer, route, principal); code != http.StatusNoContent {
+				t.Errorf("route '%v' answered %v to principals with permission", route, code)
+			}
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"time"
+)
+
+// the number of checks answered by a single batch request
+const maxBatchChecks = 100
+
+// PermissionCatalog maps roles to the permissions they grant, a role grants always the permission of its own name.
+// Permissions are patterns '<resource>:<action>' and may use the wildcards of path.Match, e.g. 'documents/*:read'.
+type PermissionCatalog struct {
+	Roles map[string][]string `json:"roles"`
+}
+
+func LoadPermissionCatalog(file string) (ret *PermissionCatalog, err error) {
+	var data []byte
+	if data, err = ioutil.ReadFile(file); err != nil {
+		return
+	}
+	ret = &PermissionCatalog{}
+	err = json.Unmarshal(data, ret)
+	return
+}
+
+func (o *PermissionCatalog) Permissions(role string) (ret []string) {
+	ret = append(ret, role)
+	if o != nil {
+		ret = append(ret, o.Roles[role]...)
+	}
+	return
+}
+
+// Permission builds the permission requested for an action on a resource
+func Permission(action string, resource string) string {
+	if resource == "" {
+		return action
+	}
+	return resource + ":" + action
+}
+
+func matchPermission(pattern string, permission string) bool {
+	if pattern == permission {
+		return true
+	}
+	matched, err := path.Match(pattern, permission)
+	return err == nil && matched
+}
+
+// CheckRequest asks whether the subject, an account id or username, may perform the action on the resource
+type CheckRequest struct {
+	Subject  string `json:"subject"`
+	Action   string `json:"action"`
+	Resource string `json:"resource,omitempty"`
+}
+
+type CheckResponse struct {
+	Subject  string   `json:"subject"`
+	Action   string   `json:"action"`
+	Resource string   `json:"resource,omitempty"`
+	Allowed  bool     `json:"allowed"`
+	Trace    []string `json:"trace"`
+	Error    string   `json:"error,omitempty"`
+}
+
+type BatchCheckRequest struct {
+	Checks []*CheckRequest `json:"checks"`
+}
+
+type BatchCheckResponse struct {
+	Results []*CheckResponse `json:"results"`
+}
+
+// AccessChecker decides about actions of accounts by their own roles, the roles of their groups, the roles inherited
+// by them and the permissions of the roles.
+type AccessChecker struct {
+	Accounts  *AccountQueryRepository
+	Roles     RoleResolver
+	Hierarchy *RoleHierarchy
+	Catalog   *PermissionCatalog
+}
+
+func NewAccessChecker(accounts *AccountQueryRepository, roles RoleResolver, hierarchy *RoleHierarchy,
+	catalog *PermissionCatalog) (ret *AccessChecker) {
+	ret = &AccessChecker{Accounts: accounts, Roles: roles, Hierarchy: hierarchy, Catalog: catalog}
+	return
+}
+
+func (o *AccessChecker) Check(request *CheckRequest) (ret *CheckResponse) {
+	ret = &CheckResponse{Subject: request.Subject, Action: request.Action, Resource: request.Resource, Trace: []string{}}
+	if request.Subject == "" || request.Action == "" {
+		ret.Error = "subject and action are required"
+		return
+	}
+
+	account, err := o.findSubject(request.Subject)
+	if err != nil || account == nil {
+		ret.Error = fmt.Sprintf("subject '%v' not found", request.Subject)
+		return
+	}
+	ret.trace("subject resolved to account '%v' (%v)", account.Id, account.Username)
+
+	if account.Disabled {
+		ret.trace("account is disabled")
+		return
+	}
+
+	var grants []*RoleGrant
+	if grants, err = o.roleGrants(account); err != nil {
+		ret.Error = err.Error()
+		return
+	}
+
+	permission := Permission(request.Action, request.Resource)
+	for _, grant := range grants {
+		var patterns []string
+		if patterns, err = o.permissions(grant.Role); err != nil {
+			ret.Error = err.Error()
+			return
+		}
+		for _, pattern := range patterns {
+			if matchPermission(pattern, permission) {
+				ret.Allowed = true
+				if grant.InheritedFrom != "" {
+					ret.trace("role '%v' inherited from '%v' grants '%v'", grant.Role, grant.InheritedFrom, pattern)
+				} else if grant.ValidUntil != nil {
+					ret.trace("role '%v' assigned until %v grants '%v'", grant.Role,
+						grant.ValidUntil.Format(time.RFC3339), pattern)
+				} else if grant.Group == "" {
+					ret.trace("role '%v' of the account grants '%v'", grant.Role, pattern)
+				} else {
+					ret.trace("role '%v' of group '%v' grants '%v'", grant.Role, grant.Group, pattern)
+				}
+				return
+			}
+		}
+		ret.trace("role '%v' does not grant '%v'", grant.Role, permission)
+	}
+	if len(grants) == 0 {
+		ret.trace("account has no roles")
+	}
+	return
+}
+
+func (o *AccessChecker) CheckBatch(request *BatchCheckRequest) (ret *BatchCheckResponse) {
+	ret = &BatchCheckResponse{Results: make([]*CheckResponse, len(request.Checks))}
+	for i, check := range request.Checks {
+		ret.Results[i] = o.Check(check)
+	}
+	return
+}
+
+func (o *AccessChecker) findSubject(subject string) (ret *Account, err error) {
+	if id, parseErr := uuid.Parse(subject); parseErr == nil {
+		ret, err = o.Accounts.FindById(id)
+	} else {
+		ret, err = o.Accounts.FindByUsername(subject)
+	}
+	return
+}
+
+// permissions of the catalog and of the role definition, inherited roles are checked as grants of their own
+func (o *AccessChecker) permissions(role string) (ret []string, err error) {
+	ret = o.Catalog.Permissions(role)
+	if o.Hierarchy != nil {
+		var definition *Role
+		if definition, err = o.Hierarchy.Roles.FindByName(role); err == nil && definition != nil {
+			ret = append(ret, definition.Permissions...)
+		}
+	}
+	return
+}
+
+func (o *AccessChecker) roleGrants(account *Account) (ret []*RoleGrant, err error) {
+	if explainer, ok := o.Roles.(RoleExplainer); ok {
+		if ret, err = explainer.RoleGrants(account); err != nil {
+			return
+		}
+	} else {
+		roles := account.ActiveRoles(time.Now())
+		if o.Roles != nil {
+			if roles, err = o.Roles.EffectiveRoles(account); err != nil {
+				return
+			}
+		}
+		for _, role := range roles {
+			ret = append(ret, &RoleGrant{Role: role})
+		}
+	}
+
+	if o.Hierarchy != nil {
+		ret, err = o.inheritedGrants(ret)
+	}
+	return
+}
+
+// inheritedGrants appends the roles inherited by the granted roles, each role once
+func (o *AccessChecker) inheritedGrants(grants []*RoleGrant) (ret []*RoleGrant, err error) {
+	ret = grants
+	known := map[string]bool{}
+	for _, grant := range grants {
+		known[grant.Role] = true
+	}
+
+	for _, grant := range grants {
+		var inheritance map[string]string
+		if inheritance, err = o.Hierarchy.Inheritance([]string{grant.Role}); err != nil {
+			return
+		}
+		var roles []string
+		if roles, err = o.Hierarchy.Expand([]string{grant.Role}); err != nil {
+			return
+		}
+		for _, role := range roles {
+			if !known[role] {
+				known[role] = true
+				ret = append(ret, &RoleGrant{Role: role, Group: grant.Group, ValidUntil: grant.ValidUntil,
+					InheritedFrom: inheritance[role]})
+			}
+		}
+	}
+	return
+}
+
+func (o *CheckResponse) trace(format string, args ...interface{}) {
+	o.Trace = append(o.Trace, fmt.Sprintf(format, args...))
+}
+
+// CheckRouter answers authorization decisions for oauth clients, e.g. other services.
+type CheckRouter struct {
+	PathPrefix string
+	Clients    *ClientConfig
+	Checker    *AccessChecker
+}
+
+func NewCheckRouter(pathPrefix string, clients *ClientConfig, checker *AccessChecker) (ret *CheckRouter) {
+	ret = &CheckRouter{
+		PathPrefix: pathPrefix + "/" + "check",
+		Clients:    clients,
+		Checker:    checker,
+	}
+	return
+}
+
+func (o *CheckRouter) Setup(router *mux.Router) (err error) {
+	router.Methods(http.MethodPost).Path(o.PathPrefix).
+		Name("Check").
+		HandlerFunc(o.Check)
+	router.Methods(http.MethodPost).Path(o.PathPrefix + "/batch").
+		Name("CheckBatch").
+		HandlerFunc(o.CheckBatch)
+	return
+}
+
+func (o *CheckRouter) Check(w http.ResponseWriter, r *http.Request) {
+	if !o.authenticateClient(w, r) {
+		return
+	}
+
+	request := &CheckRequest{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, o.Checker.Check(request))
+}
+
+func (o *CheckRouter) CheckBatch(w http.ResponseWriter, r *http.Request) {
+	if !o.authenticateClient(w, r) {
+		return
+	}
+
+	request := &BatchCheckRequest{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+	if len(request.Checks) > maxBatchChecks {
+		writeError(w, http.StatusBadRequest, "invalid_request",
+			fmt.Errorf("at most %v checks are allowed per request", maxBatchChecks))
+		return
+	}
+	writeJSON(w, http.StatusOK, o.Checker.CheckBatch(request))
+}
+
+func (o *CheckRouter) authenticateClient(w http.ResponseWriter, r *http.Request) (ok bool) {
+	if _, err := o.Clients.Authenticate(r); err != nil {
+		w.Header().Set("WWW-Authenticate", `Basic realm="auth"`)
+		writeError(w, http.StatusUnauthorized, "invalid_client", err)
+		return
+	}
+	ok = true
+	return
+}
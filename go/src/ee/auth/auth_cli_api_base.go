@@ -81,17 +81,479 @@ func (o *AccountCli) BuildCommandDeleteById() (ret cli.Command) {
 	return
 }
 
+type GroupCli struct {
+	Client *GroupClient
+}
+
+func NewGroupCli(client *GroupClient) (ret *GroupCli) {
+	ret = &GroupCli{
+		Client: client,
+	}
+	return
+}
+
+func (o *GroupCli) BuildCommands() (ret []cli.Command) {
+	ret = []cli.Command{
+		o.BuildCommandImportJSON(), o.BuildCommandExportJSON(), o.BuildCommandDeleteById(), o.BuildCommandDeleteByIds(),
+	}
+
+	return
+}
+
+func (o *GroupCli) BuildCommandImportJSON() (ret cli.Command) {
+
+	return
+}
+
+func (o *GroupCli) BuildCommandExportJSON() (ret cli.Command) {
+
+	return
+}
+
+func (o *GroupCli) BuildCommandDeleteByIds() (ret cli.Command) {
+	ret = cli.Command{
+		Name:  "deleteByIds",
+		Usage: "delete Group by ids",
+		Flags: []cli.Flag{&cli.StringFlag{
+			Name:     "ids",
+			Usage:    "ids of the Groups to delete, separated by semicolon",
+			Required: true,
+		}},
+		Action: func(c *cli.Context) (err error) {
+			var id uuid.UUID
+			var ids []uuid.UUID
+			for _, idString := range strings.Split(c.String("ids"), ",") {
+				if id, err = uuid.Parse(idString); err != nil {
+					return
+				}
+				ids = append(ids, id)
+			}
+			err = o.Client.DeleteByIds(ids)
+			return
+		},
+	}
+	return
+}
+
+func (o *GroupCli) BuildCommandDeleteById() (ret cli.Command) {
+	ret = cli.Command{
+		Name:  "deleteById",
+		Usage: "delete Group by id",
+		Flags: []cli.Flag{&cli.StringFlag{
+			Name:     "id",
+			Usage:    "id of the Group to delete",
+			Required: true,
+		}},
+		Action: func(c *cli.Context) (err error) {
+			var id uuid.UUID
+			if id, err = uuid.Parse(c.String("id")); err == nil {
+				err = o.Client.DeleteById(&id)
+			}
+			return
+		},
+	}
+	return
+}
+
+type OrganizationCli struct {
+	Client *OrganizationClient
+}
+
+func NewOrganizationCli(client *OrganizationClient) (ret *OrganizationCli) {
+	ret = &OrganizationCli{
+		Client: client,
+	}
+	return
+}
+
+func (o *OrganizationCli) BuildCommands() (ret []cli.Command) {
+	ret = []cli.Command{
+		o.BuildCommandImportJSON(), o.BuildCommandExportJSON(), o.BuildCommandDeleteById(), o.BuildCommandDeleteByIds(),
+	}
+
+	return
+}
+
+func (o *OrganizationCli) BuildCommandImportJSON() (ret cli.Command) {
+
+	return
+}
+
+func (o *OrganizationCli) BuildCommandExportJSON() (ret cli.Command) {
+
+	return
+}
+
+func (o *OrganizationCli) BuildCommandDeleteByIds() (ret cli.Command) {
+	ret = cli.Command{
+		Name:  "deleteByIds",
+		Usage: "delete Organization by ids",
+		Flags: []cli.Flag{&cli.StringFlag{
+			Name:     "ids",
+			Usage:    "ids of the Organizations to delete, separated by semicolon",
+			Required: true,
+		}},
+		Action: func(c *cli.Context) (err error) {
+			var id uuid.UUID
+			var ids []uuid.UUID
+			for _, idString := range strings.Split(c.String("ids"), ",") {
+				if id, err = uuid.Parse(idString); err != nil {
+					return
+				}
+				ids = append(ids, id)
+			}
+			err = o.Client.DeleteByIds(ids)
+			return
+		},
+	}
+	return
+}
+
+func (o *OrganizationCli) BuildCommandDeleteById() (ret cli.Command) {
+	ret = cli.Command{
+		Name:  "deleteById",
+		Usage: "delete Organization by id",
+		Flags: []cli.Flag{&cli.StringFlag{
+			Name:     "id",
+			Usage:    "id of the Organization to delete",
+			Required: true,
+		}},
+		Action: func(c *cli.Context) (err error) {
+			var id uuid.UUID
+			if id, err = uuid.Parse(c.String("id")); err == nil {
+				err = o.Client.DeleteById(&id)
+			}
+			return
+		},
+	}
+	return
+}
+
+type RoleGrantRequestCli struct {
+	Client *RoleGrantRequestClient
+}
+
+func NewRoleGrantRequestCli(client *RoleGrantRequestClient) (ret *RoleGrantRequestCli) {
+	ret = &RoleGrantRequestCli{
+		Client: client,
+	}
+	return
+}
+
+func (o *RoleGrantRequestCli) BuildCommands() (ret []cli.Command) {
+	ret = []cli.Command{
+		o.BuildCommandImportJSON(), o.BuildCommandExportJSON(), o.BuildCommandDeleteById(), o.BuildCommandDeleteByIds(),
+	}
+
+	return
+}
+
+func (o *RoleGrantRequestCli) BuildCommandImportJSON() (ret cli.Command) {
+
+	return
+}
+
+func (o *RoleGrantRequestCli) BuildCommandExportJSON() (ret cli.Command) {
+
+	return
+}
+
+func (o *RoleGrantRequestCli) BuildCommandDeleteByIds() (ret cli.Command) {
+	ret = cli.Command{
+		Name:  "deleteByIds",
+		Usage: "delete RoleGrantRequest by ids",
+		Flags: []cli.Flag{&cli.StringFlag{
+			Name:     "ids",
+			Usage:    "ids of the RoleGrantRequests to delete, separated by semicolon",
+			Required: true,
+		}},
+		Action: func(c *cli.Context) (err error) {
+			var id uuid.UUID
+			var ids []uuid.UUID
+			for _, idString := range strings.Split(c.String("ids"), ",") {
+				if id, err = uuid.Parse(idString); err != nil {
+					return
+				}
+				ids = append(ids, id)
+			}
+			err = o.Client.DeleteByIds(ids)
+			return
+		},
+	}
+	return
+}
+
+func (o *RoleGrantRequestCli) BuildCommandDeleteById() (ret cli.Command) {
+	ret = cli.Command{
+		Name:  "deleteById",
+		Usage: "delete RoleGrantRequest by id",
+		Flags: []cli.Flag{&cli.StringFlag{
+			Name:     "id",
+			Usage:    "id of the RoleGrantRequest to delete",
+			Required: true,
+		}},
+		Action: func(c *cli.Context) (err error) {
+			var id uuid.UUID
+			if id, err = uuid.Parse(c.String("id")); err == nil {
+				err = o.Client.DeleteById(&id)
+			}
+			return
+		},
+	}
+	return
+}
+
+type RoleCli struct {
+	Client *RoleClient
+}
+
+func NewRoleCli(client *RoleClient) (ret *RoleCli) {
+	ret = &RoleCli{
+		Client: client,
+	}
+	return
+}
+
+func (o *RoleCli) BuildCommands() (ret []cli.Command) {
+	ret = []cli.Command{
+		o.BuildCommandImportJSON(), o.BuildCommandExportJSON(), o.BuildCommandDeleteById(), o.BuildCommandDeleteByIds(),
+	}
+
+	return
+}
+
+func (o *RoleCli) BuildCommandImportJSON() (ret cli.Command) {
+
+	return
+}
+
+func (o *RoleCli) BuildCommandExportJSON() (ret cli.Command) {
+
+	return
+}
+
+func (o *RoleCli) BuildCommandDeleteByIds() (ret cli.Command) {
+	ret = cli.Command{
+		Name:  "deleteByIds",
+		Usage: "delete Role by ids",
+		Flags: []cli.Flag{&cli.StringFlag{
+			Name:     "ids",
+			Usage:    "ids of the Roles to delete, separated by semicolon",
+			Required: true,
+		}},
+		Action: func(c *cli.Context) (err error) {
+			var id uuid.UUID
+			var ids []uuid.UUID
+			for _, idString := range strings.Split(c.String("ids"), ",") {
+				if id, err = uuid.Parse(idString); err != nil {
+					return
+				}
+				ids = append(ids, id)
+			}
+			err = o.Client.DeleteByIds(ids)
+			return
+		},
+	}
+	return
+}
+
+func (o *RoleCli) BuildCommandDeleteById() (ret cli.Command) {
+	ret = cli.Command{
+		Name:  "deleteById",
+		Usage: "delete Role by id",
+		Flags: []cli.Flag{&cli.StringFlag{
+			Name:     "id",
+			Usage:    "id of the Role to delete",
+			Required: true,
+		}},
+		Action: func(c *cli.Context) (err error) {
+			var id uuid.UUID
+			if id, err = uuid.Parse(c.String("id")); err == nil {
+				err = o.Client.DeleteById(&id)
+			}
+			return
+		},
+	}
+	return
+}
+
+type RelationTupleCli struct {
+	Client *RelationTupleClient
+}
+
+func NewRelationTupleCli(client *RelationTupleClient) (ret *RelationTupleCli) {
+	ret = &RelationTupleCli{
+		Client: client,
+	}
+	return
+}
+
+func (o *RelationTupleCli) BuildCommands() (ret []cli.Command) {
+	ret = []cli.Command{
+		o.BuildCommandImportJSON(), o.BuildCommandExportJSON(), o.BuildCommandDeleteById(), o.BuildCommandDeleteByIds(),
+	}
+
+	return
+}
+
+func (o *RelationTupleCli) BuildCommandImportJSON() (ret cli.Command) {
+
+	return
+}
+
+func (o *RelationTupleCli) BuildCommandExportJSON() (ret cli.Command) {
+
+	return
+}
+
+func (o *RelationTupleCli) BuildCommandDeleteByIds() (ret cli.Command) {
+	ret = cli.Command{
+		Name:  "deleteByIds",
+		Usage: "delete RelationTuple by ids",
+		Flags: []cli.Flag{&cli.StringFlag{
+			Name:     "ids",
+			Usage:    "ids of the RelationTuples to delete, separated by semicolon",
+			Required: true,
+		}},
+		Action: func(c *cli.Context) (err error) {
+			var id uuid.UUID
+			var ids []uuid.UUID
+			for _, idString := range strings.Split(c.String("ids"), ",") {
+				if id, err = uuid.Parse(idString); err != nil {
+					return
+				}
+				ids = append(ids, id)
+			}
+			err = o.Client.DeleteByIds(ids)
+			return
+		},
+	}
+	return
+}
+
+func (o *RelationTupleCli) BuildCommandDeleteById() (ret cli.Command) {
+	ret = cli.Command{
+		Name:  "deleteById",
+		Usage: "delete RelationTuple by id",
+		Flags: []cli.Flag{&cli.StringFlag{
+			Name:     "id",
+			Usage:    "id of the RelationTuple to delete",
+			Required: true,
+		}},
+		Action: func(c *cli.Context) (err error) {
+			var id uuid.UUID
+			if id, err = uuid.Parse(c.String("id")); err == nil {
+				err = o.Client.DeleteById(&id)
+			}
+			return
+		},
+	}
+	return
+}
+
+type InvitationCli struct {
+	Client *InvitationClient
+}
+
+func NewInvitationCli(client *InvitationClient) (ret *InvitationCli) {
+	ret = &InvitationCli{
+		Client: client,
+	}
+	return
+}
+
+func (o *InvitationCli) BuildCommands() (ret []cli.Command) {
+	ret = []cli.Command{
+		o.BuildCommandImportJSON(), o.BuildCommandExportJSON(), o.BuildCommandDeleteById(), o.BuildCommandDeleteByIds(),
+	}
+
+	return
+}
+
+func (o *InvitationCli) BuildCommandImportJSON() (ret cli.Command) {
+
+	return
+}
+
+func (o *InvitationCli) BuildCommandExportJSON() (ret cli.Command) {
+
+	return
+}
+
+func (o *InvitationCli) BuildCommandDeleteByIds() (ret cli.Command) {
+	ret = cli.Command{
+		Name:  "deleteByIds",
+		Usage: "delete Invitation by ids",
+		Flags: []cli.Flag{&cli.StringFlag{
+			Name:     "ids",
+			Usage:    "ids of the Invitations to delete, separated by semicolon",
+			Required: true,
+		}},
+		Action: func(c *cli.Context) (err error) {
+			var id uuid.UUID
+			var ids []uuid.UUID
+			for _, idString := range strings.Split(c.String("ids"), ",") {
+				if id, err = uuid.Parse(idString); err != nil {
+					return
+				}
+				ids = append(ids, id)
+			}
+			err = o.Client.DeleteByIds(ids)
+			return
+		},
+	}
+	return
+}
+
+func (o *InvitationCli) BuildCommandDeleteById() (ret cli.Command) {
+	ret = cli.Command{
+		Name:  "deleteById",
+		Usage: "delete Invitation by id",
+		Flags: []cli.Flag{&cli.StringFlag{
+			Name:     "id",
+			Usage:    "id of the Invitation to delete",
+			Required: true,
+		}},
+		Action: func(c *cli.Context) (err error) {
+			var id uuid.UUID
+			if id, err = uuid.Parse(c.String("id")); err == nil {
+				err = o.Client.DeleteById(&id)
+			}
+			return
+		},
+	}
+	return
+}
+
 type Cli struct {
-	Client     *Client
-	AccountCli *AccountCli
+	Client              *Client
+	AccountCli          *AccountCli
+	GroupCli            *GroupCli
+	OrganizationCli     *OrganizationCli
+	RoleGrantRequestCli *RoleGrantRequestCli
+	RoleCli             *RoleCli
+	RelationTupleCli    *RelationTupleCli
+	InvitationCli       *InvitationCli
 }
 
 func NewCli(url string, httpClient *http.Client) (ret *Cli) {
 	client := NewClient(url, httpClient)
 	accountCli := NewAccountCli(client.AccountClient)
+	groupCli := NewGroupCli(client.GroupClient)
+	organizationCli := NewOrganizationCli(client.OrganizationClient)
+	roleGrantRequestCli := NewRoleGrantRequestCli(client.RoleGrantRequestClient)
+	roleCli := NewRoleCli(client.RoleClient)
+	relationTupleCli := NewRelationTupleCli(client.RelationTupleClient)
+	invitationCli := NewInvitationCli(client.InvitationClient)
 	ret = &Cli{
-		Client:     client,
-		AccountCli: accountCli,
+		Client:              client,
+		AccountCli:          accountCli,
+		GroupCli:            groupCli,
+		OrganizationCli:     organizationCli,
+		RoleGrantRequestCli: roleGrantRequestCli,
+		RoleCli:             roleCli,
+		RelationTupleCli:    relationTupleCli,
+		InvitationCli:       invitationCli,
 	}
 	return
 }
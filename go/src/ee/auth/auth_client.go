@@ -0,0 +1,266 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type CachedToken struct {
+	*TokenResponse
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func (o *CachedToken) IsValid() bool {
+	return o.TokenResponse != nil && o.AccessToken != "" && time.Now().Add(time.Minute).Before(o.ExpiresAt)
+}
+
+type TokenCache interface {
+	Load() (ret *CachedToken, err error)
+	Save(token *CachedToken) (err error)
+}
+
+type FileTokenCache struct {
+	File string
+}
+
+// NewFileTokenCache stores the token of the app in the user config folder
+func NewFileTokenCache(appName string) (ret *FileTokenCache, err error) {
+	var folder string
+	if folder, err = os.UserConfigDir(); err == nil {
+		ret = &FileTokenCache{File: filepath.Join(folder, appName, "token.json")}
+	}
+	return
+}
+
+func (o *FileTokenCache) Load() (ret *CachedToken, err error) {
+	var data []byte
+	if data, err = ioutil.ReadFile(o.File); err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+	ret = &CachedToken{}
+	err = json.Unmarshal(data, ret)
+	return
+}
+
+func (o *FileTokenCache) Save(token *CachedToken) (err error) {
+	var data []byte
+	if data, err = json.Marshal(token); err != nil {
+		return
+	}
+	if err = os.MkdirAll(filepath.Dir(o.File), 0700); err == nil {
+		err = ioutil.WriteFile(o.File, data, 0600)
+	}
+	return
+}
+
+// LoginWithDevice reuses a valid cached token or runs the device flow, prompt shows the user code to the user
+func (o *AccountClient) LoginWithDevice(clientId string, cache TokenCache,
+	prompt func(code *DeviceCodeResponse)) (ret *CachedToken, err error) {
+
+	if cache != nil {
+		if ret, err = cache.Load(); err == nil && ret != nil && ret.IsValid() {
+			o.UseToken(ret.AccessToken)
+			return
+		}
+	}
+
+	var code *DeviceCodeResponse
+	if code, err = o.RequestDeviceCode(clientId); err != nil {
+		return
+	}
+	prompt(code)
+
+	var token *TokenResponse
+	if token, err = o.PollDeviceToken(clientId, code); err != nil {
+		return
+	}
+
+	ret = &CachedToken{TokenResponse: token, ExpiresAt: time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)}
+	if cache != nil {
+		err = cache.Save(ret)
+	}
+	o.UseToken(ret.AccessToken)
+	return
+}
+
+func (o *AccountClient) RequestDeviceCode(clientId string) (ret *DeviceCodeResponse, err error) {
+	ret = &DeviceCodeResponse{}
+	_, err = o.postForm(o.authUrl()+"/device/code", url.Values{"client_id": {clientId}}, ret)
+	return
+}
+
+// PollDeviceToken polls the token endpoint in the interval of the server until the device is approved or denied
+func (o *AccountClient) PollDeviceToken(clientId string, code *DeviceCodeResponse) (ret *TokenResponse, err error) {
+	interval := time.Duration(code.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	deadline := time.Now().Add(time.Duration(code.ExpiresIn) * time.Second)
+
+	form := url.Values{
+		"grant_type":  {DeviceCodeGrantType},
+		"device_code": {code.DeviceCode},
+		"client_id":   {clientId},
+	}
+	for time.Now().Before(deadline) {
+		time.Sleep(interval)
+
+		token := &TokenResponse{}
+		var status int
+		if status, err = o.postForm(o.authUrl()+"/token", form, token); err == nil {
+			ret = token
+			return
+		}
+
+		if status != http.StatusBadRequest {
+			return
+		}
+		switch err.Error() {
+		case ErrAuthorizationPending.Error():
+		case ErrSlowDown.Error():
+			interval += 5 * time.Second
+		default:
+			return
+		}
+	}
+	err = ErrExpiredToken
+	return
+}
+
+// UseToken sends the access token with every request of the client, it replaces the token used before
+func (o *AccountClient) UseToken(accessToken string) {
+	client := &http.Client{}
+	if o.Client != nil {
+		*client = *o.Client
+	}
+	next := client.Transport
+	if bearer, ok := next.(*bearerTransport); ok {
+		next = bearer.next
+	}
+	client.Transport = &bearerTransport{accessToken: accessToken, next: next}
+	o.Client = client
+}
+
+func (o *AccountClient) authUrl() string {
+	return strings.TrimSuffix(o.UrlIdBased, "/account")
+}
+
+func (o *AccountClient) postForm(target string, form url.Values, result interface{}) (status int, err error) {
+	var resp *http.Response
+	if resp, err = httpClient(o.Client).PostForm(target, form); err == nil {
+		status, err = decodeResponse(target, resp, result)
+	}
+	return
+}
+
+// UseClientCredentials authenticates the requests of the client as oauth client, e.g. for authorization checks.
+// Credentials used before are replaced.
+func (o *Client) UseClientCredentials(clientId string, clientSecret string) {
+	client := &http.Client{}
+	if o.Client != nil {
+		*client = *o.Client
+	}
+	next := client.Transport
+	if credentials, ok := next.(*clientCredentialsTransport); ok {
+		next = credentials.next
+	}
+	client.Transport = &clientCredentialsTransport{clientId: clientId, clientSecret: clientSecret, next: next}
+	o.Client = client
+}
+
+// Check asks the auth service whether the subject may perform the action on the resource
+func (o *Client) Check(request *CheckRequest) (ret *CheckResponse, err error) {
+	ret = &CheckResponse{}
+	_, err = o.postJSON(o.Url+"/check", request, ret)
+	return
+}
+
+// CheckBatch answers several checks at once, e.g. to filter lists
+func (o *Client) CheckBatch(request *BatchCheckRequest) (ret *BatchCheckResponse, err error) {
+	ret = &BatchCheckResponse{}
+	_, err = o.postJSON(o.Url+"/check/batch", request, ret)
+	return
+}
+
+func (o *Client) postJSON(target string, body interface{}, result interface{}) (status int, err error) {
+	var data []byte
+	if data, err = json.Marshal(body); err != nil {
+		return
+	}
+
+	var resp *http.Response
+	if resp, err = httpClient(o.Client).Post(target, "application/json", bytes.NewReader(data)); err == nil {
+		status, err = decodeResponse(target, resp, result)
+	}
+	return
+}
+
+func httpClient(client *http.Client) *http.Client {
+	if client == nil {
+		return http.DefaultClient
+	}
+	return client
+}
+
+func decodeResponse(target string, resp *http.Response, result interface{}) (status int, err error) {
+	defer resp.Body.Close()
+
+	status = resp.StatusCode
+	if status == http.StatusOK {
+		err = json.NewDecoder(resp.Body).Decode(result)
+		return
+	}
+
+	ret := &ErrorResponse{}
+	if json.NewDecoder(resp.Body).Decode(ret) == nil && ret.Error != "" {
+		err = errors.New(ret.Error)
+	} else {
+		err = fmt.Errorf("request to '%v' failed with status %v", target, status)
+	}
+	return
+}
+
+type clientCredentialsTransport struct {
+	clientId     string
+	clientSecret string
+	next         http.RoundTripper
+}
+
+func (o *clientCredentialsTransport) RoundTrip(r *http.Request) (ret *http.Response, err error) {
+	next := o.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	r = r.Clone(r.Context())
+	r.SetBasicAuth(url.QueryEscape(o.clientId), url.QueryEscape(o.clientSecret))
+	ret, err = next.RoundTrip(r)
+	return
+}
+
+type bearerTransport struct {
+	accessToken string
+	next        http.RoundTripper
+}
+
+func (o *bearerTransport) RoundTrip(r *http.Request) (ret *http.Response, err error) {
+	next := o.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	r = r.Clone(r.Context())
+	r.Header.Set("Authorization", "Bearer "+o.accessToken)
+	ret, err = next.RoundTrip(r)
+	return
+}
@@ -84,19 +84,493 @@ func (o *AccountClient) ReadFileJSON(fileJSON string) (ret []*Account, err error
 	return
 }
 
+type GroupClient struct {
+	UrlIdBased string
+	Url        string
+	Client     *http.Client
+}
+
+func NewGroupClient(url string, client *http.Client) (ret *GroupClient) {
+	urlIdBased := url + "/" + "group"
+	url = url + "/" + "groups"
+	ret = &GroupClient{
+		UrlIdBased: urlIdBased,
+		Url:        url,
+		Client:     client,
+	}
+	return
+}
+
+func (o *GroupClient) ImportJSON(fileJSON string) (err error) {
+	var items []*Group
+	if items, err = o.ReadFileJSON(fileJSON); err != nil {
+		return
+	}
+
+	err = o.CreateItems(items)
+	return
+}
+
+func (o *GroupClient) ExportJSON(targetFileJSON string) (err error) {
+	/*
+	    var items []*Group
+		if items, err = o.FindAll(); err == nil {
+	    }
+	*/
+	return
+}
+
+func (o *GroupClient) Create(item *Group) (err error) {
+	err = net.PostById(item, item.Id, o.UrlIdBased, o.Client)
+	return
+}
+
+func (o *GroupClient) CreateItems(items []*Group) (err error) {
+	for _, item := range items {
+		if err = o.Create(item); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (o *GroupClient) DeleteByIds(itemIds []uuid.UUID) (err error) {
+	for _, itemId := range itemIds {
+		if err = net.DeleteById(itemId, o.UrlIdBased, o.Client); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (o *GroupClient) DeleteById(itemId *uuid.UUID) (err error) {
+	err = net.DeleteById(itemId, o.UrlIdBased, o.Client)
+	return
+}
+
+func (o *GroupClient) FindAll() (ret []*Group, err error) {
+	err = net.GetItems(&ret, o.Url, o.Client)
+	return
+}
+
+func (o *GroupClient) ReadFileJSON(fileJSON string) (ret []*Group, err error) {
+	jsonBytes, _ := ioutil.ReadFile(fileJSON)
+
+	err = json.Unmarshal(jsonBytes, &ret)
+	return
+}
+
+type OrganizationClient struct {
+	UrlIdBased string
+	Url        string
+	Client     *http.Client
+}
+
+func NewOrganizationClient(url string, client *http.Client) (ret *OrganizationClient) {
+	urlIdBased := url + "/" + "organization"
+	url = url + "/" + "organizations"
+	ret = &OrganizationClient{
+		UrlIdBased: urlIdBased,
+		Url:        url,
+		Client:     client,
+	}
+	return
+}
+
+func (o *OrganizationClient) ImportJSON(fileJSON string) (err error) {
+	var items []*Organization
+	if items, err = o.ReadFileJSON(fileJSON); err != nil {
+		return
+	}
+
+	err = o.CreateItems(items)
+	return
+}
+
+func (o *OrganizationClient) ExportJSON(targetFileJSON string) (err error) {
+	/*
+	    var items []*Organization
+		if items, err = o.FindAll(); err == nil {
+	    }
+	*/
+	return
+}
+
+func (o *OrganizationClient) Create(item *Organization) (err error) {
+	err = net.PostById(item, item.Id, o.UrlIdBased, o.Client)
+	return
+}
+
+func (o *OrganizationClient) CreateItems(items []*Organization) (err error) {
+	for _, item := range items {
+		if err = o.Create(item); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (o *OrganizationClient) DeleteByIds(itemIds []uuid.UUID) (err error) {
+	for _, itemId := range itemIds {
+		if err = net.DeleteById(itemId, o.UrlIdBased, o.Client); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (o *OrganizationClient) DeleteById(itemId *uuid.UUID) (err error) {
+	err = net.DeleteById(itemId, o.UrlIdBased, o.Client)
+	return
+}
+
+func (o *OrganizationClient) FindAll() (ret []*Organization, err error) {
+	err = net.GetItems(&ret, o.Url, o.Client)
+	return
+}
+
+func (o *OrganizationClient) ReadFileJSON(fileJSON string) (ret []*Organization, err error) {
+	jsonBytes, _ := ioutil.ReadFile(fileJSON)
+
+	err = json.Unmarshal(jsonBytes, &ret)
+	return
+}
+
+type RoleGrantRequestClient struct {
+	UrlIdBased string
+	Url        string
+	Client     *http.Client
+}
+
+func NewRoleGrantRequestClient(url string, client *http.Client) (ret *RoleGrantRequestClient) {
+	urlIdBased := url + "/" + "roleGrantRequest"
+	url = url + "/" + "roleGrantRequests"
+	ret = &RoleGrantRequestClient{
+		UrlIdBased: urlIdBased,
+		Url:        url,
+		Client:     client,
+	}
+	return
+}
+
+func (o *RoleGrantRequestClient) ImportJSON(fileJSON string) (err error) {
+	var items []*RoleGrantRequest
+	if items, err = o.ReadFileJSON(fileJSON); err != nil {
+		return
+	}
+
+	err = o.CreateItems(items)
+	return
+}
+
+func (o *RoleGrantRequestClient) ExportJSON(targetFileJSON string) (err error) {
+	/*
+	    var items []*RoleGrantRequest
+		if items, err = o.FindAll(); err == nil {
+	    }
+	*/
+	return
+}
+
+func (o *RoleGrantRequestClient) Create(item *RoleGrantRequest) (err error) {
+	err = net.PostById(item, item.Id, o.UrlIdBased, o.Client)
+	return
+}
+
+func (o *RoleGrantRequestClient) CreateItems(items []*RoleGrantRequest) (err error) {
+	for _, item := range items {
+		if err = o.Create(item); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (o *RoleGrantRequestClient) DeleteByIds(itemIds []uuid.UUID) (err error) {
+	for _, itemId := range itemIds {
+		if err = net.DeleteById(itemId, o.UrlIdBased, o.Client); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (o *RoleGrantRequestClient) DeleteById(itemId *uuid.UUID) (err error) {
+	err = net.DeleteById(itemId, o.UrlIdBased, o.Client)
+	return
+}
+
+func (o *RoleGrantRequestClient) FindAll() (ret []*RoleGrantRequest, err error) {
+	err = net.GetItems(&ret, o.Url, o.Client)
+	return
+}
+
+func (o *RoleGrantRequestClient) ReadFileJSON(fileJSON string) (ret []*RoleGrantRequest, err error) {
+	jsonBytes, _ := ioutil.ReadFile(fileJSON)
+
+	err = json.Unmarshal(jsonBytes, &ret)
+	return
+}
+
+type RoleClient struct {
+	UrlIdBased string
+	Url        string
+	Client     *http.Client
+}
+
+func NewRoleClient(url string, client *http.Client) (ret *RoleClient) {
+	urlIdBased := url + "/" + "role"
+	url = url + "/" + "roles"
+	ret = &RoleClient{
+		UrlIdBased: urlIdBased,
+		Url:        url,
+		Client:     client,
+	}
+	return
+}
+
+func (o *RoleClient) ImportJSON(fileJSON string) (err error) {
+	var items []*Role
+	if items, err = o.ReadFileJSON(fileJSON); err != nil {
+		return
+	}
+
+	err = o.CreateItems(items)
+	return
+}
+
+func (o *RoleClient) ExportJSON(targetFileJSON string) (err error) {
+	/*
+	    var items []*Role
+		if items, err = o.FindAll(); err == nil {
+	    }
+	*/
+	return
+}
+
+func (o *RoleClient) Create(item *Role) (err error) {
+	err = net.PostById(item, item.Id, o.UrlIdBased, o.Client)
+	return
+}
+
+func (o *RoleClient) CreateItems(items []*Role) (err error) {
+	for _, item := range items {
+		if err = o.Create(item); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (o *RoleClient) DeleteByIds(itemIds []uuid.UUID) (err error) {
+	for _, itemId := range itemIds {
+		if err = net.DeleteById(itemId, o.UrlIdBased, o.Client); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (o *RoleClient) DeleteById(itemId *uuid.UUID) (err error) {
+	err = net.DeleteById(itemId, o.UrlIdBased, o.Client)
+	return
+}
+
+func (o *RoleClient) FindAll() (ret []*Role, err error) {
+	err = net.GetItems(&ret, o.Url, o.Client)
+	return
+}
+
+func (o *RoleClient) ReadFileJSON(fileJSON string) (ret []*Role, err error) {
+	jsonBytes, _ := ioutil.ReadFile(fileJSON)
+
+	err = json.Unmarshal(jsonBytes, &ret)
+	return
+}
+
+type RelationTupleClient struct {
+	UrlIdBased string
+	Url        string
+	Client     *http.Client
+}
+
+func NewRelationTupleClient(url string, client *http.Client) (ret *RelationTupleClient) {
+	urlIdBased := url + "/" + "relationTuple"
+	url = url + "/" + "relationTuples"
+	ret = &RelationTupleClient{
+		UrlIdBased: urlIdBased,
+		Url:        url,
+		Client:     client,
+	}
+	return
+}
+
+func (o *RelationTupleClient) ImportJSON(fileJSON string) (err error) {
+	var items []*RelationTuple
+	if items, err = o.ReadFileJSON(fileJSON); err != nil {
+		return
+	}
+
+	err = o.CreateItems(items)
+	return
+}
+
+func (o *RelationTupleClient) ExportJSON(targetFileJSON string) (err error) {
+	/*
+	    var items []*RelationTuple
+		if items, err = o.FindAll(); err == nil {
+	    }
+	*/
+	return
+}
+
+func (o *RelationTupleClient) Create(item *RelationTuple) (err error) {
+	err = net.PostById(item, item.Id, o.UrlIdBased, o.Client)
+	return
+}
+
+func (o *RelationTupleClient) CreateItems(items []*RelationTuple) (err error) {
+	for _, item := range items {
+		if err = o.Create(item); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (o *RelationTupleClient) DeleteByIds(itemIds []uuid.UUID) (err error) {
+	for _, itemId := range itemIds {
+		if err = net.DeleteById(itemId, o.UrlIdBased, o.Client); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (o *RelationTupleClient) DeleteById(itemId *uuid.UUID) (err error) {
+	err = net.DeleteById(itemId, o.UrlIdBased, o.Client)
+	return
+}
+
+func (o *RelationTupleClient) FindAll() (ret []*RelationTuple, err error) {
+	err = net.GetItems(&ret, o.Url, o.Client)
+	return
+}
+
+func (o *RelationTupleClient) ReadFileJSON(fileJSON string) (ret []*RelationTuple, err error) {
+	jsonBytes, _ := ioutil.ReadFile(fileJSON)
+
+	err = json.Unmarshal(jsonBytes, &ret)
+	return
+}
+
+type InvitationClient struct {
+	UrlIdBased string
+	Url        string
+	Client     *http.Client
+}
+
+func NewInvitationClient(url string, client *http.Client) (ret *InvitationClient) {
+	urlIdBased := url + "/" + "invitation"
+	url = url + "/" + "invitations"
+	ret = &InvitationClient{
+		UrlIdBased: urlIdBased,
+		Url:        url,
+		Client:     client,
+	}
+	return
+}
+
+func (o *InvitationClient) ImportJSON(fileJSON string) (err error) {
+	var items []*Invitation
+	if items, err = o.ReadFileJSON(fileJSON); err != nil {
+		return
+	}
+
+	err = o.CreateItems(items)
+	return
+}
+
+func (o *InvitationClient) ExportJSON(targetFileJSON string) (err error) {
+	/*
+	    var items []*Invitation
+		if items, err = o.FindAll(); err == nil {
+	    }
+	*/
+	return
+}
+
+func (o *InvitationClient) Create(item *Invitation) (err error) {
+	err = net.PostById(item, item.Id, o.UrlIdBased, o.Client)
+	return
+}
+
+func (o *InvitationClient) CreateItems(items []*Invitation) (err error) {
+	for _, item := range items {
+		if err = o.Create(item); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (o *InvitationClient) DeleteByIds(itemIds []uuid.UUID) (err error) {
+	for _, itemId := range itemIds {
+		if err = net.DeleteById(itemId, o.UrlIdBased, o.Client); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (o *InvitationClient) DeleteById(itemId *uuid.UUID) (err error) {
+	err = net.DeleteById(itemId, o.UrlIdBased, o.Client)
+	return
+}
+
+func (o *InvitationClient) FindAll() (ret []*Invitation, err error) {
+	err = net.GetItems(&ret, o.Url, o.Client)
+	return
+}
+
+func (o *InvitationClient) ReadFileJSON(fileJSON string) (ret []*Invitation, err error) {
+	jsonBytes, _ := ioutil.ReadFile(fileJSON)
+
+	err = json.Unmarshal(jsonBytes, &ret)
+	return
+}
+
 type Client struct {
-	Url           string
-	Client        *http.Client
-	AccountClient *AccountClient
+	Url                    string
+	Client                 *http.Client
+	AccountClient          *AccountClient
+	GroupClient            *GroupClient
+	OrganizationClient     *OrganizationClient
+	RoleGrantRequestClient *RoleGrantRequestClient
+	RoleClient             *RoleClient
+	RelationTupleClient    *RelationTupleClient
+	InvitationClient       *InvitationClient
 }
 
 func NewClient(url string, client *http.Client) (ret *Client) {
 	url = url + "/" + "auth"
 	accountClient := NewAccountClient(url, client)
+	groupClient := NewGroupClient(url, client)
+	organizationClient := NewOrganizationClient(url, client)
+	roleGrantRequestClient := NewRoleGrantRequestClient(url, client)
+	roleClient := NewRoleClient(url, client)
+	relationTupleClient := NewRelationTupleClient(url, client)
+	invitationClient := NewInvitationClient(url, client)
 	ret = &Client{
-		Url:           url,
-		Client:        client,
-		AccountClient: accountClient,
+		Url:                    url,
+		Client:                 client,
+		AccountClient:          accountClient,
+		GroupClient:            groupClient,
+		OrganizationClient:     organizationClient,
+		RoleGrantRequestClient: roleGrantRequestClient,
+		RoleClient:             roleClient,
+		RelationTupleClient:    relationTupleClient,
+		InvitationClient:       invitationClient,
 	}
 	return
 }
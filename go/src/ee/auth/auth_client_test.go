@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// a refreshed token replaces the token of the client instead of wrapping it
+func TestUseTokenReplacesToken(t *testing.T) {
+	var authorizations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+
+	client := &AccountClient{}
+	for _, token := range []string{"first", "second", "third"} {
+		client.UseToken(token)
+	}
+	if _, ok := client.Client.Transport.(*bearerTransport).next.(*bearerTransport); ok {
+		t.Errorf("tokens are stacked")
+	}
+
+	resp, err := client.Client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if len(authorizations) != 1 || authorizations[0] != "Bearer third" {
+		t.Errorf("request sent with %v", authorizations)
+	}
+}
@@ -10,16 +10,22 @@ func (o *EsEngine) ActivatePasswordEncryption() {
 }
 
 func (o *AccountAggregateEngine) ActivatePasswordEncryption() {
+	// accounts created without password, e.g. by federation, can not log in with a password
 	o.AggregateExecutors.Initial.AddCreatePreparer(
 		func(cmd *CreateAccount, entity *Account) (err error) {
-			cmd.Password, err = crypt.Hash(cmd.Password)
+			if len(cmd.Password) > 0 {
+				cmd.Password, err = crypt.Hash(cmd.Password)
+			}
 			return
 		})
 
+	// updates without password keep the current one
 	o.AggregateExecutors.Exist.AddUpdatePreparer(
 		func(cmd *UpdateAccount, entity *Account) (err error) {
 			if len(cmd.Password) > 0 {
 				cmd.Password, err = crypt.Hash(cmd.Password)
+			} else {
+				cmd.Password = entity.Password
 			}
 			return
 		})
@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"github.com/go-ee/utils/crypt"
+	"github.com/go-ee/utils/eh/app"
+	"github.com/go-ee/utils/eh/app/memory"
+	"github.com/google/uuid"
+	"github.com/looplab/eventhorizon"
+	"testing"
+	"time"
+)
+
+// newMemoryRepo is the read repository of the projection of the memory backend
+func newMemoryRepo(t *testing.T, aggregateType eventhorizon.AggregateType,
+	factory func() eventhorizon.Entity) (ret eventhorizon.ReadWriteRepo) {
+
+	var err error
+	if ret, err = memory.NewAppMemory(&app.AppInfo{AppName: "auth"}, &app.ServerConfig{}, false).Middleware.Repos(
+		string(aggregateType), factory); err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+// recordedEvents is the store of the executed commands, it keeps the appended events
+type recordedEvents struct {
+	Types []eventhorizon.EventType
+	Data  []eventhorizon.EventData
+}
+
+func (o *recordedEvents) AppendEvent(eventType eventhorizon.EventType, data eventhorizon.EventData,
+	timestamp time.Time) eventhorizon.Event {
+
+	o.Types = append(o.Types, eventType)
+	o.Data = append(o.Data, data)
+	return nil
+}
+
+// preparedAccount is the command as seen by the preparers activated after the encryption
+type preparedAccount struct {
+	Username string
+	Email    string
+	Password string
+}
+
+// newPreparedAccountEngine activates the encryption in the order of the app, followed by a preparer
+// observing the commands and the password history
+func newPreparedAccountEngine(t *testing.T, personalData *PersonalData) (
+	ret *AccountAggregateEngine, prepared *preparedAccount) {
+
+	ret = &AccountAggregateEngine{AggregateExecutors: NewAccountAggregateExecutorsFull(),
+		AggregateHandlers: NewAccountAggregateHandlersFull()}
+	if err := ret.AggregateExecutors.SetupCommandHandler(); err != nil {
+		t.Fatal(err)
+	}
+
+	ret.ActivateDataEncryption(personalData)
+	ret.ActivatePasswordEncryption()
+
+	prepared = &preparedAccount{}
+	ret.AggregateExecutors.Initial.AddCreatePreparer(func(cmd *CreateAccount, entity *Account) (err error) {
+		*prepared = preparedAccount{Username: cmd.Username, Email: cmd.Email, Password: cmd.Password}
+		return
+	})
+	ret.AggregateExecutors.Exist.AddUpdatePreparer(func(cmd *UpdateAccount, entity *Account) (err error) {
+		*prepared = preparedAccount{Username: cmd.Username, Email: cmd.Email, Password: cmd.Password}
+		return
+	})
+	ret.ImplementPasswordHistory(NewPasswordPolicy())
+	return
+}
+
+func TestCreatePreparersSeePlainValues(t *testing.T) {
+	personalData := &PersonalData{Keys: NewMemoryKeyStore()}
+	engine, prepared := newPreparedAccountEngine(t, personalData)
+
+	cmd := &CreateAccount{Id: uuid.New(), Username: "alice", Email: "alice@example.com", Password: "secret-123"}
+	events := &recordedEvents{}
+	if err := engine.AggregateExecutors.Initial.CreateHandler(cmd, NewAccountDefault(), events); err != nil {
+		t.Fatal(err)
+	}
+
+	if prepared.Username != "alice" || prepared.Email != "alice@example.com" || prepared.Password != "secret-123" {
+		t.Errorf("preparers see prepared values %v", prepared)
+	}
+
+	created := events.Data[0].(*AccountCreated)
+	if created.Password == "secret-123" || !crypt.HashAndEquals("secret-123", created.Password) {
+		t.Errorf("password of the event is not hashed")
+	}
+	if created.Username == "alice" || personalData.Open(cmd.Id, created.Username) != "alice" {
+		t.Errorf("username of the event is not sealed")
+	}
+	if created.Email == "alice@example.com" || personalData.Open(cmd.Id, created.Email) != "alice@example.com" {
+		t.Errorf("email of the event is not sealed")
+	}
+}
+
+func TestUpdatePreparersSeePlainValues(t *testing.T) {
+	personalData := &PersonalData{Keys: NewMemoryKeyStore()}
+	engine, prepared := newPreparedAccountEngine(t, personalData)
+
+	hash, _ := crypt.Hash("secret-123")
+	account := &Account{Id: uuid.New(), Password: hash, PasswordHistory: []string{hash}}
+
+	if err := engine.AggregateExecutors.Exist.UpdateHandler(&UpdateAccount{Id: account.Id,
+		Password: "secret-123"}, account, &recordedEvents{}); err == nil {
+		t.Errorf("reused password accepted")
+	}
+
+	events := &recordedEvents{}
+	if err := engine.AggregateExecutors.Exist.UpdateHandler(&UpdateAccount{Id: account.Id,
+		Email: "alice@example.com", Password: "secret-456"}, account, events); err != nil {
+		t.Fatal(err)
+	}
+	if prepared.Email != "alice@example.com" || prepared.Password != "secret-456" {
+		t.Errorf("preparers see prepared values %v", prepared)
+	}
+
+	updated := events.Data[0].(*AccountUpdated)
+	if updated.Password == "secret-456" || !crypt.HashAndEquals("secret-456", updated.Password) {
+		t.Errorf("password of the event is not hashed")
+	}
+
+	events = &recordedEvents{}
+	if err := engine.AggregateExecutors.Exist.UpdateHandler(&UpdateAccount{Id: account.Id,
+		Email: "alice@example.com"}, account, events); err != nil {
+		t.Fatal(err)
+	}
+	if kept := events.Data[0].(*AccountUpdated); kept.Password != account.Password {
+		t.Errorf("update without password changed the password")
+	}
+	if updated.Email == "alice@example.com" || personalData.Open(account.Id, updated.Email) != "alice@example.com" {
+		t.Errorf("email of the event is not sealed")
+	}
+}
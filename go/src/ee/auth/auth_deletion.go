@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"errors"
+	"github.com/go-ee/utils"
+	"github.com/looplab/eventhorizon"
+	"time"
+)
+
+func (o *EsEngine) ImplementSoftDelete(retention time.Duration) {
+	o.Account.ImplementSoftDelete(retention)
+}
+
+// ImplementSoftDelete keeps the data of deleted accounts, they can be restored to Disabled within the retention
+// and stay in the projection until they are purged. Deleted accounts keep their username and email reserved.
+func (o *AccountAggregateEngine) ImplementSoftDelete(retention time.Duration) {
+	o.AggregateExecutors.Deleted.AddRestorePreparer(func(cmd *RestoreAccount, entity *Account) (err error) {
+		if entity.DeletedAt == nil {
+			err = errors.New("account is not restorable")
+		} else if retention > 0 && time.Since(*entity.DeletedAt) > retention {
+			err = errors.New("retention of the deleted account is over, it can be purged only")
+		}
+		return
+	})
+
+	// sessions end with the deletion, the account stays disabled after a restore until it is enabled
+	deletedHandler := func(event eventhorizon.Event, entity *Account) (err error) {
+		entity.DeletedAt = utils.PtrTime(event.Timestamp())
+		entity.Disabled = true
+		entity.Sessions = nil
+		return
+	}
+	o.AggregateHandlers.Enabled.DeletedHandler = deletedHandler
+	o.AggregateHandlers.Exist.DeletedHandler = deletedHandler
+
+	o.AggregateHandlers.Deleted.RestoredHandler = func(event eventhorizon.Event, entity *Account) (err error) {
+		entity.DeletedAt = nil
+		return
+	}
+
+	o.AggregateHandlers.Deleted.PurgedHandler = func(event eventhorizon.Event, entity *Account) (err error) {
+		*entity = *NewAccountDefault()
+		return
+	}
+}
@@ -0,0 +1,364 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"html/template"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const DeviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+const userCodeAlphabet = "BCDFGHJKLMNPQRSTVWXZ"
+
+var (
+	ErrAuthorizationPending = errors.New("authorization_pending")
+	ErrSlowDown             = errors.New("slow_down")
+	ErrExpiredToken         = errors.New("expired_token")
+	ErrAccessDenied         = errors.New("access_denied")
+)
+
+type DeviceAuthorization struct {
+	DeviceCode   string
+	UserCode     string
+	ClientId     string
+	Scope        string
+	ExpiresAt    time.Time
+	Interval     time.Duration
+	LastPolledAt time.Time
+	AccountId    uuid.UUID
+	Denied       bool
+}
+
+// DeviceCodeResponse follows RFC 8628 section 3.2
+type DeviceCodeResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationUri         string `json:"verification_uri"`
+	VerificationUriComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int64  `json:"expires_in"`
+	Interval                int64  `json:"interval,omitempty"`
+}
+
+// DeviceAuthorizations keeps pending device authorizations in memory, they live for minutes only.
+type DeviceAuthorizations struct {
+	Ttl        time.Duration
+	Interval   time.Duration
+	byDevice   map[string]*DeviceAuthorization
+	byUserCode map[string]*DeviceAuthorization
+	mutex      sync.Mutex
+}
+
+func NewDeviceAuthorizations() (ret *DeviceAuthorizations) {
+	ret = &DeviceAuthorizations{
+		Ttl:        10 * time.Minute,
+		Interval:   5 * time.Second,
+		byDevice:   map[string]*DeviceAuthorization{},
+		byUserCode: map[string]*DeviceAuthorization{},
+	}
+	return
+}
+
+func (o *DeviceAuthorizations) Create(clientId string, scope string) (ret *DeviceAuthorization, err error) {
+	var deviceCode, userCode string
+	if deviceCode, err = randomToken(); err != nil {
+		return
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.removeExpired(time.Now())
+	for userCode == "" || o.byUserCode[userCode] != nil {
+		if userCode, err = newUserCode(); err != nil {
+			return
+		}
+	}
+
+	ret = &DeviceAuthorization{
+		DeviceCode: deviceCode,
+		UserCode:   userCode,
+		ClientId:   clientId,
+		Scope:      scope,
+		ExpiresAt:  time.Now().Add(o.Ttl),
+		Interval:   o.Interval,
+	}
+	o.byDevice[deviceCode] = ret
+	o.byUserCode[userCode] = ret
+	return
+}
+
+func (o *DeviceAuthorizations) FindByUserCode(userCode string) (ret *DeviceAuthorization) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if ret = o.byUserCode[normalizeUserCode(userCode)]; ret != nil && time.Now().After(ret.ExpiresAt) {
+		ret = nil
+	}
+	return
+}
+
+// Decide approves the authorization for the account or denies it
+func (o *DeviceAuthorizations) Decide(userCode string, accountId uuid.UUID, approve bool) (err error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	authorization := o.byUserCode[normalizeUserCode(userCode)]
+	if authorization == nil || time.Now().After(authorization.ExpiresAt) {
+		err = ErrExpiredToken
+	} else if authorization.AccountId != uuid.Nil || authorization.Denied {
+		err = errors.New("device authorization is decided already")
+	} else if approve {
+		authorization.AccountId = accountId
+	} else {
+		authorization.Denied = true
+	}
+	return
+}
+
+// Poll returns the approving account id, or one of the RFC 8628 errors while the authorization is not finished
+func (o *DeviceAuthorizations) Poll(deviceCode string, clientId string) (ret uuid.UUID, err error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	now := time.Now()
+	authorization := o.byDevice[deviceCode]
+	if authorization == nil || authorization.ClientId != clientId {
+		err = errors.New("invalid_grant")
+		return
+	}
+
+	if now.After(authorization.ExpiresAt) {
+		o.remove(authorization)
+		err = ErrExpiredToken
+	} else if authorization.Denied {
+		o.remove(authorization)
+		err = ErrAccessDenied
+	} else if authorization.AccountId != uuid.Nil {
+		o.remove(authorization)
+		ret = authorization.AccountId
+	} else if now.Sub(authorization.LastPolledAt) < authorization.Interval {
+		authorization.Interval += 5 * time.Second
+		authorization.LastPolledAt = now
+		err = ErrSlowDown
+	} else {
+		authorization.LastPolledAt = now
+		err = ErrAuthorizationPending
+	}
+	return
+}
+
+func (o *DeviceAuthorizations) removeExpired(now time.Time) {
+	for _, authorization := range o.byDevice {
+		if now.After(authorization.ExpiresAt) {
+			o.remove(authorization)
+		}
+	}
+}
+
+func (o *DeviceAuthorizations) remove(authorization *DeviceAuthorization) {
+	delete(o.byDevice, authorization.DeviceCode)
+	delete(o.byUserCode, authorization.UserCode)
+}
+
+func newUserCode() (ret string, err error) {
+	max := big.NewInt(int64(len(userCodeAlphabet)))
+	code := make([]byte, 8)
+	for i := range code {
+		var n *big.Int
+		if n, err = rand.Int(rand.Reader, max); err != nil {
+			return
+		}
+		code[i] = userCodeAlphabet[n.Int64()]
+	}
+	ret = string(code[:4]) + "-" + string(code[4:])
+	return
+}
+
+func normalizeUserCode(userCode string) (ret string) {
+	ret = strings.ToUpper(strings.Replace(strings.TrimSpace(userCode), "-", "", -1))
+	if len(ret) == 8 {
+		ret = ret[:4] + "-" + ret[4:]
+	}
+	return
+}
+
+var deviceTemplate = template.Must(template.New("device").Parse(`<!DOCTYPE html>
+<html>
+<head><title>Device login</title></head>
+<body>
+{{if .Message}}<p>{{.Message}}</p>{{else}}
+<form method="post">
+<p>Signed in as <b>{{.Username}}</b>. Enter the code shown on your device.</p>
+<input name="user_code" value="{{.UserCode}}" autocomplete="off" autofocus>
+<button name="action" value="approve">Approve</button>
+<button name="action" value="deny">Deny</button>
+</form>{{end}}
+</body>
+</html>
+`))
+
+// DeviceRouter implements the OAuth 2.0 device authorization grant (RFC 8628) for command line clients.
+type DeviceRouter struct {
+	PathPrefix     string
+	TokenPath      string
+	Authorizations *DeviceAuthorizations
+	Accounts       *AccountQueryRepository
+	Sessions       *Sessions
+	ctx            context.Context
+}
+
+func NewDeviceRouter(pathPrefix string, newContext func(string) (ret context.Context),
+	accounts *AccountQueryRepository, sessions *Sessions) (ret *DeviceRouter) {
+	ret = &DeviceRouter{
+		PathPrefix:     pathPrefix + "/" + "device",
+		TokenPath:      pathPrefix + "/" + "token",
+		Authorizations: NewDeviceAuthorizations(),
+		Accounts:       accounts,
+		Sessions:       sessions,
+		ctx:            newContext("device"),
+	}
+	return
+}
+
+func (o *DeviceRouter) Setup(router *mux.Router) (err error) {
+	router.Methods(http.MethodPost).Path(o.PathPrefix + "/code").
+		Name("DeviceCode").
+		HandlerFunc(o.Code)
+	router.Methods(http.MethodGet).Path(o.PathPrefix).
+		Name("DeviceVerification").
+		HandlerFunc(o.Verification)
+	router.Methods(http.MethodPost).Path(o.PathPrefix).
+		Name("DeviceDecision").
+		HandlerFunc(o.Decision)
+	router.Methods(http.MethodPost).Path(o.TokenPath).
+		Name("Token").
+		HandlerFunc(o.Token)
+	return
+}
+
+func (o *DeviceRouter) Code(w http.ResponseWriter, r *http.Request) {
+	clientId := r.PostFormValue("client_id")
+	if clientId == "" {
+		writeError(w, http.StatusBadRequest, "invalid_request", errors.New("client_id is required"))
+		return
+	}
+
+	authorization, err := o.Authorizations.Create(clientId, r.PostFormValue("scope"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", err)
+		return
+	}
+
+	verificationUri := o.verificationUri(r)
+	writeJSON(w, http.StatusOK, &DeviceCodeResponse{
+		DeviceCode:              authorization.DeviceCode,
+		UserCode:                authorization.UserCode,
+		VerificationUri:         verificationUri,
+		VerificationUriComplete: verificationUri + "?user_code=" + url.QueryEscape(authorization.UserCode),
+		ExpiresIn:               int64(time.Until(authorization.ExpiresAt).Seconds()),
+		Interval:                int64(authorization.Interval.Seconds()),
+	})
+}
+
+// Verification shows the approval form, browsers are asked for the credentials of the account
+func (o *DeviceRouter) Verification(w http.ResponseWriter, r *http.Request) {
+	principal, ok := o.principal(w, r)
+	if !ok {
+		return
+	}
+	o.render(w, http.StatusOK, principal.Username, r.URL.Query().Get("user_code"), "")
+}
+
+func (o *DeviceRouter) Decision(w http.ResponseWriter, r *http.Request) {
+	principal, ok := o.principal(w, r)
+	if !ok {
+		return
+	}
+
+	// the form is the only legitimate origin, basic credentials are sent by browsers for any site
+	if origin := r.Header.Get("Origin"); origin != "" {
+		if originUrl, err := url.Parse(origin); err != nil || originUrl.Host != r.Host {
+			writeError(w, http.StatusForbidden, "access_denied", errors.New("cross origin request"))
+			return
+		}
+	}
+
+	approve := r.PostFormValue("action") == "approve"
+	if err := o.Authorizations.Decide(r.PostFormValue("user_code"), principal.AccountId, approve); err != nil {
+		o.render(w, http.StatusBadRequest, principal.Username, "", "The code is invalid or expired.")
+	} else if approve {
+		o.render(w, http.StatusOK, principal.Username, "", "The device is signed in, you can close this window.")
+	} else {
+		o.render(w, http.StatusOK, principal.Username, "", "The device was denied.")
+	}
+}
+
+func (o *DeviceRouter) Token(w http.ResponseWriter, r *http.Request) {
+	if grantType := r.PostFormValue("grant_type"); grantType != DeviceCodeGrantType {
+		writeError(w, http.StatusBadRequest, "unsupported_grant_type", nil)
+		return
+	}
+
+	accountId, err := o.Authorizations.Poll(r.PostFormValue("device_code"), r.PostFormValue("client_id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	// the account may have changed since it approved the device, it is checked like a password login
+	var account *Account
+	if account, err = o.Accounts.FindById(accountId); err != nil || account == nil || verifyAccount(account) != nil {
+		writeError(w, http.StatusBadRequest, "access_denied", nil)
+		return
+	}
+
+	var token *TokenResponse
+	if token, err = o.Sessions.Start(account, "device "+r.PostFormValue("client_id"), r); err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, token)
+}
+
+func (o *DeviceRouter) principal(w http.ResponseWriter, r *http.Request) (ret *Principal, ok bool) {
+	if ret = PrincipalFrom(r.Context()); ret == nil {
+		w.Header().Set("WWW-Authenticate", `Basic realm="auth"`)
+		http.Error(w, "sign in to approve the device", http.StatusUnauthorized)
+		return
+	}
+	if ret.ApiKey != nil || ret.IsImpersonated() {
+		http.Error(w, "devices are approved by the account itself", http.StatusForbidden)
+		return
+	}
+	ok = true
+	return
+}
+
+func (o *DeviceRouter) render(w http.ResponseWriter, status int, username string, userCode string, message string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Frame-Options", "DENY")
+	w.WriteHeader(status)
+	_ = deviceTemplate.Execute(w, map[string]string{
+		"Username": username,
+		"UserCode": userCode,
+		"Message":  message,
+	})
+}
+
+func (o *DeviceRouter) verificationUri(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host + o.PathPrefix
+}
@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"context"
+	"github.com/go-ee/utils"
+	"github.com/google/uuid"
+	"github.com/looplab/eventhorizon"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// accounts which can not log in with a password get no token for a device they approved before
+func TestDeviceTokenRejectsUnusableAccounts(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryRepo(t, AccountAggregateType, func() eventhorizon.Entity { return NewAccountDefault() })
+	router := NewDeviceRouter("/auth", func(string) context.Context { return ctx },
+		NewAccountQueryRepositoryFull(repo, ctx), nil)
+
+	for _, item := range []struct {
+		name    string
+		account *Account
+	}{
+		{"disabled", &Account{Id: uuid.New(), Disabled: true}},
+		{"deleted", &Account{Id: uuid.New(), DeletedAt: utils.PtrTime(time.Now())}},
+		{"pending", &Account{Id: uuid.New(), PendingApproval: true}},
+		{"merged", &Account{Id: uuid.New(), MergedInto: uuid.New()}},
+	} {
+		if err := repo.Save(ctx, item.account); err != nil {
+			t.Fatal(err)
+		}
+		authorization, err := router.Authorizations.Create("tv", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = router.Authorizations.Decide(authorization.UserCode, item.account.Id, true); err != nil {
+			t.Fatal(err)
+		}
+
+		form := url.Values{"grant_type": {DeviceCodeGrantType}, "device_code": {authorization.DeviceCode},
+			"client_id": {"tv"}}
+		request := httptest.NewRequest(http.MethodPost, "/auth/token", strings.NewReader(form.Encode()))
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		recorder := httptest.NewRecorder()
+		router.Token(recorder, request)
+		if recorder.Code != http.StatusBadRequest || !strings.Contains(recorder.Body.String(), "access_denied") {
+			t.Errorf("%v account answered %v: %v", item.name, recorder.Code, recorder.Body.String())
+		}
+	}
+}
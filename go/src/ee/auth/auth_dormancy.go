@@ -0,0 +1,166 @@
+package auth
+
+import (
+	"context"
+	"github.com/go-ee/utils"
+	"github.com/looplab/eventhorizon"
+	"github.com/sirupsen/logrus"
+	"sync"
+	"time"
+)
+
+// DisabledReasonInactivity is the reason of accounts disabled by the DormancyScheduler
+const DisabledReasonInactivity = "inactivity"
+
+func (o *EsEngine) ImplementDormancy() {
+	o.Account.ImplementDormancy()
+}
+
+// ImplementDormancy tracks the last login and the last enabling of accounts, they start the period of inactivity
+func (o *AccountAggregateEngine) ImplementDormancy() {
+	startedSessionHandler := o.AggregateHandlers.Enabled.StartedSessionHandler
+	o.AggregateHandlers.Enabled.StartedSessionHandler =
+		func(event eventhorizon.Event, eventData *AccountStartedSession, entity *Account) (err error) {
+			if err = startedSessionHandler(event, eventData, entity); err == nil {
+				entity.LastLoginAt = utils.PtrTime(event.Timestamp())
+				entity.SentInactivityWarning = false
+			}
+			return
+		}
+
+	enabledHandler := o.AggregateHandlers.Disabled.EnabledHandler
+	o.AggregateHandlers.Disabled.EnabledHandler =
+		func(event eventhorizon.Event, entity *Account) (err error) {
+			if err = enabledHandler(event, entity); err == nil {
+				entity.EnabledAt = utils.PtrTime(event.Timestamp())
+				entity.SentInactivityWarning = false
+			}
+			return
+		}
+}
+
+// LastActivity is the latest of the last login, the last enabling and the last password change,
+// accounts without any of them are not judged
+func (o *Account) LastActivity() (ret *time.Time) {
+	for _, at := range []*time.Time{o.LastLoginAt, o.EnabledAt, o.PasswordChangedAt} {
+		if at != nil && (ret == nil || at.After(*ret)) {
+			ret = at
+		}
+	}
+	return
+}
+
+// IsDormancyExempt is true for service and break-glass accounts, they are never disabled for inactivity
+func (o *Account) IsDormancyExempt() bool {
+	return o.Service || o.BreakGlass
+}
+
+// DormancyScheduler disables accounts without activity over the Period, the owners are warned Warning before.
+type DormancyScheduler struct {
+	Accounts   *AccountQueryRepository
+	CommandBus eventhorizon.CommandHandler
+	Notifier   Notifier
+	Period     time.Duration
+	Warning    time.Duration
+	Interval   time.Duration
+	ctx        context.Context
+	stop       chan struct{}
+	mutex      sync.Mutex
+}
+
+func NewDormancyScheduler(newContext func(string) (ret context.Context), commandBus eventhorizon.CommandHandler,
+	accounts *AccountQueryRepository, notifier Notifier, period time.Duration, warning time.Duration,
+	interval time.Duration) (ret *DormancyScheduler) {
+	ret = &DormancyScheduler{
+		Accounts:   accounts,
+		CommandBus: commandBus,
+		Notifier:   notifier,
+		Period:     period,
+		Warning:    warning,
+		Interval:   interval,
+		ctx:        newContext("dormancy"),
+	}
+	return
+}
+
+func (o *DormancyScheduler) Start() {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if o.stop != nil {
+		return
+	}
+
+	o.stop = make(chan struct{})
+	go func(stop chan struct{}) {
+		ticker := time.NewTicker(o.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				if err := o.DisableDormant(now); err != nil {
+					logrus.Warnf("disabling of dormant accounts failed: %v", err)
+				}
+			case <-stop:
+				return
+			}
+		}
+	}(o.stop)
+}
+
+func (o *DormancyScheduler) Stop() {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if o.stop != nil {
+		close(o.stop)
+		o.stop = nil
+	}
+}
+
+// DisableDormant warns and disables the dormant accounts at the time, failures do not stop the other accounts
+func (o *DormancyScheduler) DisableDormant(now time.Time) (err error) {
+	var accounts []*Account
+	if accounts, err = o.Accounts.FindAll(); err != nil {
+		return
+	}
+
+	for _, account := range accounts {
+		if account.Disabled || account.IsDormancyExempt() {
+			continue
+		}
+		lastActivity := account.LastActivity()
+		if lastActivity == nil {
+			continue
+		}
+
+		var accountErr error
+		disableAt := lastActivity.Add(o.Period)
+		if !now.Before(disableAt) {
+			accountErr = o.CommandBus.HandleCommand(o.ctx,
+				&DisableAccount{Id: account.Id, DisabledReason: DisabledReasonInactivity})
+		} else if !account.SentInactivityWarning && !now.Before(disableAt.Add(-o.Warning)) {
+			accountErr = o.warn(account, disableAt)
+		}
+		if accountErr != nil {
+			err = accountErr
+		}
+	}
+	return
+}
+
+func (o *DormancyScheduler) warn(account *Account, disableAt time.Time) (err error) {
+	if account.Email != "" {
+		if err = o.Notifier.Notify(&Notification{
+			Kind:    "inactivity_warning",
+			To:      account.Email,
+			Subject: "Your account will be disabled for inactivity",
+			Data: map[string]string{
+				"username":  account.Username,
+				"disableAt": disableAt.Format(time.RFC3339),
+			},
+		}); err != nil {
+			return
+		}
+	}
+	err = o.CommandBus.HandleCommand(o.ctx, &WarnInactivityAccount{Id: account.Id})
+	return
+}
@@ -0,0 +1,213 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/go-ee/utils"
+	"github.com/go-ee/utils/crypt"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/looplab/eventhorizon"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	NotificationEmailChange       = "email_change"
+	NotificationEmailChangeNotice = "email_change_notice"
+)
+
+func (o *EsEngine) ImplementEmailChange(accounts *AccountQueryRepository) {
+	o.Account.ImplementEmailChange(accounts)
+}
+
+// ImplementEmailChange applies a new email only after its owner confirmed it with the token sent to it
+func (o *AccountAggregateEngine) ImplementEmailChange(accounts *AccountQueryRepository) {
+	o.AggregateExecutors.Exist.AddUpdatePreparer(func(cmd *UpdateAccount, entity *Account) (err error) {
+		if !strings.EqualFold(cmd.Email, entity.Email) {
+			err = errors.New("email is changed over a confirmed email change only")
+		}
+		return
+	})
+
+	o.AggregateExecutors.Enabled.AddRequestEmailChangePreparer(
+		func(cmd *RequestEmailChangeAccount, entity *Account) (err error) {
+			if !strings.Contains(cmd.PendingEmail, "@") {
+				err = errors.New("valid email is required")
+			} else if strings.EqualFold(cmd.PendingEmail, entity.Email) {
+				err = errors.New("email is not changed")
+			} else if cmd.EmailChangeTokenHash == "" || cmd.EmailChangeExpiresAt == nil {
+				err = errors.New("token and expiry of the email change are required")
+			} else {
+				err = accounts.ForTenant(entity.OrganizationId).checkUnique(entity.Id, "", cmd.PendingEmail)
+			}
+			return
+		})
+
+	// the token is verified against the pending change, uniqueness is checked again
+	// because another account could have taken the email in the meantime
+	o.AggregateExecutors.Enabled.AddConfirmEmailChangePreparer(
+		func(cmd *ConfirmEmailChangeAccount, entity *Account) (err error) {
+			if entity.PendingEmail == "" || entity.EmailChangeExpiresAt == nil ||
+				time.Now().After(*entity.EmailChangeExpiresAt) ||
+				!crypt.HashAndEquals(cmd.Token, entity.EmailChangeTokenHash) {
+				err = errors.New("email change is invalid or expired")
+			} else if err = accounts.ForTenant(entity.OrganizationId).checkUnique(
+				entity.Id, "", entity.PendingEmail); err == nil {
+				cmd.Email = entity.PendingEmail
+			}
+			return
+		})
+
+	o.AggregateHandlers.Enabled.EmailChangeConfirmedHandler =
+		func(event eventhorizon.Event, eventData *AccountEmailChangeConfirmed, entity *Account) (err error) {
+			entity.Email = eventData.Email
+			entity.PendingEmail = ""
+			entity.EmailChangeTokenHash = ""
+			entity.EmailChangeExpiresAt = nil
+			return
+		}
+}
+
+type EmailChangeRequest struct {
+	Email string `json:"email"`
+}
+
+type ConfirmEmailChangeRequest struct {
+	Token string `json:"token"`
+}
+
+// EmailRouter changes the email of the own account, the token goes to the new address and a notice
+// to the former one. The confirmation is open without authentication, the token proves the new address.
+type EmailRouter struct {
+	PathPrefix string
+	Accounts   *AccountQueryRepository
+	CommandBus eventhorizon.CommandHandler
+	Notifier   Notifier
+	Ttl        time.Duration
+	ctx        context.Context
+}
+
+func NewEmailRouter(pathPrefix string, newContext func(string) (ret context.Context),
+	commandBus eventhorizon.CommandHandler, accounts *AccountQueryRepository, notifier Notifier,
+	ttl time.Duration) (ret *EmailRouter) {
+	ret = &EmailRouter{
+		PathPrefix: pathPrefix + "/" + "account",
+		Accounts:   accounts,
+		CommandBus: commandBus,
+		Notifier:   notifier,
+		Ttl:        ttl,
+		ctx:        newContext("email"),
+	}
+	return
+}
+
+func (o *EmailRouter) Setup(router *mux.Router) (err error) {
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefix).Path("/{id}/email").
+		Name("RequestEmailChange").
+		HandlerFunc(o.Request)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefix).Path("/{id}/email/confirm").
+		Name("ConfirmEmailChange").
+		HandlerFunc(o.Confirm)
+	return
+}
+
+func (o *EmailRouter) Request(w http.ResponseWriter, r *http.Request) {
+	principal := PrincipalFrom(r.Context())
+	if principal == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", ErrUnauthenticated)
+		return
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	if id != principal.AccountId || principal.ApiKey != nil {
+		writeError(w, http.StatusForbidden, "access_denied", errors.New("only the own email can be changed"))
+		return
+	}
+
+	request := &EmailChangeRequest{}
+	if err = json.NewDecoder(r.Body).Decode(request); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+
+	var account *Account
+	if account, err = o.Accounts.FindById(id); err != nil || account == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	var secret, hash string
+	if secret, err = randomToken(); err == nil {
+		hash, err = crypt.Hash(secret)
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", err)
+		return
+	}
+
+	change := &RequestEmailChangeAccount{Id: id, PendingEmail: strings.TrimSpace(request.Email),
+		EmailChangeTokenHash: hash, EmailChangeExpiresAt: utils.PtrTime(time.Now().Add(o.Ttl))}
+	if err = o.CommandBus.HandleCommand(o.ctx, change); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+
+	if err = o.notify(account, change, secret); err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (o *EmailRouter) notify(account *Account, change *RequestEmailChangeAccount, secret string) (err error) {
+	if err = o.Notifier.Notify(&Notification{
+		Kind:    NotificationEmailChange,
+		To:      change.PendingEmail,
+		Subject: "Confirm your new email address",
+		Data: map[string]string{
+			"accountId": account.Id.String(),
+			"token":     secret,
+			"expiresAt": change.EmailChangeExpiresAt.Format(time.RFC3339),
+		},
+	}); err != nil || account.Email == "" {
+		return
+	}
+
+	err = o.Notifier.Notify(&Notification{
+		Kind:    NotificationEmailChangeNotice,
+		To:      account.Email,
+		Subject: "The email address of your account is being changed",
+		Data: map[string]string{
+			"username": account.Username,
+			"newEmail": change.PendingEmail,
+		},
+	})
+	return
+}
+
+func (o *EmailRouter) Confirm(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	request := &ConfirmEmailChangeRequest{}
+	if err = json.NewDecoder(r.Body).Decode(request); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+
+	if err = o.CommandBus.HandleCommand(o.ctx, &ConfirmEmailChangeAccount{Id: id, Token: request.Token}); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_grant", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
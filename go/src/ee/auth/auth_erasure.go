@@ -0,0 +1,590 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"github.com/go-ee/utils"
+	"github.com/go-ee/utils/eh"
+	"github.com/google/uuid"
+	"github.com/looplab/eventhorizon"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Redacted replaces personal data of forgotten accounts
+const Redacted = "[redacted]"
+
+const sealedPrefix = "sealed:"
+
+// KeyStore holds the data keys of accounts, destroying a key erases the personal data sealed with it
+type KeyStore interface {
+	// Key returns the key of the account and creates it on first use
+	Key(accountId uuid.UUID) (ret []byte, err error)
+	// Find returns the key of the account, nil if it does not exist or was destroyed
+	Find(accountId uuid.UUID) (ret []byte, err error)
+	Destroy(accountId uuid.UUID) (err error)
+}
+
+type MemoryKeyStore struct {
+	keys  map[uuid.UUID][]byte
+	mutex sync.RWMutex
+}
+
+func NewMemoryKeyStore() (ret *MemoryKeyStore) {
+	ret = &MemoryKeyStore{keys: map[uuid.UUID][]byte{}}
+	return
+}
+
+func (o *MemoryKeyStore) Key(accountId uuid.UUID) (ret []byte, err error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if ret = o.keys[accountId]; ret == nil {
+		if ret, err = newDataKey(); err == nil {
+			o.keys[accountId] = ret
+		}
+	}
+	return
+}
+
+func (o *MemoryKeyStore) Find(accountId uuid.UUID) (ret []byte, err error) {
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+	ret = o.keys[accountId]
+	return
+}
+
+func (o *MemoryKeyStore) Destroy(accountId uuid.UUID) (err error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	delete(o.keys, accountId)
+	return
+}
+
+// FileKeyStore keeps a key file '<accountId>.key' per account in the folder, it must not be part of the backups
+// of the event store, otherwise a destroyed key can be recovered.
+type FileKeyStore struct {
+	Folder string
+	mutex  sync.Mutex
+}
+
+func NewFileKeyStore(folder string) (ret *FileKeyStore, err error) {
+	if err = os.MkdirAll(folder, 0700); err == nil {
+		ret = &FileKeyStore{Folder: folder}
+	}
+	return
+}
+
+func (o *FileKeyStore) Key(accountId uuid.UUID) (ret []byte, err error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if ret, err = o.read(accountId); ret != nil || err != nil {
+		return
+	}
+	if ret, err = newDataKey(); err == nil {
+		err = ioutil.WriteFile(o.file(accountId), ret, 0600)
+	}
+	return
+}
+
+func (o *FileKeyStore) Find(accountId uuid.UUID) (ret []byte, err error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	ret, err = o.read(accountId)
+	return
+}
+
+func (o *FileKeyStore) Destroy(accountId uuid.UUID) (err error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if err = os.Remove(o.file(accountId)); os.IsNotExist(err) {
+		err = nil
+	}
+	return
+}
+
+func (o *FileKeyStore) read(accountId uuid.UUID) (ret []byte, err error) {
+	if ret, err = ioutil.ReadFile(o.file(accountId)); os.IsNotExist(err) {
+		ret, err = nil, nil
+	}
+	return
+}
+
+func (o *FileKeyStore) file(accountId uuid.UUID) string {
+	return filepath.Join(o.Folder, accountId.String()+".key")
+}
+
+func newDataKey() (ret []byte, err error) {
+	ret = make([]byte, 32)
+	_, err = rand.Read(ret)
+	return
+}
+
+// PersonalData seals personal values with the key of their account, values without seal are plain values
+// of events before the activation. Invitations have no account yet, their values are sealed with the key of
+// the invitation.
+type PersonalData struct {
+	Keys KeyStore
+}
+
+func (o *PersonalData) Seal(accountId uuid.UUID, value string) (ret string, err error) {
+	if value == "" || strings.HasPrefix(value, sealedPrefix) {
+		ret = value
+		return
+	}
+
+	var gcm cipher.AEAD
+	if gcm, err = o.cipher(o.Keys.Key(accountId)); err != nil {
+		return
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return
+	}
+	ret = sealedPrefix + base64.RawStdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(value), nil))
+	return
+}
+
+// Open reveals a sealed value, values of destroyed keys and not readable values are redacted
+func (o *PersonalData) Open(accountId uuid.UUID, value string) (ret string) {
+	if !strings.HasPrefix(value, sealedPrefix) {
+		ret = value
+		return
+	}
+
+	ret = Redacted
+	gcm, err := o.cipher(o.Keys.Find(accountId))
+	if err != nil {
+		return
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(strings.TrimPrefix(value, sealedPrefix))
+	if err != nil || len(sealed) < gcm.NonceSize() {
+		return
+	}
+	if plain, openErr := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil); openErr == nil {
+		ret = string(plain)
+	}
+	return
+}
+
+func (o *PersonalData) cipher(key []byte, keyErr error) (ret cipher.AEAD, err error) {
+	if keyErr != nil {
+		err = keyErr
+		return
+	} else if key == nil {
+		err = errors.New("data key not found")
+		return
+	}
+
+	var block cipher.Block
+	if block, err = aes.NewCipher(key); err == nil {
+		ret, err = cipher.NewGCM(block)
+	}
+	return
+}
+
+func (o *PersonalData) sealName(accountId uuid.UUID, name *PersonName) (ret *PersonName, err error) {
+	if name == nil {
+		return
+	}
+	ret = &PersonName{}
+	if ret.First, err = o.Seal(accountId, name.First); err == nil {
+		ret.Last, err = o.Seal(accountId, name.Last)
+	}
+	return
+}
+
+// sealIdentities returns sealed copies, the identities of the command may be the ones of another account
+func (o *PersonalData) sealIdentities(accountId uuid.UUID, identities []*ExternalIdentity) (
+	ret []*ExternalIdentity, err error) {
+
+	for _, identity := range identities {
+		sealed := *identity
+		if sealed.Email, err = o.Seal(accountId, identity.Email); err != nil {
+			return
+		}
+		ret = append(ret, &sealed)
+	}
+	return
+}
+
+func (o *PersonalData) openIdentities(accountId uuid.UUID, identities []*ExternalIdentity) (
+	ret []*ExternalIdentity) {
+
+	for _, identity := range identities {
+		opened := *identity
+		opened.Email = o.Open(accountId, identity.Email)
+		ret = append(ret, &opened)
+	}
+	return
+}
+
+func (o *PersonalData) openName(accountId uuid.UUID, name *PersonName) (ret *PersonName) {
+	if name != nil {
+		ret = &PersonName{First: o.Open(accountId, name.First), Last: o.Open(accountId, name.Last)}
+	}
+	return
+}
+
+// sealAttributes seals the texts of the custom attributes, numbers and flags stay plain
+func (o *PersonalData) sealAttributes(accountId uuid.UUID, attributes map[string]interface{}) (
+	ret map[string]interface{}, err error) {
+
+	if attributes == nil {
+		return
+	}
+	var sealed interface{}
+	if sealed, err = o.sealValue(accountId, normalizeAttribute(attributes)); err == nil {
+		ret, _ = sealed.(map[string]interface{})
+	}
+	return
+}
+
+func (o *PersonalData) sealValue(accountId uuid.UUID, value interface{}) (ret interface{}, err error) {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, item := range typed {
+			if typed[key], err = o.sealValue(accountId, item); err != nil {
+				return
+			}
+		}
+	case []interface{}:
+		for i, item := range typed {
+			if typed[i], err = o.sealValue(accountId, item); err != nil {
+				return
+			}
+		}
+	case string:
+		ret, err = o.Seal(accountId, typed)
+		return
+	}
+	ret = value
+	return
+}
+
+func (o *PersonalData) openAttributes(accountId uuid.UUID, attributes map[string]interface{}) (
+	ret map[string]interface{}) {
+
+	if attributes != nil {
+		ret, _ = o.openValue(accountId, normalizeAttribute(attributes)).(map[string]interface{})
+	}
+	return
+}
+
+func (o *PersonalData) openValue(accountId uuid.UUID, value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, item := range typed {
+			typed[key] = o.openValue(accountId, item)
+		}
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = o.openValue(accountId, item)
+		}
+	case string:
+		return o.Open(accountId, typed)
+	}
+	return value
+}
+
+func (o *EsEngine) ActivateDataEncryption(personalData *PersonalData) {
+	o.Account.ActivateDataEncryption(personalData)
+	o.Invitation.ActivateDataEncryption(personalData)
+}
+
+// ActivateDataEncryption seals name, username, emails, custom attributes, emails of linked identities and the
+// client data of sessions of the account commands before they become events.
+// It must be activated before the other preparers, they run before it and see the plain values.
+func (o *AccountAggregateEngine) ActivateDataEncryption(personalData *PersonalData) {
+	o.AggregateExecutors.Initial.AddCreatePreparer(func(cmd *CreateAccount, entity *Account) (err error) {
+		if cmd.Name, cmd.Username, cmd.Email, err = personalData.sealAccount(
+			cmd.Id, cmd.Name, cmd.Username, cmd.Email); err == nil {
+			cmd.Attributes, err = personalData.sealAttributes(cmd.Id, cmd.Attributes)
+		}
+		return
+	})
+	o.AggregateExecutors.Initial.AddRegisterPreparer(func(cmd *RegisterAccount, entity *Account) (err error) {
+		cmd.Name, cmd.Username, cmd.Email, err = personalData.sealAccount(cmd.Id, cmd.Name, cmd.Username, cmd.Email)
+		return
+	})
+	o.AggregateExecutors.Exist.AddUpdatePreparer(func(cmd *UpdateAccount, entity *Account) (err error) {
+		if cmd.Name, cmd.Username, cmd.Email, err = personalData.sealAccount(
+			cmd.Id, cmd.Name, cmd.Username, cmd.Email); err == nil {
+			cmd.Attributes, err = personalData.sealAttributes(cmd.Id, cmd.Attributes)
+		}
+		return
+	})
+	o.AggregateExecutors.Enabled.AddRequestEmailChangePreparer(
+		func(cmd *RequestEmailChangeAccount, entity *Account) (err error) {
+			cmd.PendingEmail, err = personalData.Seal(cmd.Id, cmd.PendingEmail)
+			return
+		})
+	o.AggregateExecutors.Enabled.AddConfirmEmailChangePreparer(
+		func(cmd *ConfirmEmailChangeAccount, entity *Account) (err error) {
+			cmd.Email, err = personalData.Seal(cmd.Id, cmd.Email)
+			return
+		})
+	changeAttributesPreparer := func(cmd *ChangeAttributesAccount, entity *Account) (err error) {
+		cmd.Attributes, err = personalData.sealAttributes(cmd.Id, cmd.Attributes)
+		return
+	}
+	o.AggregateExecutors.Enabled.AddChangeAttributesPreparer(changeAttributesPreparer)
+	o.AggregateExecutors.Disabled.AddChangeAttributesPreparer(changeAttributesPreparer)
+
+	linkIdentityPreparer := func(cmd *LinkIdentityAccount, entity *Account) (err error) {
+		cmd.Email, err = personalData.Seal(cmd.Id, cmd.Email)
+		return
+	}
+	o.AggregateExecutors.Enabled.AddLinkIdentityPreparer(linkIdentityPreparer)
+	o.AggregateExecutors.Disabled.AddLinkIdentityPreparer(linkIdentityPreparer)
+
+	// the merged identities are sealed with the key of the target, they belong to it from now on
+	mergePreparer := func(cmd *MergeAccount, entity *Account) (err error) {
+		cmd.Identities, err = personalData.sealIdentities(cmd.Id, cmd.Identities)
+		return
+	}
+	o.AggregateExecutors.Enabled.AddMergePreparer(mergePreparer)
+	o.AggregateExecutors.Disabled.AddMergePreparer(mergePreparer)
+
+	o.AggregateExecutors.Enabled.AddStartSessionPreparer(
+		func(cmd *StartSessionAccount, entity *Account) (err error) {
+			if cmd.Device, err = personalData.Seal(cmd.Id, cmd.Device); err != nil {
+				return
+			}
+			if cmd.Ip, err = personalData.Seal(cmd.Id, cmd.Ip); err != nil {
+				return
+			}
+			cmd.UserAgent, err = personalData.Seal(cmd.Id, cmd.UserAgent)
+			return
+		})
+}
+
+// ActivateDataEncryption seals the email of the invitation with the key of the invitation
+func (o *InvitationAggregateEngine) ActivateDataEncryption(personalData *PersonalData) {
+	o.AggregateExecutors.Initial.AddCreatePreparer(func(cmd *CreateInvitation, entity *Invitation) (err error) {
+		cmd.Email, err = personalData.Seal(cmd.Id, cmd.Email)
+		return
+	})
+}
+
+func (o *PersonalData) sealAccount(accountId uuid.UUID, name *PersonName, username string, email string) (
+	retName *PersonName, retUsername string, retEmail string, err error) {
+
+	if retName, err = o.sealName(accountId, name); err != nil {
+		return
+	}
+	if retUsername, err = o.Seal(accountId, username); err != nil {
+		return
+	}
+	retEmail, err = o.Seal(accountId, email)
+	return
+}
+
+func (o *EsEngine) ImplementErasure(personalData *PersonalData) {
+	o.Account.ImplementErasure(personalData)
+	o.Invitation.ImplementErasure(personalData)
+}
+
+// ImplementErasure opens the sealed values of the events for the handlers, they are redacted after ForgetAccount
+// destroyed the key of the account. It must be implemented after the other handlers, it passes the opened
+// events to them.
+func (o *AccountAggregateEngine) ImplementErasure(personalData *PersonalData) {
+	forgetPreparer := func(cmd *ForgetAccount, entity *Account) (err error) {
+		err = personalData.Keys.Destroy(cmd.Id)
+		return
+	}
+	o.AggregateExecutors.Enabled.AddForgetPreparer(forgetPreparer)
+	o.AggregateExecutors.Disabled.AddForgetPreparer(forgetPreparer)
+	o.AggregateExecutors.Deleted.AddForgetPreparer(forgetPreparer)
+	o.AggregateExecutors.Merged.AddForgetPreparer(forgetPreparer)
+
+	restorePreparer := func(cmd *RestoreAccount, entity *Account) (err error) {
+		if entity.Forgotten {
+			err = errors.New("forgotten account can not be restored")
+		}
+		return
+	}
+	o.AggregateExecutors.Deleted.AddRestorePreparer(restorePreparer)
+
+	createdHandler := o.AggregateHandlers.Initial.CreatedHandler
+	o.AggregateHandlers.Initial.CreatedHandler =
+		func(event eventhorizon.Event, eventData *AccountCreated, entity *Account) (err error) {
+			opened := *eventData
+			id := event.AggregateID()
+			opened.Name = personalData.openName(id, eventData.Name)
+			opened.Username = personalData.Open(id, eventData.Username)
+			opened.Email = personalData.Open(id, eventData.Email)
+			opened.Attributes = personalData.openAttributes(id, eventData.Attributes)
+			return createdHandler(event, &opened, entity)
+		}
+
+	registeredHandler := o.AggregateHandlers.Initial.RegisteredHandler
+	o.AggregateHandlers.Initial.RegisteredHandler =
+		func(event eventhorizon.Event, eventData *AccountRegistered, entity *Account) (err error) {
+			opened := *eventData
+			id := event.AggregateID()
+			opened.Name = personalData.openName(id, eventData.Name)
+			opened.Username = personalData.Open(id, eventData.Username)
+			opened.Email = personalData.Open(id, eventData.Email)
+			return registeredHandler(event, &opened, entity)
+		}
+
+	updatedHandler := o.AggregateHandlers.Exist.UpdatedHandler
+	o.AggregateHandlers.Exist.UpdatedHandler =
+		func(event eventhorizon.Event, eventData *AccountUpdated, entity *Account) (err error) {
+			opened := *eventData
+			id := event.AggregateID()
+			opened.Name = personalData.openName(id, eventData.Name)
+			opened.Username = personalData.Open(id, eventData.Username)
+			opened.Email = personalData.Open(id, eventData.Email)
+			opened.Attributes = personalData.openAttributes(id, eventData.Attributes)
+			return updatedHandler(event, &opened, entity)
+		}
+
+	emailChangeRequestedHandler := o.AggregateHandlers.Enabled.EmailChangeRequestedHandler
+	o.AggregateHandlers.Enabled.EmailChangeRequestedHandler =
+		func(event eventhorizon.Event, eventData *AccountEmailChangeRequested, entity *Account) (err error) {
+			opened := *eventData
+			opened.PendingEmail = personalData.Open(event.AggregateID(), eventData.PendingEmail)
+			return emailChangeRequestedHandler(event, &opened, entity)
+		}
+
+	emailChangeConfirmedHandler := o.AggregateHandlers.Enabled.EmailChangeConfirmedHandler
+	o.AggregateHandlers.Enabled.EmailChangeConfirmedHandler =
+		func(event eventhorizon.Event, eventData *AccountEmailChangeConfirmed, entity *Account) (err error) {
+			opened := *eventData
+			opened.Email = personalData.Open(event.AggregateID(), eventData.Email)
+			return emailChangeConfirmedHandler(event, &opened, entity)
+		}
+
+	enabledAttributesChangedHandler := o.AggregateHandlers.Enabled.AttributesChangedHandler
+	o.AggregateHandlers.Enabled.AttributesChangedHandler =
+		func(event eventhorizon.Event, eventData *AccountAttributesChanged, entity *Account) (err error) {
+			opened := *eventData
+			opened.Attributes = personalData.openAttributes(event.AggregateID(), eventData.Attributes)
+			return enabledAttributesChangedHandler(event, &opened, entity)
+		}
+
+	disabledAttributesChangedHandler := o.AggregateHandlers.Disabled.AttributesChangedHandler
+	o.AggregateHandlers.Disabled.AttributesChangedHandler =
+		func(event eventhorizon.Event, eventData *AccountAttributesChanged, entity *Account) (err error) {
+			opened := *eventData
+			opened.Attributes = personalData.openAttributes(event.AggregateID(), eventData.Attributes)
+			return disabledAttributesChangedHandler(event, &opened, entity)
+		}
+
+	enabledLinkedIdentityHandler := o.AggregateHandlers.Enabled.LinkedIdentityHandler
+	o.AggregateHandlers.Enabled.LinkedIdentityHandler =
+		func(event eventhorizon.Event, eventData *AccountLinkedIdentity, entity *Account) (err error) {
+			opened := *eventData
+			opened.Email = personalData.Open(event.AggregateID(), eventData.Email)
+			return enabledLinkedIdentityHandler(event, &opened, entity)
+		}
+
+	disabledLinkedIdentityHandler := o.AggregateHandlers.Disabled.LinkedIdentityHandler
+	o.AggregateHandlers.Disabled.LinkedIdentityHandler =
+		func(event eventhorizon.Event, eventData *AccountLinkedIdentity, entity *Account) (err error) {
+			opened := *eventData
+			opened.Email = personalData.Open(event.AggregateID(), eventData.Email)
+			return disabledLinkedIdentityHandler(event, &opened, entity)
+		}
+
+	enabledMergedHandler := o.AggregateHandlers.Enabled.MergedHandler
+	o.AggregateHandlers.Enabled.MergedHandler =
+		func(event eventhorizon.Event, eventData *AccountMerged, entity *Account) (err error) {
+			opened := *eventData
+			opened.Identities = personalData.openIdentities(event.AggregateID(), eventData.Identities)
+			return enabledMergedHandler(event, &opened, entity)
+		}
+
+	disabledMergedHandler := o.AggregateHandlers.Disabled.MergedHandler
+	o.AggregateHandlers.Disabled.MergedHandler =
+		func(event eventhorizon.Event, eventData *AccountMerged, entity *Account) (err error) {
+			opened := *eventData
+			opened.Identities = personalData.openIdentities(event.AggregateID(), eventData.Identities)
+			return disabledMergedHandler(event, &opened, entity)
+		}
+
+	startedSessionHandler := o.AggregateHandlers.Enabled.StartedSessionHandler
+	o.AggregateHandlers.Enabled.StartedSessionHandler =
+		func(event eventhorizon.Event, eventData *AccountStartedSession, entity *Account) (err error) {
+			opened := *eventData
+			id := event.AggregateID()
+			opened.Device = personalData.Open(id, eventData.Device)
+			opened.Ip = personalData.Open(id, eventData.Ip)
+			opened.UserAgent = personalData.Open(id, eventData.UserAgent)
+			return startedSessionHandler(event, &opened, entity)
+		}
+
+	// the forgotten account stays deleted or merged, its data is redacted also without a replay
+	forgottenHandler := func(event eventhorizon.Event, entity *Account) (err error) {
+		if entity.Name != nil {
+			entity.Name = &PersonName{First: Redacted, Last: Redacted}
+		}
+		entity.Username = Redacted
+		entity.Email = Redacted
+		entity.Attributes = nil
+		entity.PendingEmail = ""
+		entity.EmailChangeTokenHash = ""
+		entity.EmailChangeExpiresAt = nil
+		entity.Identities = nil
+		entity.Sessions = nil
+		entity.Disabled = true
+		entity.Forgotten = true
+		if entity.DeletedAt == nil {
+			entity.DeletedAt = utils.PtrTime(event.Timestamp())
+		}
+		return
+	}
+	o.AggregateHandlers.Enabled.ForgottenHandler = forgottenHandler
+	o.AggregateHandlers.Disabled.ForgottenHandler = forgottenHandler
+	o.AggregateHandlers.Deleted.ForgottenHandler = forgottenHandler
+	o.AggregateHandlers.Merged.ForgottenHandler = forgottenHandler
+}
+
+// ImplementErasure opens the sealed email of the invitation for the handlers. The key is destroyed when the
+// invitation is accepted, revoked or deleted: the email of an accepted invitation lives on in the account
+// under the key of the account, the other ones are not needed anymore.
+func (o *InvitationAggregateEngine) ImplementErasure(personalData *PersonalData) {
+	createdHandler := o.AggregateHandlers.Initial.CreatedHandler
+	o.AggregateHandlers.Initial.CreatedHandler =
+		func(event eventhorizon.Event, eventData *InvitationCreated, entity *Invitation) (err error) {
+			opened := *eventData
+			opened.Email = personalData.Open(event.AggregateID(), eventData.Email)
+			return createdHandler(event, &opened, entity)
+		}
+
+	// the key is destroyed after the command is accepted, a rejected command keeps it
+	acceptHandler := o.AggregateExecutors.Pending.AcceptHandler
+	o.AggregateExecutors.Pending.AcceptHandler =
+		func(cmd *AcceptInvitation, entity *Invitation, store eh.AggregateStoreEvent) (err error) {
+			if err = acceptHandler(cmd, entity, store); err == nil {
+				err = personalData.Keys.Destroy(cmd.Id)
+			}
+			return
+		}
+	revokeHandler := o.AggregateExecutors.Pending.RevokeHandler
+	o.AggregateExecutors.Pending.RevokeHandler =
+		func(cmd *RevokeInvitation, entity *Invitation, store eh.AggregateStoreEvent) (err error) {
+			if err = revokeHandler(cmd, entity, store); err == nil {
+				err = personalData.Keys.Destroy(cmd.Id)
+			}
+			return
+		}
+	deleteHandler := o.AggregateExecutors.Pending.DeleteHandler
+	o.AggregateExecutors.Pending.DeleteHandler =
+		func(cmd *DeleteInvitation, entity *Invitation, store eh.AggregateStoreEvent) (err error) {
+			if err = deleteHandler(cmd, entity, store); err == nil {
+				err = personalData.Keys.Destroy(cmd.Id)
+			}
+			return
+		}
+}
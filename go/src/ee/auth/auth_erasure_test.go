@@ -0,0 +1,138 @@
+package auth
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sealedValues returns the sealed strings of the recorded events
+func sealedValues(t *testing.T, events *recordedEvents) (ret []string) {
+	for _, data := range events.Data {
+		var value interface{}
+		if encoded, err := json.Marshal(data); err != nil {
+			t.Fatal(err)
+		} else if err = json.Unmarshal(encoded, &value); err != nil {
+			t.Fatal(err)
+		}
+		ret = append(ret, collectSealed(value)...)
+	}
+	return
+}
+
+func collectSealed(value interface{}) (ret []string) {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for _, item := range typed {
+			ret = append(ret, collectSealed(item)...)
+		}
+	case []interface{}:
+		for _, item := range typed {
+			ret = append(ret, collectSealed(item)...)
+		}
+	case string:
+		if strings.HasPrefix(typed, sealedPrefix) {
+			ret = append(ret, typed)
+		}
+	}
+	return
+}
+
+// the personal data of all account events is sealed, after the erasure none of it can be read anymore
+func TestErasureLeavesNoPersonalData(t *testing.T) {
+	personalData := &PersonalData{Keys: NewMemoryKeyStore()}
+	engine := &AccountAggregateEngine{AggregateExecutors: NewAccountAggregateExecutorsFull(),
+		AggregateHandlers: NewAccountAggregateHandlersFull()}
+	if err := engine.AggregateExecutors.SetupCommandHandler(); err != nil {
+		t.Fatal(err)
+	}
+	engine.ActivateDataEncryption(personalData)
+	engine.ImplementSessions()
+	engine.ImplementIdentityLinking(nil)
+	engine.ImplementErasure(personalData)
+
+	id := uuid.New()
+	account := &Account{Id: id}
+	events := &recordedEvents{}
+	for _, execute := range []func() error{
+		func() error {
+			return engine.AggregateExecutors.Initial.CreateHandler(&CreateAccount{Id: id,
+				Name: &PersonName{First: "Alice", Last: "Liddell"}, Username: "alice", Email: "alice@example.com",
+				Attributes: map[string]interface{}{"city": "Oxford"}}, account, events)
+		},
+		func() error {
+			return engine.AggregateExecutors.Enabled.LinkIdentityHandler(&LinkIdentityAccount{Id: id,
+				Issuer: "https://idp.example.com", Subject: "42", Email: "alice@idp.example.com"}, account, events)
+		},
+		func() error {
+			return engine.AggregateExecutors.Enabled.StartSessionHandler(&StartSessionAccount{Id: id,
+				SessionId: uuid.New(), Device: "Alice's phone", Ip: "203.0.113.7", UserAgent: "Mozilla/5.0"},
+				account, events)
+		},
+		func() error {
+			return engine.AggregateExecutors.Enabled.MergeHandler(&MergeAccount{Id: id, SourceId: uuid.New(),
+				Identities: []*ExternalIdentity{{Issuer: "https://idp.example.com", Subject: "43",
+					Email: "alice@old.example.com"}}}, account, events)
+		},
+	} {
+		if err := execute(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	encoded, _ := json.Marshal(events.Data)
+	for _, plain := range []string{"Alice", "Liddell", "alice", "Oxford", "203.0.113.7", "Mozilla"} {
+		if strings.Contains(string(encoded), plain) {
+			t.Errorf("events contain '%v': %s", plain, encoded)
+		}
+	}
+
+	sealed := sealedValues(t, events)
+	if len(sealed) != 10 {
+		t.Errorf("%v values are sealed instead of 10", len(sealed))
+	}
+	if err := engine.AggregateExecutors.Enabled.ForgetHandler(&ForgetAccount{Id: id}, account,
+		&recordedEvents{}); err != nil {
+		t.Fatal(err)
+	}
+	for _, value := range sealed {
+		if opened := personalData.Open(id, value); opened != Redacted {
+			t.Errorf("'%v' is readable after the erasure", opened)
+		}
+	}
+}
+
+// the email of an invitation is readable until the invitation is accepted, it lives on in the account only
+func TestInvitationEmailIsErasedOnAccept(t *testing.T) {
+	personalData := &PersonalData{Keys: NewMemoryKeyStore()}
+	engine := &InvitationAggregateEngine{AggregateExecutors: NewInvitationAggregateExecutorsFull(),
+		AggregateHandlers: NewInvitationAggregateHandlersFull()}
+	if err := engine.AggregateExecutors.SetupCommandHandler(); err != nil {
+		t.Fatal(err)
+	}
+	engine.ActivateDataEncryption(personalData)
+	engine.ImplementErasure(personalData)
+
+	invitation := &Invitation{Id: uuid.New()}
+	events := &recordedEvents{}
+	expiresAt := time.Now().Add(time.Hour)
+	if err := engine.AggregateExecutors.Initial.CreateHandler(&CreateInvitation{Id: invitation.Id,
+		Email: "bob@example.com", TokenHash: "hash", ExpiresAt: &expiresAt, InvitedBy: uuid.New()},
+		invitation, events); err != nil {
+		t.Fatal(err)
+	}
+	email := events.Data[0].(*InvitationCreated).Email
+	if email == "bob@example.com" || personalData.Open(invitation.Id, email) != "bob@example.com" {
+		t.Errorf("email of the invitation is not sealed")
+	}
+
+	if err := engine.AggregateExecutors.Pending.AcceptHandler(&AcceptInvitation{Id: invitation.Id,
+		AccountId: uuid.New()}, invitation, events); err != nil {
+		t.Fatal(err)
+	}
+	if opened := personalData.Open(invitation.Id, email); opened != Redacted {
+		t.Errorf("email '%v' is readable after the accept", opened)
+	}
+}
@@ -3,6 +3,7 @@ package auth
 import (
 	"github.com/google/uuid"
 	"github.com/looplab/eventhorizon"
+	"time"
 )
 
 const (
@@ -13,7 +14,34 @@ const (
 	DeleteAccountCommand                   eventhorizon.CommandType = "DeleteAccount"
 	EnableAccountCommand                   eventhorizon.CommandType = "EnableAccount"
 	DisableAccountCommand                  eventhorizon.CommandType = "DisableAccount"
+	WarnInactivityAccountCommand           eventhorizon.CommandType = "WarnInactivityAccount"
 	UpdateAccountCommand                   eventhorizon.CommandType = "UpdateAccount"
+	LinkIdentityAccountCommand             eventhorizon.CommandType = "LinkIdentityAccount"
+	UnlinkIdentityAccountCommand           eventhorizon.CommandType = "UnlinkIdentityAccount"
+	CreateApiKeyAccountCommand             eventhorizon.CommandType = "CreateApiKeyAccount"
+	RevokeApiKeyAccountCommand             eventhorizon.CommandType = "RevokeApiKeyAccount"
+	UseApiKeyAccountCommand                eventhorizon.CommandType = "UseApiKeyAccount"
+	ImpersonateAccountCommand              eventhorizon.CommandType = "ImpersonateAccount"
+	EndImpersonationAccountCommand         eventhorizon.CommandType = "EndImpersonationAccount"
+	StartSessionAccountCommand             eventhorizon.CommandType = "StartSessionAccount"
+	TouchSessionAccountCommand             eventhorizon.CommandType = "TouchSessionAccount"
+	RevokeSessionAccountCommand            eventhorizon.CommandType = "RevokeSessionAccount"
+	RevokeSessionsAccountCommand           eventhorizon.CommandType = "RevokeSessionsAccount"
+	AssignRoleAccountCommand               eventhorizon.CommandType = "AssignRoleAccount"
+	UnassignRoleAccountCommand             eventhorizon.CommandType = "UnassignRoleAccount"
+	ExpireRoleAccountCommand               eventhorizon.CommandType = "ExpireRoleAccount"
+	RegisterAccountCommand                 eventhorizon.CommandType = "RegisterAccount"
+	ApproveRegistrationAccountCommand      eventhorizon.CommandType = "ApproveRegistrationAccount"
+	RejectRegistrationAccountCommand       eventhorizon.CommandType = "RejectRegistrationAccount"
+	ChangePasswordAccountCommand           eventhorizon.CommandType = "ChangePasswordAccount"
+	RestoreAccountCommand                  eventhorizon.CommandType = "RestoreAccount"
+	PurgeAccountCommand                    eventhorizon.CommandType = "PurgeAccount"
+	ForgetAccountCommand                   eventhorizon.CommandType = "ForgetAccount"
+	RequestEmailChangeAccountCommand       eventhorizon.CommandType = "RequestEmailChangeAccount"
+	ConfirmEmailChangeAccountCommand       eventhorizon.CommandType = "ConfirmEmailChangeAccount"
+	ChangeAttributesAccountCommand         eventhorizon.CommandType = "ChangeAttributesAccount"
+	MergeAccountCommand                    eventhorizon.CommandType = "MergeAccount"
+	MergeIntoAccountCommand                eventhorizon.CommandType = "MergeIntoAccount"
 )
 
 type SendEnabledConfirmationAccount struct {
@@ -52,12 +80,16 @@ func (o *LoginAccount) AggregateType() eventhorizon.AggregateType { return Accou
 func (o *LoginAccount) CommandType() eventhorizon.CommandType     { return LoginAccountCommand }
 
 type CreateAccount struct {
-	Name     *PersonName `json:"name,omitempty" eh:"optional"`
-	Username string      `json:"username,omitempty" eh:"optional"`
-	Password string      `json:"password,omitempty" eh:"optional"`
-	Email    string      `json:"email,omitempty" eh:"optional"`
-	Roles    []string    `json:"roles,omitempty" eh:"optional"`
-	Id       uuid.UUID   `json:"id,omitempty" eh:"optional"`
+	Name           *PersonName            `json:"name,omitempty" eh:"optional"`
+	Username       string                 `json:"username,omitempty" eh:"optional"`
+	Password       string                 `json:"password,omitempty" eh:"optional"`
+	Email          string                 `json:"email,omitempty" eh:"optional"`
+	Roles          []string               `json:"roles,omitempty" eh:"optional"`
+	OrganizationId uuid.UUID              `json:"organizationId,omitempty" eh:"optional"`
+	Service        bool                   `json:"service,omitempty" eh:"optional"`
+	BreakGlass     bool                   `json:"breakGlass,omitempty" eh:"optional"`
+	Attributes     map[string]interface{} `json:"attributes,omitempty" eh:"optional"`
+	Id             uuid.UUID              `json:"id,omitempty" eh:"optional"`
 }
 
 func (o *CreateAccount) AddToRoles(item string) string {
@@ -85,20 +117,37 @@ func (o *EnableAccount) AggregateType() eventhorizon.AggregateType { return Acco
 func (o *EnableAccount) CommandType() eventhorizon.CommandType     { return EnableAccountCommand }
 
 type DisableAccount struct {
-	Id uuid.UUID `json:"id,omitempty" eh:"optional"`
+	DisabledReason string    `json:"disabledReason,omitempty" eh:"optional"`
+	Id             uuid.UUID `json:"id,omitempty" eh:"optional"`
 }
 
 func (o *DisableAccount) AggregateID() uuid.UUID                    { return o.Id }
 func (o *DisableAccount) AggregateType() eventhorizon.AggregateType { return AccountAggregateType }
 func (o *DisableAccount) CommandType() eventhorizon.CommandType     { return DisableAccountCommand }
 
+type WarnInactivityAccount struct {
+	Id uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *WarnInactivityAccount) AggregateID() uuid.UUID { return o.Id }
+func (o *WarnInactivityAccount) AggregateType() eventhorizon.AggregateType {
+	return AccountAggregateType
+}
+func (o *WarnInactivityAccount) CommandType() eventhorizon.CommandType {
+	return WarnInactivityAccountCommand
+}
+
 type UpdateAccount struct {
-	Name     *PersonName `json:"name,omitempty" eh:"optional"`
-	Username string      `json:"username,omitempty" eh:"optional"`
-	Password string      `json:"password,omitempty" eh:"optional"`
-	Email    string      `json:"email,omitempty" eh:"optional"`
-	Roles    []string    `json:"roles,omitempty" eh:"optional"`
-	Id       uuid.UUID   `json:"id,omitempty" eh:"optional"`
+	Name           *PersonName            `json:"name,omitempty" eh:"optional"`
+	Username       string                 `json:"username,omitempty" eh:"optional"`
+	Password       string                 `json:"password,omitempty" eh:"optional"`
+	Email          string                 `json:"email,omitempty" eh:"optional"`
+	Roles          []string               `json:"roles,omitempty" eh:"optional"`
+	OrganizationId uuid.UUID              `json:"organizationId,omitempty" eh:"optional"`
+	Service        bool                   `json:"service,omitempty" eh:"optional"`
+	BreakGlass     bool                   `json:"breakGlass,omitempty" eh:"optional"`
+	Attributes     map[string]interface{} `json:"attributes,omitempty" eh:"optional"`
+	Id             uuid.UUID              `json:"id,omitempty" eh:"optional"`
 }
 
 func (o *UpdateAccount) AddToRoles(item string) string {
@@ -108,3 +157,766 @@ func (o *UpdateAccount) AddToRoles(item string) string {
 func (o *UpdateAccount) AggregateID() uuid.UUID                    { return o.Id }
 func (o *UpdateAccount) AggregateType() eventhorizon.AggregateType { return AccountAggregateType }
 func (o *UpdateAccount) CommandType() eventhorizon.CommandType     { return UpdateAccountCommand }
+
+type LinkIdentityAccount struct {
+	Issuer  string    `json:"issuer,omitempty" eh:"optional"`
+	Subject string    `json:"subject,omitempty" eh:"optional"`
+	Email   string    `json:"email,omitempty" eh:"optional"`
+	Id      uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *LinkIdentityAccount) AggregateID() uuid.UUID { return o.Id }
+func (o *LinkIdentityAccount) AggregateType() eventhorizon.AggregateType {
+	return AccountAggregateType
+}
+func (o *LinkIdentityAccount) CommandType() eventhorizon.CommandType {
+	return LinkIdentityAccountCommand
+}
+
+type UnlinkIdentityAccount struct {
+	Issuer  string    `json:"issuer,omitempty" eh:"optional"`
+	Subject string    `json:"subject,omitempty" eh:"optional"`
+	Id      uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *UnlinkIdentityAccount) AggregateID() uuid.UUID { return o.Id }
+func (o *UnlinkIdentityAccount) AggregateType() eventhorizon.AggregateType {
+	return AccountAggregateType
+}
+func (o *UnlinkIdentityAccount) CommandType() eventhorizon.CommandType {
+	return UnlinkIdentityAccountCommand
+}
+
+type CreateApiKeyAccount struct {
+	KeyId     uuid.UUID  `json:"keyId,omitempty" eh:"optional"`
+	Name      string     `json:"name,omitempty" eh:"optional"`
+	Scopes    []string   `json:"scopes,omitempty" eh:"optional"`
+	Hash      string     `json:"hash,omitempty" eh:"optional"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty" eh:"optional"`
+	Id        uuid.UUID  `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *CreateApiKeyAccount) AddToScopes(item string) string {
+	o.Scopes = append(o.Scopes, item)
+	return item
+}
+func (o *CreateApiKeyAccount) AggregateID() uuid.UUID { return o.Id }
+func (o *CreateApiKeyAccount) AggregateType() eventhorizon.AggregateType {
+	return AccountAggregateType
+}
+func (o *CreateApiKeyAccount) CommandType() eventhorizon.CommandType {
+	return CreateApiKeyAccountCommand
+}
+
+type RevokeApiKeyAccount struct {
+	KeyId uuid.UUID `json:"keyId,omitempty" eh:"optional"`
+	Id    uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *RevokeApiKeyAccount) AggregateID() uuid.UUID { return o.Id }
+func (o *RevokeApiKeyAccount) AggregateType() eventhorizon.AggregateType {
+	return AccountAggregateType
+}
+func (o *RevokeApiKeyAccount) CommandType() eventhorizon.CommandType {
+	return RevokeApiKeyAccountCommand
+}
+
+type UseApiKeyAccount struct {
+	KeyId uuid.UUID `json:"keyId,omitempty" eh:"optional"`
+	Id    uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *UseApiKeyAccount) AggregateID() uuid.UUID { return o.Id }
+func (o *UseApiKeyAccount) AggregateType() eventhorizon.AggregateType {
+	return AccountAggregateType
+}
+func (o *UseApiKeyAccount) CommandType() eventhorizon.CommandType {
+	return UseApiKeyAccountCommand
+}
+
+type ImpersonateAccount struct {
+	ImpersonationId uuid.UUID  `json:"impersonationId,omitempty" eh:"optional"`
+	ActorId         uuid.UUID  `json:"actorId,omitempty" eh:"optional"`
+	Reason          string     `json:"reason,omitempty" eh:"optional"`
+	ExpiresAt       *time.Time `json:"expiresAt,omitempty" eh:"optional"`
+	Id              uuid.UUID  `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *ImpersonateAccount) AggregateID() uuid.UUID { return o.Id }
+func (o *ImpersonateAccount) AggregateType() eventhorizon.AggregateType {
+	return AccountAggregateType
+}
+func (o *ImpersonateAccount) CommandType() eventhorizon.CommandType {
+	return ImpersonateAccountCommand
+}
+
+type EndImpersonationAccount struct {
+	ImpersonationId uuid.UUID `json:"impersonationId,omitempty" eh:"optional"`
+	ActorId         uuid.UUID `json:"actorId,omitempty" eh:"optional"`
+	Id              uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *EndImpersonationAccount) AggregateID() uuid.UUID { return o.Id }
+func (o *EndImpersonationAccount) AggregateType() eventhorizon.AggregateType {
+	return AccountAggregateType
+}
+func (o *EndImpersonationAccount) CommandType() eventhorizon.CommandType {
+	return EndImpersonationAccountCommand
+}
+
+type StartSessionAccount struct {
+	SessionId uuid.UUID `json:"sessionId,omitempty" eh:"optional"`
+	Device    string    `json:"device,omitempty" eh:"optional"`
+	Ip        string    `json:"ip,omitempty" eh:"optional"`
+	UserAgent string    `json:"userAgent,omitempty" eh:"optional"`
+	Id        uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *StartSessionAccount) AggregateID() uuid.UUID { return o.Id }
+func (o *StartSessionAccount) AggregateType() eventhorizon.AggregateType {
+	return AccountAggregateType
+}
+func (o *StartSessionAccount) CommandType() eventhorizon.CommandType {
+	return StartSessionAccountCommand
+}
+
+type TouchSessionAccount struct {
+	SessionId uuid.UUID `json:"sessionId,omitempty" eh:"optional"`
+	Id        uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *TouchSessionAccount) AggregateID() uuid.UUID { return o.Id }
+func (o *TouchSessionAccount) AggregateType() eventhorizon.AggregateType {
+	return AccountAggregateType
+}
+func (o *TouchSessionAccount) CommandType() eventhorizon.CommandType {
+	return TouchSessionAccountCommand
+}
+
+type RevokeSessionAccount struct {
+	SessionId uuid.UUID `json:"sessionId,omitempty" eh:"optional"`
+	Id        uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *RevokeSessionAccount) AggregateID() uuid.UUID { return o.Id }
+func (o *RevokeSessionAccount) AggregateType() eventhorizon.AggregateType {
+	return AccountAggregateType
+}
+func (o *RevokeSessionAccount) CommandType() eventhorizon.CommandType {
+	return RevokeSessionAccountCommand
+}
+
+type RevokeSessionsAccount struct {
+	Id uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *RevokeSessionsAccount) AggregateID() uuid.UUID { return o.Id }
+func (o *RevokeSessionsAccount) AggregateType() eventhorizon.AggregateType {
+	return AccountAggregateType
+}
+func (o *RevokeSessionsAccount) CommandType() eventhorizon.CommandType {
+	return RevokeSessionsAccountCommand
+}
+
+type AssignRoleAccount struct {
+	AssignmentId   uuid.UUID  `json:"assignmentId,omitempty" eh:"optional"`
+	Role           string     `json:"role,omitempty" eh:"optional"`
+	ValidFrom      *time.Time `json:"validFrom,omitempty" eh:"optional"`
+	ValidUntil     *time.Time `json:"validUntil,omitempty" eh:"optional"`
+	AssignedBy     uuid.UUID  `json:"assignedBy,omitempty" eh:"optional"`
+	GrantRequestId uuid.UUID  `json:"grantRequestId,omitempty" eh:"optional"`
+	Id             uuid.UUID  `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *AssignRoleAccount) AggregateID() uuid.UUID { return o.Id }
+func (o *AssignRoleAccount) AggregateType() eventhorizon.AggregateType {
+	return AccountAggregateType
+}
+func (o *AssignRoleAccount) CommandType() eventhorizon.CommandType {
+	return AssignRoleAccountCommand
+}
+
+type UnassignRoleAccount struct {
+	AssignmentId uuid.UUID `json:"assignmentId,omitempty" eh:"optional"`
+	Id           uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *UnassignRoleAccount) AggregateID() uuid.UUID { return o.Id }
+func (o *UnassignRoleAccount) AggregateType() eventhorizon.AggregateType {
+	return AccountAggregateType
+}
+func (o *UnassignRoleAccount) CommandType() eventhorizon.CommandType {
+	return UnassignRoleAccountCommand
+}
+
+type ExpireRoleAccount struct {
+	AssignmentId uuid.UUID `json:"assignmentId,omitempty" eh:"optional"`
+	Role         string    `json:"role,omitempty" eh:"optional"`
+	Id           uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *ExpireRoleAccount) AggregateID() uuid.UUID { return o.Id }
+func (o *ExpireRoleAccount) AggregateType() eventhorizon.AggregateType {
+	return AccountAggregateType
+}
+func (o *ExpireRoleAccount) CommandType() eventhorizon.CommandType {
+	return ExpireRoleAccountCommand
+}
+
+type RegisterAccount struct {
+	Name            *PersonName `json:"name,omitempty" eh:"optional"`
+	Username        string      `json:"username,omitempty" eh:"optional"`
+	Password        string      `json:"password,omitempty" eh:"optional"`
+	Email           string      `json:"email,omitempty" eh:"optional"`
+	Roles           []string    `json:"roles,omitempty" eh:"optional"`
+	OrganizationId  uuid.UUID   `json:"organizationId,omitempty" eh:"optional"`
+	PendingApproval bool        `json:"pendingApproval,omitempty" eh:"optional"`
+	Id              uuid.UUID   `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *RegisterAccount) AddToRoles(item string) string {
+	o.Roles = append(o.Roles, item)
+	return item
+}
+func (o *RegisterAccount) AggregateID() uuid.UUID                    { return o.Id }
+func (o *RegisterAccount) AggregateType() eventhorizon.AggregateType { return AccountAggregateType }
+func (o *RegisterAccount) CommandType() eventhorizon.CommandType     { return RegisterAccountCommand }
+
+type ApproveRegistrationAccount struct {
+	ApprovedBy uuid.UUID `json:"approvedBy,omitempty" eh:"optional"`
+	Id         uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *ApproveRegistrationAccount) AggregateID() uuid.UUID { return o.Id }
+func (o *ApproveRegistrationAccount) AggregateType() eventhorizon.AggregateType {
+	return AccountAggregateType
+}
+func (o *ApproveRegistrationAccount) CommandType() eventhorizon.CommandType {
+	return ApproveRegistrationAccountCommand
+}
+
+type RejectRegistrationAccount struct {
+	RejectedBy uuid.UUID `json:"rejectedBy,omitempty" eh:"optional"`
+	Reason     string    `json:"reason,omitempty" eh:"optional"`
+	Id         uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *RejectRegistrationAccount) AggregateID() uuid.UUID { return o.Id }
+func (o *RejectRegistrationAccount) AggregateType() eventhorizon.AggregateType {
+	return AccountAggregateType
+}
+func (o *RejectRegistrationAccount) CommandType() eventhorizon.CommandType {
+	return RejectRegistrationAccountCommand
+}
+
+type ChangePasswordAccount struct {
+	OldPassword string    `json:"oldPassword,omitempty" eh:"optional"`
+	Password    string    `json:"password,omitempty" eh:"optional"`
+	Id          uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *ChangePasswordAccount) AggregateID() uuid.UUID { return o.Id }
+func (o *ChangePasswordAccount) AggregateType() eventhorizon.AggregateType {
+	return AccountAggregateType
+}
+func (o *ChangePasswordAccount) CommandType() eventhorizon.CommandType {
+	return ChangePasswordAccountCommand
+}
+
+type RestoreAccount struct {
+	Id uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *RestoreAccount) AggregateID() uuid.UUID                    { return o.Id }
+func (o *RestoreAccount) AggregateType() eventhorizon.AggregateType { return AccountAggregateType }
+func (o *RestoreAccount) CommandType() eventhorizon.CommandType     { return RestoreAccountCommand }
+
+type PurgeAccount struct {
+	Id uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *PurgeAccount) AggregateID() uuid.UUID                    { return o.Id }
+func (o *PurgeAccount) AggregateType() eventhorizon.AggregateType { return AccountAggregateType }
+func (o *PurgeAccount) CommandType() eventhorizon.CommandType     { return PurgeAccountCommand }
+
+type ForgetAccount struct {
+	Id uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *ForgetAccount) AggregateID() uuid.UUID                    { return o.Id }
+func (o *ForgetAccount) AggregateType() eventhorizon.AggregateType { return AccountAggregateType }
+func (o *ForgetAccount) CommandType() eventhorizon.CommandType     { return ForgetAccountCommand }
+
+type RequestEmailChangeAccount struct {
+	PendingEmail         string     `json:"pendingEmail,omitempty" eh:"optional"`
+	EmailChangeTokenHash string     `json:"emailChangeTokenHash,omitempty" eh:"optional"`
+	EmailChangeExpiresAt *time.Time `json:"emailChangeExpiresAt,omitempty" eh:"optional"`
+	Id                   uuid.UUID  `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *RequestEmailChangeAccount) AggregateID() uuid.UUID { return o.Id }
+func (o *RequestEmailChangeAccount) AggregateType() eventhorizon.AggregateType {
+	return AccountAggregateType
+}
+func (o *RequestEmailChangeAccount) CommandType() eventhorizon.CommandType {
+	return RequestEmailChangeAccountCommand
+}
+
+type ConfirmEmailChangeAccount struct {
+	Token string    `json:"token,omitempty" eh:"optional"`
+	Email string    `json:"email,omitempty" eh:"optional"`
+	Id    uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *ConfirmEmailChangeAccount) AggregateID() uuid.UUID { return o.Id }
+func (o *ConfirmEmailChangeAccount) AggregateType() eventhorizon.AggregateType {
+	return AccountAggregateType
+}
+func (o *ConfirmEmailChangeAccount) CommandType() eventhorizon.CommandType {
+	return ConfirmEmailChangeAccountCommand
+}
+
+type ChangeAttributesAccount struct {
+	Attributes map[string]interface{} `json:"attributes,omitempty" eh:"optional"`
+	Id         uuid.UUID              `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *ChangeAttributesAccount) AggregateID() uuid.UUID { return o.Id }
+func (o *ChangeAttributesAccount) AggregateType() eventhorizon.AggregateType {
+	return AccountAggregateType
+}
+func (o *ChangeAttributesAccount) CommandType() eventhorizon.CommandType {
+	return ChangeAttributesAccountCommand
+}
+
+type MergeAccount struct {
+	SourceId        uuid.UUID           `json:"sourceId,omitempty" eh:"optional"`
+	Roles           []string            `json:"roles,omitempty" eh:"optional"`
+	RoleAssignments []*RoleAssignment   `json:"roleAssignments,omitempty" eh:"optional"`
+	Identities      []*ExternalIdentity `json:"identities,omitempty" eh:"optional"`
+	ApiKeys         []*ApiKey           `json:"apiKeys,omitempty" eh:"optional"`
+	Id              uuid.UUID           `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *MergeAccount) AddToRoles(item string) string {
+	o.Roles = append(o.Roles, item)
+	return item
+}
+func (o *MergeAccount) AddToRoleAssignments(item *RoleAssignment) *RoleAssignment {
+	o.RoleAssignments = append(o.RoleAssignments, item)
+	return item
+}
+func (o *MergeAccount) AddToIdentities(item *ExternalIdentity) *ExternalIdentity {
+	o.Identities = append(o.Identities, item)
+	return item
+}
+func (o *MergeAccount) AddToApiKeys(item *ApiKey) *ApiKey {
+	o.ApiKeys = append(o.ApiKeys, item)
+	return item
+}
+func (o *MergeAccount) AggregateID() uuid.UUID                    { return o.Id }
+func (o *MergeAccount) AggregateType() eventhorizon.AggregateType { return AccountAggregateType }
+func (o *MergeAccount) CommandType() eventhorizon.CommandType     { return MergeAccountCommand }
+
+type MergeIntoAccount struct {
+	TargetId uuid.UUID `json:"targetId,omitempty" eh:"optional"`
+	Id       uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *MergeIntoAccount) AggregateID() uuid.UUID { return o.Id }
+func (o *MergeIntoAccount) AggregateType() eventhorizon.AggregateType {
+	return AccountAggregateType
+}
+func (o *MergeIntoAccount) CommandType() eventhorizon.CommandType {
+	return MergeIntoAccountCommand
+}
+
+const (
+	CreateGroupCommand         eventhorizon.CommandType = "CreateGroup"
+	UpdateGroupCommand         eventhorizon.CommandType = "UpdateGroup"
+	DeleteGroupCommand         eventhorizon.CommandType = "DeleteGroup"
+	AddMemberGroupCommand      eventhorizon.CommandType = "AddMemberGroup"
+	RemoveMemberGroupCommand   eventhorizon.CommandType = "RemoveMemberGroup"
+	AddSubgroupGroupCommand    eventhorizon.CommandType = "AddSubgroupGroup"
+	RemoveSubgroupGroupCommand eventhorizon.CommandType = "RemoveSubgroupGroup"
+)
+
+type CreateGroup struct {
+	Name        string    `json:"name,omitempty" eh:"optional"`
+	Description string    `json:"description,omitempty" eh:"optional"`
+	Roles       []string  `json:"roles,omitempty" eh:"optional"`
+	Id          uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *CreateGroup) AddToRoles(item string) string {
+	o.Roles = append(o.Roles, item)
+	return item
+}
+func (o *CreateGroup) AggregateID() uuid.UUID                    { return o.Id }
+func (o *CreateGroup) AggregateType() eventhorizon.AggregateType { return GroupAggregateType }
+func (o *CreateGroup) CommandType() eventhorizon.CommandType     { return CreateGroupCommand }
+
+type UpdateGroup struct {
+	Name        string    `json:"name,omitempty" eh:"optional"`
+	Description string    `json:"description,omitempty" eh:"optional"`
+	Roles       []string  `json:"roles,omitempty" eh:"optional"`
+	Id          uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *UpdateGroup) AddToRoles(item string) string {
+	o.Roles = append(o.Roles, item)
+	return item
+}
+func (o *UpdateGroup) AggregateID() uuid.UUID                    { return o.Id }
+func (o *UpdateGroup) AggregateType() eventhorizon.AggregateType { return GroupAggregateType }
+func (o *UpdateGroup) CommandType() eventhorizon.CommandType     { return UpdateGroupCommand }
+
+type DeleteGroup struct {
+	Id uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *DeleteGroup) AggregateID() uuid.UUID                    { return o.Id }
+func (o *DeleteGroup) AggregateType() eventhorizon.AggregateType { return GroupAggregateType }
+func (o *DeleteGroup) CommandType() eventhorizon.CommandType     { return DeleteGroupCommand }
+
+type AddMemberGroup struct {
+	AccountId uuid.UUID `json:"accountId,omitempty" eh:"optional"`
+	Id        uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *AddMemberGroup) AggregateID() uuid.UUID                    { return o.Id }
+func (o *AddMemberGroup) AggregateType() eventhorizon.AggregateType { return GroupAggregateType }
+func (o *AddMemberGroup) CommandType() eventhorizon.CommandType     { return AddMemberGroupCommand }
+
+type RemoveMemberGroup struct {
+	AccountId uuid.UUID `json:"accountId,omitempty" eh:"optional"`
+	Id        uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *RemoveMemberGroup) AggregateID() uuid.UUID                    { return o.Id }
+func (o *RemoveMemberGroup) AggregateType() eventhorizon.AggregateType { return GroupAggregateType }
+func (o *RemoveMemberGroup) CommandType() eventhorizon.CommandType     { return RemoveMemberGroupCommand }
+
+type AddSubgroupGroup struct {
+	GroupId uuid.UUID `json:"groupId,omitempty" eh:"optional"`
+	Id      uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *AddSubgroupGroup) AggregateID() uuid.UUID                    { return o.Id }
+func (o *AddSubgroupGroup) AggregateType() eventhorizon.AggregateType { return GroupAggregateType }
+func (o *AddSubgroupGroup) CommandType() eventhorizon.CommandType     { return AddSubgroupGroupCommand }
+
+type RemoveSubgroupGroup struct {
+	GroupId uuid.UUID `json:"groupId,omitempty" eh:"optional"`
+	Id      uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *RemoveSubgroupGroup) AggregateID() uuid.UUID { return o.Id }
+func (o *RemoveSubgroupGroup) AggregateType() eventhorizon.AggregateType {
+	return GroupAggregateType
+}
+func (o *RemoveSubgroupGroup) CommandType() eventhorizon.CommandType {
+	return RemoveSubgroupGroupCommand
+}
+
+const (
+	CreateOrganizationCommand eventhorizon.CommandType = "CreateOrganization"
+	UpdateOrganizationCommand eventhorizon.CommandType = "UpdateOrganization"
+	DeleteOrganizationCommand eventhorizon.CommandType = "DeleteOrganization"
+)
+
+type CreateOrganization struct {
+	Name        string    `json:"name,omitempty" eh:"optional"`
+	Description string    `json:"description,omitempty" eh:"optional"`
+	Id          uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *CreateOrganization) AggregateID() uuid.UUID { return o.Id }
+func (o *CreateOrganization) AggregateType() eventhorizon.AggregateType {
+	return OrganizationAggregateType
+}
+func (o *CreateOrganization) CommandType() eventhorizon.CommandType {
+	return CreateOrganizationCommand
+}
+
+type UpdateOrganization struct {
+	Name        string    `json:"name,omitempty" eh:"optional"`
+	Description string    `json:"description,omitempty" eh:"optional"`
+	Id          uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *UpdateOrganization) AggregateID() uuid.UUID { return o.Id }
+func (o *UpdateOrganization) AggregateType() eventhorizon.AggregateType {
+	return OrganizationAggregateType
+}
+func (o *UpdateOrganization) CommandType() eventhorizon.CommandType {
+	return UpdateOrganizationCommand
+}
+
+type DeleteOrganization struct {
+	Id uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *DeleteOrganization) AggregateID() uuid.UUID { return o.Id }
+func (o *DeleteOrganization) AggregateType() eventhorizon.AggregateType {
+	return OrganizationAggregateType
+}
+func (o *DeleteOrganization) CommandType() eventhorizon.CommandType {
+	return DeleteOrganizationCommand
+}
+
+const (
+	CreateRoleGrantRequestCommand  eventhorizon.CommandType = "CreateRoleGrantRequest"
+	UpdateRoleGrantRequestCommand  eventhorizon.CommandType = "UpdateRoleGrantRequest"
+	DeleteRoleGrantRequestCommand  eventhorizon.CommandType = "DeleteRoleGrantRequest"
+	ApproveRoleGrantRequestCommand eventhorizon.CommandType = "ApproveRoleGrantRequest"
+	RejectRoleGrantRequestCommand  eventhorizon.CommandType = "RejectRoleGrantRequest"
+)
+
+type CreateRoleGrantRequest struct {
+	AccountId   uuid.UUID  `json:"accountId,omitempty" eh:"optional"`
+	Role        string     `json:"role,omitempty" eh:"optional"`
+	Reason      string     `json:"reason,omitempty" eh:"optional"`
+	ValidFrom   *time.Time `json:"validFrom,omitempty" eh:"optional"`
+	ValidUntil  *time.Time `json:"validUntil,omitempty" eh:"optional"`
+	RequestedBy uuid.UUID  `json:"requestedBy,omitempty" eh:"optional"`
+	Id          uuid.UUID  `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *CreateRoleGrantRequest) AggregateID() uuid.UUID { return o.Id }
+func (o *CreateRoleGrantRequest) AggregateType() eventhorizon.AggregateType {
+	return RoleGrantRequestAggregateType
+}
+func (o *CreateRoleGrantRequest) CommandType() eventhorizon.CommandType {
+	return CreateRoleGrantRequestCommand
+}
+
+type UpdateRoleGrantRequest struct {
+	AccountId   uuid.UUID  `json:"accountId,omitempty" eh:"optional"`
+	Role        string     `json:"role,omitempty" eh:"optional"`
+	Reason      string     `json:"reason,omitempty" eh:"optional"`
+	ValidFrom   *time.Time `json:"validFrom,omitempty" eh:"optional"`
+	ValidUntil  *time.Time `json:"validUntil,omitempty" eh:"optional"`
+	RequestedBy uuid.UUID  `json:"requestedBy,omitempty" eh:"optional"`
+	Id          uuid.UUID  `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *UpdateRoleGrantRequest) AggregateID() uuid.UUID { return o.Id }
+func (o *UpdateRoleGrantRequest) AggregateType() eventhorizon.AggregateType {
+	return RoleGrantRequestAggregateType
+}
+func (o *UpdateRoleGrantRequest) CommandType() eventhorizon.CommandType {
+	return UpdateRoleGrantRequestCommand
+}
+
+type DeleteRoleGrantRequest struct {
+	Id uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *DeleteRoleGrantRequest) AggregateID() uuid.UUID { return o.Id }
+func (o *DeleteRoleGrantRequest) AggregateType() eventhorizon.AggregateType {
+	return RoleGrantRequestAggregateType
+}
+func (o *DeleteRoleGrantRequest) CommandType() eventhorizon.CommandType {
+	return DeleteRoleGrantRequestCommand
+}
+
+type ApproveRoleGrantRequest struct {
+	DecidedBy  uuid.UUID  `json:"decidedBy,omitempty" eh:"optional"`
+	Comment    string     `json:"comment,omitempty" eh:"optional"`
+	AccountId  uuid.UUID  `json:"accountId,omitempty" eh:"optional"`
+	Role       string     `json:"role,omitempty" eh:"optional"`
+	ValidFrom  *time.Time `json:"validFrom,omitempty" eh:"optional"`
+	ValidUntil *time.Time `json:"validUntil,omitempty" eh:"optional"`
+	Id         uuid.UUID  `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *ApproveRoleGrantRequest) AggregateID() uuid.UUID { return o.Id }
+func (o *ApproveRoleGrantRequest) AggregateType() eventhorizon.AggregateType {
+	return RoleGrantRequestAggregateType
+}
+func (o *ApproveRoleGrantRequest) CommandType() eventhorizon.CommandType {
+	return ApproveRoleGrantRequestCommand
+}
+
+type RejectRoleGrantRequest struct {
+	DecidedBy uuid.UUID `json:"decidedBy,omitempty" eh:"optional"`
+	Comment   string    `json:"comment,omitempty" eh:"optional"`
+	Id        uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *RejectRoleGrantRequest) AggregateID() uuid.UUID { return o.Id }
+func (o *RejectRoleGrantRequest) AggregateType() eventhorizon.AggregateType {
+	return RoleGrantRequestAggregateType
+}
+func (o *RejectRoleGrantRequest) CommandType() eventhorizon.CommandType {
+	return RejectRoleGrantRequestCommand
+}
+
+const (
+	CreateRoleCommand eventhorizon.CommandType = "CreateRole"
+	UpdateRoleCommand eventhorizon.CommandType = "UpdateRole"
+	DeleteRoleCommand eventhorizon.CommandType = "DeleteRole"
+)
+
+type CreateRole struct {
+	Name        string    `json:"name,omitempty" eh:"optional"`
+	Description string    `json:"description,omitempty" eh:"optional"`
+	Inherits    []string  `json:"inherits,omitempty" eh:"optional"`
+	Permissions []string  `json:"permissions,omitempty" eh:"optional"`
+	Id          uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *CreateRole) AddToInherits(item string) string {
+	o.Inherits = append(o.Inherits, item)
+	return item
+}
+func (o *CreateRole) AddToPermissions(item string) string {
+	o.Permissions = append(o.Permissions, item)
+	return item
+}
+func (o *CreateRole) AggregateID() uuid.UUID                    { return o.Id }
+func (o *CreateRole) AggregateType() eventhorizon.AggregateType { return RoleAggregateType }
+func (o *CreateRole) CommandType() eventhorizon.CommandType     { return CreateRoleCommand }
+
+type UpdateRole struct {
+	Name        string    `json:"name,omitempty" eh:"optional"`
+	Description string    `json:"description,omitempty" eh:"optional"`
+	Inherits    []string  `json:"inherits,omitempty" eh:"optional"`
+	Permissions []string  `json:"permissions,omitempty" eh:"optional"`
+	Id          uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *UpdateRole) AddToInherits(item string) string {
+	o.Inherits = append(o.Inherits, item)
+	return item
+}
+func (o *UpdateRole) AddToPermissions(item string) string {
+	o.Permissions = append(o.Permissions, item)
+	return item
+}
+func (o *UpdateRole) AggregateID() uuid.UUID                    { return o.Id }
+func (o *UpdateRole) AggregateType() eventhorizon.AggregateType { return RoleAggregateType }
+func (o *UpdateRole) CommandType() eventhorizon.CommandType     { return UpdateRoleCommand }
+
+type DeleteRole struct {
+	Id uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *DeleteRole) AggregateID() uuid.UUID                    { return o.Id }
+func (o *DeleteRole) AggregateType() eventhorizon.AggregateType { return RoleAggregateType }
+func (o *DeleteRole) CommandType() eventhorizon.CommandType     { return DeleteRoleCommand }
+
+const (
+	CreateRelationTupleCommand eventhorizon.CommandType = "CreateRelationTuple"
+	DeleteRelationTupleCommand eventhorizon.CommandType = "DeleteRelationTuple"
+)
+
+type CreateRelationTuple struct {
+	Namespace string    `json:"namespace,omitempty" eh:"optional"`
+	Object    string    `json:"object,omitempty" eh:"optional"`
+	Relation  string    `json:"relation,omitempty" eh:"optional"`
+	Subject   string    `json:"subject,omitempty" eh:"optional"`
+	Id        uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *CreateRelationTuple) AggregateID() uuid.UUID { return o.Id }
+func (o *CreateRelationTuple) AggregateType() eventhorizon.AggregateType {
+	return RelationTupleAggregateType
+}
+func (o *CreateRelationTuple) CommandType() eventhorizon.CommandType {
+	return CreateRelationTupleCommand
+}
+
+type DeleteRelationTuple struct {
+	Id uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *DeleteRelationTuple) AggregateID() uuid.UUID { return o.Id }
+func (o *DeleteRelationTuple) AggregateType() eventhorizon.AggregateType {
+	return RelationTupleAggregateType
+}
+func (o *DeleteRelationTuple) CommandType() eventhorizon.CommandType {
+	return DeleteRelationTupleCommand
+}
+
+const (
+	CreateInvitationCommand eventhorizon.CommandType = "CreateInvitation"
+	DeleteInvitationCommand eventhorizon.CommandType = "DeleteInvitation"
+	ResendInvitationCommand eventhorizon.CommandType = "ResendInvitation"
+	RevokeInvitationCommand eventhorizon.CommandType = "RevokeInvitation"
+	AcceptInvitationCommand eventhorizon.CommandType = "AcceptInvitation"
+)
+
+type CreateInvitation struct {
+	Email          string     `json:"email,omitempty" eh:"optional"`
+	Roles          []string   `json:"roles,omitempty" eh:"optional"`
+	OrganizationId uuid.UUID  `json:"organizationId,omitempty" eh:"optional"`
+	TokenHash      string     `json:"tokenHash,omitempty" eh:"optional"`
+	ExpiresAt      *time.Time `json:"expiresAt,omitempty" eh:"optional"`
+	InvitedBy      uuid.UUID  `json:"invitedBy,omitempty" eh:"optional"`
+	Id             uuid.UUID  `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *CreateInvitation) AddToRoles(item string) string {
+	o.Roles = append(o.Roles, item)
+	return item
+}
+func (o *CreateInvitation) AggregateID() uuid.UUID { return o.Id }
+func (o *CreateInvitation) AggregateType() eventhorizon.AggregateType {
+	return InvitationAggregateType
+}
+func (o *CreateInvitation) CommandType() eventhorizon.CommandType {
+	return CreateInvitationCommand
+}
+
+type DeleteInvitation struct {
+	Id uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *DeleteInvitation) AggregateID() uuid.UUID { return o.Id }
+func (o *DeleteInvitation) AggregateType() eventhorizon.AggregateType {
+	return InvitationAggregateType
+}
+func (o *DeleteInvitation) CommandType() eventhorizon.CommandType {
+	return DeleteInvitationCommand
+}
+
+type ResendInvitation struct {
+	TokenHash string     `json:"tokenHash,omitempty" eh:"optional"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty" eh:"optional"`
+	Id        uuid.UUID  `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *ResendInvitation) AggregateID() uuid.UUID { return o.Id }
+func (o *ResendInvitation) AggregateType() eventhorizon.AggregateType {
+	return InvitationAggregateType
+}
+func (o *ResendInvitation) CommandType() eventhorizon.CommandType {
+	return ResendInvitationCommand
+}
+
+type RevokeInvitation struct {
+	Id uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *RevokeInvitation) AggregateID() uuid.UUID { return o.Id }
+func (o *RevokeInvitation) AggregateType() eventhorizon.AggregateType {
+	return InvitationAggregateType
+}
+func (o *RevokeInvitation) CommandType() eventhorizon.CommandType {
+	return RevokeInvitationCommand
+}
+
+type AcceptInvitation struct {
+	AccountId uuid.UUID `json:"accountId,omitempty" eh:"optional"`
+	Id        uuid.UUID `json:"id,omitempty" eh:"optional"`
+}
+
+func (o *AcceptInvitation) AggregateID() uuid.UUID { return o.Id }
+func (o *AcceptInvitation) AggregateType() eventhorizon.AggregateType {
+	return InvitationAggregateType
+}
+func (o *AcceptInvitation) CommandType() eventhorizon.CommandType {
+	return AcceptInvitationCommand
+}
@@ -1,7 +1,9 @@
 package auth
 
 import (
+	"github.com/google/uuid"
 	"github.com/looplab/eventhorizon"
+	"time"
 )
 
 const (
@@ -13,6 +15,33 @@ const (
 	AccountUpdatedEvent                  eventhorizon.EventType = "AccountUpdated"
 	AccountCreatedEvent                  eventhorizon.EventType = "AccountCreated"
 	AccountLoggedEvent                   eventhorizon.EventType = "AccountLogged"
+	AccountAssignedRoleEvent             eventhorizon.EventType = "AccountAssignedRole"
+	AccountAttributesChangedEvent        eventhorizon.EventType = "AccountAttributesChanged"
+	AccountCreatedApiKeyEvent            eventhorizon.EventType = "AccountCreatedApiKey"
+	AccountEmailChangeConfirmedEvent     eventhorizon.EventType = "AccountEmailChangeConfirmed"
+	AccountEmailChangeRequestedEvent     eventhorizon.EventType = "AccountEmailChangeRequested"
+	AccountForgottenEvent                eventhorizon.EventType = "AccountForgotten"
+	AccountInactivityWarnedEvent         eventhorizon.EventType = "AccountInactivityWarned"
+	AccountLinkedIdentityEvent           eventhorizon.EventType = "AccountLinkedIdentity"
+	AccountMergedEvent                   eventhorizon.EventType = "AccountMerged"
+	AccountMergedIntoEvent               eventhorizon.EventType = "AccountMergedInto"
+	AccountPasswordChangedEvent          eventhorizon.EventType = "AccountPasswordChanged"
+	AccountPurgedEvent                   eventhorizon.EventType = "AccountPurged"
+	AccountRegisteredEvent               eventhorizon.EventType = "AccountRegistered"
+	AccountRegistrationApprovedEvent     eventhorizon.EventType = "AccountRegistrationApproved"
+	AccountRegistrationRejectedEvent     eventhorizon.EventType = "AccountRegistrationRejected"
+	AccountRestoredEvent                 eventhorizon.EventType = "AccountRestored"
+	AccountRevokedApiKeyEvent            eventhorizon.EventType = "AccountRevokedApiKey"
+	AccountRevokedSessionEvent           eventhorizon.EventType = "AccountRevokedSession"
+	AccountRevokedSessionsEvent          eventhorizon.EventType = "AccountRevokedSessions"
+	AccountRoleExpiredEvent              eventhorizon.EventType = "AccountRoleExpired"
+	AccountStartedSessionEvent           eventhorizon.EventType = "AccountStartedSession"
+	AccountTouchedSessionEvent           eventhorizon.EventType = "AccountTouchedSession"
+	AccountUnassignedRoleEvent           eventhorizon.EventType = "AccountUnassignedRole"
+	AccountUnlinkedIdentityEvent         eventhorizon.EventType = "AccountUnlinkedIdentity"
+	AccountUsedApiKeyEvent               eventhorizon.EventType = "AccountUsedApiKey"
+	ImpersonationEndedEvent              eventhorizon.EventType = "ImpersonationEnded"
+	ImpersonationStartedEvent            eventhorizon.EventType = "ImpersonationStarted"
 )
 
 type AccountLogged struct {
@@ -22,11 +51,15 @@ type AccountLogged struct {
 }
 
 type AccountCreated struct {
-	Name     *PersonName `json:"name,omitempty" eh:"optional"`
-	Username string      `json:"username,omitempty" eh:"optional"`
-	Password string      `json:"password,omitempty" eh:"optional"`
-	Email    string      `json:"email,omitempty" eh:"optional"`
-	Roles    []string    `json:"roles,omitempty" eh:"optional"`
+	Name           *PersonName            `json:"name,omitempty" eh:"optional"`
+	Username       string                 `json:"username,omitempty" eh:"optional"`
+	Password       string                 `json:"password,omitempty" eh:"optional"`
+	Email          string                 `json:"email,omitempty" eh:"optional"`
+	Roles          []string               `json:"roles,omitempty" eh:"optional"`
+	OrganizationId uuid.UUID              `json:"organizationId,omitempty" eh:"optional"`
+	Service        bool                   `json:"service,omitempty" eh:"optional"`
+	BreakGlass     bool                   `json:"breakGlass,omitempty" eh:"optional"`
+	Attributes     map[string]interface{} `json:"attributes,omitempty" eh:"optional"`
 }
 
 func (o *AccountCreated) AddToRoles(item string) string {
@@ -38,17 +71,363 @@ type AccountEnabled struct {
 }
 
 type AccountDisabled struct {
+	DisabledReason string `json:"disabledReason,omitempty" eh:"optional"`
+}
+
+type AccountInactivityWarned struct {
 }
 
 type AccountUpdated struct {
-	Name     *PersonName `json:"name,omitempty" eh:"optional"`
-	Username string      `json:"username,omitempty" eh:"optional"`
-	Password string      `json:"password,omitempty" eh:"optional"`
-	Email    string      `json:"email,omitempty" eh:"optional"`
-	Roles    []string    `json:"roles,omitempty" eh:"optional"`
+	Name           *PersonName            `json:"name,omitempty" eh:"optional"`
+	Username       string                 `json:"username,omitempty" eh:"optional"`
+	Password       string                 `json:"password,omitempty" eh:"optional"`
+	Email          string                 `json:"email,omitempty" eh:"optional"`
+	Roles          []string               `json:"roles,omitempty" eh:"optional"`
+	OrganizationId uuid.UUID              `json:"organizationId,omitempty" eh:"optional"`
+	Service        bool                   `json:"service,omitempty" eh:"optional"`
+	BreakGlass     bool                   `json:"breakGlass,omitempty" eh:"optional"`
+	Attributes     map[string]interface{} `json:"attributes,omitempty" eh:"optional"`
 }
 
 func (o *AccountUpdated) AddToRoles(item string) string {
 	o.Roles = append(o.Roles, item)
 	return item
 }
+
+type AccountLinkedIdentity struct {
+	Issuer  string `json:"issuer,omitempty" eh:"optional"`
+	Subject string `json:"subject,omitempty" eh:"optional"`
+	Email   string `json:"email,omitempty" eh:"optional"`
+}
+
+type AccountUnlinkedIdentity struct {
+	Issuer  string `json:"issuer,omitempty" eh:"optional"`
+	Subject string `json:"subject,omitempty" eh:"optional"`
+}
+
+type AccountCreatedApiKey struct {
+	KeyId     uuid.UUID  `json:"keyId,omitempty" eh:"optional"`
+	Name      string     `json:"name,omitempty" eh:"optional"`
+	Scopes    []string   `json:"scopes,omitempty" eh:"optional"`
+	Hash      string     `json:"hash,omitempty" eh:"optional"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty" eh:"optional"`
+}
+
+func (o *AccountCreatedApiKey) AddToScopes(item string) string {
+	o.Scopes = append(o.Scopes, item)
+	return item
+}
+
+type AccountRevokedApiKey struct {
+	KeyId uuid.UUID `json:"keyId,omitempty" eh:"optional"`
+}
+
+type AccountUsedApiKey struct {
+	KeyId uuid.UUID `json:"keyId,omitempty" eh:"optional"`
+}
+
+type ImpersonationStarted struct {
+	ImpersonationId uuid.UUID  `json:"impersonationId,omitempty" eh:"optional"`
+	ActorId         uuid.UUID  `json:"actorId,omitempty" eh:"optional"`
+	Reason          string     `json:"reason,omitempty" eh:"optional"`
+	ExpiresAt       *time.Time `json:"expiresAt,omitempty" eh:"optional"`
+}
+
+type ImpersonationEnded struct {
+	ImpersonationId uuid.UUID `json:"impersonationId,omitempty" eh:"optional"`
+	ActorId         uuid.UUID `json:"actorId,omitempty" eh:"optional"`
+}
+
+type AccountStartedSession struct {
+	SessionId uuid.UUID `json:"sessionId,omitempty" eh:"optional"`
+	Device    string    `json:"device,omitempty" eh:"optional"`
+	Ip        string    `json:"ip,omitempty" eh:"optional"`
+	UserAgent string    `json:"userAgent,omitempty" eh:"optional"`
+}
+
+type AccountTouchedSession struct {
+	SessionId uuid.UUID `json:"sessionId,omitempty" eh:"optional"`
+}
+
+type AccountRevokedSession struct {
+	SessionId uuid.UUID `json:"sessionId,omitempty" eh:"optional"`
+}
+
+type AccountAssignedRole struct {
+	AssignmentId   uuid.UUID  `json:"assignmentId,omitempty" eh:"optional"`
+	Role           string     `json:"role,omitempty" eh:"optional"`
+	ValidFrom      *time.Time `json:"validFrom,omitempty" eh:"optional"`
+	ValidUntil     *time.Time `json:"validUntil,omitempty" eh:"optional"`
+	AssignedBy     uuid.UUID  `json:"assignedBy,omitempty" eh:"optional"`
+	GrantRequestId uuid.UUID  `json:"grantRequestId,omitempty" eh:"optional"`
+}
+
+type AccountUnassignedRole struct {
+	AssignmentId uuid.UUID `json:"assignmentId,omitempty" eh:"optional"`
+}
+
+type AccountRoleExpired struct {
+	AssignmentId uuid.UUID `json:"assignmentId,omitempty" eh:"optional"`
+	Role         string    `json:"role,omitempty" eh:"optional"`
+}
+
+type AccountRegistered struct {
+	Name            *PersonName `json:"name,omitempty" eh:"optional"`
+	Username        string      `json:"username,omitempty" eh:"optional"`
+	Password        string      `json:"password,omitempty" eh:"optional"`
+	Email           string      `json:"email,omitempty" eh:"optional"`
+	Roles           []string    `json:"roles,omitempty" eh:"optional"`
+	OrganizationId  uuid.UUID   `json:"organizationId,omitempty" eh:"optional"`
+	PendingApproval bool        `json:"pendingApproval,omitempty" eh:"optional"`
+}
+
+func (o *AccountRegistered) AddToRoles(item string) string {
+	o.Roles = append(o.Roles, item)
+	return item
+}
+
+type AccountRegistrationApproved struct {
+	ApprovedBy uuid.UUID `json:"approvedBy,omitempty" eh:"optional"`
+}
+
+type AccountRegistrationRejected struct {
+	RejectedBy uuid.UUID `json:"rejectedBy,omitempty" eh:"optional"`
+	Reason     string    `json:"reason,omitempty" eh:"optional"`
+}
+
+type AccountPasswordChanged struct {
+	Password string `json:"password,omitempty" eh:"optional"`
+}
+
+type AccountEmailChangeRequested struct {
+	PendingEmail         string     `json:"pendingEmail,omitempty" eh:"optional"`
+	EmailChangeTokenHash string     `json:"emailChangeTokenHash,omitempty" eh:"optional"`
+	EmailChangeExpiresAt *time.Time `json:"emailChangeExpiresAt,omitempty" eh:"optional"`
+}
+
+type AccountEmailChangeConfirmed struct {
+	Email string `json:"email,omitempty" eh:"optional"`
+}
+
+type AccountAttributesChanged struct {
+	Attributes map[string]interface{} `json:"attributes,omitempty" eh:"optional"`
+}
+
+type AccountMerged struct {
+	SourceId        uuid.UUID           `json:"sourceId,omitempty" eh:"optional"`
+	Roles           []string            `json:"roles,omitempty" eh:"optional"`
+	RoleAssignments []*RoleAssignment   `json:"roleAssignments,omitempty" eh:"optional"`
+	Identities      []*ExternalIdentity `json:"identities,omitempty" eh:"optional"`
+	ApiKeys         []*ApiKey           `json:"apiKeys,omitempty" eh:"optional"`
+}
+
+func (o *AccountMerged) AddToRoles(item string) string {
+	o.Roles = append(o.Roles, item)
+	return item
+}
+
+func (o *AccountMerged) AddToRoleAssignments(item *RoleAssignment) *RoleAssignment {
+	o.RoleAssignments = append(o.RoleAssignments, item)
+	return item
+}
+
+func (o *AccountMerged) AddToIdentities(item *ExternalIdentity) *ExternalIdentity {
+	o.Identities = append(o.Identities, item)
+	return item
+}
+
+func (o *AccountMerged) AddToApiKeys(item *ApiKey) *ApiKey {
+	o.ApiKeys = append(o.ApiKeys, item)
+	return item
+}
+
+type AccountMergedInto struct {
+	TargetId uuid.UUID `json:"targetId,omitempty" eh:"optional"`
+}
+
+const (
+	GroupAddedMemberEvent     eventhorizon.EventType = "GroupAddedMember"
+	GroupAddedSubgroupEvent   eventhorizon.EventType = "GroupAddedSubgroup"
+	GroupCreatedEvent         eventhorizon.EventType = "GroupCreated"
+	GroupDeletedEvent         eventhorizon.EventType = "GroupDeleted"
+	GroupRemovedMemberEvent   eventhorizon.EventType = "GroupRemovedMember"
+	GroupRemovedSubgroupEvent eventhorizon.EventType = "GroupRemovedSubgroup"
+	GroupUpdatedEvent         eventhorizon.EventType = "GroupUpdated"
+)
+
+type GroupCreated struct {
+	Name        string   `json:"name,omitempty" eh:"optional"`
+	Description string   `json:"description,omitempty" eh:"optional"`
+	Roles       []string `json:"roles,omitempty" eh:"optional"`
+}
+
+func (o *GroupCreated) AddToRoles(item string) string {
+	o.Roles = append(o.Roles, item)
+	return item
+}
+
+type GroupUpdated struct {
+	Name        string   `json:"name,omitempty" eh:"optional"`
+	Description string   `json:"description,omitempty" eh:"optional"`
+	Roles       []string `json:"roles,omitempty" eh:"optional"`
+}
+
+func (o *GroupUpdated) AddToRoles(item string) string {
+	o.Roles = append(o.Roles, item)
+	return item
+}
+
+type GroupAddedMember struct {
+	AccountId uuid.UUID `json:"accountId,omitempty" eh:"optional"`
+}
+
+type GroupRemovedMember struct {
+	AccountId uuid.UUID `json:"accountId,omitempty" eh:"optional"`
+}
+
+type GroupAddedSubgroup struct {
+	GroupId uuid.UUID `json:"groupId,omitempty" eh:"optional"`
+}
+
+type GroupRemovedSubgroup struct {
+	GroupId uuid.UUID `json:"groupId,omitempty" eh:"optional"`
+}
+
+const (
+	OrganizationCreatedEvent eventhorizon.EventType = "OrganizationCreated"
+	OrganizationDeletedEvent eventhorizon.EventType = "OrganizationDeleted"
+	OrganizationUpdatedEvent eventhorizon.EventType = "OrganizationUpdated"
+)
+
+type OrganizationCreated struct {
+	Name        string `json:"name,omitempty" eh:"optional"`
+	Description string `json:"description,omitempty" eh:"optional"`
+}
+
+type OrganizationUpdated struct {
+	Name        string `json:"name,omitempty" eh:"optional"`
+	Description string `json:"description,omitempty" eh:"optional"`
+}
+
+const (
+	RoleGrantRequestApprovedEvent eventhorizon.EventType = "RoleGrantRequestApproved"
+	RoleGrantRequestCreatedEvent  eventhorizon.EventType = "RoleGrantRequestCreated"
+	RoleGrantRequestDeletedEvent  eventhorizon.EventType = "RoleGrantRequestDeleted"
+	RoleGrantRequestRejectedEvent eventhorizon.EventType = "RoleGrantRequestRejected"
+	RoleGrantRequestUpdatedEvent  eventhorizon.EventType = "RoleGrantRequestUpdated"
+)
+
+type RoleGrantRequestCreated struct {
+	AccountId   uuid.UUID  `json:"accountId,omitempty" eh:"optional"`
+	Role        string     `json:"role,omitempty" eh:"optional"`
+	Reason      string     `json:"reason,omitempty" eh:"optional"`
+	ValidFrom   *time.Time `json:"validFrom,omitempty" eh:"optional"`
+	ValidUntil  *time.Time `json:"validUntil,omitempty" eh:"optional"`
+	RequestedBy uuid.UUID  `json:"requestedBy,omitempty" eh:"optional"`
+}
+
+type RoleGrantRequestUpdated struct {
+	AccountId   uuid.UUID  `json:"accountId,omitempty" eh:"optional"`
+	Role        string     `json:"role,omitempty" eh:"optional"`
+	Reason      string     `json:"reason,omitempty" eh:"optional"`
+	ValidFrom   *time.Time `json:"validFrom,omitempty" eh:"optional"`
+	ValidUntil  *time.Time `json:"validUntil,omitempty" eh:"optional"`
+	RequestedBy uuid.UUID  `json:"requestedBy,omitempty" eh:"optional"`
+}
+
+type RoleGrantRequestApproved struct {
+	DecidedBy  uuid.UUID  `json:"decidedBy,omitempty" eh:"optional"`
+	Comment    string     `json:"comment,omitempty" eh:"optional"`
+	AccountId  uuid.UUID  `json:"accountId,omitempty" eh:"optional"`
+	Role       string     `json:"role,omitempty" eh:"optional"`
+	ValidFrom  *time.Time `json:"validFrom,omitempty" eh:"optional"`
+	ValidUntil *time.Time `json:"validUntil,omitempty" eh:"optional"`
+}
+
+type RoleGrantRequestRejected struct {
+	DecidedBy uuid.UUID `json:"decidedBy,omitempty" eh:"optional"`
+	Comment   string    `json:"comment,omitempty" eh:"optional"`
+}
+
+const (
+	RoleCreatedEvent eventhorizon.EventType = "RoleCreated"
+	RoleDeletedEvent eventhorizon.EventType = "RoleDeleted"
+	RoleUpdatedEvent eventhorizon.EventType = "RoleUpdated"
+)
+
+type RoleCreated struct {
+	Name        string   `json:"name,omitempty" eh:"optional"`
+	Description string   `json:"description,omitempty" eh:"optional"`
+	Inherits    []string `json:"inherits,omitempty" eh:"optional"`
+	Permissions []string `json:"permissions,omitempty" eh:"optional"`
+}
+
+func (o *RoleCreated) AddToInherits(item string) string {
+	o.Inherits = append(o.Inherits, item)
+	return item
+}
+
+func (o *RoleCreated) AddToPermissions(item string) string {
+	o.Permissions = append(o.Permissions, item)
+	return item
+}
+
+type RoleUpdated struct {
+	Name        string   `json:"name,omitempty" eh:"optional"`
+	Description string   `json:"description,omitempty" eh:"optional"`
+	Inherits    []string `json:"inherits,omitempty" eh:"optional"`
+	Permissions []string `json:"permissions,omitempty" eh:"optional"`
+}
+
+func (o *RoleUpdated) AddToInherits(item string) string {
+	o.Inherits = append(o.Inherits, item)
+	return item
+}
+
+func (o *RoleUpdated) AddToPermissions(item string) string {
+	o.Permissions = append(o.Permissions, item)
+	return item
+}
+
+const (
+	RelationTupleCreatedEvent eventhorizon.EventType = "RelationTupleCreated"
+	RelationTupleDeletedEvent eventhorizon.EventType = "RelationTupleDeleted"
+)
+
+type RelationTupleCreated struct {
+	Namespace string `json:"namespace,omitempty" eh:"optional"`
+	Object    string `json:"object,omitempty" eh:"optional"`
+	Relation  string `json:"relation,omitempty" eh:"optional"`
+	Subject   string `json:"subject,omitempty" eh:"optional"`
+}
+
+const (
+	InvitationAcceptedEvent eventhorizon.EventType = "InvitationAccepted"
+	InvitationCreatedEvent  eventhorizon.EventType = "InvitationCreated"
+	InvitationDeletedEvent  eventhorizon.EventType = "InvitationDeleted"
+	InvitationResentEvent   eventhorizon.EventType = "InvitationResent"
+	InvitationRevokedEvent  eventhorizon.EventType = "InvitationRevoked"
+)
+
+type InvitationCreated struct {
+	Email          string     `json:"email,omitempty" eh:"optional"`
+	Roles          []string   `json:"roles,omitempty" eh:"optional"`
+	OrganizationId uuid.UUID  `json:"organizationId,omitempty" eh:"optional"`
+	TokenHash      string     `json:"tokenHash,omitempty" eh:"optional"`
+	ExpiresAt      *time.Time `json:"expiresAt,omitempty" eh:"optional"`
+	InvitedBy      uuid.UUID  `json:"invitedBy,omitempty" eh:"optional"`
+}
+
+func (o *InvitationCreated) AddToRoles(item string) string {
+	o.Roles = append(o.Roles, item)
+	return item
+}
+
+type InvitationResent struct {
+	TokenHash string     `json:"tokenHash,omitempty" eh:"optional"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty" eh:"optional"`
+}
+
+type InvitationAccepted struct {
+	AccountId uuid.UUID `json:"accountId,omitempty" eh:"optional"`
+}
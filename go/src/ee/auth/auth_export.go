@@ -0,0 +1,185 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/looplab/eventhorizon"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// exportSecrets are the keys of values never exported, e.g. password hashes and second factor seeds
+var exportSecrets = map[string]bool{
+	"password":             true,
+	"oldPassword":          true,
+	"passwordHistory":      true,
+	"hash":                 true,
+	"tokenHash":            true,
+	"token":                true,
+	"emailChangeTokenHash": true,
+	"secret":               true,
+	"totpSecret":           true,
+}
+
+// AccountExport is the personal data of an account for an access request of its owner
+type AccountExport struct {
+	ExportedAt   time.Time           `json:"exportedAt"`
+	Account      interface{}         `json:"account"`
+	Sessions     []*Session          `json:"sessions"`
+	Identities   []*ExternalIdentity `json:"identities"`
+	LoginHistory []*LoginRecord      `json:"loginHistory"`
+	Events       []*ExportedEvent    `json:"events"`
+}
+
+type LoginRecord struct {
+	At        time.Time `json:"at"`
+	SessionId uuid.UUID `json:"sessionId"`
+	Device    string    `json:"device,omitempty"`
+	Ip        string    `json:"ip,omitempty"`
+	UserAgent string    `json:"userAgent,omitempty"`
+}
+
+type ExportedEvent struct {
+	Type      string      `json:"type"`
+	Version   int         `json:"version"`
+	Timestamp time.Time   `json:"timestamp"`
+	Data      interface{} `json:"data,omitempty"`
+}
+
+// ExportRouter exports the data of an account to its owner and to account managers.
+type ExportRouter struct {
+	PathPrefix   string
+	Accounts     *AccountQueryRepository
+	EventStore   eventhorizon.EventStore
+	PersonalData *PersonalData
+	ctx          context.Context
+}
+
+func NewExportRouter(pathPrefix string, newContext func(string) (ret context.Context),
+	accounts *AccountQueryRepository, eventStore eventhorizon.EventStore, personalData *PersonalData) (ret *ExportRouter) {
+	ret = &ExportRouter{
+		PathPrefix:   pathPrefix + "/" + "account",
+		Accounts:     accounts,
+		EventStore:   eventStore,
+		PersonalData: personalData,
+		ctx:          newContext("export"),
+	}
+	return
+}
+
+func (o *ExportRouter) Setup(router *mux.Router) (err error) {
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefix).Path("/{id}/export").
+		Name("ExportAccount").
+		HandlerFunc(o.Export)
+	return
+}
+
+func (o *ExportRouter) Export(w http.ResponseWriter, r *http.Request) {
+	principal := PrincipalFrom(r.Context())
+	if principal == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", ErrUnauthenticated)
+		return
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	if principal.IsImpersonated() {
+		writeError(w, http.StatusForbidden, "access_denied", errors.New("data can not be exported during impersonation"))
+		return
+	}
+	if id != principal.AccountId && !principal.HasPermission(PermissionManageAccounts) {
+		writeError(w, http.StatusForbidden, "access_denied", errors.New("data of other accounts is not accessible"))
+		return
+	}
+
+	var account *Account
+	if account, err = o.Accounts.FindById(id); err != nil || account == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	var ret *AccountExport
+	if ret, err = o.Build(account); err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", err)
+		return
+	}
+	w.Header().Set("Content-Disposition", "attachment; filename=\"account-"+id.String()+".json\"")
+	writeJSON(w, http.StatusOK, ret)
+}
+
+// Build collects the projection and the events of the account, secrets are removed and sealed values opened
+func (o *ExportRouter) Build(account *Account) (ret *AccountExport, err error) {
+	ret = &AccountExport{
+		ExportedAt:   time.Now(),
+		Sessions:     account.Sessions,
+		Identities:   account.Identities,
+		LoginHistory: []*LoginRecord{},
+		Events:       []*ExportedEvent{},
+	}
+	if ret.Account, err = o.scrub(account.Id, account); err != nil {
+		return
+	}
+
+	var events []eventhorizon.Event
+	if events, err = o.EventStore.Load(o.ctx, account.Id); err != nil {
+		return
+	}
+	for _, event := range events {
+		exported := &ExportedEvent{Type: string(event.EventType()), Version: event.Version(),
+			Timestamp: event.Timestamp()}
+		if event.Data() != nil {
+			if exported.Data, err = o.scrub(account.Id, event.Data()); err != nil {
+				return
+			}
+		}
+		ret.Events = append(ret.Events, exported)
+
+		if started, ok := event.Data().(*AccountStartedSession); ok {
+			ret.LoginHistory = append(ret.LoginHistory, &LoginRecord{At: event.Timestamp(),
+				SessionId: started.SessionId, Device: started.Device, Ip: started.Ip, UserAgent: started.UserAgent})
+		}
+	}
+	return
+}
+
+// scrub converts the value to plain JSON values without the secrets
+func (o *ExportRouter) scrub(accountId uuid.UUID, value interface{}) (ret interface{}, err error) {
+	var data []byte
+	if data, err = json.Marshal(value); err != nil {
+		return
+	}
+	if err = json.Unmarshal(data, &ret); err == nil {
+		ret = o.scrubValue(accountId, ret)
+	}
+	return
+}
+
+func (o *ExportRouter) scrubValue(accountId uuid.UUID, value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, item := range typed {
+			if exportSecrets[key] {
+				delete(typed, key)
+			} else {
+				typed[key] = o.scrubValue(accountId, item)
+			}
+		}
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = o.scrubValue(accountId, item)
+		}
+	case string:
+		if o.PersonalData != nil && strings.HasPrefix(typed, sealedPrefix) {
+			return o.PersonalData.Open(accountId, typed)
+		}
+	}
+	return value
+}
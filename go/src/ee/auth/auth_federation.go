@@ -0,0 +1,507 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-ee/utils"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/looplab/eventhorizon"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const federationStateCookie = "auth_federation_state"
+
+var ErrNotProvisioned = errors.New("no account is linked to the external identity")
+
+type Provisioning struct {
+	AutoCreate     bool     `json:"autoCreate,omitempty"`
+	LinkByEmail    bool     `json:"linkByEmail,omitempty"`
+	AllowedDomains []string `json:"allowedDomains,omitempty"`
+	DefaultRoles   []string `json:"defaultRoles,omitempty"`
+	// OrganizationId is the organization whose accounts are linked by email and created, none by default
+	OrganizationId uuid.UUID `json:"organizationId,omitempty"`
+}
+
+func (o *Provisioning) AllowsEmail(email string) (ret bool) {
+	if len(o.AllowedDomains) == 0 {
+		return true
+	}
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domain := strings.ToLower(email[at+1:])
+	for _, allowed := range o.AllowedDomains {
+		if strings.ToLower(allowed) == domain {
+			return true
+		}
+	}
+	return false
+}
+
+type IdentityProvider struct {
+	Name         string        `json:"name"`
+	Issuer       string        `json:"issuer"`
+	ClientId     string        `json:"clientId"`
+	ClientSecret string        `json:"clientSecret"`
+	AuthUrl      string        `json:"authUrl,omitempty"`
+	TokenUrl     string        `json:"tokenUrl,omitempty"`
+	UserInfoUrl  string        `json:"userInfoUrl,omitempty"`
+	RedirectUrl  string        `json:"redirectUrl"`
+	Scopes       []string      `json:"scopes,omitempty"`
+	Provisioning *Provisioning `json:"provisioning,omitempty"`
+}
+
+// Discover fills missing endpoints from the OpenID Connect discovery document of the issuer.
+func (o *IdentityProvider) Discover(client *http.Client) (err error) {
+	if o.AuthUrl != "" && o.TokenUrl != "" && o.UserInfoUrl != "" {
+		return
+	}
+
+	var resp *http.Response
+	if resp, err = client.Get(strings.TrimSuffix(o.Issuer, "/") + "/.well-known/openid-configuration"); err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("discovery of '%v' failed with status %v", o.Issuer, resp.StatusCode)
+		return
+	}
+
+	discovery := &struct {
+		AuthorizationEndpoint string `json:"authorization_endpoint"`
+		TokenEndpoint         string `json:"token_endpoint"`
+		UserInfoEndpoint      string `json:"userinfo_endpoint"`
+	}{}
+	if err = json.NewDecoder(resp.Body).Decode(discovery); err != nil {
+		return
+	}
+
+	if o.AuthUrl == "" {
+		o.AuthUrl = discovery.AuthorizationEndpoint
+	}
+	if o.TokenUrl == "" {
+		o.TokenUrl = discovery.TokenEndpoint
+	}
+	if o.UserInfoUrl == "" {
+		o.UserInfoUrl = discovery.UserInfoEndpoint
+	}
+	return
+}
+
+func (o *IdentityProvider) AuthCodeUrl(state string) string {
+	scopes := o.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "profile", "email"}
+	}
+	params := url.Values{
+		"response_type": {"code"},
+		"client_id":     {o.ClientId},
+		"redirect_uri":  {o.RedirectUrl},
+		"scope":         {strings.Join(scopes, " ")},
+		"state":         {state},
+	}
+	return o.AuthUrl + "?" + params.Encode()
+}
+
+func (o *IdentityProvider) Exchange(client *http.Client, code string) (ret string, err error) {
+	form := url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {code},
+		"redirect_uri": {o.RedirectUrl},
+	}
+
+	var req *http.Request
+	if req, err = http.NewRequest(http.MethodPost, o.TokenUrl, strings.NewReader(form.Encode())); err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(o.ClientId), url.QueryEscape(o.ClientSecret))
+
+	var resp *http.Response
+	if resp, err = client.Do(req); err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	token := &struct {
+		AccessToken      string `json:"access_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}{}
+	if err = json.NewDecoder(resp.Body).Decode(token); err != nil {
+		return
+	}
+
+	if token.Error != "" {
+		err = fmt.Errorf("token exchange with '%v' failed: %v %v", o.Issuer, token.Error, token.ErrorDescription)
+	} else if token.AccessToken == "" {
+		err = fmt.Errorf("token exchange with '%v' returned no access token", o.Issuer)
+	} else {
+		ret = token.AccessToken
+	}
+	return
+}
+
+func (o *IdentityProvider) UserInfo(client *http.Client, accessToken string) (ret *ExternalUser, err error) {
+	var req *http.Request
+	if req, err = http.NewRequest(http.MethodGet, o.UserInfoUrl, nil); err != nil {
+		return
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	var resp *http.Response
+	if resp, err = client.Do(req); err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("user info of '%v' failed with status %v", o.Issuer, resp.StatusCode)
+		return
+	}
+
+	user := &ExternalUser{}
+	if err = json.NewDecoder(resp.Body).Decode(user); err != nil {
+		return
+	}
+
+	if user.Subject == "" {
+		err = fmt.Errorf("user info of '%v' contains no subject", o.Issuer)
+	} else {
+		ret = user
+	}
+	return
+}
+
+func (o *IdentityProvider) provisioning() (ret *Provisioning) {
+	if ret = o.Provisioning; ret == nil {
+		ret = &Provisioning{}
+	}
+	return
+}
+
+type ExternalUser struct {
+	Subject           string `json:"sub"`
+	Email             string `json:"email,omitempty"`
+	EmailVerified     bool   `json:"email_verified,omitempty"`
+	Name              string `json:"name,omitempty"`
+	GivenName         string `json:"given_name,omitempty"`
+	FamilyName        string `json:"family_name,omitempty"`
+	PreferredUsername string `json:"preferred_username,omitempty"`
+}
+
+func (o *ExternalUser) Username() (ret string) {
+	if ret = o.PreferredUsername; ret == "" {
+		ret = o.Email
+	}
+	return
+}
+
+func (o *ExternalUser) PersonName() (ret *PersonName) {
+	ret = &PersonName{First: o.GivenName, Last: o.FamilyName}
+	if ret.First == "" && ret.Last == "" && o.Name != "" {
+		ret.First = o.Name
+	}
+	return
+}
+
+type FederationConfig struct {
+	Providers []*IdentityProvider `json:"providers"`
+}
+
+func LoadFederationConfig(file string) (ret *FederationConfig, err error) {
+	var data []byte
+	if data, err = ioutil.ReadFile(file); err != nil {
+		return
+	}
+	ret = &FederationConfig{}
+	err = json.Unmarshal(data, ret)
+	return
+}
+
+func (o *EsEngine) ImplementIdentityLinking(accounts *AccountQueryRepository) {
+	o.Account.ImplementIdentityLinking(accounts)
+}
+
+func (o *AccountAggregateEngine) ImplementIdentityLinking(accounts *AccountQueryRepository) {
+	linkPreparer := func(cmd *LinkIdentityAccount, entity *Account) (err error) {
+		if cmd.Issuer == "" || cmd.Subject == "" {
+			err = errors.New("issuer and subject of the external identity are required")
+		} else if entity.FindIdentity(cmd.Issuer, cmd.Subject) != nil {
+			err = fmt.Errorf("identity '%v' of '%v' is already linked", cmd.Subject, cmd.Issuer)
+		} else if accounts != nil {
+			var linked *Account
+			if linked, err = accounts.FindByIdentity(cmd.Issuer, cmd.Subject); err == nil && linked != nil {
+				err = fmt.Errorf("identity '%v' of '%v' is linked to another account", cmd.Subject, cmd.Issuer)
+			}
+		}
+		return
+	}
+	unlinkPreparer := func(cmd *UnlinkIdentityAccount, entity *Account) (err error) {
+		if entity.FindIdentity(cmd.Issuer, cmd.Subject) == nil {
+			err = fmt.Errorf("identity '%v' of '%v' is not linked", cmd.Subject, cmd.Issuer)
+		}
+		return
+	}
+
+	o.AggregateExecutors.Enabled.AddLinkIdentityPreparer(linkPreparer)
+	o.AggregateExecutors.Disabled.AddLinkIdentityPreparer(linkPreparer)
+	o.AggregateExecutors.Enabled.AddUnlinkIdentityPreparer(unlinkPreparer)
+	o.AggregateExecutors.Disabled.AddUnlinkIdentityPreparer(unlinkPreparer)
+
+	linkedHandler := func(event eventhorizon.Event, eventData *AccountLinkedIdentity, entity *Account) (err error) {
+		entity.AddToIdentities(&ExternalIdentity{
+			Issuer:   eventData.Issuer,
+			Subject:  eventData.Subject,
+			Email:    eventData.Email,
+			LinkedAt: utils.PtrTime(event.Timestamp()),
+		})
+		return
+	}
+	unlinkedHandler := func(event eventhorizon.Event, eventData *AccountUnlinkedIdentity, entity *Account) (err error) {
+		entity.RemoveIdentity(eventData.Issuer, eventData.Subject)
+		return
+	}
+
+	o.AggregateHandlers.Enabled.LinkedIdentityHandler = linkedHandler
+	o.AggregateHandlers.Disabled.LinkedIdentityHandler = linkedHandler
+	o.AggregateHandlers.Enabled.UnlinkedIdentityHandler = unlinkedHandler
+	o.AggregateHandlers.Disabled.UnlinkedIdentityHandler = unlinkedHandler
+}
+
+func (o *Account) FindIdentity(issuer string, subject string) (ret *ExternalIdentity) {
+	for _, identity := range o.Identities {
+		if identity.Issuer == issuer && identity.Subject == subject {
+			return identity
+		}
+	}
+	return
+}
+
+func (o *Account) RemoveIdentity(issuer string, subject string) {
+	identities := o.Identities[:0]
+	for _, identity := range o.Identities {
+		if identity.Issuer != issuer || identity.Subject != subject {
+			identities = append(identities, identity)
+		}
+	}
+	o.Identities = identities
+}
+
+func (o *AccountQueryRepository) FindByIdentity(issuer string, subject string) (ret *Account, err error) {
+	var accounts []*Account
+	if accounts, err = o.FindAll(); err == nil {
+		for _, account := range accounts {
+			if account.FindIdentity(issuer, subject) != nil {
+				return account, nil
+			}
+		}
+	}
+	return
+}
+
+type FederationRouter struct {
+	PathPrefix string
+	Providers  map[string]*IdentityProvider
+	Accounts   *AccountQueryRepository
+	CommandBus eventhorizon.CommandHandler
+	Sessions   *Sessions
+	Client     *http.Client
+	ctx        context.Context
+}
+
+func NewFederationRouter(pathPrefix string, newContext func(string) (ret context.Context),
+	commandBus eventhorizon.CommandHandler, accounts *AccountQueryRepository, sessions *Sessions,
+	config *FederationConfig) (ret *FederationRouter, err error) {
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	providers := make(map[string]*IdentityProvider, len(config.Providers))
+	for _, provider := range config.Providers {
+		if err = provider.Discover(client); err != nil {
+			return
+		}
+		providers[provider.Name] = provider
+	}
+
+	ret = &FederationRouter{
+		PathPrefix: pathPrefix + "/" + "federation",
+		Providers:  providers,
+		Accounts:   accounts,
+		CommandBus: commandBus,
+		Sessions:   sessions,
+		Client:     client,
+		ctx:        newContext("federation"),
+	}
+	return
+}
+
+func (o *FederationRouter) Setup(router *mux.Router) (err error) {
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefix).Path("/{provider}/login").
+		Name("FederationLogin").
+		HandlerFunc(o.Login)
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefix).Path("/{provider}/callback").
+		Name("FederationCallback").
+		HandlerFunc(o.Callback)
+	return
+}
+
+func (o *FederationRouter) Login(w http.ResponseWriter, r *http.Request) {
+	provider := o.Providers[mux.Vars(r)["provider"]]
+	if provider == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	state, err := randomToken()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", err)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     federationStateCookie,
+		Value:    state,
+		Path:     o.PathPrefix,
+		MaxAge:   600,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, provider.AuthCodeUrl(state), http.StatusFound)
+}
+
+func (o *FederationRouter) Callback(w http.ResponseWriter, r *http.Request) {
+	provider := o.Providers[mux.Vars(r)["provider"]]
+	if provider == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	query := r.URL.Query()
+	if providerError := query.Get("error"); providerError != "" {
+		writeError(w, http.StatusUnauthorized, providerError, errors.New(query.Get("error_description")))
+		return
+	}
+
+	cookie, err := r.Cookie(federationStateCookie)
+	if err != nil || cookie.Value == "" || cookie.Value != query.Get("state") {
+		writeError(w, http.StatusBadRequest, "invalid_request", errors.New("state mismatch"))
+		return
+	}
+	http.SetCookie(w, &http.Cookie{Name: federationStateCookie, Path: o.PathPrefix, MaxAge: -1})
+
+	var accessToken string
+	if accessToken, err = provider.Exchange(o.Client, query.Get("code")); err != nil {
+		writeError(w, http.StatusUnauthorized, "access_denied", err)
+		return
+	}
+
+	var user *ExternalUser
+	if user, err = provider.UserInfo(o.Client, accessToken); err != nil {
+		writeError(w, http.StatusUnauthorized, "access_denied", err)
+		return
+	}
+
+	var account *Account
+	if account, err = o.resolveAccount(provider, user); err != nil {
+		writeError(w, http.StatusForbidden, "access_denied", err)
+		return
+	}
+
+	if err = verifyAccount(account); err != nil {
+		writeError(w, http.StatusForbidden, "access_denied", err)
+		return
+	}
+
+	var token *TokenResponse
+	if token, err = o.Sessions.Start(account, provider.Name, r); err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, token)
+}
+
+func (o *FederationRouter) resolveAccount(provider *IdentityProvider, user *ExternalUser) (ret *Account, err error) {
+	if ret, err = o.Accounts.FindByIdentity(provider.Issuer, user.Subject); err != nil || ret != nil {
+		return
+	}
+
+	// an email is only unique within an organization, an ambiguous or not usable match is not linked
+	provisioning := provider.provisioning()
+	if provisioning.LinkByEmail && user.EmailVerified && user.Email != "" {
+		var matches []*Account
+		if matches, err = o.Accounts.ForTenant(provisioning.OrganizationId).FindAllByEmail(user.Email); err != nil {
+			return
+		}
+		if len(matches) > 1 {
+			err = fmt.Errorf("email '%v' belongs to more than one account", user.Email)
+			return
+		} else if len(matches) == 1 {
+			if err = verifyAccount(matches[0]); err != nil {
+				return
+			}
+			ret = matches[0]
+		}
+	}
+
+	if ret == nil {
+		if !provisioning.AutoCreate {
+			err = ErrNotProvisioned
+			return
+		}
+		if len(provisioning.AllowedDomains) > 0 && !(user.EmailVerified && provisioning.AllowsEmail(user.Email)) {
+			err = fmt.Errorf("email '%v' is not allowed for auto provisioning", user.Email)
+			return
+		}
+		if ret, err = o.createAccount(provisioning, user); err != nil {
+			return
+		}
+	}
+
+	// the command is sealed by the preparers, the returned account keeps the plain values
+	link := &LinkIdentityAccount{Id: ret.Id, Issuer: provider.Issuer, Subject: user.Subject, Email: user.Email}
+	if err = o.CommandBus.HandleCommand(o.ctx, link); err == nil {
+		ret.AddToIdentities(&ExternalIdentity{Issuer: provider.Issuer, Subject: user.Subject, Email: user.Email,
+			LinkedAt: utils.PtrTime(time.Now())})
+	}
+	return
+}
+
+// createAccount creates the account without password, it logs in over its identity provider only
+func (o *FederationRouter) createAccount(provisioning *Provisioning, user *ExternalUser) (ret *Account, err error) {
+	account := &Account{
+		Id:             uuid.New(),
+		Name:           user.PersonName(),
+		Username:       user.Username(),
+		Email:          user.Email,
+		Roles:          provisioning.DefaultRoles,
+		OrganizationId: provisioning.OrganizationId,
+	}
+	create := &CreateAccount{Id: account.Id, Name: account.Name, Username: account.Username, Email: account.Email,
+		Roles: account.Roles, OrganizationId: account.OrganizationId}
+	if err = o.CommandBus.HandleCommand(o.ctx, create); err == nil {
+		ret = account
+	}
+	return
+}
+
+func randomToken() (ret string, err error) {
+	buf := make([]byte, 32)
+	if _, err = rand.Read(buf); err == nil {
+		ret = base64.RawURLEncoding.EncodeToString(buf)
+	}
+	return
+}
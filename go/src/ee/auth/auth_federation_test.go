@@ -0,0 +1,98 @@
+package auth
+
+import (
+	"context"
+	"github.com/go-ee/utils"
+	"github.com/google/uuid"
+	"github.com/looplab/eventhorizon"
+	"testing"
+	"time"
+)
+
+// linking by email stays in the organization of the provider and never picks one of several accounts
+func TestFederationLinksByEmailOfTenant(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryRepo(t, AccountAggregateType, func() eventhorizon.Entity { return NewAccountDefault() })
+	organizationId := uuid.New()
+	for _, account := range []*Account{
+		{Id: uuid.New(), Email: "alice@example.com", OrganizationId: organizationId},
+		{Id: uuid.New(), Email: "bob@example.com"},
+		{Id: uuid.New(), Email: "carol@example.com", OrganizationId: organizationId},
+		{Id: uuid.New(), Email: "CAROL@example.com", OrganizationId: organizationId},
+		{Id: uuid.New(), Email: "dave@example.com", OrganizationId: organizationId, Disabled: true},
+		{Id: uuid.New(), Email: "erin@example.com", OrganizationId: organizationId,
+			DeletedAt: utils.PtrTime(time.Now())},
+		{Id: uuid.New(), Email: "frank@example.com", OrganizationId: organizationId, PendingApproval: true},
+		{Id: uuid.New(), Email: "grace@example.com", OrganizationId: organizationId, MergedInto: uuid.New(),
+			Disabled: true},
+	} {
+		if err := repo.Save(ctx, account); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var linked []uuid.UUID
+	router := &FederationRouter{Accounts: NewAccountQueryRepositoryFull(repo, ctx), ctx: ctx,
+		CommandBus: eventhorizon.CommandHandlerFunc(func(ctx context.Context, cmd eventhorizon.Command) error {
+			linked = append(linked, cmd.AggregateID())
+			return nil
+		})}
+	provider := &IdentityProvider{Issuer: "https://idp.example.com",
+		Provisioning: &Provisioning{LinkByEmail: true, OrganizationId: organizationId}}
+
+	for _, item := range []struct {
+		email    string
+		accepted bool
+	}{
+		{"alice@example.com", true},
+		{"bob@example.com", false},
+		{"carol@example.com", false},
+		{"dave@example.com", false},
+		{"erin@example.com", false},
+		{"frank@example.com", false},
+		{"grace@example.com", false},
+	} {
+		linked = nil
+		account, err := router.resolveAccount(provider, &ExternalUser{Subject: item.email, Email: item.email,
+			EmailVerified: true})
+		if (err == nil) != item.accepted {
+			t.Errorf("%v answered %v", item.email, err)
+		}
+		if !item.accepted && len(linked) > 0 {
+			t.Errorf("identity of %v is linked", item.email)
+		}
+		if item.accepted && (account == nil || len(linked) != 1 || linked[0] != account.Id) {
+			t.Errorf("identity of %v is not linked", item.email)
+		}
+	}
+}
+
+// federated accounts are created without password, no password login and no forced password change applies
+func TestFederationCreatesAccountsWithoutPassword(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryRepo(t, AccountAggregateType, func() eventhorizon.Entity { return NewAccountDefault() })
+	accounts := NewAccountQueryRepositoryFull(repo, ctx)
+
+	var created *CreateAccount
+	router := &FederationRouter{Accounts: accounts, ctx: ctx,
+		CommandBus: eventhorizon.CommandHandlerFunc(func(ctx context.Context, cmd eventhorizon.Command) error {
+			if create, ok := cmd.(*CreateAccount); ok {
+				created = create
+			}
+			return nil
+		})}
+	provider := &IdentityProvider{Issuer: "https://idp.example.com", Provisioning: &Provisioning{AutoCreate: true}}
+	if _, err := router.resolveAccount(provider, &ExternalUser{Subject: "42", Email: "alice@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if created == nil || created.Password != "" {
+		t.Fatalf("federated account is created with password")
+	}
+
+	if err := repo.Save(ctx, &Account{Id: created.Id, Username: "alice"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := accounts.FindByCredentials("alice", ""); err == nil {
+		t.Errorf("account without password logs in with an empty password")
+	}
+}
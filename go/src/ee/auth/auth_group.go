@@ -0,0 +1,266 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/looplab/eventhorizon"
+	"time"
+)
+
+const PermissionManageGroups = "auth:groups"
+
+// RoleResolver determines the roles an account effectively has, own roles and inherited ones.
+type RoleResolver interface {
+	EffectiveRoles(account *Account) (ret []string, err error)
+}
+
+// RoleGrant is a role of an account and the group it is inherited from, empty for own roles
+type RoleGrant struct {
+	Role          string     `json:"role"`
+	Group         string     `json:"group,omitempty"`
+	ValidUntil    *time.Time `json:"validUntil,omitempty"`
+	InheritedFrom string     `json:"inheritedFrom,omitempty"`
+}
+
+// RoleExplainer is implemented by role resolvers able to tell the origin of roles
+type RoleExplainer interface {
+	RoleGrants(account *Account) (ret []*RoleGrant, err error)
+}
+
+func (o *Group) HasMember(accountId uuid.UUID) bool {
+	return containsId(o.Members, accountId)
+}
+
+func (o *Group) HasSubgroup(groupId uuid.UUID) bool {
+	return containsId(o.Subgroups, groupId)
+}
+
+func (o *Group) RemoveMember(accountId uuid.UUID) {
+	o.Members = removeId(o.Members, accountId)
+}
+
+func (o *Group) RemoveSubgroup(groupId uuid.UUID) {
+	o.Subgroups = removeId(o.Subgroups, groupId)
+}
+
+func (o *GroupQueryRepository) FindByName(name string) (ret *Group, err error) {
+	var groups []*Group
+	if groups, err = o.FindAll(); err != nil {
+		return
+	}
+	for _, group := range groups {
+		if group.Name == name {
+			return group, nil
+		}
+	}
+	return
+}
+
+// IsDescendant checks whether the group is reachable from the start group over subgroups
+func (o *GroupQueryRepository) IsDescendant(startId uuid.UUID, groupId uuid.UUID) (ret bool, err error) {
+	visited := map[uuid.UUID]bool{}
+	pending := []uuid.UUID{startId}
+	for len(pending) > 0 {
+		current := pending[0]
+		pending = pending[1:]
+		if current == groupId {
+			ret = true
+			return
+		}
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+
+		var group *Group
+		if group, err = o.FindById(current); err != nil {
+			return
+		}
+		if group != nil {
+			pending = append(pending, group.Subgroups...)
+		}
+	}
+	return
+}
+
+func (o *EsEngine) ImplementGroups(groups *GroupQueryRepository) {
+	o.Group.ImplementGroups(groups)
+}
+
+func (o *GroupAggregateEngine) ImplementGroups(groups *GroupQueryRepository) {
+	o.AggregateExecutors.Initial.AddCreatePreparer(func(cmd *CreateGroup, entity *Group) (err error) {
+		if cmd.Name == "" {
+			err = errors.New("group name is required")
+		} else if existing, findErr := groups.FindByName(cmd.Name); findErr != nil {
+			err = findErr
+		} else if existing != nil {
+			err = fmt.Errorf("group '%v' exists already", cmd.Name)
+		}
+		return
+	})
+
+	o.AggregateExecutors.Exist.AddUpdatePreparer(func(cmd *UpdateGroup, entity *Group) (err error) {
+		if cmd.Name != "" && cmd.Name != entity.Name {
+			var existing *Group
+			if existing, err = groups.FindByName(cmd.Name); err == nil && existing != nil {
+				err = fmt.Errorf("group '%v' exists already", cmd.Name)
+			}
+		}
+		return
+	})
+
+	o.AggregateExecutors.Exist.AddAddMemberPreparer(func(cmd *AddMemberGroup, entity *Group) (err error) {
+		if cmd.AccountId == uuid.Nil {
+			err = errors.New("account id is required")
+		} else if entity.HasMember(cmd.AccountId) {
+			err = fmt.Errorf("account '%v' is member already", cmd.AccountId)
+		}
+		return
+	})
+
+	o.AggregateExecutors.Exist.AddRemoveMemberPreparer(func(cmd *RemoveMemberGroup, entity *Group) (err error) {
+		if !entity.HasMember(cmd.AccountId) {
+			err = fmt.Errorf("account '%v' is no member", cmd.AccountId)
+		}
+		return
+	})
+
+	o.AggregateExecutors.Exist.AddAddSubgroupPreparer(func(cmd *AddSubgroupGroup, entity *Group) (err error) {
+		if cmd.GroupId == uuid.Nil {
+			err = errors.New("group id is required")
+		} else if cmd.GroupId == entity.Id {
+			err = errors.New("group can not be its own subgroup")
+		} else if entity.HasSubgroup(cmd.GroupId) {
+			err = fmt.Errorf("group '%v' is subgroup already", cmd.GroupId)
+		} else {
+			var cycle bool
+			if cycle, err = groups.IsDescendant(cmd.GroupId, entity.Id); err == nil && cycle {
+				err = fmt.Errorf("group '%v' contains the group already", cmd.GroupId)
+			}
+		}
+		return
+	})
+
+	o.AggregateExecutors.Exist.AddRemoveSubgroupPreparer(func(cmd *RemoveSubgroupGroup, entity *Group) (err error) {
+		if !entity.HasSubgroup(cmd.GroupId) {
+			err = fmt.Errorf("group '%v' is no subgroup", cmd.GroupId)
+		}
+		return
+	})
+
+	o.AggregateHandlers.Exist.AddedMemberHandler =
+		func(event eventhorizon.Event, eventData *GroupAddedMember, entity *Group) (err error) {
+			entity.AddToMembers(eventData.AccountId)
+			return
+		}
+	o.AggregateHandlers.Exist.RemovedMemberHandler =
+		func(event eventhorizon.Event, eventData *GroupRemovedMember, entity *Group) (err error) {
+			entity.RemoveMember(eventData.AccountId)
+			return
+		}
+	o.AggregateHandlers.Exist.AddedSubgroupHandler =
+		func(event eventhorizon.Event, eventData *GroupAddedSubgroup, entity *Group) (err error) {
+			entity.AddToSubgroups(eventData.GroupId)
+			return
+		}
+	o.AggregateHandlers.Exist.RemovedSubgroupHandler =
+		func(event eventhorizon.Event, eventData *GroupRemovedSubgroup, entity *Group) (err error) {
+			entity.RemoveSubgroup(eventData.GroupId)
+			return
+		}
+}
+
+// GroupRoleResolver adds the roles of all groups the account belongs to, directly or over parent groups.
+type GroupRoleResolver struct {
+	Groups *GroupQueryRepository
+}
+
+func NewGroupRoleResolver(groups *GroupQueryRepository) (ret *GroupRoleResolver) {
+	ret = &GroupRoleResolver{Groups: groups}
+	return
+}
+
+func (o *GroupRoleResolver) EffectiveRoles(account *Account) (ret []string, err error) {
+	var grants []*RoleGrant
+	if grants, err = o.RoleGrants(account); err != nil {
+		return
+	}
+	for _, grant := range grants {
+		ret = append(ret, grant.Role)
+	}
+	return
+}
+
+// RoleGrants explains where the roles of the account come from, the nearest group of a role wins
+func (o *GroupRoleResolver) RoleGrants(account *Account) (ret []*RoleGrant, err error) {
+	var groups []*Group
+	if groups, err = o.Groups.FindAll(); err != nil {
+		return
+	}
+
+	parents := map[uuid.UUID][]*Group{}
+	var pending []*Group
+	for _, group := range groups {
+		for _, subgroupId := range group.Subgroups {
+			parents[subgroupId] = append(parents[subgroupId], group)
+		}
+		if group.HasMember(account.Id) {
+			pending = append(pending, group)
+		}
+	}
+
+	granted := map[string]bool{}
+	for _, role := range account.Roles {
+		if !granted[role] {
+			granted[role] = true
+			ret = append(ret, &RoleGrant{Role: role})
+		}
+	}
+
+	now := time.Now()
+	for _, assignment := range account.RoleAssignments {
+		if assignment.IsActive(now) && !granted[assignment.Role] {
+			granted[assignment.Role] = true
+			ret = append(ret, &RoleGrant{Role: assignment.Role, ValidUntil: assignment.ValidUntil})
+		}
+	}
+
+	visited := map[uuid.UUID]bool{}
+	for len(pending) > 0 {
+		group := pending[0]
+		pending = pending[1:]
+		if visited[group.Id] {
+			continue
+		}
+		visited[group.Id] = true
+
+		for _, role := range group.Roles {
+			if !granted[role] {
+				granted[role] = true
+				ret = append(ret, &RoleGrant{Role: role, Group: group.Name})
+			}
+		}
+		pending = append(pending, parents[group.Id]...)
+	}
+	return
+}
+
+func containsId(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, item := range ids {
+		if item == id {
+			return true
+		}
+	}
+	return false
+}
+
+func removeId(ids []uuid.UUID, id uuid.UUID) (ret []uuid.UUID) {
+	ret = ids[:0]
+	for _, item := range ids {
+		if item != id {
+			ret = append(ret, item)
+		}
+	}
+	return
+}
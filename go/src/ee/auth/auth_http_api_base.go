@@ -88,6 +88,18 @@ func (o *AccountHttpCommandHandler) Disable(w http.ResponseWriter, r *http.Reque
 	o.HandleCommand(&DisableAccount{Id: id}, w, r)
 }
 
+func (o *AccountHttpCommandHandler) WarnInactivity(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&WarnInactivityAccount{Id: id}, w, r)
+}
+
+func (o *AccountHttpCommandHandler) ChangeAttributes(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&ChangeAttributesAccount{Id: id}, w, r)
+}
+
 func (o *AccountHttpCommandHandler) Update(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, _ := uuid.Parse(vars["id"])
@@ -118,6 +130,156 @@ func (o *AccountHttpCommandHandler) Login(w http.ResponseWriter, r *http.Request
 	o.HandleCommand(&LoginAccount{Id: id}, w, r)
 }
 
+func (o *AccountHttpCommandHandler) LinkIdentity(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&LinkIdentityAccount{Id: id}, w, r)
+}
+
+func (o *AccountHttpCommandHandler) UnlinkIdentity(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&UnlinkIdentityAccount{Id: id}, w, r)
+}
+
+func (o *AccountHttpCommandHandler) CreateApiKey(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&CreateApiKeyAccount{Id: id}, w, r)
+}
+
+func (o *AccountHttpCommandHandler) RevokeApiKey(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&RevokeApiKeyAccount{Id: id}, w, r)
+}
+
+func (o *AccountHttpCommandHandler) UseApiKey(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&UseApiKeyAccount{Id: id}, w, r)
+}
+
+func (o *AccountHttpCommandHandler) Impersonate(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&ImpersonateAccount{Id: id}, w, r)
+}
+
+func (o *AccountHttpCommandHandler) EndImpersonation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&EndImpersonationAccount{Id: id}, w, r)
+}
+
+func (o *AccountHttpCommandHandler) StartSession(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&StartSessionAccount{Id: id}, w, r)
+}
+
+func (o *AccountHttpCommandHandler) TouchSession(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&TouchSessionAccount{Id: id}, w, r)
+}
+
+func (o *AccountHttpCommandHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&RevokeSessionAccount{Id: id}, w, r)
+}
+
+func (o *AccountHttpCommandHandler) RevokeSessions(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&RevokeSessionsAccount{Id: id}, w, r)
+}
+
+func (o *AccountHttpCommandHandler) AssignRole(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&AssignRoleAccount{Id: id}, w, r)
+}
+
+func (o *AccountHttpCommandHandler) UnassignRole(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&UnassignRoleAccount{Id: id}, w, r)
+}
+
+func (o *AccountHttpCommandHandler) ExpireRole(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&ExpireRoleAccount{Id: id}, w, r)
+}
+
+func (o *AccountHttpCommandHandler) Register(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&RegisterAccount{Id: id}, w, r)
+}
+
+func (o *AccountHttpCommandHandler) ApproveRegistration(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&ApproveRegistrationAccount{Id: id}, w, r)
+}
+
+func (o *AccountHttpCommandHandler) RejectRegistration(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&RejectRegistrationAccount{Id: id}, w, r)
+}
+
+func (o *AccountHttpCommandHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&ChangePasswordAccount{Id: id}, w, r)
+}
+
+func (o *AccountHttpCommandHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&RestoreAccount{Id: id}, w, r)
+}
+
+func (o *AccountHttpCommandHandler) Purge(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&PurgeAccount{Id: id}, w, r)
+}
+
+func (o *AccountHttpCommandHandler) Forget(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&ForgetAccount{Id: id}, w, r)
+}
+
+func (o *AccountHttpCommandHandler) RequestEmailChange(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&RequestEmailChangeAccount{Id: id}, w, r)
+}
+
+func (o *AccountHttpCommandHandler) ConfirmEmailChange(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&ConfirmEmailChangeAccount{Id: id}, w, r)
+}
+
+func (o *AccountHttpCommandHandler) Merge(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&MergeAccount{Id: id}, w, r)
+}
+
+func (o *AccountHttpCommandHandler) MergeInto(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&MergeIntoAccount{Id: id}, w, r)
+}
+
 type AccountRouter struct {
 	PathPrefix        string
 	PathPrefixIdBased string
@@ -167,6 +329,81 @@ func (o *AccountRouter) Setup(router *mux.Router) (err error) {
 	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/send-disabled-confirmation").
 		Name("SendDisabledConfirmationAccount").
 		HandlerFunc(o.CommandHandler.SendDisabledConfirmation)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/link-identity").
+		Name("LinkIdentityAccount").
+		HandlerFunc(o.CommandHandler.LinkIdentity)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/unlink-identity").
+		Name("UnlinkIdentityAccount").
+		HandlerFunc(o.CommandHandler.UnlinkIdentity)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/create-api-key").
+		Name("CreateApiKeyAccount").
+		HandlerFunc(o.CommandHandler.CreateApiKey)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/revoke-api-key").
+		Name("RevokeApiKeyAccount").
+		HandlerFunc(o.CommandHandler.RevokeApiKey)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/use-api-key").
+		Name("UseApiKeyAccount").
+		HandlerFunc(o.CommandHandler.UseApiKey)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/impersonate").
+		Name("ImpersonateAccount").
+		HandlerFunc(o.CommandHandler.Impersonate)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/end-impersonation").
+		Name("EndImpersonationAccount").
+		HandlerFunc(o.CommandHandler.EndImpersonation)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/start-session").
+		Name("StartSessionAccount").
+		HandlerFunc(o.CommandHandler.StartSession)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/touch-session").
+		Name("TouchSessionAccount").
+		HandlerFunc(o.CommandHandler.TouchSession)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/revoke-session").
+		Name("RevokeSessionAccount").
+		HandlerFunc(o.CommandHandler.RevokeSession)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/revoke-sessions").
+		Name("RevokeSessionsAccount").
+		HandlerFunc(o.CommandHandler.RevokeSessions)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/assign-role").
+		Name("AssignRoleAccount").
+		HandlerFunc(o.CommandHandler.AssignRole)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/unassign-role").
+		Name("UnassignRoleAccount").
+		HandlerFunc(o.CommandHandler.UnassignRole)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/expire-role").
+		Name("ExpireRoleAccount").
+		HandlerFunc(o.CommandHandler.ExpireRole)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/register").
+		Name("RegisterAccount").
+		HandlerFunc(o.CommandHandler.Register)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/approve-registration").
+		Name("ApproveRegistrationAccount").
+		HandlerFunc(o.CommandHandler.ApproveRegistration)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/reject-registration").
+		Name("RejectRegistrationAccount").
+		HandlerFunc(o.CommandHandler.RejectRegistration)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/change-password").
+		Name("ChangePasswordAccount").
+		HandlerFunc(o.CommandHandler.ChangePassword)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/restore").
+		Name("RestoreAccount").
+		HandlerFunc(o.CommandHandler.Restore)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/purge").
+		Name("PurgeAccount").
+		HandlerFunc(o.CommandHandler.Purge)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/forget").
+		Name("ForgetAccount").
+		HandlerFunc(o.CommandHandler.Forget)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/request-email-change").
+		Name("RequestEmailChangeAccount").
+		HandlerFunc(o.CommandHandler.RequestEmailChange)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/confirm-email-change").
+		Name("ConfirmEmailChangeAccount").
+		HandlerFunc(o.CommandHandler.ConfirmEmailChange)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/merge").
+		Name("MergeAccount").
+		HandlerFunc(o.CommandHandler.Merge)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/merge-into").
+		Name("MergeIntoAccount").
+		HandlerFunc(o.CommandHandler.MergeInto)
 	router.Methods(http.MethodPut).PathPrefix(o.PathPrefixIdBased).Path("/{id}").
 		Name("UpdateAccount").
 		HandlerFunc(o.CommandHandler.Update)
@@ -176,6 +413,12 @@ func (o *AccountRouter) Setup(router *mux.Router) (err error) {
 	router.Methods(http.MethodPut).PathPrefix(o.PathPrefixIdBased).Path("/{id}/disable").
 		Name("DisableAccount").
 		HandlerFunc(o.CommandHandler.Disable)
+	router.Methods(http.MethodPut).PathPrefix(o.PathPrefixIdBased).Path("/{id}/warn-inactivity").
+		Name("WarnInactivityAccount").
+		HandlerFunc(o.CommandHandler.WarnInactivity)
+	router.Methods(http.MethodPut).PathPrefix(o.PathPrefixIdBased).Path("/{id}/change-attributes").
+		Name("ChangeAttributesAccount").
+		HandlerFunc(o.CommandHandler.ChangeAttributes)
 	router.Methods(http.MethodDelete).PathPrefix(o.PathPrefixIdBased).Path("/{id}").
 		Name("DeleteAccount").
 		HandlerFunc(o.CommandHandler.Delete)
@@ -191,31 +434,982 @@ func (o *AccountRouter) Setup(router *mux.Router) (err error) {
 	return
 }
 
-type Router struct {
-	PathPrefix    string
-	AccountRouter *AccountRouter
+type GroupHttpQueryHandler struct {
+	*eh.HttpQueryHandler
+	QueryRepository *GroupQueryRepository
 }
 
-func NewRouter(pathPrefix string, newContext func(string) (ret context.Context), esEngine *EsEngine) (ret *Router, err error) {
-	pathPrefix = pathPrefix + "/" + "auth"
+func NewGroupHttpQueryHandlerFull(httpQueryHandler *eh.HttpQueryHandler, queryRepository *GroupQueryRepository) (ret *GroupHttpQueryHandler) {
+	ret = &GroupHttpQueryHandler{
+		HttpQueryHandler: httpQueryHandler,
+		QueryRepository:  queryRepository,
+	}
+	return
+}
 
-	var projectorAccount *AccountProjector
-	if projectorAccount, err = esEngine.Account.RegisterAccountProjector(string(AccountAggregateType),
-		esEngine.Account.AggregateHandlers, esEngine.Account.Events); err != nil {
-		return
+func (o *GroupHttpQueryHandler) FindAll(w http.ResponseWriter, r *http.Request) {
+	ret, err := o.QueryRepository.FindAll()
+	o.HandleResult(ret, err, "GroupFindAll", w, r)
+}
+
+func (o *GroupHttpQueryHandler) FindById(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	ret, err := o.QueryRepository.FindById(id)
+	o.HandleResult(ret, err, "GroupFindById", w, r)
+}
+
+func (o *GroupHttpQueryHandler) CountAll(w http.ResponseWriter, r *http.Request) {
+	ret, err := o.QueryRepository.CountAll()
+	o.HandleResult(ret, err, "GroupCountAll", w, r)
+}
+
+func (o *GroupHttpQueryHandler) CountById(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	ret, err := o.QueryRepository.CountById(id)
+	o.HandleResult(ret, err, "GroupCountById", w, r)
+}
+
+func (o *GroupHttpQueryHandler) ExistAll(w http.ResponseWriter, r *http.Request) {
+	ret, err := o.QueryRepository.ExistAll()
+	o.HandleResult(ret, err, "GroupExistAll", w, r)
+}
+
+func (o *GroupHttpQueryHandler) ExistById(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	ret, err := o.QueryRepository.ExistById(id)
+	o.HandleResult(ret, err, "GroupExistById", w, r)
+}
+
+type GroupHttpCommandHandler struct {
+	*eh.HttpCommandHandler
+}
+
+func NewGroupHttpCommandHandlerFull(httpCommandHandler *eh.HttpCommandHandler) (ret *GroupHttpCommandHandler) {
+	ret = &GroupHttpCommandHandler{
+		HttpCommandHandler: httpCommandHandler,
 	}
+	return
+}
 
-	accountRouter := NewAccountRouter(pathPrefix, newContext, esEngine.CommandBus, projectorAccount.Repo)
+func (o *GroupHttpCommandHandler) Create(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&CreateGroup{Id: id}, w, r)
+}
 
-	ret = &Router{
-		PathPrefix:    pathPrefix,
-		AccountRouter: accountRouter,
+func (o *GroupHttpCommandHandler) Update(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&UpdateGroup{Id: id}, w, r)
+}
+
+func (o *GroupHttpCommandHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&DeleteGroup{Id: id}, w, r)
+}
+
+func (o *GroupHttpCommandHandler) AddMember(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&AddMemberGroup{Id: id}, w, r)
+}
+
+func (o *GroupHttpCommandHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&RemoveMemberGroup{Id: id}, w, r)
+}
+
+func (o *GroupHttpCommandHandler) AddSubgroup(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&AddSubgroupGroup{Id: id}, w, r)
+}
+
+func (o *GroupHttpCommandHandler) RemoveSubgroup(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&RemoveSubgroupGroup{Id: id}, w, r)
+}
+
+type GroupRouter struct {
+	PathPrefix        string
+	PathPrefixIdBased string
+	QueryHandler      *GroupHttpQueryHandler
+	CommandHandler    *GroupHttpCommandHandler
+}
+
+func NewGroupRouter(pathPrefix string, newContext func(string) (ret context.Context), commandBus *bus.CommandHandler,
+	repo eventhorizon.ReadRepo) (ret *GroupRouter) {
+	pathPrefixIdBased := pathPrefix + "/" + "group"
+	pathPrefix = pathPrefix + "/" + "groups"
+	ctx := newContext("group")
+	httpQueryHandler := eh.NewHttpQueryHandlerFull()
+	httpCommandHandler := eh.NewHttpCommandHandlerFull(ctx, commandBus)
+
+	queryRepository := NewGroupQueryRepositoryFull(repo, ctx)
+	queryHandler := NewGroupHttpQueryHandlerFull(httpQueryHandler, queryRepository)
+	commandHandler := NewGroupHttpCommandHandlerFull(httpCommandHandler)
+	ret = &GroupRouter{
+		PathPrefix:        pathPrefix,
+		PathPrefixIdBased: pathPrefixIdBased,
+		QueryHandler:      queryHandler,
+		CommandHandler:    commandHandler,
 	}
 	return
 }
 
-func (o *Router) Setup(router *mux.Router) (err error) {
-	if err = o.AccountRouter.Setup(router); err != nil {
+func (o *GroupRouter) Setup(router *mux.Router) (err error) {
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefixIdBased).Path("/{id}").
+		Name("GroupFindById").
+		HandlerFunc(o.QueryHandler.FindById)
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefixIdBased).Path("/{id}/count").
+		Name("GroupCountById").
+		HandlerFunc(o.QueryHandler.CountById)
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefixIdBased).Path("/{id}/exist").
+		Name("GroupExistById").
+		HandlerFunc(o.QueryHandler.ExistById)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}").
+		Name("CreateGroup").
+		HandlerFunc(o.CommandHandler.Create)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/add-member").
+		Name("AddMemberGroup").
+		HandlerFunc(o.CommandHandler.AddMember)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/remove-member").
+		Name("RemoveMemberGroup").
+		HandlerFunc(o.CommandHandler.RemoveMember)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/add-subgroup").
+		Name("AddSubgroupGroup").
+		HandlerFunc(o.CommandHandler.AddSubgroup)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/remove-subgroup").
+		Name("RemoveSubgroupGroup").
+		HandlerFunc(o.CommandHandler.RemoveSubgroup)
+	router.Methods(http.MethodPut).PathPrefix(o.PathPrefixIdBased).Path("/{id}").
+		Name("UpdateGroup").
+		HandlerFunc(o.CommandHandler.Update)
+	router.Methods(http.MethodDelete).PathPrefix(o.PathPrefixIdBased).Path("/{id}").
+		Name("DeleteGroup").
+		HandlerFunc(o.CommandHandler.Delete)
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefix).Path("").
+		Name("GroupFindAll").
+		HandlerFunc(o.QueryHandler.FindAll)
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefix).Path("/count").
+		Name("GroupCountAll").
+		HandlerFunc(o.QueryHandler.CountAll)
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefix).Path("/exist").
+		Name("GroupExistAll").
+		HandlerFunc(o.QueryHandler.ExistAll)
+	return
+}
+
+type OrganizationHttpQueryHandler struct {
+	*eh.HttpQueryHandler
+	QueryRepository *OrganizationQueryRepository
+}
+
+func NewOrganizationHttpQueryHandlerFull(httpQueryHandler *eh.HttpQueryHandler, queryRepository *OrganizationQueryRepository) (ret *OrganizationHttpQueryHandler) {
+	ret = &OrganizationHttpQueryHandler{
+		HttpQueryHandler: httpQueryHandler,
+		QueryRepository:  queryRepository,
+	}
+	return
+}
+
+func (o *OrganizationHttpQueryHandler) FindAll(w http.ResponseWriter, r *http.Request) {
+	ret, err := o.QueryRepository.FindAll()
+	o.HandleResult(ret, err, "OrganizationFindAll", w, r)
+}
+
+func (o *OrganizationHttpQueryHandler) FindById(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	ret, err := o.QueryRepository.FindById(id)
+	o.HandleResult(ret, err, "OrganizationFindById", w, r)
+}
+
+func (o *OrganizationHttpQueryHandler) CountAll(w http.ResponseWriter, r *http.Request) {
+	ret, err := o.QueryRepository.CountAll()
+	o.HandleResult(ret, err, "OrganizationCountAll", w, r)
+}
+
+func (o *OrganizationHttpQueryHandler) CountById(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	ret, err := o.QueryRepository.CountById(id)
+	o.HandleResult(ret, err, "OrganizationCountById", w, r)
+}
+
+func (o *OrganizationHttpQueryHandler) ExistAll(w http.ResponseWriter, r *http.Request) {
+	ret, err := o.QueryRepository.ExistAll()
+	o.HandleResult(ret, err, "OrganizationExistAll", w, r)
+}
+
+func (o *OrganizationHttpQueryHandler) ExistById(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	ret, err := o.QueryRepository.ExistById(id)
+	o.HandleResult(ret, err, "OrganizationExistById", w, r)
+}
+
+type OrganizationHttpCommandHandler struct {
+	*eh.HttpCommandHandler
+}
+
+func NewOrganizationHttpCommandHandlerFull(httpCommandHandler *eh.HttpCommandHandler) (ret *OrganizationHttpCommandHandler) {
+	ret = &OrganizationHttpCommandHandler{
+		HttpCommandHandler: httpCommandHandler,
+	}
+	return
+}
+
+func (o *OrganizationHttpCommandHandler) Create(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&CreateOrganization{Id: id}, w, r)
+}
+
+func (o *OrganizationHttpCommandHandler) Update(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&UpdateOrganization{Id: id}, w, r)
+}
+
+func (o *OrganizationHttpCommandHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&DeleteOrganization{Id: id}, w, r)
+}
+
+type OrganizationRouter struct {
+	PathPrefix        string
+	PathPrefixIdBased string
+	QueryHandler      *OrganizationHttpQueryHandler
+	CommandHandler    *OrganizationHttpCommandHandler
+}
+
+func NewOrganizationRouter(pathPrefix string, newContext func(string) (ret context.Context), commandBus *bus.CommandHandler,
+	repo eventhorizon.ReadRepo) (ret *OrganizationRouter) {
+	pathPrefixIdBased := pathPrefix + "/" + "organization"
+	pathPrefix = pathPrefix + "/" + "organizations"
+	ctx := newContext("organization")
+	httpQueryHandler := eh.NewHttpQueryHandlerFull()
+	httpCommandHandler := eh.NewHttpCommandHandlerFull(ctx, commandBus)
+
+	queryRepository := NewOrganizationQueryRepositoryFull(repo, ctx)
+	queryHandler := NewOrganizationHttpQueryHandlerFull(httpQueryHandler, queryRepository)
+	commandHandler := NewOrganizationHttpCommandHandlerFull(httpCommandHandler)
+	ret = &OrganizationRouter{
+		PathPrefix:        pathPrefix,
+		PathPrefixIdBased: pathPrefixIdBased,
+		QueryHandler:      queryHandler,
+		CommandHandler:    commandHandler,
+	}
+	return
+}
+
+func (o *OrganizationRouter) Setup(router *mux.Router) (err error) {
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefixIdBased).Path("/{id}").
+		Name("OrganizationFindById").
+		HandlerFunc(o.QueryHandler.FindById)
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefixIdBased).Path("/{id}/count").
+		Name("OrganizationCountById").
+		HandlerFunc(o.QueryHandler.CountById)
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefixIdBased).Path("/{id}/exist").
+		Name("OrganizationExistById").
+		HandlerFunc(o.QueryHandler.ExistById)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}").
+		Name("CreateOrganization").
+		HandlerFunc(o.CommandHandler.Create)
+	router.Methods(http.MethodPut).PathPrefix(o.PathPrefixIdBased).Path("/{id}").
+		Name("UpdateOrganization").
+		HandlerFunc(o.CommandHandler.Update)
+	router.Methods(http.MethodDelete).PathPrefix(o.PathPrefixIdBased).Path("/{id}").
+		Name("DeleteOrganization").
+		HandlerFunc(o.CommandHandler.Delete)
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefix).Path("").
+		Name("OrganizationFindAll").
+		HandlerFunc(o.QueryHandler.FindAll)
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefix).Path("/count").
+		Name("OrganizationCountAll").
+		HandlerFunc(o.QueryHandler.CountAll)
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefix).Path("/exist").
+		Name("OrganizationExistAll").
+		HandlerFunc(o.QueryHandler.ExistAll)
+	return
+}
+
+type RoleGrantRequestHttpQueryHandler struct {
+	*eh.HttpQueryHandler
+	QueryRepository *RoleGrantRequestQueryRepository
+}
+
+func NewRoleGrantRequestHttpQueryHandlerFull(httpQueryHandler *eh.HttpQueryHandler, queryRepository *RoleGrantRequestQueryRepository) (ret *RoleGrantRequestHttpQueryHandler) {
+	ret = &RoleGrantRequestHttpQueryHandler{
+		HttpQueryHandler: httpQueryHandler,
+		QueryRepository:  queryRepository,
+	}
+	return
+}
+
+func (o *RoleGrantRequestHttpQueryHandler) FindAll(w http.ResponseWriter, r *http.Request) {
+	ret, err := o.QueryRepository.FindAll()
+	o.HandleResult(ret, err, "RoleGrantRequestFindAll", w, r)
+}
+
+func (o *RoleGrantRequestHttpQueryHandler) FindById(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	ret, err := o.QueryRepository.FindById(id)
+	o.HandleResult(ret, err, "RoleGrantRequestFindById", w, r)
+}
+
+func (o *RoleGrantRequestHttpQueryHandler) CountAll(w http.ResponseWriter, r *http.Request) {
+	ret, err := o.QueryRepository.CountAll()
+	o.HandleResult(ret, err, "RoleGrantRequestCountAll", w, r)
+}
+
+func (o *RoleGrantRequestHttpQueryHandler) CountById(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	ret, err := o.QueryRepository.CountById(id)
+	o.HandleResult(ret, err, "RoleGrantRequestCountById", w, r)
+}
+
+func (o *RoleGrantRequestHttpQueryHandler) ExistAll(w http.ResponseWriter, r *http.Request) {
+	ret, err := o.QueryRepository.ExistAll()
+	o.HandleResult(ret, err, "RoleGrantRequestExistAll", w, r)
+}
+
+func (o *RoleGrantRequestHttpQueryHandler) ExistById(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	ret, err := o.QueryRepository.ExistById(id)
+	o.HandleResult(ret, err, "RoleGrantRequestExistById", w, r)
+}
+
+type RoleGrantRequestHttpCommandHandler struct {
+	*eh.HttpCommandHandler
+}
+
+func NewRoleGrantRequestHttpCommandHandlerFull(httpCommandHandler *eh.HttpCommandHandler) (ret *RoleGrantRequestHttpCommandHandler) {
+	ret = &RoleGrantRequestHttpCommandHandler{
+		HttpCommandHandler: httpCommandHandler,
+	}
+	return
+}
+
+func (o *RoleGrantRequestHttpCommandHandler) Create(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&CreateRoleGrantRequest{Id: id}, w, r)
+}
+
+func (o *RoleGrantRequestHttpCommandHandler) Update(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&UpdateRoleGrantRequest{Id: id}, w, r)
+}
+
+func (o *RoleGrantRequestHttpCommandHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&DeleteRoleGrantRequest{Id: id}, w, r)
+}
+
+func (o *RoleGrantRequestHttpCommandHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&ApproveRoleGrantRequest{Id: id}, w, r)
+}
+
+func (o *RoleGrantRequestHttpCommandHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&RejectRoleGrantRequest{Id: id}, w, r)
+}
+
+type RoleGrantRequestRouter struct {
+	PathPrefix        string
+	PathPrefixIdBased string
+	QueryHandler      *RoleGrantRequestHttpQueryHandler
+	CommandHandler    *RoleGrantRequestHttpCommandHandler
+}
+
+func NewRoleGrantRequestRouter(pathPrefix string, newContext func(string) (ret context.Context), commandBus *bus.CommandHandler,
+	repo eventhorizon.ReadRepo) (ret *RoleGrantRequestRouter) {
+	pathPrefixIdBased := pathPrefix + "/" + "roleGrantRequest"
+	pathPrefix = pathPrefix + "/" + "roleGrantRequests"
+	ctx := newContext("roleGrantRequest")
+	httpQueryHandler := eh.NewHttpQueryHandlerFull()
+	httpCommandHandler := eh.NewHttpCommandHandlerFull(ctx, commandBus)
+
+	queryRepository := NewRoleGrantRequestQueryRepositoryFull(repo, ctx)
+	queryHandler := NewRoleGrantRequestHttpQueryHandlerFull(httpQueryHandler, queryRepository)
+	commandHandler := NewRoleGrantRequestHttpCommandHandlerFull(httpCommandHandler)
+	ret = &RoleGrantRequestRouter{
+		PathPrefix:        pathPrefix,
+		PathPrefixIdBased: pathPrefixIdBased,
+		QueryHandler:      queryHandler,
+		CommandHandler:    commandHandler,
+	}
+	return
+}
+
+func (o *RoleGrantRequestRouter) Setup(router *mux.Router) (err error) {
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefixIdBased).Path("/{id}").
+		Name("RoleGrantRequestFindById").
+		HandlerFunc(o.QueryHandler.FindById)
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefixIdBased).Path("/{id}/count").
+		Name("RoleGrantRequestCountById").
+		HandlerFunc(o.QueryHandler.CountById)
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefixIdBased).Path("/{id}/exist").
+		Name("RoleGrantRequestExistById").
+		HandlerFunc(o.QueryHandler.ExistById)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}").
+		Name("CreateRoleGrantRequest").
+		HandlerFunc(o.CommandHandler.Create)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/approve").
+		Name("ApproveRoleGrantRequest").
+		HandlerFunc(o.CommandHandler.Approve)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/reject").
+		Name("RejectRoleGrantRequest").
+		HandlerFunc(o.CommandHandler.Reject)
+	router.Methods(http.MethodPut).PathPrefix(o.PathPrefixIdBased).Path("/{id}").
+		Name("UpdateRoleGrantRequest").
+		HandlerFunc(o.CommandHandler.Update)
+	router.Methods(http.MethodDelete).PathPrefix(o.PathPrefixIdBased).Path("/{id}").
+		Name("DeleteRoleGrantRequest").
+		HandlerFunc(o.CommandHandler.Delete)
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefix).Path("").
+		Name("RoleGrantRequestFindAll").
+		HandlerFunc(o.QueryHandler.FindAll)
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefix).Path("/count").
+		Name("RoleGrantRequestCountAll").
+		HandlerFunc(o.QueryHandler.CountAll)
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefix).Path("/exist").
+		Name("RoleGrantRequestExistAll").
+		HandlerFunc(o.QueryHandler.ExistAll)
+	return
+}
+
+type RoleHttpQueryHandler struct {
+	*eh.HttpQueryHandler
+	QueryRepository *RoleQueryRepository
+}
+
+func NewRoleHttpQueryHandlerFull(httpQueryHandler *eh.HttpQueryHandler, queryRepository *RoleQueryRepository) (ret *RoleHttpQueryHandler) {
+	ret = &RoleHttpQueryHandler{
+		HttpQueryHandler: httpQueryHandler,
+		QueryRepository:  queryRepository,
+	}
+	return
+}
+
+func (o *RoleHttpQueryHandler) FindAll(w http.ResponseWriter, r *http.Request) {
+	ret, err := o.QueryRepository.FindAll()
+	o.HandleResult(ret, err, "RoleFindAll", w, r)
+}
+
+func (o *RoleHttpQueryHandler) FindById(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	ret, err := o.QueryRepository.FindById(id)
+	o.HandleResult(ret, err, "RoleFindById", w, r)
+}
+
+func (o *RoleHttpQueryHandler) CountAll(w http.ResponseWriter, r *http.Request) {
+	ret, err := o.QueryRepository.CountAll()
+	o.HandleResult(ret, err, "RoleCountAll", w, r)
+}
+
+func (o *RoleHttpQueryHandler) CountById(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	ret, err := o.QueryRepository.CountById(id)
+	o.HandleResult(ret, err, "RoleCountById", w, r)
+}
+
+func (o *RoleHttpQueryHandler) ExistAll(w http.ResponseWriter, r *http.Request) {
+	ret, err := o.QueryRepository.ExistAll()
+	o.HandleResult(ret, err, "RoleExistAll", w, r)
+}
+
+func (o *RoleHttpQueryHandler) ExistById(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	ret, err := o.QueryRepository.ExistById(id)
+	o.HandleResult(ret, err, "RoleExistById", w, r)
+}
+
+type RoleHttpCommandHandler struct {
+	*eh.HttpCommandHandler
+}
+
+func NewRoleHttpCommandHandlerFull(httpCommandHandler *eh.HttpCommandHandler) (ret *RoleHttpCommandHandler) {
+	ret = &RoleHttpCommandHandler{
+		HttpCommandHandler: httpCommandHandler,
+	}
+	return
+}
+
+func (o *RoleHttpCommandHandler) Create(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&CreateRole{Id: id}, w, r)
+}
+
+func (o *RoleHttpCommandHandler) Update(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&UpdateRole{Id: id}, w, r)
+}
+
+func (o *RoleHttpCommandHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&DeleteRole{Id: id}, w, r)
+}
+
+type RoleRouter struct {
+	PathPrefix        string
+	PathPrefixIdBased string
+	QueryHandler      *RoleHttpQueryHandler
+	CommandHandler    *RoleHttpCommandHandler
+}
+
+func NewRoleRouter(pathPrefix string, newContext func(string) (ret context.Context), commandBus *bus.CommandHandler,
+	repo eventhorizon.ReadRepo) (ret *RoleRouter) {
+	pathPrefixIdBased := pathPrefix + "/" + "role"
+	pathPrefix = pathPrefix + "/" + "roles"
+	ctx := newContext("role")
+	httpQueryHandler := eh.NewHttpQueryHandlerFull()
+	httpCommandHandler := eh.NewHttpCommandHandlerFull(ctx, commandBus)
+
+	queryRepository := NewRoleQueryRepositoryFull(repo, ctx)
+	queryHandler := NewRoleHttpQueryHandlerFull(httpQueryHandler, queryRepository)
+	commandHandler := NewRoleHttpCommandHandlerFull(httpCommandHandler)
+	ret = &RoleRouter{
+		PathPrefix:        pathPrefix,
+		PathPrefixIdBased: pathPrefixIdBased,
+		QueryHandler:      queryHandler,
+		CommandHandler:    commandHandler,
+	}
+	return
+}
+
+func (o *RoleRouter) Setup(router *mux.Router) (err error) {
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefixIdBased).Path("/{id}").
+		Name("RoleFindById").
+		HandlerFunc(o.QueryHandler.FindById)
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefixIdBased).Path("/{id}/count").
+		Name("RoleCountById").
+		HandlerFunc(o.QueryHandler.CountById)
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefixIdBased).Path("/{id}/exist").
+		Name("RoleExistById").
+		HandlerFunc(o.QueryHandler.ExistById)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}").
+		Name("CreateRole").
+		HandlerFunc(o.CommandHandler.Create)
+	router.Methods(http.MethodPut).PathPrefix(o.PathPrefixIdBased).Path("/{id}").
+		Name("UpdateRole").
+		HandlerFunc(o.CommandHandler.Update)
+	router.Methods(http.MethodDelete).PathPrefix(o.PathPrefixIdBased).Path("/{id}").
+		Name("DeleteRole").
+		HandlerFunc(o.CommandHandler.Delete)
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefix).Path("").
+		Name("RoleFindAll").
+		HandlerFunc(o.QueryHandler.FindAll)
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefix).Path("/count").
+		Name("RoleCountAll").
+		HandlerFunc(o.QueryHandler.CountAll)
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefix).Path("/exist").
+		Name("RoleExistAll").
+		HandlerFunc(o.QueryHandler.ExistAll)
+	return
+}
+
+type RelationTupleHttpQueryHandler struct {
+	*eh.HttpQueryHandler
+	QueryRepository *RelationTupleQueryRepository
+}
+
+func NewRelationTupleHttpQueryHandlerFull(httpQueryHandler *eh.HttpQueryHandler, queryRepository *RelationTupleQueryRepository) (ret *RelationTupleHttpQueryHandler) {
+	ret = &RelationTupleHttpQueryHandler{
+		HttpQueryHandler: httpQueryHandler,
+		QueryRepository:  queryRepository,
+	}
+	return
+}
+
+func (o *RelationTupleHttpQueryHandler) FindAll(w http.ResponseWriter, r *http.Request) {
+	ret, err := o.QueryRepository.FindAll()
+	o.HandleResult(ret, err, "RelationTupleFindAll", w, r)
+}
+
+func (o *RelationTupleHttpQueryHandler) FindById(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	ret, err := o.QueryRepository.FindById(id)
+	o.HandleResult(ret, err, "RelationTupleFindById", w, r)
+}
+
+func (o *RelationTupleHttpQueryHandler) CountAll(w http.ResponseWriter, r *http.Request) {
+	ret, err := o.QueryRepository.CountAll()
+	o.HandleResult(ret, err, "RelationTupleCountAll", w, r)
+}
+
+func (o *RelationTupleHttpQueryHandler) CountById(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	ret, err := o.QueryRepository.CountById(id)
+	o.HandleResult(ret, err, "RelationTupleCountById", w, r)
+}
+
+func (o *RelationTupleHttpQueryHandler) ExistAll(w http.ResponseWriter, r *http.Request) {
+	ret, err := o.QueryRepository.ExistAll()
+	o.HandleResult(ret, err, "RelationTupleExistAll", w, r)
+}
+
+func (o *RelationTupleHttpQueryHandler) ExistById(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	ret, err := o.QueryRepository.ExistById(id)
+	o.HandleResult(ret, err, "RelationTupleExistById", w, r)
+}
+
+type RelationTupleHttpCommandHandler struct {
+	*eh.HttpCommandHandler
+}
+
+func NewRelationTupleHttpCommandHandlerFull(httpCommandHandler *eh.HttpCommandHandler) (ret *RelationTupleHttpCommandHandler) {
+	ret = &RelationTupleHttpCommandHandler{
+		HttpCommandHandler: httpCommandHandler,
+	}
+	return
+}
+
+func (o *RelationTupleHttpCommandHandler) Create(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&CreateRelationTuple{Id: id}, w, r)
+}
+
+func (o *RelationTupleHttpCommandHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&DeleteRelationTuple{Id: id}, w, r)
+}
+
+type RelationTupleRouter struct {
+	PathPrefix        string
+	PathPrefixIdBased string
+	QueryHandler      *RelationTupleHttpQueryHandler
+	CommandHandler    *RelationTupleHttpCommandHandler
+}
+
+func NewRelationTupleRouter(pathPrefix string, newContext func(string) (ret context.Context), commandBus *bus.CommandHandler,
+	repo eventhorizon.ReadRepo) (ret *RelationTupleRouter) {
+	pathPrefixIdBased := pathPrefix + "/" + "relationTuple"
+	pathPrefix = pathPrefix + "/" + "relationTuples"
+	ctx := newContext("relationTuple")
+	httpQueryHandler := eh.NewHttpQueryHandlerFull()
+	httpCommandHandler := eh.NewHttpCommandHandlerFull(ctx, commandBus)
+
+	queryRepository := NewRelationTupleQueryRepositoryFull(repo, ctx)
+	queryHandler := NewRelationTupleHttpQueryHandlerFull(httpQueryHandler, queryRepository)
+	commandHandler := NewRelationTupleHttpCommandHandlerFull(httpCommandHandler)
+	ret = &RelationTupleRouter{
+		PathPrefix:        pathPrefix,
+		PathPrefixIdBased: pathPrefixIdBased,
+		QueryHandler:      queryHandler,
+		CommandHandler:    commandHandler,
+	}
+	return
+}
+
+func (o *RelationTupleRouter) Setup(router *mux.Router) (err error) {
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefixIdBased).Path("/{id}").
+		Name("RelationTupleFindById").
+		HandlerFunc(o.QueryHandler.FindById)
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefixIdBased).Path("/{id}/count").
+		Name("RelationTupleCountById").
+		HandlerFunc(o.QueryHandler.CountById)
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefixIdBased).Path("/{id}/exist").
+		Name("RelationTupleExistById").
+		HandlerFunc(o.QueryHandler.ExistById)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}").
+		Name("CreateRelationTuple").
+		HandlerFunc(o.CommandHandler.Create)
+	router.Methods(http.MethodDelete).PathPrefix(o.PathPrefixIdBased).Path("/{id}").
+		Name("DeleteRelationTuple").
+		HandlerFunc(o.CommandHandler.Delete)
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefix).Path("").
+		Name("RelationTupleFindAll").
+		HandlerFunc(o.QueryHandler.FindAll)
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefix).Path("/count").
+		Name("RelationTupleCountAll").
+		HandlerFunc(o.QueryHandler.CountAll)
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefix).Path("/exist").
+		Name("RelationTupleExistAll").
+		HandlerFunc(o.QueryHandler.ExistAll)
+	return
+}
+
+type InvitationHttpQueryHandler struct {
+	*eh.HttpQueryHandler
+	QueryRepository *InvitationQueryRepository
+}
+
+func NewInvitationHttpQueryHandlerFull(httpQueryHandler *eh.HttpQueryHandler, queryRepository *InvitationQueryRepository) (ret *InvitationHttpQueryHandler) {
+	ret = &InvitationHttpQueryHandler{
+		HttpQueryHandler: httpQueryHandler,
+		QueryRepository:  queryRepository,
+	}
+	return
+}
+
+func (o *InvitationHttpQueryHandler) FindAll(w http.ResponseWriter, r *http.Request) {
+	ret, err := o.QueryRepository.FindAll()
+	o.HandleResult(ret, err, "InvitationFindAll", w, r)
+}
+
+func (o *InvitationHttpQueryHandler) FindById(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	ret, err := o.QueryRepository.FindById(id)
+	o.HandleResult(ret, err, "InvitationFindById", w, r)
+}
+
+func (o *InvitationHttpQueryHandler) CountAll(w http.ResponseWriter, r *http.Request) {
+	ret, err := o.QueryRepository.CountAll()
+	o.HandleResult(ret, err, "InvitationCountAll", w, r)
+}
+
+func (o *InvitationHttpQueryHandler) CountById(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	ret, err := o.QueryRepository.CountById(id)
+	o.HandleResult(ret, err, "InvitationCountById", w, r)
+}
+
+func (o *InvitationHttpQueryHandler) ExistAll(w http.ResponseWriter, r *http.Request) {
+	ret, err := o.QueryRepository.ExistAll()
+	o.HandleResult(ret, err, "InvitationExistAll", w, r)
+}
+
+func (o *InvitationHttpQueryHandler) ExistById(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	ret, err := o.QueryRepository.ExistById(id)
+	o.HandleResult(ret, err, "InvitationExistById", w, r)
+}
+
+type InvitationHttpCommandHandler struct {
+	*eh.HttpCommandHandler
+}
+
+func NewInvitationHttpCommandHandlerFull(httpCommandHandler *eh.HttpCommandHandler) (ret *InvitationHttpCommandHandler) {
+	ret = &InvitationHttpCommandHandler{
+		HttpCommandHandler: httpCommandHandler,
+	}
+	return
+}
+
+func (o *InvitationHttpCommandHandler) Create(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&CreateInvitation{Id: id}, w, r)
+}
+
+func (o *InvitationHttpCommandHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&DeleteInvitation{Id: id}, w, r)
+}
+
+func (o *InvitationHttpCommandHandler) Resend(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&ResendInvitation{Id: id}, w, r)
+}
+
+func (o *InvitationHttpCommandHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&RevokeInvitation{Id: id}, w, r)
+}
+
+func (o *InvitationHttpCommandHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := uuid.Parse(vars["id"])
+	o.HandleCommand(&AcceptInvitation{Id: id}, w, r)
+}
+
+type InvitationRouter struct {
+	PathPrefix        string
+	PathPrefixIdBased string
+	QueryHandler      *InvitationHttpQueryHandler
+	CommandHandler    *InvitationHttpCommandHandler
+}
+
+func NewInvitationRouter(pathPrefix string, newContext func(string) (ret context.Context), commandBus *bus.CommandHandler,
+	repo eventhorizon.ReadRepo) (ret *InvitationRouter) {
+	pathPrefixIdBased := pathPrefix + "/" + "invitation"
+	pathPrefix = pathPrefix + "/" + "invitations"
+	ctx := newContext("invitation")
+	httpQueryHandler := eh.NewHttpQueryHandlerFull()
+	httpCommandHandler := eh.NewHttpCommandHandlerFull(ctx, commandBus)
+
+	queryRepository := NewInvitationQueryRepositoryFull(repo, ctx)
+	queryHandler := NewInvitationHttpQueryHandlerFull(httpQueryHandler, queryRepository)
+	commandHandler := NewInvitationHttpCommandHandlerFull(httpCommandHandler)
+	ret = &InvitationRouter{
+		PathPrefix:        pathPrefix,
+		PathPrefixIdBased: pathPrefixIdBased,
+		QueryHandler:      queryHandler,
+		CommandHandler:    commandHandler,
+	}
+	return
+}
+
+func (o *InvitationRouter) Setup(router *mux.Router) (err error) {
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefixIdBased).Path("/{id}").
+		Name("InvitationFindById").
+		HandlerFunc(o.QueryHandler.FindById)
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefixIdBased).Path("/{id}/count").
+		Name("InvitationCountById").
+		HandlerFunc(o.QueryHandler.CountById)
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefixIdBased).Path("/{id}/exist").
+		Name("InvitationExistById").
+		HandlerFunc(o.QueryHandler.ExistById)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}").
+		Name("CreateInvitation").
+		HandlerFunc(o.CommandHandler.Create)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/resend").
+		Name("ResendInvitation").
+		HandlerFunc(o.CommandHandler.Resend)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/revoke").
+		Name("RevokeInvitation").
+		HandlerFunc(o.CommandHandler.Revoke)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefixIdBased).Path("/{id}/accept").
+		Name("AcceptInvitation").
+		HandlerFunc(o.CommandHandler.Accept)
+	router.Methods(http.MethodDelete).PathPrefix(o.PathPrefixIdBased).Path("/{id}").
+		Name("DeleteInvitation").
+		HandlerFunc(o.CommandHandler.Delete)
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefix).Path("").
+		Name("InvitationFindAll").
+		HandlerFunc(o.QueryHandler.FindAll)
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefix).Path("/count").
+		Name("InvitationCountAll").
+		HandlerFunc(o.QueryHandler.CountAll)
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefix).Path("/exist").
+		Name("InvitationExistAll").
+		HandlerFunc(o.QueryHandler.ExistAll)
+	return
+}
+
+type Router struct {
+	PathPrefix             string
+	AccountRouter          *AccountRouter
+	GroupRouter            *GroupRouter
+	OrganizationRouter     *OrganizationRouter
+	RoleGrantRequestRouter *RoleGrantRequestRouter
+	RoleRouter             *RoleRouter
+	RelationTupleRouter    *RelationTupleRouter
+	InvitationRouter       *InvitationRouter
+}
+
+func NewRouter(pathPrefix string, newContext func(string) (ret context.Context), esEngine *EsEngine) (ret *Router, err error) {
+	pathPrefix = pathPrefix + "/" + "auth"
+
+	var projectorAccount *AccountProjector
+	if projectorAccount, err = esEngine.Account.RegisterAccountProjector(string(AccountAggregateType),
+		esEngine.Account.AggregateHandlers, esEngine.Account.Events); err != nil {
+		return
+	}
+
+	var projectorGroup *GroupProjector
+	if projectorGroup, err = esEngine.Group.RegisterGroupProjector(string(GroupAggregateType),
+		esEngine.Group.AggregateHandlers, esEngine.Group.Events); err != nil {
+		return
+	}
+
+	var projectorOrganization *OrganizationProjector
+	if projectorOrganization, err = esEngine.Organization.RegisterOrganizationProjector(string(OrganizationAggregateType),
+		esEngine.Organization.AggregateHandlers, esEngine.Organization.Events); err != nil {
+		return
+	}
+
+	var projectorRoleGrantRequest *RoleGrantRequestProjector
+	if projectorRoleGrantRequest, err = esEngine.RoleGrantRequest.RegisterRoleGrantRequestProjector(string(RoleGrantRequestAggregateType),
+		esEngine.RoleGrantRequest.AggregateHandlers, esEngine.RoleGrantRequest.Events); err != nil {
+		return
+	}
+
+	var projectorRole *RoleProjector
+	if projectorRole, err = esEngine.Role.RegisterRoleProjector(string(RoleAggregateType),
+		esEngine.Role.AggregateHandlers, esEngine.Role.Events); err != nil {
+		return
+	}
+
+	var projectorRelationTuple *RelationTupleProjector
+	if projectorRelationTuple, err = esEngine.RelationTuple.RegisterRelationTupleProjector(string(RelationTupleAggregateType),
+		esEngine.RelationTuple.AggregateHandlers, esEngine.RelationTuple.Events); err != nil {
+		return
+	}
+
+	var projectorInvitation *InvitationProjector
+	if projectorInvitation, err = esEngine.Invitation.RegisterInvitationProjector(string(InvitationAggregateType),
+		esEngine.Invitation.AggregateHandlers, esEngine.Invitation.Events); err != nil {
+		return
+	}
+
+	accountRouter := NewAccountRouter(pathPrefix, newContext, esEngine.CommandBus, projectorAccount.Repo)
+	groupRouter := NewGroupRouter(pathPrefix, newContext, esEngine.CommandBus, projectorGroup.Repo)
+	organizationRouter := NewOrganizationRouter(pathPrefix, newContext, esEngine.CommandBus, projectorOrganization.Repo)
+	roleGrantRequestRouter := NewRoleGrantRequestRouter(pathPrefix, newContext, esEngine.CommandBus, projectorRoleGrantRequest.Repo)
+	roleRouter := NewRoleRouter(pathPrefix, newContext, esEngine.CommandBus, projectorRole.Repo)
+	relationTupleRouter := NewRelationTupleRouter(pathPrefix, newContext, esEngine.CommandBus, projectorRelationTuple.Repo)
+	invitationRouter := NewInvitationRouter(pathPrefix, newContext, esEngine.CommandBus, projectorInvitation.Repo)
+
+	ret = &Router{
+		PathPrefix:             pathPrefix,
+		AccountRouter:          accountRouter,
+		GroupRouter:            groupRouter,
+		OrganizationRouter:     organizationRouter,
+		RoleGrantRequestRouter: roleGrantRequestRouter,
+		RoleRouter:             roleRouter,
+		RelationTupleRouter:    relationTupleRouter,
+		InvitationRouter:       invitationRouter,
+	}
+	return
+}
+
+func (o *Router) Setup(router *mux.Router) (err error) {
+	if err = o.AccountRouter.Setup(router); err != nil {
+		return
+	}
+	if err = o.GroupRouter.Setup(router); err != nil {
+		return
+	}
+	if err = o.OrganizationRouter.Setup(router); err != nil {
+		return
+	}
+	if err = o.RoleGrantRequestRouter.Setup(router); err != nil {
+		return
+	}
+	if err = o.RoleRouter.Setup(router); err != nil {
+		return
+	}
+	if err = o.RelationTupleRouter.Setup(router); err != nil {
+		return
+	}
+	if err = o.InvitationRouter.Setup(router); err != nil {
 		return
 	}
 	return
@@ -0,0 +1,384 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-ee/utils"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/looplab/eventhorizon"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const PermissionImpersonate = "auth:impersonate"
+
+// impersonationProtected are the permissions of admins, accounts having one of them can not be impersonated
+var impersonationProtected = []string{PermissionImpersonate, PermissionManageAccounts, PermissionManageRoles,
+	PermissionManageOrganizations}
+
+func (o *Account) HasRole(role string) bool {
+	for _, item := range o.Roles {
+		if item == role {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *Account) FindImpersonation(impersonationId uuid.UUID) (ret *Impersonation) {
+	for _, impersonation := range o.Impersonations {
+		if impersonation.ImpersonationId == impersonationId {
+			return impersonation
+		}
+	}
+	return
+}
+
+func (o *Account) RemoveImpersonation(impersonationId uuid.UUID) {
+	impersonations := o.Impersonations[:0]
+	for _, impersonation := range o.Impersonations {
+		if impersonation.ImpersonationId != impersonationId {
+			impersonations = append(impersonations, impersonation)
+		}
+	}
+	o.Impersonations = impersonations
+}
+
+// IsExpired tells whether the impersonation is over at the time
+func (o *Impersonation) IsExpired(now time.Time) bool {
+	return o.ExpiresAt != nil && !now.Before(*o.ExpiresAt)
+}
+
+// HasAnyPermission checks the permissions of the account over its own, group and inherited roles
+func (o *Tokens) HasAnyPermission(account *Account, permissions []string) (ret bool, err error) {
+	principal := &Principal{AccountId: account.Id}
+	if principal.Roles, err = o.EffectiveRoles(account); err != nil {
+		return
+	}
+	if principal.Permissions, err = o.EffectivePermissions(principal.Roles); err != nil {
+		return
+	}
+	for _, permission := range permissions {
+		if principal.HasPermission(permission) {
+			ret = true
+			return
+		}
+	}
+	return
+}
+
+func (o *EsEngine) ImplementImpersonation(tokens *Tokens) {
+	o.Account.ImplementImpersonation(tokens)
+}
+
+// ImplementImpersonation rejects the impersonation of admins, their permissions are resolved like for their tokens
+func (o *AccountAggregateEngine) ImplementImpersonation(tokens *Tokens) {
+	o.AggregateExecutors.Enabled.AddImpersonatePreparer(
+		func(cmd *ImpersonateAccount, entity *Account) (err error) {
+			if cmd.ImpersonationId == uuid.Nil || cmd.ActorId == uuid.Nil {
+				err = errors.New("impersonation id and actor are required")
+			} else if cmd.Reason == "" {
+				err = errors.New("a reason for the impersonation is required")
+			} else if cmd.ActorId == entity.Id {
+				err = errors.New("an account can not impersonate itself")
+			} else if cmd.ExpiresAt == nil || !cmd.ExpiresAt.After(time.Now()) {
+				err = errors.New("expiry of the impersonation must be in the future")
+			} else if entity.FindImpersonation(cmd.ImpersonationId) != nil {
+				err = fmt.Errorf("impersonation '%v' exists already", cmd.ImpersonationId)
+			} else {
+				var protected bool
+				if protected, err = tokens.HasAnyPermission(entity, impersonationProtected); err == nil && protected {
+					err = fmt.Errorf("account '%v' is an admin and can not be impersonated", entity.Id)
+				}
+			}
+			return
+		})
+
+	endPreparer := func(cmd *EndImpersonationAccount, entity *Account) (err error) {
+		if entity.FindImpersonation(cmd.ImpersonationId) == nil {
+			err = fmt.Errorf("impersonation '%v' is not active", cmd.ImpersonationId)
+		}
+		return
+	}
+	o.AggregateExecutors.Enabled.AddEndImpersonationPreparer(endPreparer)
+	o.AggregateExecutors.Disabled.AddEndImpersonationPreparer(endPreparer)
+
+	o.AggregateHandlers.Enabled.ImpersonationStartedHandler =
+		func(event eventhorizon.Event, eventData *ImpersonationStarted, entity *Account) (err error) {
+			entity.AddToImpersonations(&Impersonation{
+				ImpersonationId: eventData.ImpersonationId,
+				ActorId:         eventData.ActorId,
+				Reason:          eventData.Reason,
+				StartedAt:       utils.PtrTime(event.Timestamp()),
+				ExpiresAt:       eventData.ExpiresAt,
+			})
+			return
+		}
+
+	endedHandler := func(event eventhorizon.Event, eventData *ImpersonationEnded, entity *Account) (err error) {
+		entity.RemoveImpersonation(eventData.ImpersonationId)
+		return
+	}
+	o.AggregateHandlers.Enabled.ImpersonationEndedHandler = endedHandler
+	o.AggregateHandlers.Disabled.ImpersonationEndedHandler = endedHandler
+}
+
+func (o *Tokens) IssueImpersonationToken(subject *Account, actor *Principal,
+	impersonation *ImpersonateAccount) (ret *TokenResponse, err error) {
+
+	var claims *TokenClaims
+	if claims, err = o.NewClaims(subject); err != nil {
+		return
+	}
+	claims.Id = impersonation.ImpersonationId.String()
+	claims.ExpiresAt = impersonation.ExpiresAt.Unix()
+	limitToRoleAssignments(claims, subject, time.Now())
+	claims.Actor = &Actor{Subject: actor.AccountId.String(), Username: actor.Username}
+	ret, err = o.Issue(claims)
+	return
+}
+
+// resolveImpersonation accepts impersonation tokens only as long as the impersonation was not ended or expired
+// and both accounts are enabled. An expired impersonation is ended.
+func (o *Authenticator) resolveImpersonation(principal *Principal, claims *TokenClaims) (err error) {
+	var impersonationId, actorId uuid.UUID
+	if impersonationId, err = uuid.Parse(claims.Id); err != nil {
+		return
+	}
+	if actorId, err = uuid.Parse(claims.Actor.Subject); err != nil {
+		return
+	}
+
+	var account *Account
+	if account, err = o.Accounts.FindById(principal.AccountId); err != nil || account == nil ||
+		account.DeletedAt != nil {
+		err = errors.New("impersonated account not found")
+		return
+	}
+
+	impersonation := account.FindImpersonation(impersonationId)
+	if impersonation == nil || impersonation.ActorId != actorId {
+		err = errors.New("impersonation is not active")
+		return
+	}
+	if impersonation.IsExpired(time.Now()) {
+		if endErr := o.CommandBus.HandleCommand(o.ctx, &EndImpersonationAccount{Id: account.Id,
+			ImpersonationId: impersonationId, ActorId: actorId}); endErr != nil {
+			logrus.Warnf("end of expired impersonation '%v' failed: %v", impersonationId, endErr)
+		}
+		err = errors.New("impersonation is expired")
+		return
+	}
+	if account.Disabled {
+		err = errors.New("impersonated account is disabled")
+		return
+	}
+
+	var actor *Account
+	if actor, err = o.Accounts.FindById(actorId); err != nil || actor == nil || actor.DeletedAt != nil ||
+		actor.Disabled {
+		err = errors.New("impersonating account is not active")
+		return
+	}
+
+	principal.ImpersonationId = impersonationId
+	principal.Actor = &Principal{AccountId: actorId, Username: claims.Actor.Username}
+	return
+}
+
+type ImpersonateRequest struct {
+	Reason string `json:"reason"`
+	// Duration of the impersonation in seconds
+	Duration int64 `json:"duration,omitempty"`
+}
+
+// ImpersonationRouter lets holders of the impersonation permission act as another account,
+// every start and end is recorded as event of the impersonated account.
+type ImpersonationRouter struct {
+	PathPrefix  string
+	MaxDuration time.Duration
+	Accounts    *AccountQueryRepository
+	CommandBus  eventhorizon.CommandHandler
+	Tokens      *Tokens
+	ctx         context.Context
+}
+
+func NewImpersonationRouter(pathPrefix string, newContext func(string) (ret context.Context),
+	commandBus eventhorizon.CommandHandler, accounts *AccountQueryRepository, tokens *Tokens) (ret *ImpersonationRouter) {
+	ret = &ImpersonationRouter{
+		PathPrefix:  pathPrefix + "/" + "impersonation",
+		MaxDuration: 30 * time.Minute,
+		Accounts:    accounts,
+		CommandBus:  commandBus,
+		Tokens:      tokens,
+		ctx:         newContext("impersonation"),
+	}
+	return
+}
+
+func (o *ImpersonationRouter) Setup(router *mux.Router) (err error) {
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefix).Path("/{id}").
+		Name("Impersonate").
+		HandlerFunc(o.Impersonate)
+	router.Methods(http.MethodDelete).PathPrefix(o.PathPrefix).Path("").
+		Name("EndImpersonation").
+		HandlerFunc(o.End)
+	return
+}
+
+func (o *ImpersonationRouter) Impersonate(w http.ResponseWriter, r *http.Request) {
+	actor := PrincipalFrom(r.Context())
+	if actor == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", ErrUnauthenticated)
+		return
+	}
+	if actor.IsImpersonated() || !actor.HasPermission(PermissionImpersonate) {
+		writeError(w, http.StatusForbidden, "access_denied", errors.New("impersonation is not permitted"))
+		return
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	var subject *Account
+	if subject, err = o.Accounts.FindById(id); err != nil || subject == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	request := &ImpersonateRequest{}
+	if err = json.NewDecoder(r.Body).Decode(request); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+
+	duration := time.Duration(request.Duration) * time.Second
+	if duration <= 0 || duration > o.MaxDuration {
+		duration = o.MaxDuration
+	}
+
+	impersonate := &ImpersonateAccount{
+		Id:              subject.Id,
+		ImpersonationId: uuid.New(),
+		ActorId:         actor.AccountId,
+		Reason:          request.Reason,
+		ExpiresAt:       utils.PtrTime(time.Now().Add(duration)),
+	}
+	if err = o.CommandBus.HandleCommand(o.ctx, impersonate); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+
+	var token *TokenResponse
+	if token, err = o.Tokens.IssueImpersonationToken(subject, actor, impersonate); err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, token)
+}
+
+func (o *ImpersonationRouter) End(w http.ResponseWriter, r *http.Request) {
+	principal := PrincipalFrom(r.Context())
+	if principal == nil || !principal.IsImpersonated() {
+		writeError(w, http.StatusBadRequest, "invalid_request", errors.New("no impersonation is active"))
+		return
+	}
+
+	end := &EndImpersonationAccount{
+		Id:              principal.AccountId,
+		ImpersonationId: principal.ImpersonationId,
+		ActorId:         principal.Actor.AccountId,
+	}
+	if err := o.CommandBus.HandleCommand(o.ctx, end); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ImpersonationExpiryScheduler ends impersonations when their expiry is over.
+type ImpersonationExpiryScheduler struct {
+	Accounts   *AccountQueryRepository
+	CommandBus eventhorizon.CommandHandler
+	Interval   time.Duration
+	ctx        context.Context
+	stop       chan struct{}
+	mutex      sync.Mutex
+}
+
+func NewImpersonationExpiryScheduler(newContext func(string) (ret context.Context),
+	commandBus eventhorizon.CommandHandler, accounts *AccountQueryRepository,
+	interval time.Duration) (ret *ImpersonationExpiryScheduler) {
+	ret = &ImpersonationExpiryScheduler{
+		Accounts:   accounts,
+		CommandBus: commandBus,
+		Interval:   interval,
+		ctx:        newContext("impersonationExpiry"),
+	}
+	return
+}
+
+func (o *ImpersonationExpiryScheduler) Start() {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if o.stop != nil {
+		return
+	}
+
+	o.stop = make(chan struct{})
+	go func(stop chan struct{}) {
+		ticker := time.NewTicker(o.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				if err := o.EndExpired(now); err != nil {
+					logrus.Warnf("end of expired impersonations failed: %v", err)
+				}
+			case <-stop:
+				return
+			}
+		}
+	}(o.stop)
+}
+
+func (o *ImpersonationExpiryScheduler) Stop() {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if o.stop != nil {
+		close(o.stop)
+		o.stop = nil
+	}
+}
+
+// EndExpired ends all impersonations expired at the time, failures do not stop the end of other impersonations
+func (o *ImpersonationExpiryScheduler) EndExpired(now time.Time) (err error) {
+	var accounts []*Account
+	if accounts, err = o.Accounts.FindAll(); err != nil {
+		return
+	}
+
+	for _, account := range accounts {
+		if account.DeletedAt != nil {
+			continue
+		}
+		for _, impersonation := range account.Impersonations {
+			if !impersonation.IsExpired(now) {
+				continue
+			}
+			if endErr := o.CommandBus.HandleCommand(o.ctx, &EndImpersonationAccount{Id: account.Id,
+				ImpersonationId: impersonation.ImpersonationId, ActorId: impersonation.ActorId}); endErr != nil {
+				err = endErr
+			}
+		}
+	}
+	return
+}
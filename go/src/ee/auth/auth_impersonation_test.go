@@ -0,0 +1,138 @@
+package auth
+
+import (
+	"context"
+	"github.com/go-ee/utils"
+	"github.com/google/uuid"
+	"github.com/looplab/eventhorizon"
+	"testing"
+	"time"
+)
+
+// admins are protected by the permissions of their roles, also when the permission is inherited
+func TestImpersonationRejectsAdmins(t *testing.T) {
+	ctx := context.Background()
+	roles := newMemoryRepo(t, RoleAggregateType, func() eventhorizon.Entity { return NewRoleDefault() })
+	for _, role := range []*Role{
+		{Id: uuid.New(), Name: "support", Permissions: []string{PermissionImpersonate}},
+		{Id: uuid.New(), Name: "lead", Inherits: []string{"support"}},
+		{Id: uuid.New(), Name: "member"},
+	} {
+		if err := roles.Save(ctx, role); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	engine := &AccountAggregateEngine{AggregateExecutors: NewAccountAggregateExecutorsFull(),
+		AggregateHandlers: NewAccountAggregateHandlersFull()}
+	if err := engine.AggregateExecutors.SetupCommandHandler(); err != nil {
+		t.Fatal(err)
+	}
+	engine.ImplementImpersonation(&Tokens{Hierarchy: NewRoleHierarchy(NewRoleQueryRepositoryFull(roles, ctx))})
+
+	for _, item := range []struct {
+		roles    []string
+		accepted bool
+	}{
+		{[]string{"member"}, true},
+		{[]string{"lead"}, false},
+		{[]string{PermissionImpersonate}, false},
+	} {
+		subject := &Account{Id: uuid.New(), Roles: item.roles}
+		err := engine.AggregateExecutors.Enabled.ImpersonateHandler(&ImpersonateAccount{Id: subject.Id,
+			ImpersonationId: uuid.New(), ActorId: uuid.New(), Reason: "support ticket",
+			ExpiresAt: utils.PtrTime(time.Now().Add(time.Minute))}, subject, &recordedEvents{})
+		if (err == nil) != item.accepted {
+			t.Errorf("impersonation of %v answered %v", item.roles, err)
+		}
+	}
+}
+
+func TestResolveImpersonation(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryRepo(t, AccountAggregateType, func() eventhorizon.Entity { return NewAccountDefault() })
+
+	var ended []uuid.UUID
+	commandBus := eventhorizon.CommandHandlerFunc(func(ctx context.Context, cmd eventhorizon.Command) error {
+		if end, ok := cmd.(*EndImpersonationAccount); ok {
+			ended = append(ended, end.ImpersonationId)
+		}
+		return nil
+	})
+	authenticator := NewAuthenticator(func(string) context.Context { return ctx }, commandBus,
+		NewAccountQueryRepositoryFull(repo, ctx), nil)
+
+	now := time.Now()
+	for _, item := range []struct {
+		name            string
+		actorDisabled   bool
+		subjectDisabled bool
+		expiresAt       time.Time
+		accepted        bool
+	}{
+		{"active", false, false, now.Add(time.Minute), true},
+		{"expired", false, false, now.Add(-time.Second), false},
+		{"disabled actor", true, false, now.Add(time.Minute), false},
+		{"disabled subject", false, true, now.Add(time.Minute), false},
+	} {
+		actor := &Account{Id: uuid.New(), Username: "support", Disabled: item.actorDisabled}
+		impersonation := &Impersonation{ImpersonationId: uuid.New(), ActorId: actor.Id,
+			ExpiresAt: utils.PtrTime(item.expiresAt)}
+		subject := &Account{Id: uuid.New(), Disabled: item.subjectDisabled,
+			Impersonations: []*Impersonation{impersonation}}
+		for _, account := range []*Account{actor, subject} {
+			if err := repo.Save(ctx, account); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		principal := &Principal{AccountId: subject.Id}
+		claims := &TokenClaims{Actor: &Actor{Subject: actor.Id.String(), Username: actor.Username}}
+		claims.Id = impersonation.ImpersonationId.String()
+		err := authenticator.resolveImpersonation(principal, claims)
+		if (err == nil) != item.accepted {
+			t.Errorf("%v impersonation answered %v", item.name, err)
+		}
+		if item.accepted && (principal.Actor == nil || principal.Actor.AccountId != actor.Id) {
+			t.Errorf("%v impersonation has no actor", item.name)
+		}
+	}
+
+	if len(ended) != 1 {
+		t.Errorf("expired impersonation ended %v times", len(ended))
+	}
+}
+
+func TestEndExpiredImpersonations(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryRepo(t, AccountAggregateType, func() eventhorizon.Entity { return NewAccountDefault() })
+
+	now := time.Now()
+	expired := &Impersonation{ImpersonationId: uuid.New(), ActorId: uuid.New(), ExpiresAt: utils.PtrTime(now)}
+	running := &Impersonation{ImpersonationId: uuid.New(), ActorId: uuid.New(),
+		ExpiresAt: utils.PtrTime(now.Add(time.Hour))}
+	deleted := &Impersonation{ImpersonationId: uuid.New(), ActorId: uuid.New(), ExpiresAt: utils.PtrTime(now)}
+	for _, account := range []*Account{
+		{Id: uuid.New(), Impersonations: []*Impersonation{expired, running}},
+		{Id: uuid.New(), Impersonations: []*Impersonation{deleted}, DeletedAt: utils.PtrTime(now)},
+	} {
+		if err := repo.Save(ctx, account); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var ended []uuid.UUID
+	commandBus := eventhorizon.CommandHandlerFunc(func(ctx context.Context, cmd eventhorizon.Command) error {
+		ended = append(ended, cmd.(*EndImpersonationAccount).ImpersonationId)
+		return nil
+	})
+	scheduler := NewImpersonationExpiryScheduler(func(string) context.Context { return ctx }, commandBus,
+		NewAccountQueryRepositoryFull(repo, ctx), time.Minute)
+
+	if err := scheduler.EndExpired(now.Add(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if len(ended) != 1 || ended[0] != expired.ImpersonationId {
+		t.Errorf("ended impersonations %v instead of %v", ended, expired.ImpersonationId)
+	}
+}
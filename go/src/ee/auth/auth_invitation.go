@@ -0,0 +1,380 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-ee/utils"
+	"github.com/go-ee/utils/crypt"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/looplab/eventhorizon"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const NotificationInvitation = "invitation"
+
+func (o *Invitation) IsPending() bool {
+	return o.AggregateState == InvitationAggregateStateTypes().Pending().Name()
+}
+
+func (o *Invitation) IsExpired(now time.Time) bool {
+	return o.ExpiresAt == nil || !now.Before(*o.ExpiresAt)
+}
+
+// Public is the invitation without the hash of its token
+func (o *Invitation) Public() (ret *Invitation) {
+	ret = &Invitation{
+		Email:          o.Email,
+		Roles:          o.Roles,
+		OrganizationId: o.OrganizationId,
+		ExpiresAt:      o.ExpiresAt,
+		InvitedBy:      o.InvitedBy,
+		SentAt:         o.SentAt,
+		AcceptedAt:     o.AcceptedAt,
+		AccountId:      o.AccountId,
+		Id:             o.Id,
+		AggregateState: o.AggregateState,
+	}
+	return
+}
+
+func (o *EsEngine) ImplementInvitations(accounts *AccountQueryRepository, policy *RoleGrantPolicy) {
+	o.Invitation.ImplementInvitations(accounts, policy)
+}
+
+func (o *InvitationAggregateEngine) ImplementInvitations(accounts *AccountQueryRepository, policy *RoleGrantPolicy) {
+	o.AggregateExecutors.Initial.AddCreatePreparer(func(cmd *CreateInvitation, entity *Invitation) (err error) {
+		if !strings.Contains(cmd.Email, "@") {
+			err = errors.New("valid email is required")
+		} else if cmd.TokenHash == "" || cmd.InvitedBy == uuid.Nil {
+			err = errors.New("token and inviter are required")
+		} else if cmd.ExpiresAt == nil || !cmd.ExpiresAt.After(time.Now()) {
+			err = errors.New("expiry of the invitation must be in the future")
+		} else {
+			if err = policy.CheckGranted(cmd.Roles, nil); err != nil {
+				return
+			}
+
+			var existing *Account
+			if existing, err = accounts.ForTenant(cmd.OrganizationId).FindByEmail(cmd.Email); err == nil &&
+				existing != nil {
+				err = fmt.Errorf("account with email '%v' exists already", cmd.Email)
+			}
+		}
+		return
+	})
+
+	o.AggregateExecutors.Pending.AddResendPreparer(func(cmd *ResendInvitation, entity *Invitation) (err error) {
+		if cmd.TokenHash == "" {
+			err = errors.New("token is required")
+		} else if cmd.ExpiresAt == nil || !cmd.ExpiresAt.After(time.Now()) {
+			err = errors.New("expiry of the invitation must be in the future")
+		}
+		return
+	})
+
+	o.AggregateExecutors.Pending.AddAcceptPreparer(func(cmd *AcceptInvitation, entity *Invitation) (err error) {
+		if cmd.AccountId == uuid.Nil {
+			err = errors.New("account is required")
+		} else if entity.IsExpired(time.Now()) {
+			err = errors.New("invitation is expired")
+		}
+		return
+	})
+
+	createdHandler := o.AggregateHandlers.Initial.CreatedHandler
+	o.AggregateHandlers.Initial.CreatedHandler =
+		func(event eventhorizon.Event, eventData *InvitationCreated, entity *Invitation) (err error) {
+			if err = createdHandler(event, eventData, entity); err == nil {
+				entity.SentAt = utils.PtrTime(event.Timestamp())
+			}
+			return
+		}
+
+	o.AggregateHandlers.Pending.ResentHandler =
+		func(event eventhorizon.Event, eventData *InvitationResent, entity *Invitation) (err error) {
+			entity.TokenHash = eventData.TokenHash
+			entity.ExpiresAt = eventData.ExpiresAt
+			entity.SentAt = utils.PtrTime(event.Timestamp())
+			return
+		}
+
+	o.AggregateHandlers.Pending.AcceptedHandler =
+		func(event eventhorizon.Event, eventData *InvitationAccepted, entity *Invitation) (err error) {
+			entity.AccountId = eventData.AccountId
+			entity.AcceptedAt = utils.PtrTime(event.Timestamp())
+			return
+		}
+}
+
+type InvitationRequest struct {
+	Email          string    `json:"email"`
+	Roles          []string  `json:"roles,omitempty"`
+	OrganizationId uuid.UUID `json:"organizationId,omitempty"`
+}
+
+// AcceptInvitationRequest carries the token of the invitation and the credentials chosen by the user
+type AcceptInvitationRequest struct {
+	Token    string      `json:"token"`
+	Username string      `json:"username"`
+	Password string      `json:"password"`
+	Name     *PersonName `json:"name,omitempty"`
+}
+
+// InviteRouter sends invitations to accounts, the invited user creates the account with the own password.
+// It has its own path, the generated invitation commands are internal.
+type InviteRouter struct {
+	PathPrefix  string
+	Invitations *InvitationQueryRepository
+	CommandBus  eventhorizon.CommandHandler
+	Notifier    Notifier
+	Ttl         time.Duration
+	ctx         context.Context
+}
+
+func NewInviteRouter(pathPrefix string, newContext func(string) (ret context.Context),
+	commandBus eventhorizon.CommandHandler, invitations *InvitationQueryRepository, notifier Notifier,
+	ttl time.Duration) (ret *InviteRouter) {
+	ret = &InviteRouter{
+		PathPrefix:  pathPrefix + "/" + "invite",
+		Invitations: invitations,
+		CommandBus:  commandBus,
+		Notifier:    notifier,
+		Ttl:         ttl,
+		ctx:         newContext("invitation"),
+	}
+	return
+}
+
+func (o *InviteRouter) Setup(router *mux.Router) (err error) {
+	router.Methods(http.MethodGet).Path(o.PathPrefix).
+		Name("InvitationFindPending").
+		HandlerFunc(o.FindPending)
+	router.Methods(http.MethodPost).Path(o.PathPrefix).
+		Name("Invite").
+		HandlerFunc(o.Invite)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefix).Path("/{id}/resend").
+		Name("ResendInvite").
+		HandlerFunc(o.Resend)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefix).Path("/{id}/revoke").
+		Name("RevokeInvite").
+		HandlerFunc(o.Revoke)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefix).Path("/{id}/accept").
+		Name("AcceptInvite").
+		HandlerFunc(o.Accept)
+	return
+}
+
+func (o *InviteRouter) FindPending(w http.ResponseWriter, r *http.Request) {
+	principal, ok := o.principal(w, r)
+	if !ok {
+		return
+	}
+
+	invitations, err := o.Invitations.FindAll()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", err)
+		return
+	}
+
+	ret := []*Invitation{}
+	for _, invitation := range invitations {
+		if invitation.IsPending() && o.isAccessible(principal, invitation) {
+			ret = append(ret, invitation.Public())
+		}
+	}
+	writeJSON(w, http.StatusOK, ret)
+}
+
+func (o *InviteRouter) Invite(w http.ResponseWriter, r *http.Request) {
+	principal, ok := o.principal(w, r)
+	if !ok {
+		return
+	}
+
+	request := &InvitationRequest{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+	if !principal.HasPermission(PermissionManageOrganizations) {
+		request.OrganizationId = principal.OrganizationId
+	}
+	if err := principal.CheckGrantable(request.Roles, nil); err != nil {
+		writeError(w, http.StatusForbidden, "access_denied", err)
+		return
+	}
+
+	secret, hash, err := newInvitationToken()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", err)
+		return
+	}
+
+	create := &CreateInvitation{
+		Id:             uuid.New(),
+		Email:          request.Email,
+		Roles:          request.Roles,
+		OrganizationId: request.OrganizationId,
+		TokenHash:      hash,
+		ExpiresAt:      utils.PtrTime(time.Now().Add(o.Ttl)),
+		InvitedBy:      principal.AccountId,
+	}
+	if err = o.CommandBus.HandleCommand(o.ctx, create); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+	// the email of the command is sealed by the preparers
+	if err = o.notify(create.Id, request.Email, secret, create.ExpiresAt); err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, &Invitation{Id: create.Id, Email: request.Email, Roles: create.Roles,
+		OrganizationId: create.OrganizationId, ExpiresAt: create.ExpiresAt, InvitedBy: create.InvitedBy})
+}
+
+// Resend replaces the token and the expiry of the invitation, the former token is invalid afterwards
+func (o *InviteRouter) Resend(w http.ResponseWriter, r *http.Request) {
+	invitation, ok := o.pendingInvitation(w, r)
+	if !ok {
+		return
+	}
+
+	secret, hash, err := newInvitationToken()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", err)
+		return
+	}
+
+	resend := &ResendInvitation{Id: invitation.Id, TokenHash: hash, ExpiresAt: utils.PtrTime(time.Now().Add(o.Ttl))}
+	if err = o.CommandBus.HandleCommand(o.ctx, resend); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+	if err = o.notify(invitation.Id, invitation.Email, secret, resend.ExpiresAt); err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (o *InviteRouter) Revoke(w http.ResponseWriter, r *http.Request) {
+	invitation, ok := o.pendingInvitation(w, r)
+	if !ok {
+		return
+	}
+
+	if err := o.CommandBus.HandleCommand(o.ctx, &RevokeInvitation{Id: invitation.Id}); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Accept creates the account of the invitation, it is open for the invited user without authentication
+func (o *InviteRouter) Accept(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	request := &AcceptInvitationRequest{}
+	if err = json.NewDecoder(r.Body).Decode(request); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+
+	var invitation *Invitation
+	if invitation, err = o.Invitations.FindById(id); err != nil || invitation == nil || !invitation.IsPending() ||
+		invitation.IsExpired(time.Now()) || !crypt.HashAndEquals(request.Token, invitation.TokenHash) {
+		writeError(w, http.StatusBadRequest, "invalid_grant", errors.New("invitation is invalid or expired"))
+		return
+	}
+
+	// the user chooses the password, it is registered instead of created to skip the forced change
+	create := &RegisterAccount{
+		Id:             uuid.New(),
+		Name:           request.Name,
+		Username:       request.Username,
+		Password:       request.Password,
+		Email:          invitation.Email,
+		Roles:          invitation.Roles,
+		OrganizationId: invitation.OrganizationId,
+	}
+	if err = o.CommandBus.HandleCommand(o.ctx, create); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+	if err = o.CommandBus.HandleCommand(o.ctx, &AcceptInvitation{Id: invitation.Id, AccountId: create.Id}); err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, map[string]uuid.UUID{"id": create.Id})
+}
+
+func (o *InviteRouter) notify(id uuid.UUID, email string, secret string, expiresAt *time.Time) (err error) {
+	err = o.Notifier.Notify(&Notification{
+		Kind:    NotificationInvitation,
+		To:      email,
+		Subject: "Invitation",
+		Data: map[string]string{
+			"invitationId": id.String(),
+			"token":        secret,
+			"expiresAt":    expiresAt.Format(time.RFC3339),
+		},
+	})
+	return
+}
+
+// principal of the request, invitations are managed by account managers within their organization
+func (o *InviteRouter) principal(w http.ResponseWriter, r *http.Request) (ret *Principal, ok bool) {
+	if ret = PrincipalFrom(r.Context()); ret == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", ErrUnauthenticated)
+		return
+	}
+	if !ret.HasPermission(PermissionManageAccounts) {
+		writeError(w, http.StatusForbidden, "access_denied", errors.New("missing permission "+PermissionManageAccounts))
+		return
+	}
+	ok = true
+	return
+}
+
+func (o *InviteRouter) isAccessible(principal *Principal, invitation *Invitation) bool {
+	return principal.HasPermission(PermissionManageOrganizations) || invitation.OrganizationId == principal.OrganizationId
+}
+
+func (o *InviteRouter) pendingInvitation(w http.ResponseWriter, r *http.Request) (ret *Invitation, ok bool) {
+	principal, authorized := o.principal(w, r)
+	if !authorized {
+		return
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	if ret, err = o.Invitations.FindById(id); err != nil || ret == nil || !o.isAccessible(principal, ret) {
+		http.NotFound(w, r)
+		return
+	}
+	if !ret.IsPending() {
+		writeError(w, http.StatusConflict, "invalid_request", errors.New("invitation is not pending"))
+		return
+	}
+	ok = true
+	return
+}
+
+func newInvitationToken() (secret string, hash string, err error) {
+	if secret, err = randomToken(); err == nil {
+		hash, err = crypt.Hash(secret)
+	}
+	return
+}
@@ -0,0 +1,169 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-ee/utils"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/looplab/eventhorizon"
+	"net/http"
+)
+
+// DisabledReasonMerged is the reason of accounts disabled because they are merged into another account
+const DisabledReasonMerged = "merged"
+
+func (o *Account) IsMerged() bool {
+	return o.MergedInto != uuid.Nil
+}
+
+func (o *EsEngine) ImplementMerge(accounts *AccountQueryRepository) {
+	o.Account.ImplementMerge(accounts)
+}
+
+// ImplementMerge moves roles, role assignments, linked identities and api keys of a duplicate account to the
+// account it is merged into. The moved data is taken from the source account, not from the command. The source
+// keeps a pointer to the target and stays disabled, the password of the target is kept.
+func (o *AccountAggregateEngine) ImplementMerge(accounts *AccountQueryRepository) {
+	mergePreparer := func(cmd *MergeAccount, entity *Account) (err error) {
+		if cmd.SourceId == uuid.Nil || cmd.SourceId == entity.Id {
+			err = errors.New("another account is required as source of the merge")
+			return
+		}
+
+		var source *Account
+		if source, err = accounts.FindById(cmd.SourceId); err != nil {
+			return
+		}
+		if source == nil || source.DeletedAt != nil {
+			err = fmt.Errorf("account '%v' not found", cmd.SourceId)
+		} else if source.OrganizationId != entity.OrganizationId {
+			err = errors.New("accounts of different organizations can not be merged")
+		} else if source.IsMerged() && source.MergedInto != entity.Id {
+			err = fmt.Errorf("account '%v' is merged into another account", cmd.SourceId)
+		} else {
+			cmd.Roles = source.Roles
+			cmd.RoleAssignments = source.RoleAssignments
+			cmd.Identities = source.Identities
+			cmd.ApiKeys = source.ApiKeys
+		}
+		return
+	}
+	o.AggregateExecutors.Enabled.AddMergePreparer(mergePreparer)
+	o.AggregateExecutors.Disabled.AddMergePreparer(mergePreparer)
+
+	mergeIntoPreparer := func(cmd *MergeIntoAccount, entity *Account) (err error) {
+		if cmd.TargetId == uuid.Nil || cmd.TargetId == entity.Id {
+			err = errors.New("another account is required as target of the merge")
+			return
+		}
+
+		var target *Account
+		if target, err = accounts.FindById(cmd.TargetId); err == nil && (target == nil || target.DeletedAt != nil) {
+			err = fmt.Errorf("account '%v' not found", cmd.TargetId)
+		} else if err == nil && target.IsMerged() {
+			err = fmt.Errorf("account '%v' is merged into another account", cmd.TargetId)
+		}
+		return
+	}
+	o.AggregateExecutors.Enabled.AddMergeIntoPreparer(mergeIntoPreparer)
+	o.AggregateExecutors.Disabled.AddMergeIntoPreparer(mergeIntoPreparer)
+
+	// data the target has already is not added again, a repeated merge changes nothing
+	mergedHandler := func(event eventhorizon.Event, eventData *AccountMerged, entity *Account) (err error) {
+		for _, role := range eventData.Roles {
+			if !entity.HasRole(role) {
+				entity.AddToRoles(role)
+			}
+		}
+		for _, assignment := range eventData.RoleAssignments {
+			if entity.FindRoleAssignment(assignment.AssignmentId) == nil {
+				entity.AddToRoleAssignments(assignment)
+			}
+		}
+		for _, identity := range eventData.Identities {
+			if entity.FindIdentity(identity.Issuer, identity.Subject) == nil {
+				entity.AddToIdentities(identity)
+			}
+		}
+		for _, apiKey := range eventData.ApiKeys {
+			if entity.FindApiKey(apiKey.KeyId) == nil {
+				entity.AddToApiKeys(apiKey)
+			}
+		}
+		return
+	}
+	o.AggregateHandlers.Enabled.MergedHandler = mergedHandler
+	o.AggregateHandlers.Disabled.MergedHandler = mergedHandler
+
+	mergedIntoHandler := func(event eventhorizon.Event, eventData *AccountMergedInto, entity *Account) (err error) {
+		entity.MergedInto = eventData.TargetId
+		entity.MergedAt = utils.PtrTime(event.Timestamp())
+		entity.Disabled = true
+		entity.DisabledReason = DisabledReasonMerged
+		entity.Roles = nil
+		entity.RoleAssignments = nil
+		entity.Identities = nil
+		entity.ApiKeys = nil
+		entity.Sessions = nil
+		return
+	}
+	o.AggregateHandlers.Enabled.MergedIntoHandler = mergedIntoHandler
+	o.AggregateHandlers.Disabled.MergedIntoHandler = mergedIntoHandler
+}
+
+type MergeRequest struct {
+	SourceId uuid.UUID `json:"sourceId"`
+}
+
+// MergeRouter merges a duplicate account into the account of the path. The target takes over the data first,
+// then the source is marked as merged, a failed marking is repeated by a repeated merge. The path differs
+// from the generated merge command, that one is internal.
+type MergeRouter struct {
+	PathPrefix string
+	CommandBus eventhorizon.CommandHandler
+	ctx        context.Context
+}
+
+func NewMergeRouter(pathPrefix string, newContext func(string) (ret context.Context),
+	commandBus eventhorizon.CommandHandler) (ret *MergeRouter) {
+	ret = &MergeRouter{
+		PathPrefix: pathPrefix + "/" + "account",
+		CommandBus: commandBus,
+		ctx:        newContext("merge"),
+	}
+	return
+}
+
+func (o *MergeRouter) Setup(router *mux.Router) (err error) {
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefix).Path("/{id}/duplicates").
+		Name("MergeAccounts").
+		HandlerFunc(o.Merge)
+	return
+}
+
+func (o *MergeRouter) Merge(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	request := &MergeRequest{}
+	if err = json.NewDecoder(r.Body).Decode(request); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+
+	if err = o.CommandBus.HandleCommand(o.ctx, &MergeAccount{Id: id, SourceId: request.SourceId}); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+	if err = o.CommandBus.HandleCommand(o.ctx, &MergeIntoAccount{Id: request.SourceId, TargetId: id}); err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
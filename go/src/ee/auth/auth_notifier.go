@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"github.com/sirupsen/logrus"
+)
+
+// Notification is a message to the owner of an email address, e.g. the token of an invitation
+type Notification struct {
+	Kind    string
+	To      string
+	Subject string
+	Data    map[string]string
+}
+
+// Notifier delivers notifications, e.g. by mail
+type Notifier interface {
+	Notify(notification *Notification) (err error)
+}
+
+// notificationSecrets are the keys of data never logged, a token grants what the notification offers
+var notificationSecrets = map[string]bool{
+	"token": true,
+}
+
+// LogNotifier writes notifications to the log, it is used until a delivery is configured.
+// Secrets are left out, the notification can not be used from the log.
+type LogNotifier struct{}
+
+func (o *LogNotifier) Notify(notification *Notification) (err error) {
+	data := make(map[string]string, len(notification.Data))
+	for key, value := range notification.Data {
+		if notificationSecrets[key] {
+			value = "[hidden]"
+		}
+		data[key] = value
+	}
+	logrus.Infof("notification '%v' to %v: %v %v", notification.Kind, notification.To, notification.Subject, data)
+	return
+}
@@ -0,0 +1,220 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/looplab/eventhorizon"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+var ErrInvalidClient = errors.New("client authentication failed")
+
+// RevocationStore keeps the ids of revoked tokens until the tokens expire.
+type RevocationStore interface {
+	Revoke(tokenId string, expiresAt time.Time) (err error)
+	IsRevoked(tokenId string) (ret bool, err error)
+}
+
+type MemoryRevocationStore struct {
+	revoked map[string]time.Time
+	mutex   sync.RWMutex
+}
+
+func NewMemoryRevocationStore() (ret *MemoryRevocationStore) {
+	ret = &MemoryRevocationStore{revoked: map[string]time.Time{}}
+	return
+}
+
+func (o *MemoryRevocationStore) Revoke(tokenId string, expiresAt time.Time) (err error) {
+	now := time.Now()
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	for id, expiry := range o.revoked {
+		if expiry.Before(now) {
+			delete(o.revoked, id)
+		}
+	}
+	o.revoked[tokenId] = expiresAt
+	return
+}
+
+func (o *MemoryRevocationStore) IsRevoked(tokenId string) (ret bool, err error) {
+	o.mutex.RLock()
+	_, ret = o.revoked[tokenId]
+	o.mutex.RUnlock()
+	return
+}
+
+type OAuthClient struct {
+	ClientId     string `json:"clientId"`
+	ClientSecret string `json:"clientSecret"`
+}
+
+type ClientConfig struct {
+	Clients []*OAuthClient `json:"clients"`
+}
+
+func LoadClientConfig(file string) (ret *ClientConfig, err error) {
+	var data []byte
+	if data, err = ioutil.ReadFile(file); err != nil {
+		return
+	}
+	ret = &ClientConfig{}
+	err = json.Unmarshal(data, ret)
+	return
+}
+
+// Authenticate checks the client credentials of the request, sent as basic credentials or form values
+func (o *ClientConfig) Authenticate(r *http.Request) (ret *OAuthClient, err error) {
+	clientId, clientSecret, ok := r.BasicAuth()
+	if ok {
+		clientId, _ = url.QueryUnescape(clientId)
+		clientSecret, _ = url.QueryUnescape(clientSecret)
+	} else {
+		clientId, clientSecret = r.PostFormValue("client_id"), r.PostFormValue("client_secret")
+	}
+
+	if clientId != "" && clientSecret != "" {
+		for _, client := range o.Clients {
+			if client.ClientId == clientId &&
+				subtle.ConstantTimeCompare([]byte(client.ClientSecret), []byte(clientSecret)) == 1 {
+				return client, nil
+			}
+		}
+	}
+	err = ErrInvalidClient
+	return
+}
+
+// IntrospectionResponse follows RFC 7662
+type IntrospectionResponse struct {
+	Active    bool     `json:"active"`
+	Scope     string   `json:"scope,omitempty"`
+	Username  string   `json:"username,omitempty"`
+	TokenType string   `json:"token_type,omitempty"`
+	Exp       int64    `json:"exp,omitempty"`
+	Iat       int64    `json:"iat,omitempty"`
+	Sub       string   `json:"sub,omitempty"`
+	Iss       string   `json:"iss,omitempty"`
+	Jti       string   `json:"jti,omitempty"`
+	Sid       string   `json:"sid,omitempty"`
+	Roles     []string `json:"roles,omitempty"`
+	Act       *Actor   `json:"act,omitempty"`
+	Tenant    string   `json:"tenant,omitempty"`
+}
+
+// OAuthRouter serves token introspection (RFC 7662) and revocation (RFC 7009) for authenticated clients.
+type OAuthRouter struct {
+	PathPrefix    string
+	Clients       *ClientConfig
+	Authenticator *Authenticator
+	CommandBus    eventhorizon.CommandHandler
+	Revocations   RevocationStore
+	ctx           context.Context
+}
+
+func NewOAuthRouter(pathPrefix string, newContext func(string) (ret context.Context),
+	commandBus eventhorizon.CommandHandler, authenticator *Authenticator, revocations RevocationStore,
+	clients *ClientConfig) (ret *OAuthRouter) {
+	ret = &OAuthRouter{
+		PathPrefix:    pathPrefix,
+		Clients:       clients,
+		Authenticator: authenticator,
+		CommandBus:    commandBus,
+		Revocations:   revocations,
+		ctx:           newContext("oauth"),
+	}
+	return
+}
+
+func (o *OAuthRouter) Setup(router *mux.Router) (err error) {
+	router.Methods(http.MethodPost).Path(o.PathPrefix + "/introspect").
+		Name("Introspect").
+		HandlerFunc(o.Introspect)
+	router.Methods(http.MethodPost).Path(o.PathPrefix + "/revoke").
+		Name("Revoke").
+		HandlerFunc(o.Revoke)
+	return
+}
+
+func (o *OAuthRouter) Introspect(w http.ResponseWriter, r *http.Request) {
+	if _, ok := o.authenticateClient(w, r); !ok {
+		return
+	}
+
+	ret := &IntrospectionResponse{}
+	if claims, principal, err := o.Authenticator.VerifyToken(r.PostFormValue("token")); err == nil {
+		ret = &IntrospectionResponse{
+			Active:    true,
+			Scope:     claims.Scope,
+			Username:  claims.Username,
+			TokenType: "Bearer",
+			Exp:       claims.ExpiresAt,
+			Iat:       claims.IssuedAt,
+			Sub:       claims.Subject,
+			Iss:       claims.Issuer,
+			Jti:       claims.Id,
+			Sid:       claims.SessionId,
+			Roles:     principal.Roles,
+			Act:       claims.Actor,
+			Tenant:    claims.Tenant,
+		}
+	}
+	writeJSON(w, http.StatusOK, ret)
+}
+
+// Revoke invalidates the token and ends its session or impersonation, unknown tokens are no error
+func (o *OAuthRouter) Revoke(w http.ResponseWriter, r *http.Request) {
+	if _, ok := o.authenticateClient(w, r); !ok {
+		return
+	}
+
+	token := r.PostFormValue("token")
+	if token == "" {
+		writeError(w, http.StatusBadRequest, "invalid_request", errors.New("token is required"))
+		return
+	}
+
+	claims, principal, err := o.Authenticator.VerifyToken(token)
+	if err != nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if err = o.Revocations.Revoke(claims.Id, time.Unix(claims.ExpiresAt, 0)); err == nil {
+		if principal.IsImpersonated() {
+			err = o.CommandBus.HandleCommand(o.ctx, &EndImpersonationAccount{Id: principal.AccountId,
+				ImpersonationId: principal.ImpersonationId, ActorId: principal.Actor.AccountId})
+		} else if principal.SessionId != uuid.Nil {
+			err = o.CommandBus.HandleCommand(o.ctx, &RevokeSessionAccount{Id: principal.AccountId,
+				SessionId: principal.SessionId})
+		}
+	}
+
+	if err != nil {
+		writeError(w, http.StatusServiceUnavailable, "temporarily_unavailable", err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (o *OAuthRouter) authenticateClient(w http.ResponseWriter, r *http.Request) (ret *OAuthClient, ok bool) {
+	var err error
+	if ret, err = o.Clients.Authenticate(r); err != nil {
+		w.Header().Set("WWW-Authenticate", `Basic realm="auth"`)
+		writeError(w, http.StatusUnauthorized, "invalid_client", err)
+		return
+	}
+	ok = true
+	return
+}
@@ -0,0 +1,404 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/looplab/eventhorizon"
+	"net/http"
+	"strings"
+)
+
+// PermissionManageOrganizations allows to manage organizations and the accounts of all organizations
+const PermissionManageOrganizations = "auth:organizations"
+
+// PermissionManageAccounts allows to create accounts in the own organization
+const PermissionManageAccounts = "auth:accounts"
+
+func (o *Account) BelongsTo(organizationId uuid.UUID) bool {
+	return o.OrganizationId == organizationId
+}
+
+func (o *OrganizationQueryRepository) FindByName(name string) (ret *Organization, err error) {
+	var organizations []*Organization
+	if organizations, err = o.FindAll(); err != nil {
+		return
+	}
+	for _, organization := range organizations {
+		if organization.Name == name {
+			return organization, nil
+		}
+	}
+	return
+}
+
+// ForTenant scopes the account queries to the organization, uuid.Nil scopes to accounts without organization
+func (o *AccountQueryRepository) ForTenant(organizationId uuid.UUID) (ret *TenantAccountQueryRepository) {
+	ret = &TenantAccountQueryRepository{Accounts: o, OrganizationId: organizationId}
+	return
+}
+
+type TenantAccountQueryRepository struct {
+	Accounts       *AccountQueryRepository
+	OrganizationId uuid.UUID
+}
+
+func (o *TenantAccountQueryRepository) FindAll() (ret []*Account, err error) {
+	var accounts []*Account
+	if accounts, err = o.Accounts.FindAll(); err != nil {
+		return
+	}
+	ret = []*Account{}
+	for _, account := range accounts {
+		if account.BelongsTo(o.OrganizationId) {
+			ret = append(ret, account)
+		}
+	}
+	return
+}
+
+func (o *TenantAccountQueryRepository) FindById(id uuid.UUID) (ret *Account, err error) {
+	var account *Account
+	if account, err = o.Accounts.FindById(id); err == nil && account != nil && account.BelongsTo(o.OrganizationId) {
+		ret = account
+	}
+	return
+}
+
+func (o *TenantAccountQueryRepository) FindByUsername(username string) (ret *Account, err error) {
+	var accounts []*Account
+	if accounts, err = o.FindAll(); err != nil {
+		return
+	}
+	for _, account := range accounts {
+		if account.Username == username {
+			return account, nil
+		}
+	}
+	err = fmt.Errorf("account '%v' not found", username)
+	return
+}
+
+func (o *TenantAccountQueryRepository) FindByEmail(email string) (ret *Account, err error) {
+	var accounts []*Account
+	if accounts, err = o.FindAll(); err == nil {
+		for _, account := range accounts {
+			if strings.EqualFold(account.Email, email) {
+				return account, nil
+			}
+		}
+	}
+	return
+}
+
+// FindAllByEmail returns all accounts of the organization with the email, forgotten accounts are skipped
+func (o *TenantAccountQueryRepository) FindAllByEmail(email string) (ret []*Account, err error) {
+	var accounts []*Account
+	if accounts, err = o.FindAll(); err == nil {
+		for _, account := range accounts {
+			if !account.Forgotten && strings.EqualFold(account.Email, email) {
+				ret = append(ret, account)
+			}
+		}
+	}
+	return
+}
+
+// FindByCredentials resolves the account of the organization by id or username and verifies the password
+func (o *TenantAccountQueryRepository) FindByCredentials(username string, password string) (ret *Account, err error) {
+	var account *Account
+	if id, parseErr := uuid.Parse(username); parseErr == nil {
+		account, err = o.FindById(id)
+	} else {
+		account, err = o.FindByUsername(username)
+	}
+	ret, err = verifyCredentials(account, err, password)
+	return
+}
+
+func (o *TenantAccountQueryRepository) CountAll() (ret int, err error) {
+	var accounts []*Account
+	if accounts, err = o.FindAll(); err == nil {
+		ret = len(accounts)
+	}
+	return
+}
+
+// checkUnique verifies that username and email are not used by another account of the organization
+func (o *TenantAccountQueryRepository) checkUnique(accountId uuid.UUID, username string, email string) (err error) {
+	var accounts []*Account
+	if accounts, err = o.FindAll(); err != nil {
+		return
+	}
+	for _, account := range accounts {
+		if account.Id == accountId || account.Forgotten {
+			continue
+		}
+		if username != "" && account.Username == username {
+			err = fmt.Errorf("username '%v' exists already", username)
+		} else if email != "" && strings.EqualFold(account.Email, email) {
+			err = fmt.Errorf("email '%v' exists already", email)
+		}
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (o *EsEngine) ImplementOrganizations(accounts *AccountQueryRepository, organizations *OrganizationQueryRepository) {
+	o.Organization.ImplementOrganizations(accounts, organizations)
+	o.Account.ImplementTenancy(accounts, organizations)
+}
+
+func (o *OrganizationAggregateEngine) ImplementOrganizations(accounts *AccountQueryRepository,
+	organizations *OrganizationQueryRepository) {
+
+	o.AggregateExecutors.Initial.AddCreatePreparer(func(cmd *CreateOrganization, entity *Organization) (err error) {
+		if cmd.Name == "" {
+			err = errors.New("organization name is required")
+		} else if existing, findErr := organizations.FindByName(cmd.Name); findErr != nil {
+			err = findErr
+		} else if existing != nil {
+			err = fmt.Errorf("organization '%v' exists already", cmd.Name)
+		}
+		return
+	})
+
+	o.AggregateExecutors.Exist.AddUpdatePreparer(func(cmd *UpdateOrganization, entity *Organization) (err error) {
+		if cmd.Name != "" && cmd.Name != entity.Name {
+			var existing *Organization
+			if existing, err = organizations.FindByName(cmd.Name); err == nil && existing != nil {
+				err = fmt.Errorf("organization '%v' exists already", cmd.Name)
+			}
+		}
+		return
+	})
+
+	o.AggregateExecutors.Exist.AddDeletePreparer(func(cmd *DeleteOrganization, entity *Organization) (err error) {
+		var count int
+		if count, err = accounts.ForTenant(entity.Id).CountAll(); err == nil && count > 0 {
+			err = fmt.Errorf("organization '%v' has %v accounts", entity.Name, count)
+		}
+		return
+	})
+}
+
+// ImplementTenancy binds accounts to their organization, username and email are unique per organization
+func (o *AccountAggregateEngine) ImplementTenancy(accounts *AccountQueryRepository,
+	organizations *OrganizationQueryRepository) {
+
+	o.AggregateExecutors.Initial.AddCreatePreparer(func(cmd *CreateAccount, entity *Account) (err error) {
+		if cmd.OrganizationId != uuid.Nil {
+			var organization *Organization
+			if organization, err = organizations.FindById(cmd.OrganizationId); err != nil || organization == nil {
+				err = fmt.Errorf("organization '%v' not found", cmd.OrganizationId)
+				return
+			}
+		}
+		err = accounts.ForTenant(cmd.OrganizationId).checkUnique(cmd.Id, cmd.Username, cmd.Email)
+		return
+	})
+
+	o.AggregateExecutors.Exist.AddUpdatePreparer(func(cmd *UpdateAccount, entity *Account) (err error) {
+		if cmd.OrganizationId == uuid.Nil {
+			cmd.OrganizationId = entity.OrganizationId
+		} else if cmd.OrganizationId != entity.OrganizationId {
+			err = errors.New("organization of an account can not be changed")
+			return
+		}
+		err = accounts.ForTenant(entity.OrganizationId).checkUnique(entity.Id, cmd.Username, cmd.Email)
+		return
+	})
+}
+
+// TenantGuard restricts principals to the accounts of their organization, principals without organization
+// to the accounts without organization. Principals managing organizations are not restricted.
+type TenantGuard struct {
+	// AccountPrefixes are the path prefixes of routes with the account id as path variable
+	AccountPrefixes []string
+	// GlobalRoutes are not scoped by an organization and open only to principals managing organizations
+	GlobalRoutes map[string]bool
+	// PublicRoutes are account routes open to unauthenticated callers
+	PublicRoutes map[string]bool
+	Accounts     *AccountQueryRepository
+}
+
+func NewTenantGuard(pathPrefix string, accounts *AccountQueryRepository) (ret *TenantGuard) {
+	ret = &TenantGuard{
+		AccountPrefixes: []string{pathPrefix + "/" + "account/", pathPrefix + "/" + "impersonation/"},
+		GlobalRoutes: map[string]bool{
+			"CreateAccount":   true,
+			"AccountFindAll":  true,
+			"AccountCountAll": true,
+			"AccountExistAll": true,
+			// invitations of the tenant are listed by InvitationFindPending
+			"InvitationFindById":  true,
+			"InvitationCountById": true,
+			"InvitationExistById": true,
+			"InvitationFindAll":   true,
+			"InvitationCountAll":  true,
+			"InvitationExistAll":  true,
+		},
+		PublicRoutes: map[string]bool{
+			"LoginAccount":       true,
+			"ConfirmEmailChange": true,
+		},
+		Accounts: accounts,
+	}
+	return
+}
+
+func (o *TenantGuard) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal := PrincipalFrom(r.Context())
+		route := mux.CurrentRoute(r)
+		if route == nil || (principal != nil && principal.HasPermission(PermissionManageOrganizations)) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if o.GlobalRoutes[route.GetName()] {
+			if principal == nil {
+				writeError(w, http.StatusUnauthorized, "unauthorized", ErrUnauthenticated)
+			} else {
+				writeError(w, http.StatusForbidden, "access_denied",
+					errors.New("missing permission "+PermissionManageOrganizations))
+			}
+			return
+		}
+
+		if id, err := uuid.Parse(mux.Vars(r)["id"]); err == nil && o.isAccountRoute(route) {
+			if principal == nil {
+				if !o.PublicRoutes[route.GetName()] {
+					writeError(w, http.StatusUnauthorized, "unauthorized", ErrUnauthenticated)
+					return
+				}
+			} else if account, findErr := o.Accounts.ForTenant(principal.OrganizationId).FindById(id); findErr != nil ||
+				account == nil {
+				http.NotFound(w, r)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (o *TenantGuard) isAccountRoute(route *mux.Route) bool {
+	if template, err := route.GetPathTemplate(); err == nil {
+		for _, prefix := range o.AccountPrefixes {
+			if strings.HasPrefix(template, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// TenantRouter serves the accounts of an organization.
+type TenantRouter struct {
+	PathPrefix    string
+	Accounts      *AccountQueryRepository
+	Organizations *OrganizationQueryRepository
+	CommandBus    eventhorizon.CommandHandler
+	ctx           context.Context
+}
+
+func NewTenantRouter(pathPrefix string, newContext func(string) (ret context.Context),
+	commandBus eventhorizon.CommandHandler, accounts *AccountQueryRepository,
+	organizations *OrganizationQueryRepository) (ret *TenantRouter) {
+	ret = &TenantRouter{
+		PathPrefix:    pathPrefix + "/" + "organization",
+		Accounts:      accounts,
+		Organizations: organizations,
+		CommandBus:    commandBus,
+		ctx:           newContext("tenant"),
+	}
+	return
+}
+
+func (o *TenantRouter) Setup(router *mux.Router) (err error) {
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefix).Path("/{id}/accounts").
+		Name("OrganizationAccountFindAll").
+		HandlerFunc(o.FindAll)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefix).Path("/{id}/accounts").
+		Name("OrganizationCreateAccount").
+		HandlerFunc(o.Create)
+	return
+}
+
+func (o *TenantRouter) FindAll(w http.ResponseWriter, r *http.Request) {
+	organization, ok := o.organization(w, r)
+	if !ok {
+		return
+	}
+
+	if ret, err := o.Accounts.ForTenant(organization.Id).FindAll(); err == nil {
+		writeJSON(w, http.StatusOK, PublicAccounts(ret))
+	} else {
+		writeError(w, http.StatusInternalServerError, "server_error", err)
+	}
+}
+
+func (o *TenantRouter) Create(w http.ResponseWriter, r *http.Request) {
+	organization, ok := o.organization(w, r)
+	if !ok {
+		return
+	}
+
+	principal := PrincipalFrom(r.Context())
+	if !principal.HasPermission(PermissionManageAccounts) && !principal.HasPermission(PermissionManageOrganizations) {
+		writeError(w, http.StatusForbidden, "access_denied", errors.New("missing permission "+PermissionManageAccounts))
+		return
+	}
+
+	cmd := &CreateAccount{}
+	if err := json.NewDecoder(r.Body).Decode(cmd); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+	if err := principal.CheckGrantable(cmd.Roles, nil); err != nil {
+		writeError(w, http.StatusForbidden, "access_denied", err)
+		return
+	}
+	if cmd.Id == uuid.Nil {
+		cmd.Id = uuid.New()
+	}
+	cmd.OrganizationId = organization.Id
+
+	if err := o.CommandBus.HandleCommand(o.ctx, cmd); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, map[string]uuid.UUID{"id": cmd.Id})
+}
+
+// organization loads the organization of the path, accessible by its members and organization managers
+func (o *TenantRouter) organization(w http.ResponseWriter, r *http.Request) (ret *Organization, ok bool) {
+	principal := PrincipalFrom(r.Context())
+	if principal == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", ErrUnauthenticated)
+		return
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	if id != principal.OrganizationId && !principal.HasPermission(PermissionManageOrganizations) {
+		writeError(w, http.StatusForbidden, "access_denied", errors.New("accounts of other organizations are not accessible"))
+		return
+	}
+
+	if ret, err = o.Organizations.FindById(id); err != nil || ret == nil {
+		http.NotFound(w, r)
+		return
+	}
+	ok = true
+	return
+}
@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/looplab/eventhorizon"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTenantGuard(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryRepo(t, AccountAggregateType, func() eventhorizon.Entity { return NewAccountDefault() })
+	organizationId := uuid.New()
+	tenantAccount := &Account{Id: uuid.New(), OrganizationId: organizationId}
+	globalAccount := &Account{Id: uuid.New()}
+	for _, account := range []*Account{tenantAccount, globalAccount} {
+		if err := repo.Save(ctx, account); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	guard := NewTenantGuard("/auth", NewAccountQueryRepositoryFull(repo, ctx))
+	guarded := func(principal *Principal, method string, path string) int {
+		router := mux.NewRouter()
+		router.Use(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if principal != nil {
+					r = r.WithContext(WithPrincipal(r.Context(), principal))
+				}
+				next.ServeHTTP(w, r)
+			})
+		}, guard.Middleware)
+		served := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) }
+		router.Methods(http.MethodGet).Path("/auth/accounts").Name("AccountFindAll").HandlerFunc(served)
+		router.Methods(http.MethodGet).Path("/auth/account/{id}").Name("AccountFindById").HandlerFunc(served)
+		router.Methods(http.MethodPost).Path("/auth/account/{id}/login").Name("LoginAccount").HandlerFunc(served)
+		router.Methods(http.MethodPost).Path("/auth/account/{id}/email/confirm").Name("ConfirmEmailChange").
+			HandlerFunc(served)
+		router.Methods(http.MethodGet).Path("/auth/invitations").Name("InvitationFindAll").HandlerFunc(served)
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(method, path, nil))
+		return recorder.Code
+	}
+
+	member := &Principal{AccountId: uuid.New(), OrganizationId: organizationId}
+	global := &Principal{AccountId: uuid.New()}
+	manager := &Principal{AccountId: uuid.New(), Roles: []string{PermissionManageOrganizations}}
+	tenantPath := "/auth/account/" + tenantAccount.Id.String()
+	globalPath := "/auth/account/" + globalAccount.Id.String()
+
+	for _, item := range []struct {
+		name      string
+		principal *Principal
+		method    string
+		path      string
+		expected  int
+	}{
+		{"anonymous list", nil, http.MethodGet, "/auth/accounts", http.StatusUnauthorized},
+		{"member list", member, http.MethodGet, "/auth/accounts", http.StatusForbidden},
+		{"global list", global, http.MethodGet, "/auth/accounts", http.StatusForbidden},
+		{"manager list", manager, http.MethodGet, "/auth/accounts", http.StatusNoContent},
+		{"member invitations", member, http.MethodGet, "/auth/invitations", http.StatusForbidden},
+		{"manager invitations", manager, http.MethodGet, "/auth/invitations", http.StatusNoContent},
+		{"anonymous account", nil, http.MethodGet, tenantPath, http.StatusUnauthorized},
+		{"anonymous login", nil, http.MethodPost, tenantPath + "/login", http.StatusNoContent},
+		{"anonymous email confirmation", nil, http.MethodPost, tenantPath + "/email/confirm", http.StatusNoContent},
+		{"member account", member, http.MethodGet, tenantPath, http.StatusNoContent},
+		{"member other account", member, http.MethodGet, globalPath, http.StatusNotFound},
+		{"global account", global, http.MethodGet, globalPath, http.StatusNoContent},
+		{"global tenant account", global, http.MethodGet, tenantPath, http.StatusNotFound},
+		{"manager account", manager, http.MethodGet, tenantPath, http.StatusNoContent},
+	} {
+		if code := guarded(item.principal, item.method, item.path); code != item.expected {
+			t.Errorf("%v answered %v instead of %v", item.name, code, item.expected)
+		}
+	}
+}
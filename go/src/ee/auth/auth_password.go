@@ -0,0 +1,337 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-ee/utils"
+	"github.com/go-ee/utils/crypt"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/looplab/eventhorizon"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// PasswordPolicy rules the passwords chosen by users, passwords older than MaxAge must be changed
+// and the last History passwords must not be reused
+type PasswordPolicy struct {
+	MinLength     int
+	RequireLetter bool
+	RequireDigit  bool
+	MaxAge        time.Duration
+	History       int
+}
+
+func NewPasswordPolicy() (ret *PasswordPolicy) {
+	ret = &PasswordPolicy{MinLength: 10, RequireLetter: true, RequireDigit: true, History: 5}
+	return
+}
+
+// Validate checks the password, it must not contain the username or the email of the account
+func (o *PasswordPolicy) Validate(password string, username string, email string) (err error) {
+	if len(password) < o.MinLength {
+		return fmt.Errorf("password must have at least %v characters", o.MinLength)
+	}
+
+	var letter, digit bool
+	for _, c := range password {
+		letter = letter || unicode.IsLetter(c)
+		digit = digit || unicode.IsDigit(c)
+	}
+	if o.RequireLetter && !letter {
+		err = errors.New("password must contain a letter")
+	} else if o.RequireDigit && !digit {
+		err = errors.New("password must contain a digit")
+	} else if containsFold(password, username) || containsFold(password, email) {
+		err = errors.New("password must not contain the username or the email")
+	}
+	return
+}
+
+func containsFold(value string, part string) bool {
+	return part != "" && strings.Contains(strings.ToLower(value), strings.ToLower(part))
+}
+
+// IsExpired tells whether the password must be changed before the account is used,
+// passwords of accounts without change time are not aged
+func (o *PasswordPolicy) IsExpired(account *Account, now time.Time) bool {
+	return account.MustChangePassword ||
+		(o.MaxAge > 0 && account.PasswordChangedAt != nil && now.Sub(*account.PasswordChangedAt) > o.MaxAge)
+}
+
+func (o *EsEngine) ImplementPasswordExpiry(passwords *PasswordPolicy) {
+	o.Account.ImplementPasswordExpiry(passwords)
+}
+
+// ImplementPasswordExpiry forces accounts created by admins with an initial password to change it on first login.
+// Registered accounts chose their password, federated accounts are created without one.
+func (o *AccountAggregateEngine) ImplementPasswordExpiry(passwords *PasswordPolicy) {
+	o.AggregateExecutors.Enabled.AddChangePasswordPreparer(
+		func(cmd *ChangePasswordAccount, entity *Account) (err error) {
+			if entity.Password == "" || !crypt.HashAndEquals(cmd.OldPassword, entity.Password) {
+				err = errors.New("old password is invalid")
+			} else if cmd.Password == cmd.OldPassword {
+				err = errors.New("new password must differ from the old password")
+			} else if err = passwords.Validate(cmd.Password, entity.Username, entity.Email); err == nil {
+				cmd.Password, err = crypt.Hash(cmd.Password)
+			}
+			return
+		})
+
+	createdHandler := o.AggregateHandlers.Initial.CreatedHandler
+	o.AggregateHandlers.Initial.CreatedHandler =
+		func(event eventhorizon.Event, eventData *AccountCreated, entity *Account) (err error) {
+			if err = createdHandler(event, eventData, entity); err == nil && eventData.Password != "" {
+				entity.PasswordChangedAt = utils.PtrTime(event.Timestamp())
+				entity.MustChangePassword = true
+			}
+			return
+		}
+
+	updatedHandler := o.AggregateHandlers.Exist.UpdatedHandler
+	o.AggregateHandlers.Exist.UpdatedHandler =
+		func(event eventhorizon.Event, eventData *AccountUpdated, entity *Account) (err error) {
+			password := entity.Password
+			if err = updatedHandler(event, eventData, entity); err == nil && entity.Password != password {
+				entity.PasswordChangedAt = utils.PtrTime(event.Timestamp())
+			}
+			return
+		}
+
+	o.AggregateHandlers.Enabled.PasswordChangedHandler =
+		func(event eventhorizon.Event, eventData *AccountPasswordChanged, entity *Account) (err error) {
+			entity.Password = eventData.Password
+			entity.PasswordChangedAt = utils.PtrTime(event.Timestamp())
+			entity.MustChangePassword = false
+			return
+		}
+}
+
+type ChangePasswordRequest struct {
+	OldPassword string `json:"oldPassword"`
+	Password    string `json:"password"`
+}
+
+// PasswordRouter lets accounts change their own password, also with a token restricted to the password change.
+type PasswordRouter struct {
+	PathPrefix string
+	CommandBus eventhorizon.CommandHandler
+	ctx        context.Context
+}
+
+func NewPasswordRouter(pathPrefix string, newContext func(string) (ret context.Context),
+	commandBus eventhorizon.CommandHandler) (ret *PasswordRouter) {
+	ret = &PasswordRouter{
+		PathPrefix: pathPrefix + "/" + "account",
+		CommandBus: commandBus,
+		ctx:        newContext("password"),
+	}
+	return
+}
+
+func (o *PasswordRouter) Setup(router *mux.Router) (err error) {
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefix).Path("/{id}/password").
+		Name("ChangePassword").
+		HandlerFunc(o.Change)
+	return
+}
+
+func (o *PasswordRouter) Change(w http.ResponseWriter, r *http.Request) {
+	principal := PrincipalFrom(r.Context())
+	if principal == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", ErrUnauthenticated)
+		return
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	if id != principal.AccountId || principal.ApiKey != nil {
+		writeError(w, http.StatusForbidden, "access_denied", errors.New("only the own password can be changed"))
+		return
+	}
+
+	request := &ChangePasswordRequest{}
+	if err = json.NewDecoder(r.Body).Decode(request); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+
+	if err = o.CommandBus.HandleCommand(o.ctx, &ChangePasswordAccount{Id: id, OldPassword: request.OldPassword,
+		Password: request.Password}); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// IsReused verifies the password against the current hash and the hashes of the credential history
+func (o *PasswordPolicy) IsReused(password string, account *Account) bool {
+	if o.History <= 0 {
+		return false
+	}
+	for _, hash := range account.PasswordHistory {
+		if crypt.HashAndEquals(password, hash) {
+			return true
+		}
+	}
+	return account.Password != "" && crypt.HashAndEquals(password, account.Password)
+}
+
+func (o *PasswordPolicy) remember(account *Account, hash string) {
+	account.AddToPasswordHistory(hash)
+	if len(account.PasswordHistory) > o.History {
+		account.PasswordHistory = account.PasswordHistory[len(account.PasswordHistory)-o.History:]
+	}
+}
+
+// Public is the account as returned by queries, without password hashes, api key hashes and pending tokens.
+// The projections persist the complete account.
+func (o *Account) Public() (ret *Account) {
+	value := *o
+	value.Password = ""
+	value.PasswordHistory = nil
+	value.EmailChangeTokenHash = ""
+	value.ApiKeys = nil
+	for _, apiKey := range o.ApiKeys {
+		value.AddToApiKeys(apiKey.Public())
+	}
+	ret = &value
+	return
+}
+
+func PublicAccounts(accounts []*Account) (ret []*Account) {
+	ret = make([]*Account, 0, len(accounts))
+	for _, account := range accounts {
+		ret = append(ret, account.Public())
+	}
+	return
+}
+
+// AccountQueryRouter answers the generated account queries with public accounts. It takes over the handlers
+// of the generated routes, it must be set up after them.
+type AccountQueryRouter struct {
+	Accounts *AccountQueryRepository
+}
+
+func NewAccountQueryRouter(accounts *AccountQueryRepository) (ret *AccountQueryRouter) {
+	ret = &AccountQueryRouter{Accounts: accounts}
+	return
+}
+
+func (o *AccountQueryRouter) Setup(router *mux.Router) (err error) {
+	for name, handler := range map[string]http.HandlerFunc{
+		"AccountFindById": o.FindById,
+		"AccountFindAll":  o.FindAll,
+	} {
+		route := router.Get(name)
+		if route == nil {
+			err = fmt.Errorf("route '%v' not found", name)
+			return
+		}
+		route.HandlerFunc(handler)
+	}
+	return
+}
+
+func (o *AccountQueryRouter) FindById(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	var account *Account
+	if account, err = o.Accounts.FindById(id); err != nil || account == nil {
+		http.NotFound(w, r)
+		return
+	}
+	writeJSON(w, http.StatusOK, account.Public())
+}
+
+func (o *AccountQueryRouter) FindAll(w http.ResponseWriter, r *http.Request) {
+	if ret, err := o.Accounts.FindAll(); err == nil {
+		writeJSON(w, http.StatusOK, PublicAccounts(ret))
+	} else {
+		writeError(w, http.StatusInternalServerError, "server_error", err)
+	}
+}
+
+func (o *EsEngine) ImplementPasswordHistory(passwords *PasswordPolicy) {
+	o.Account.ImplementPasswordHistory(passwords)
+}
+
+// ImplementPasswordHistory keeps the last hashes of each account and rejects their reuse,
+// it must be activated after the other password handlers because it wraps them.
+func (o *AccountAggregateEngine) ImplementPasswordHistory(passwords *PasswordPolicy) {
+	if passwords.History <= 0 {
+		return
+	}
+
+	errReused := fmt.Errorf("password must differ from the last %v passwords", passwords.History)
+	o.AggregateExecutors.Exist.AddUpdatePreparer(
+		func(cmd *UpdateAccount, entity *Account) (err error) {
+			if cmd.Password != "" && passwords.IsReused(cmd.Password, entity) {
+				err = errReused
+			}
+			return
+		})
+	o.AggregateExecutors.Enabled.AddChangePasswordPreparer(
+		func(cmd *ChangePasswordAccount, entity *Account) (err error) {
+			if passwords.IsReused(cmd.Password, entity) {
+				err = errReused
+			}
+			return
+		})
+
+	// the hashes of the events are remembered, the plain passwords are never known to the handlers
+	remember := func(entity *Account, previous string) {
+		if entity.Password != "" && entity.Password != previous {
+			passwords.remember(entity, entity.Password)
+		}
+	}
+
+	createdHandler := o.AggregateHandlers.Initial.CreatedHandler
+	o.AggregateHandlers.Initial.CreatedHandler =
+		func(event eventhorizon.Event, eventData *AccountCreated, entity *Account) (err error) {
+			if err = createdHandler(event, eventData, entity); err == nil {
+				remember(entity, "")
+			}
+			return
+		}
+
+	registeredHandler := o.AggregateHandlers.Initial.RegisteredHandler
+	o.AggregateHandlers.Initial.RegisteredHandler =
+		func(event eventhorizon.Event, eventData *AccountRegistered, entity *Account) (err error) {
+			if err = registeredHandler(event, eventData, entity); err == nil {
+				remember(entity, "")
+			}
+			return
+		}
+
+	updatedHandler := o.AggregateHandlers.Exist.UpdatedHandler
+	o.AggregateHandlers.Exist.UpdatedHandler =
+		func(event eventhorizon.Event, eventData *AccountUpdated, entity *Account) (err error) {
+			previous := entity.Password
+			if err = updatedHandler(event, eventData, entity); err == nil {
+				remember(entity, previous)
+			}
+			return
+		}
+
+	passwordChangedHandler := o.AggregateHandlers.Enabled.PasswordChangedHandler
+	o.AggregateHandlers.Enabled.PasswordChangedHandler =
+		func(event eventhorizon.Event, eventData *AccountPasswordChanged, entity *Account) (err error) {
+			previous := entity.Password
+			if err = passwordChangedHandler(event, eventData, entity); err == nil {
+				remember(entity, previous)
+			}
+			return
+		}
+}
@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-ee/utils/crypt"
+	"github.com/go-ee/utils/eh/app"
+	"github.com/go-ee/utils/eh/app/filestore"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/looplab/eventhorizon"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAccountQueriesArePublic(t *testing.T) {
+	ctx := context.Background()
+	repo, err := filestore.NewAppFileStore(&app.AppInfo{AppName: "auth"}, &app.ServerConfig{}, false,
+		t.TempDir()).Middleware.Repos(string(AccountAggregateType),
+		func() eventhorizon.Entity { return NewAccountDefault() })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	account := &Account{Id: uuid.New(), Username: "alice", Password: "password-hash",
+		PasswordHistory: []string{"history-hash"}, EmailChangeTokenHash: "token-hash",
+		ApiKeys: []*ApiKey{{KeyId: uuid.New(), Name: "ci", Hash: "key-hash"}}}
+	if err = repo.Save(ctx, account); err != nil {
+		t.Fatal(err)
+	}
+
+	accounts := NewAccountQueryRepositoryFull(repo, ctx)
+	if stored, findErr := accounts.FindById(account.Id); findErr != nil || stored.Password != "password-hash" ||
+		len(stored.PasswordHistory) != 1 || stored.ApiKeys[0].Hash != "key-hash" {
+		t.Errorf("projection lost credentials: %v", findErr)
+	}
+
+	router := mux.NewRouter()
+	if err = NewAccountRouter("/auth", func(string) context.Context { return ctx }, nil, nil).Setup(router); err != nil {
+		t.Fatal(err)
+	}
+	if err = NewAccountQueryRouter(accounts).Setup(router); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{"/auth/account/" + account.Id.String(), "/auth/accounts"} {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		body := recorder.Body.String()
+		if recorder.Code != http.StatusOK || !strings.Contains(body, "alice") {
+			t.Errorf("%v answered %v: %v", path, recorder.Code, body)
+		}
+		for _, secret := range []string{"password-hash", "history-hash", "token-hash", "key-hash"} {
+			if strings.Contains(body, secret) {
+				t.Errorf("%v returns %v", path, secret)
+			}
+		}
+		if !json.Valid([]byte(body)) {
+			t.Errorf("%v returns invalid json", path)
+		}
+	}
+}
+
+// the password change event carries the new hash only, the verified old password is not recorded
+func TestPasswordChangedEventHasNoOldPassword(t *testing.T) {
+	engine := &AccountAggregateEngine{AggregateExecutors: NewAccountAggregateExecutorsFull(),
+		AggregateHandlers: NewAccountAggregateHandlersFull()}
+	if err := engine.AggregateExecutors.SetupCommandHandler(); err != nil {
+		t.Fatal(err)
+	}
+	engine.ImplementPasswordExpiry(NewPasswordPolicy())
+
+	hash, err := crypt.Hash("Old-secret-123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	account := &Account{Id: uuid.New(), Username: "alice", Password: hash}
+	events := &recordedEvents{}
+	if err = engine.AggregateExecutors.Enabled.ChangePasswordHandler(&ChangePasswordAccount{Id: account.Id,
+		OldPassword: "Old-secret-123", Password: "New-secret-456"}, account, events); err != nil {
+		t.Fatal(err)
+	}
+
+	data, _ := json.Marshal(events.Data[0])
+	for _, secret := range []string{"Old-secret-123", hash, "oldPassword"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("password change event contains %v: %s", secret, data)
+		}
+	}
+}
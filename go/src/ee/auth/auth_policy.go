@@ -0,0 +1,528 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+const PermissionManagePolicies = "auth:policies"
+
+const (
+	PolicyAllow         = "allow"
+	PolicyDeny          = "deny"
+	PolicyNotApplicable = "notApplicable"
+)
+
+// PolicyCondition compares an attribute with a value or, if Ref is set, with another attribute.
+// Attributes are paths like 'subject.roles', 'resource.organizationId' or 'context.hour'.
+type PolicyCondition struct {
+	Attribute string      `json:"attribute"`
+	Operator  string      `json:"operator"`
+	Value     interface{} `json:"value,omitempty"`
+	Ref       string      `json:"ref,omitempty"`
+}
+
+// Policy allows or denies the routes, patterns of path.Match, if all conditions are true.
+// Resource names the kind of the resource identified by the path variable 'id', e.g. 'account'.
+type Policy struct {
+	Id          string             `json:"id"`
+	Description string             `json:"description,omitempty"`
+	Effect      string             `json:"effect"`
+	Routes      []string           `json:"routes"`
+	Resource    string             `json:"resource,omitempty"`
+	Conditions  []*PolicyCondition `json:"conditions,omitempty"`
+}
+
+type PolicySet struct {
+	Policies []*Policy `json:"policies"`
+}
+
+func LoadPolicySet(file string) (ret *PolicySet, err error) {
+	var data []byte
+	if data, err = ioutil.ReadFile(file); err != nil {
+		return
+	}
+	ret = &PolicySet{}
+	if err = json.Unmarshal(data, ret); err == nil {
+		err = ret.Validate()
+	}
+	return
+}
+
+func (o *PolicySet) Validate() (err error) {
+	for _, policy := range o.Policies {
+		if policy.Id == "" {
+			return errors.New("policy without id")
+		}
+		if policy.Effect != PolicyAllow && policy.Effect != PolicyDeny {
+			return fmt.Errorf("policy '%v' has the invalid effect '%v'", policy.Id, policy.Effect)
+		}
+		if len(policy.Routes) == 0 {
+			return fmt.Errorf("policy '%v' applies to no route", policy.Id)
+		}
+		for _, condition := range policy.Conditions {
+			if _, ok := policyOperators[condition.Operator]; !ok {
+				return fmt.Errorf("policy '%v' uses the unknown operator '%v'", policy.Id, condition.Operator)
+			}
+		}
+	}
+	return
+}
+
+func (o *Policy) AppliesTo(route string) bool {
+	for _, pattern := range o.Routes {
+		if matched, err := path.Match(pattern, route); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// PolicyRequest holds the attributes a decision is made on, the resource is loaded by the kind of each policy
+type PolicyRequest struct {
+	Route      string                 `json:"route"`
+	Subject    map[string]interface{} `json:"subject"`
+	ResourceId uuid.UUID              `json:"resourceId,omitempty"`
+	Context    map[string]interface{} `json:"context"`
+}
+
+type PolicyDecision struct {
+	Decision string   `json:"decision"`
+	Policy   string   `json:"policy,omitempty"`
+	Trace    []string `json:"trace"`
+}
+
+func (o *PolicyDecision) trace(format string, args ...interface{}) {
+	o.Trace = append(o.Trace, fmt.Sprintf(format, args...))
+}
+
+// ResourceLoader finds the resource of a kind by its id
+type ResourceLoader func(id uuid.UUID) (ret interface{}, err error)
+
+// PolicyEngine evaluates the policies of a local file and reloads them when the file changes.
+// A deny overrides an allow, routes without a true policy are left to the role permissions.
+type PolicyEngine struct {
+	File      string
+	Accounts  *AccountQueryRepository
+	Resources map[string]ResourceLoader
+	policies  *PolicySet
+	modTime   time.Time
+	stop      chan struct{}
+	mutex     sync.RWMutex
+}
+
+func NewPolicyEngine(file string, accounts *AccountQueryRepository) (ret *PolicyEngine, err error) {
+	ret = &PolicyEngine{
+		File:     file,
+		Accounts: accounts,
+		Resources: map[string]ResourceLoader{
+			"account": func(id uuid.UUID) (ret interface{}, err error) {
+				var account *Account
+				if account, err = accounts.FindById(id); err == nil && account != nil {
+					ret = account.Public()
+				}
+				return
+			},
+		},
+		policies: &PolicySet{},
+	}
+	_, err = ret.Reload()
+	return
+}
+
+func (o *PolicyEngine) Policies() (ret *PolicySet) {
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+	ret = o.policies
+	return
+}
+
+// Reload reads the file if it changed since the last load, invalid policies keep the loaded ones active
+func (o *PolicyEngine) Reload() (changed bool, err error) {
+	var info os.FileInfo
+	if info, err = os.Stat(o.File); err != nil {
+		return
+	}
+
+	o.mutex.RLock()
+	changed = !info.ModTime().Equal(o.modTime)
+	o.mutex.RUnlock()
+	if !changed {
+		return
+	}
+
+	var policies *PolicySet
+	if policies, err = LoadPolicySet(o.File); err != nil {
+		changed = false
+		return
+	}
+
+	o.mutex.Lock()
+	o.policies = policies
+	o.modTime = info.ModTime()
+	o.mutex.Unlock()
+	return
+}
+
+func (o *PolicyEngine) Start(interval time.Duration) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if o.stop != nil {
+		return
+	}
+
+	o.stop = make(chan struct{})
+	go func(stop chan struct{}) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if changed, err := o.Reload(); err != nil {
+					logrus.Warnf("reload of policies '%v' failed: %v", o.File, err)
+				} else if changed {
+					logrus.Infof("reloaded policies '%v'", o.File)
+				}
+			case <-stop:
+				return
+			}
+		}
+	}(o.stop)
+}
+
+func (o *PolicyEngine) Stop() {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if o.stop != nil {
+		close(o.stop)
+		o.stop = nil
+	}
+}
+
+// Authorize decides about the request of the principal to the route
+func (o *PolicyEngine) Authorize(r *http.Request, principal *Principal, route string) (ret *PolicyDecision) {
+	request := &PolicyRequest{
+		Route: route,
+		Context: map[string]interface{}{
+			"method":     r.Method,
+			"remoteAddr": r.RemoteAddr,
+		},
+	}
+	if id, err := uuid.Parse(mux.Vars(r)["id"]); err == nil {
+		request.ResourceId = id
+	}
+
+	var err error
+	if request.Subject, err = o.SubjectAttributes(principal.AccountId, principal.Roles); err != nil {
+		ret = &PolicyDecision{Decision: PolicyDeny}
+		ret.trace("subject attributes failed: %v", err)
+		return
+	}
+	ret = o.Evaluate(request, time.Now())
+	return
+}
+
+// SubjectAttributes are the attributes of the account, the roles are replaced by the effective roles
+func (o *PolicyEngine) SubjectAttributes(accountId uuid.UUID, roles []string) (ret map[string]interface{}, err error) {
+	var account *Account
+	if account, err = o.Accounts.FindById(accountId); err != nil {
+		return
+	}
+	if account == nil {
+		err = fmt.Errorf("account '%v' not found", accountId)
+		return
+	}
+	if ret, err = attributesOf(account.Public()); err == nil {
+		ret["roles"] = normalizeAttribute(roles)
+	}
+	return
+}
+
+// Evaluate decides by the policies of the route, the time fills the context unless given by the request
+func (o *PolicyEngine) Evaluate(request *PolicyRequest, now time.Time) (ret *PolicyDecision) {
+	ret = &PolicyDecision{Decision: PolicyNotApplicable, Trace: []string{}}
+
+	attributes := map[string]interface{}{
+		"subject": request.Subject,
+		"context": contextAttributes(request.Context, now),
+	}
+	resources := map[string]interface{}{}
+
+	for _, policy := range o.Policies().Policies {
+		if !policy.AppliesTo(request.Route) {
+			continue
+		}
+
+		if policy.Resource != "" {
+			resource, err := o.resource(resources, policy.Resource, request.ResourceId)
+			if err != nil {
+				ret.trace("policy '%v' skipped: %v", policy.Id, err)
+				continue
+			}
+			attributes["resource"] = resource
+		} else {
+			delete(attributes, "resource")
+		}
+
+		if matched, reason := policy.matches(attributes); !matched {
+			ret.trace("policy '%v' does not apply: %v", policy.Id, reason)
+			continue
+		}
+
+		ret.trace("policy '%v' %vs", policy.Id, policy.Effect)
+		if policy.Effect == PolicyDeny {
+			ret.Decision = PolicyDeny
+			ret.Policy = policy.Id
+			return
+		}
+		if ret.Decision != PolicyAllow {
+			ret.Decision = PolicyAllow
+			ret.Policy = policy.Id
+		}
+	}
+	return
+}
+
+func (o *PolicyEngine) resource(resources map[string]interface{}, kind string, id uuid.UUID) (ret interface{},
+	err error) {
+
+	if ret = resources[kind]; ret != nil {
+		return
+	}
+	loader := o.Resources[kind]
+	if loader == nil {
+		err = fmt.Errorf("unknown resource '%v'", kind)
+		return
+	}
+	if id == uuid.Nil {
+		err = fmt.Errorf("no %v in the request", kind)
+		return
+	}
+
+	var resource interface{}
+	if resource, err = loader(id); err != nil {
+		return
+	}
+	if resource == nil {
+		err = fmt.Errorf("%v '%v' not found", kind, id)
+		return
+	}
+	if ret, err = attributesOf(resource); err == nil {
+		resources[kind] = ret
+	}
+	return
+}
+
+func (o *Policy) matches(attributes map[string]interface{}) (ret bool, reason string) {
+	for _, condition := range o.Conditions {
+		actual := lookupAttribute(attributes, condition.Attribute)
+		expected := normalizeAttribute(condition.Value)
+		if condition.Ref != "" {
+			expected = lookupAttribute(attributes, condition.Ref)
+		}
+		if !policyOperators[condition.Operator](actual, expected) {
+			reason = fmt.Sprintf("%v %v %v is false", condition.Attribute, condition.Operator, condition.expected())
+			return
+		}
+	}
+	ret = true
+	return
+}
+
+func (o *PolicyCondition) expected() interface{} {
+	if o.Ref != "" {
+		return o.Ref
+	}
+	return o.Value
+}
+
+var policyOperators = map[string]func(actual interface{}, expected interface{}) bool{
+	"eq": func(actual interface{}, expected interface{}) bool {
+		return actual != nil && reflect.DeepEqual(actual, expected)
+	},
+	"ne": func(actual interface{}, expected interface{}) bool {
+		return !reflect.DeepEqual(actual, expected)
+	},
+	"in": func(actual interface{}, expected interface{}) bool {
+		return containsAttribute(expected, actual)
+	},
+	"contains": func(actual interface{}, expected interface{}) bool {
+		return containsAttribute(actual, expected)
+	},
+	"lt": func(actual interface{}, expected interface{}) bool {
+		return compareAttributes(actual, expected, func(c int) bool { return c < 0 })
+	},
+	"lte": func(actual interface{}, expected interface{}) bool {
+		return compareAttributes(actual, expected, func(c int) bool { return c <= 0 })
+	},
+	"gt": func(actual interface{}, expected interface{}) bool {
+		return compareAttributes(actual, expected, func(c int) bool { return c > 0 })
+	},
+	"gte": func(actual interface{}, expected interface{}) bool {
+		return compareAttributes(actual, expected, func(c int) bool { return c >= 0 })
+	},
+	"exists": func(actual interface{}, expected interface{}) bool {
+		return (actual != nil) == (expected != false)
+	},
+}
+
+func containsAttribute(list interface{}, item interface{}) bool {
+	if items, ok := list.([]interface{}); ok {
+		for _, value := range items {
+			if reflect.DeepEqual(value, item) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// compareAttributes compares numbers, strings compare lexically, e.g. times formatted as RFC 3339
+func compareAttributes(actual interface{}, expected interface{}, accept func(c int) bool) bool {
+	switch value := actual.(type) {
+	case float64:
+		if other, ok := expected.(float64); ok {
+			if value < other {
+				return accept(-1)
+			} else if value > other {
+				return accept(1)
+			}
+			return accept(0)
+		}
+	case string:
+		if other, ok := expected.(string); ok {
+			return accept(strings.Compare(value, other))
+		}
+	}
+	return false
+}
+
+func lookupAttribute(attributes map[string]interface{}, name string) (ret interface{}) {
+	ret = attributes
+	for _, part := range strings.Split(name, ".") {
+		values, ok := ret.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		ret = values[part]
+	}
+	return
+}
+
+// contextAttributes adds time, hour and weekday of the time unless they are given
+func contextAttributes(given map[string]interface{}, now time.Time) (ret map[string]interface{}) {
+	ret = map[string]interface{}{
+		"time":    now.Format(time.RFC3339),
+		"hour":    float64(now.Hour()),
+		"weekday": now.Weekday().String(),
+	}
+	for key, value := range given {
+		ret[key] = normalizeAttribute(value)
+	}
+	return
+}
+
+func attributesOf(item interface{}) (ret map[string]interface{}, err error) {
+	var data []byte
+	if data, err = json.Marshal(item); err == nil {
+		ret = map[string]interface{}{}
+		err = json.Unmarshal(data, &ret)
+	}
+	return
+}
+
+// normalizeAttribute converts values to the types of decoded json, e.g. numbers to float64
+func normalizeAttribute(value interface{}) (ret interface{}) {
+	if data, err := json.Marshal(value); err == nil {
+		_ = json.Unmarshal(data, &ret)
+	}
+	return
+}
+
+// PolicyEvaluation is a dry run for a subject, an account id or username, on a route
+type PolicyEvaluation struct {
+	Subject    string                 `json:"subject"`
+	Route      string                 `json:"route"`
+	ResourceId uuid.UUID              `json:"resourceId,omitempty"`
+	Context    map[string]interface{} `json:"context,omitempty"`
+}
+
+type PolicyRouter struct {
+	PathPrefix string
+	Policies   *PolicyEngine
+	Tokens     *Tokens
+}
+
+func NewPolicyRouter(pathPrefix string, policies *PolicyEngine, tokens *Tokens) (ret *PolicyRouter) {
+	ret = &PolicyRouter{
+		PathPrefix: pathPrefix + "/" + "policy",
+		Policies:   policies,
+		Tokens:     tokens,
+	}
+	return
+}
+
+func (o *PolicyRouter) Setup(router *mux.Router) (err error) {
+	router.Methods(http.MethodGet).Path(o.PathPrefix).
+		Name("PolicyFindAll").
+		HandlerFunc(o.FindAll)
+	router.Methods(http.MethodPost).Path(o.PathPrefix + "/evaluate").
+		Name("EvaluatePolicy").
+		HandlerFunc(o.Evaluate)
+	return
+}
+
+func (o *PolicyRouter) FindAll(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, o.Policies.Policies())
+}
+
+// Evaluate decides without executing the route, the context may override time, hour and weekday
+func (o *PolicyRouter) Evaluate(w http.ResponseWriter, r *http.Request) {
+	evaluation := &PolicyEvaluation{}
+	if err := json.NewDecoder(r.Body).Decode(evaluation); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+	if evaluation.Subject == "" || evaluation.Route == "" {
+		writeError(w, http.StatusBadRequest, "invalid_request", errors.New("subject and route are required"))
+		return
+	}
+
+	var account *Account
+	var err error
+	if id, parseErr := uuid.Parse(evaluation.Subject); parseErr == nil {
+		account, err = o.Policies.Accounts.FindById(id)
+	} else {
+		account, err = o.Policies.Accounts.FindByUsername(evaluation.Subject)
+	}
+	if err != nil || account == nil {
+		writeError(w, http.StatusBadRequest, "invalid_request",
+			fmt.Errorf("subject '%v' not found", evaluation.Subject))
+		return
+	}
+
+	var roles []string
+	if roles, err = o.Tokens.EffectiveRoles(account); err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", err)
+		return
+	}
+
+	request := &PolicyRequest{Route: evaluation.Route, ResourceId: evaluation.ResourceId, Context: evaluation.Context}
+	if request.Subject, err = o.Policies.SubjectAttributes(account.Id, roles); err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, o.Policies.Evaluate(request, time.Now()))
+}
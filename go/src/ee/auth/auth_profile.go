@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/looplab/eventhorizon"
+	"net/http"
+)
+
+// ProfileRequest is the change of the own account, missing values are kept.
+// Roles, password, email, state and organization are not part of it, they are changed over their own routes.
+type ProfileRequest struct {
+	Name       *PersonName            `json:"name,omitempty"`
+	Username   string                 `json:"username,omitempty"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// ProfileRouter lets accounts change their own profile without the permission to manage accounts.
+type ProfileRouter struct {
+	PathPrefix string
+	Accounts   *AccountQueryRepository
+	CommandBus eventhorizon.CommandHandler
+	ctx        context.Context
+}
+
+func NewProfileRouter(pathPrefix string, newContext func(string) (ret context.Context),
+	commandBus eventhorizon.CommandHandler, accounts *AccountQueryRepository) (ret *ProfileRouter) {
+	ret = &ProfileRouter{
+		PathPrefix: pathPrefix + "/" + "account",
+		Accounts:   accounts,
+		CommandBus: commandBus,
+		ctx:        newContext("profile"),
+	}
+	return
+}
+
+func (o *ProfileRouter) Setup(router *mux.Router) (err error) {
+	router.Methods(http.MethodPut).PathPrefix(o.PathPrefix).Path("/{id}/profile").
+		Name("UpdateProfile").
+		HandlerFunc(o.Update)
+	return
+}
+
+func (o *ProfileRouter) Update(w http.ResponseWriter, r *http.Request) {
+	principal := PrincipalFrom(r.Context())
+	if principal == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", ErrUnauthenticated)
+		return
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	if id != principal.AccountId || principal.ApiKey != nil {
+		writeError(w, http.StatusForbidden, "access_denied", errors.New("only the own profile can be changed"))
+		return
+	}
+
+	// roles, password, state or organization in the request are rejected, not ignored
+	request := &ProfileRequest{}
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(request); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+
+	var account *Account
+	if account, err = o.Accounts.FindById(id); err != nil || account == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	update := &UpdateAccount{Id: id, Name: account.Name, Username: account.Username, Email: account.Email,
+		Roles: account.Roles, OrganizationId: account.OrganizationId, Service: account.Service,
+		BreakGlass: account.BreakGlass, Attributes: request.Attributes}
+	if request.Name != nil {
+		update.Name = request.Name
+	}
+	if request.Username != "" {
+		update.Username = request.Username
+	}
+	if err = o.CommandBus.HandleCommand(o.ctx, update); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
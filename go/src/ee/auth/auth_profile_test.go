@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/looplab/eventhorizon"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestProfileKeepsRolesAndOrganization(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryRepo(t, AccountAggregateType, func() eventhorizon.Entity { return NewAccountDefault() })
+	account := &Account{Id: uuid.New(), Username: "alice", Email: "alice@example.com",
+		Roles: []string{"member"}, OrganizationId: uuid.New()}
+	if err := repo.Save(ctx, account); err != nil {
+		t.Fatal(err)
+	}
+
+	var updates []*UpdateAccount
+	commandBus := eventhorizon.CommandHandlerFunc(func(ctx context.Context, cmd eventhorizon.Command) error {
+		updates = append(updates, cmd.(*UpdateAccount))
+		return nil
+	})
+	router := mux.NewRouter()
+	if err := NewProfileRouter("/auth", func(string) context.Context { return ctx }, commandBus,
+		NewAccountQueryRepositoryFull(repo, ctx)).Setup(router); err != nil {
+		t.Fatal(err)
+	}
+	update := func(principal *Principal, id uuid.UUID, body string) int {
+		request := httptest.NewRequest(http.MethodPut, "/auth/account/"+id.String()+"/profile",
+			strings.NewReader(body))
+		request = request.WithContext(WithPrincipal(request.Context(), principal))
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder.Code
+	}
+
+	owner := &Principal{AccountId: account.Id}
+	for _, item := range []struct {
+		name      string
+		principal *Principal
+		body      string
+		expected  int
+	}{
+		{"other account", &Principal{AccountId: uuid.New()}, `{"username":"bob"}`, http.StatusForbidden},
+		{"roles", owner, `{"roles":["auth:organizations"]}`, http.StatusBadRequest},
+		{"password", owner, `{"password":"secret-123"}`, http.StatusBadRequest},
+		{"disabled", owner, `{"disabled":true}`, http.StatusBadRequest},
+		{"organization", owner, `{"organizationId":"` + uuid.New().String() + `"}`, http.StatusBadRequest},
+		{"username", owner, `{"username":"alice2"}`, http.StatusNoContent},
+	} {
+		if code := update(item.principal, account.Id, item.body); code != item.expected {
+			t.Errorf("%v answered %v instead of %v", item.name, code, item.expected)
+		}
+	}
+
+	if len(updates) != 1 {
+		t.Fatalf("%v updates dispatched", len(updates))
+	}
+	if updated := updates[0]; updated.Username != "alice2" || updated.Password != "" ||
+		updated.OrganizationId != account.OrganizationId || len(updated.Roles) != 1 || updated.Roles[0] != "member" {
+		t.Errorf("profile changed more than the username: %v", updated)
+	}
+}
@@ -69,3 +69,387 @@ func (o *AccountQueryRepository) ExistById(id uuid.UUID) (ret bool, err error) {
 	}
 	return
 }
+
+type GroupQueryRepository struct {
+	repo eventhorizon.ReadRepo
+	ctx  context.Context
+}
+
+func NewGroupQueryRepositoryFull(repo eventhorizon.ReadRepo, ctx context.Context) (ret *GroupQueryRepository) {
+	ret = &GroupQueryRepository{
+		repo: repo,
+		ctx:  ctx,
+	}
+	return
+}
+
+func (o *GroupQueryRepository) FindAll() (ret []*Group, err error) {
+	var result []eventhorizon.Entity
+	if result, err = o.repo.FindAll(o.ctx); err == nil {
+		ret = make([]*Group, len(result))
+		for i, e := range result {
+			ret[i] = e.(*Group)
+		}
+	}
+	return
+}
+
+func (o *GroupQueryRepository) FindById(id uuid.UUID) (ret *Group, err error) {
+	var result eventhorizon.Entity
+	if result, err = o.repo.Find(o.ctx, id); err == nil {
+		ret = result.(*Group)
+	}
+	return
+}
+
+func (o *GroupQueryRepository) CountAll() (ret int, err error) {
+	var result []*Group
+	if result, err = o.FindAll(); err == nil {
+		ret = len(result)
+	}
+	return
+}
+
+func (o *GroupQueryRepository) CountById(id uuid.UUID) (ret int, err error) {
+	var result *Group
+	if result, err = o.FindById(id); err == nil && result != nil {
+		ret = 1
+	}
+	return
+}
+
+func (o *GroupQueryRepository) ExistAll() (ret bool, err error) {
+	var result int
+	if result, err = o.CountAll(); err == nil {
+		ret = result > 0
+	}
+	return
+}
+
+func (o *GroupQueryRepository) ExistById(id uuid.UUID) (ret bool, err error) {
+	var result int
+	if result, err = o.CountById(id); err == nil {
+		ret = result > 0
+	}
+	return
+}
+
+type OrganizationQueryRepository struct {
+	repo eventhorizon.ReadRepo
+	ctx  context.Context
+}
+
+func NewOrganizationQueryRepositoryFull(repo eventhorizon.ReadRepo, ctx context.Context) (ret *OrganizationQueryRepository) {
+	ret = &OrganizationQueryRepository{
+		repo: repo,
+		ctx:  ctx,
+	}
+	return
+}
+
+func (o *OrganizationQueryRepository) FindAll() (ret []*Organization, err error) {
+	var result []eventhorizon.Entity
+	if result, err = o.repo.FindAll(o.ctx); err == nil {
+		ret = make([]*Organization, len(result))
+		for i, e := range result {
+			ret[i] = e.(*Organization)
+		}
+	}
+	return
+}
+
+func (o *OrganizationQueryRepository) FindById(id uuid.UUID) (ret *Organization, err error) {
+	var result eventhorizon.Entity
+	if result, err = o.repo.Find(o.ctx, id); err == nil {
+		ret = result.(*Organization)
+	}
+	return
+}
+
+func (o *OrganizationQueryRepository) CountAll() (ret int, err error) {
+	var result []*Organization
+	if result, err = o.FindAll(); err == nil {
+		ret = len(result)
+	}
+	return
+}
+
+func (o *OrganizationQueryRepository) CountById(id uuid.UUID) (ret int, err error) {
+	var result *Organization
+	if result, err = o.FindById(id); err == nil && result != nil {
+		ret = 1
+	}
+	return
+}
+
+func (o *OrganizationQueryRepository) ExistAll() (ret bool, err error) {
+	var result int
+	if result, err = o.CountAll(); err == nil {
+		ret = result > 0
+	}
+	return
+}
+
+func (o *OrganizationQueryRepository) ExistById(id uuid.UUID) (ret bool, err error) {
+	var result int
+	if result, err = o.CountById(id); err == nil {
+		ret = result > 0
+	}
+	return
+}
+
+type RoleGrantRequestQueryRepository struct {
+	repo eventhorizon.ReadRepo
+	ctx  context.Context
+}
+
+func NewRoleGrantRequestQueryRepositoryFull(repo eventhorizon.ReadRepo, ctx context.Context) (ret *RoleGrantRequestQueryRepository) {
+	ret = &RoleGrantRequestQueryRepository{
+		repo: repo,
+		ctx:  ctx,
+	}
+	return
+}
+
+func (o *RoleGrantRequestQueryRepository) FindAll() (ret []*RoleGrantRequest, err error) {
+	var result []eventhorizon.Entity
+	if result, err = o.repo.FindAll(o.ctx); err == nil {
+		ret = make([]*RoleGrantRequest, len(result))
+		for i, e := range result {
+			ret[i] = e.(*RoleGrantRequest)
+		}
+	}
+	return
+}
+
+func (o *RoleGrantRequestQueryRepository) FindById(id uuid.UUID) (ret *RoleGrantRequest, err error) {
+	var result eventhorizon.Entity
+	if result, err = o.repo.Find(o.ctx, id); err == nil {
+		ret = result.(*RoleGrantRequest)
+	}
+	return
+}
+
+func (o *RoleGrantRequestQueryRepository) CountAll() (ret int, err error) {
+	var result []*RoleGrantRequest
+	if result, err = o.FindAll(); err == nil {
+		ret = len(result)
+	}
+	return
+}
+
+func (o *RoleGrantRequestQueryRepository) CountById(id uuid.UUID) (ret int, err error) {
+	var result *RoleGrantRequest
+	if result, err = o.FindById(id); err == nil && result != nil {
+		ret = 1
+	}
+	return
+}
+
+func (o *RoleGrantRequestQueryRepository) ExistAll() (ret bool, err error) {
+	var result int
+	if result, err = o.CountAll(); err == nil {
+		ret = result > 0
+	}
+	return
+}
+
+func (o *RoleGrantRequestQueryRepository) ExistById(id uuid.UUID) (ret bool, err error) {
+	var result int
+	if result, err = o.CountById(id); err == nil {
+		ret = result > 0
+	}
+	return
+}
+
+type RoleQueryRepository struct {
+	repo eventhorizon.ReadRepo
+	ctx  context.Context
+}
+
+func NewRoleQueryRepositoryFull(repo eventhorizon.ReadRepo, ctx context.Context) (ret *RoleQueryRepository) {
+	ret = &RoleQueryRepository{
+		repo: repo,
+		ctx:  ctx,
+	}
+	return
+}
+
+func (o *RoleQueryRepository) FindAll() (ret []*Role, err error) {
+	var result []eventhorizon.Entity
+	if result, err = o.repo.FindAll(o.ctx); err == nil {
+		ret = make([]*Role, len(result))
+		for i, e := range result {
+			ret[i] = e.(*Role)
+		}
+	}
+	return
+}
+
+func (o *RoleQueryRepository) FindById(id uuid.UUID) (ret *Role, err error) {
+	var result eventhorizon.Entity
+	if result, err = o.repo.Find(o.ctx, id); err == nil {
+		ret = result.(*Role)
+	}
+	return
+}
+
+func (o *RoleQueryRepository) CountAll() (ret int, err error) {
+	var result []*Role
+	if result, err = o.FindAll(); err == nil {
+		ret = len(result)
+	}
+	return
+}
+
+func (o *RoleQueryRepository) CountById(id uuid.UUID) (ret int, err error) {
+	var result *Role
+	if result, err = o.FindById(id); err == nil && result != nil {
+		ret = 1
+	}
+	return
+}
+
+func (o *RoleQueryRepository) ExistAll() (ret bool, err error) {
+	var result int
+	if result, err = o.CountAll(); err == nil {
+		ret = result > 0
+	}
+	return
+}
+
+func (o *RoleQueryRepository) ExistById(id uuid.UUID) (ret bool, err error) {
+	var result int
+	if result, err = o.CountById(id); err == nil {
+		ret = result > 0
+	}
+	return
+}
+
+type RelationTupleQueryRepository struct {
+	repo eventhorizon.ReadRepo
+	ctx  context.Context
+}
+
+func NewRelationTupleQueryRepositoryFull(repo eventhorizon.ReadRepo, ctx context.Context) (ret *RelationTupleQueryRepository) {
+	ret = &RelationTupleQueryRepository{
+		repo: repo,
+		ctx:  ctx,
+	}
+	return
+}
+
+func (o *RelationTupleQueryRepository) FindAll() (ret []*RelationTuple, err error) {
+	var result []eventhorizon.Entity
+	if result, err = o.repo.FindAll(o.ctx); err == nil {
+		ret = make([]*RelationTuple, len(result))
+		for i, e := range result {
+			ret[i] = e.(*RelationTuple)
+		}
+	}
+	return
+}
+
+func (o *RelationTupleQueryRepository) FindById(id uuid.UUID) (ret *RelationTuple, err error) {
+	var result eventhorizon.Entity
+	if result, err = o.repo.Find(o.ctx, id); err == nil {
+		ret = result.(*RelationTuple)
+	}
+	return
+}
+
+func (o *RelationTupleQueryRepository) CountAll() (ret int, err error) {
+	var result []*RelationTuple
+	if result, err = o.FindAll(); err == nil {
+		ret = len(result)
+	}
+	return
+}
+
+func (o *RelationTupleQueryRepository) CountById(id uuid.UUID) (ret int, err error) {
+	var result *RelationTuple
+	if result, err = o.FindById(id); err == nil && result != nil {
+		ret = 1
+	}
+	return
+}
+
+func (o *RelationTupleQueryRepository) ExistAll() (ret bool, err error) {
+	var result int
+	if result, err = o.CountAll(); err == nil {
+		ret = result > 0
+	}
+	return
+}
+
+func (o *RelationTupleQueryRepository) ExistById(id uuid.UUID) (ret bool, err error) {
+	var result int
+	if result, err = o.CountById(id); err == nil {
+		ret = result > 0
+	}
+	return
+}
+
+type InvitationQueryRepository struct {
+	repo eventhorizon.ReadRepo
+	ctx  context.Context
+}
+
+func NewInvitationQueryRepositoryFull(repo eventhorizon.ReadRepo, ctx context.Context) (ret *InvitationQueryRepository) {
+	ret = &InvitationQueryRepository{
+		repo: repo,
+		ctx:  ctx,
+	}
+	return
+}
+
+func (o *InvitationQueryRepository) FindAll() (ret []*Invitation, err error) {
+	var result []eventhorizon.Entity
+	if result, err = o.repo.FindAll(o.ctx); err == nil {
+		ret = make([]*Invitation, len(result))
+		for i, e := range result {
+			ret[i] = e.(*Invitation)
+		}
+	}
+	return
+}
+
+func (o *InvitationQueryRepository) FindById(id uuid.UUID) (ret *Invitation, err error) {
+	var result eventhorizon.Entity
+	if result, err = o.repo.Find(o.ctx, id); err == nil {
+		ret = result.(*Invitation)
+	}
+	return
+}
+
+func (o *InvitationQueryRepository) CountAll() (ret int, err error) {
+	var result []*Invitation
+	if result, err = o.FindAll(); err == nil {
+		ret = len(result)
+	}
+	return
+}
+
+func (o *InvitationQueryRepository) CountById(id uuid.UUID) (ret int, err error) {
+	var result *Invitation
+	if result, err = o.FindById(id); err == nil && result != nil {
+		ret = 1
+	}
+	return
+}
+
+func (o *InvitationQueryRepository) ExistAll() (ret bool, err error) {
+	var result int
+	if result, err = o.CountAll(); err == nil {
+		ret = result > 0
+	}
+	return
+}
+
+func (o *InvitationQueryRepository) ExistById(id uuid.UUID) (ret bool, err error) {
+	var result int
+	if result, err = o.CountById(id); err == nil {
+		ret = result > 0
+	}
+	return
+}
@@ -0,0 +1,231 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-ee/utils"
+	"github.com/go-ee/utils/crypt"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/looplab/eventhorizon"
+	"net/http"
+	"strings"
+)
+
+type RegistrationMode string
+
+const (
+	// RegistrationClosed disables the self-registration
+	RegistrationClosed RegistrationMode = "closed"
+	// RegistrationOpen activates registered accounts immediately
+	RegistrationOpen RegistrationMode = "open"
+	// RegistrationApproval keeps registered accounts disabled until an admin approves them
+	RegistrationApproval RegistrationMode = "approval"
+)
+
+func ParseRegistrationMode(value string) (ret RegistrationMode, err error) {
+	switch ret = RegistrationMode(strings.ToLower(strings.TrimSpace(value))); ret {
+	case RegistrationClosed, RegistrationOpen, RegistrationApproval:
+	default:
+		err = fmt.Errorf("unknown registration mode '%v'", value)
+	}
+	return
+}
+
+func (o *EsEngine) ImplementRegistration(accounts *AccountQueryRepository, passwords *PasswordPolicy) {
+	o.Account.ImplementRegistration(accounts, passwords)
+}
+
+func (o *AccountAggregateEngine) ImplementRegistration(accounts *AccountQueryRepository, passwords *PasswordPolicy) {
+	o.AggregateExecutors.Initial.AddRegisterPreparer(func(cmd *RegisterAccount, entity *Account) (err error) {
+		if cmd.Username == "" || !strings.Contains(cmd.Email, "@") {
+			err = errors.New("username and valid email are required")
+		} else if err = passwords.Validate(cmd.Password, cmd.Username, cmd.Email); err == nil {
+			if err = accounts.ForTenant(cmd.OrganizationId).checkUnique(cmd.Id, cmd.Username, cmd.Email); err == nil {
+				cmd.Password, err = crypt.Hash(cmd.Password)
+			}
+		}
+		return
+	})
+
+	o.AggregateExecutors.PendingApproval.AddApproveRegistrationPreparer(
+		func(cmd *ApproveRegistrationAccount, entity *Account) (err error) {
+			if cmd.ApprovedBy == uuid.Nil {
+				err = errors.New("approver is required")
+			}
+			return
+		})
+
+	o.AggregateExecutors.PendingApproval.AddRejectRegistrationPreparer(
+		func(cmd *RejectRegistrationAccount, entity *Account) (err error) {
+			if cmd.RejectedBy == uuid.Nil {
+				err = errors.New("decider is required")
+			}
+			return
+		})
+
+	// accounts pending approval are disabled, the checks of disabled accounts apply to them
+	o.AggregateHandlers.Initial.RegisteredHandler =
+		func(event eventhorizon.Event, eventData *AccountRegistered, entity *Account) (err error) {
+			entity.Id = event.AggregateID()
+			entity.Name = eventData.Name
+			entity.Username = eventData.Username
+			entity.Password = eventData.Password
+			entity.Email = eventData.Email
+			entity.Roles = eventData.Roles
+			entity.OrganizationId = eventData.OrganizationId
+			entity.PasswordChangedAt = utils.PtrTime(event.Timestamp())
+			entity.PendingApproval = eventData.PendingApproval
+			entity.Disabled = eventData.PendingApproval
+			return
+		}
+
+	o.AggregateHandlers.PendingApproval.RegistrationApprovedHandler =
+		func(event eventhorizon.Event, eventData *AccountRegistrationApproved, entity *Account) (err error) {
+			entity.PendingApproval = false
+			entity.Disabled = false
+			return
+		}
+
+	o.AggregateHandlers.PendingApproval.RegistrationRejectedHandler =
+		func(event eventhorizon.Event, eventData *AccountRegistrationRejected, entity *Account) (err error) {
+			*entity = *NewAccountDefault()
+			return
+		}
+}
+
+type RegisterRequest struct {
+	Name     *PersonName `json:"name,omitempty"`
+	Username string      `json:"username"`
+	Password string      `json:"password"`
+	Email    string      `json:"email"`
+}
+
+type RegistrationDecision struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+// RegistrationRouter lets users register accounts without roles, ids are generated by the service.
+// Roles are registered for accepted invitations only.
+type RegistrationRouter struct {
+	PathPrefix string
+	Mode       RegistrationMode
+	Accounts   *AccountQueryRepository
+	CommandBus eventhorizon.CommandHandler
+	ctx        context.Context
+}
+
+func NewRegistrationRouter(pathPrefix string, newContext func(string) (ret context.Context),
+	commandBus eventhorizon.CommandHandler, accounts *AccountQueryRepository, mode RegistrationMode) (ret *RegistrationRouter) {
+	ret = &RegistrationRouter{
+		PathPrefix: pathPrefix,
+		Mode:       mode,
+		Accounts:   accounts,
+		CommandBus: commandBus,
+		ctx:        newContext("registration"),
+	}
+	return
+}
+
+func (o *RegistrationRouter) Setup(router *mux.Router) (err error) {
+	router.Methods(http.MethodPost).Path(o.PathPrefix + "/register").
+		Name("Register").
+		HandlerFunc(o.Register)
+	router.Methods(http.MethodGet).Path(o.PathPrefix + "/registration").
+		Name("RegistrationFindPending").
+		HandlerFunc(o.FindPending)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefix + "/registration").Path("/{id}/approve").
+		Name("ApproveRegistration").
+		HandlerFunc(o.Approve)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefix + "/registration").Path("/{id}/reject").
+		Name("RejectRegistration").
+		HandlerFunc(o.Reject)
+	return
+}
+
+func (o *RegistrationRouter) Register(w http.ResponseWriter, r *http.Request) {
+	if o.Mode != RegistrationOpen && o.Mode != RegistrationApproval {
+		http.NotFound(w, r)
+		return
+	}
+
+	request := &RegisterRequest{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+
+	register := &RegisterAccount{
+		Id:              uuid.New(),
+		Name:            request.Name,
+		Username:        request.Username,
+		Password:        request.Password,
+		Email:           request.Email,
+		PendingApproval: o.Mode == RegistrationApproval,
+	}
+	if err := o.CommandBus.HandleCommand(o.ctx, register); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"id": register.Id, "pendingApproval": register.PendingApproval})
+}
+
+func (o *RegistrationRouter) FindPending(w http.ResponseWriter, r *http.Request) {
+	accounts, err := o.Accounts.FindAll()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", err)
+		return
+	}
+
+	ret := []*Account{}
+	for _, account := range accounts {
+		if account.PendingApproval {
+			ret = append(ret, account.Public())
+		}
+	}
+	writeJSON(w, http.StatusOK, ret)
+}
+
+func (o *RegistrationRouter) Approve(w http.ResponseWriter, r *http.Request) {
+	o.decide(w, r, func(id uuid.UUID, decidedBy uuid.UUID, reason string) eventhorizon.Command {
+		return &ApproveRegistrationAccount{Id: id, ApprovedBy: decidedBy}
+	})
+}
+
+func (o *RegistrationRouter) Reject(w http.ResponseWriter, r *http.Request) {
+	o.decide(w, r, func(id uuid.UUID, decidedBy uuid.UUID, reason string) eventhorizon.Command {
+		return &RejectRegistrationAccount{Id: id, RejectedBy: decidedBy, Reason: reason}
+	})
+}
+
+func (o *RegistrationRouter) decide(w http.ResponseWriter, r *http.Request,
+	command func(id uuid.UUID, decidedBy uuid.UUID, reason string) eventhorizon.Command) {
+
+	principal := PrincipalFrom(r.Context())
+	if principal == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", ErrUnauthenticated)
+		return
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	decision := &RegistrationDecision{}
+	if r.ContentLength != 0 {
+		if err = json.NewDecoder(r.Body).Decode(decision); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_request", err)
+			return
+		}
+	}
+
+	if err = o.CommandBus.HandleCommand(o.ctx, command(id, principal.AccountId, decision.Reason)); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
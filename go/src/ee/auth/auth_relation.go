@@ -0,0 +1,430 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/looplab/eventhorizon"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+const (
+	PermissionManageRelations = "auth:relations"
+	PermissionReadRelations   = "auth:relations:read"
+)
+
+// the depth of nested usersets followed by checks and expansions
+const maxRelationDepth = 16
+
+// TupleToUserset follows the objects related by the tupleset, e.g. 'parent', to their computed userset
+type TupleToUserset struct {
+	Tupleset        string `json:"tupleset"`
+	ComputedUserset string `json:"computedUserset"`
+}
+
+// UsersetRewrite is one of the subject sets united to a relation
+type UsersetRewrite struct {
+	This            bool            `json:"this,omitempty"`
+	ComputedUserset string          `json:"computedUserset,omitempty"`
+	TupleToUserset  *TupleToUserset `json:"tupleToUserset,omitempty"`
+}
+
+type RelationConfig struct {
+	Union []*UsersetRewrite `json:"union,omitempty"`
+}
+
+type NamespaceConfig struct {
+	Relations map[string]*RelationConfig `json:"relations"`
+}
+
+// RelationConfigs define the relations of namespaces, without namespaces any relation holds its own tuples only
+type RelationConfigs struct {
+	Namespaces map[string]*NamespaceConfig `json:"namespaces"`
+}
+
+func LoadRelationConfigs(file string) (ret *RelationConfigs, err error) {
+	var data []byte
+	if data, err = ioutil.ReadFile(file); err != nil {
+		return
+	}
+	ret = &RelationConfigs{}
+	err = json.Unmarshal(data, ret)
+	return
+}
+
+func (o *RelationConfigs) Validate(namespace string, relation string) (err error) {
+	if o == nil || len(o.Namespaces) == 0 {
+		return
+	}
+	if config := o.Namespaces[namespace]; config == nil {
+		err = fmt.Errorf("unknown namespace '%v'", namespace)
+	} else if config.Relations[relation] == nil {
+		err = fmt.Errorf("unknown relation '%v' of namespace '%v'", relation, namespace)
+	}
+	return
+}
+
+func (o *RelationConfigs) rewrites(namespace string, relation string) (ret []*UsersetRewrite) {
+	if o != nil {
+		if config := o.Namespaces[namespace]; config != nil {
+			if relationConfig := config.Relations[relation]; relationConfig != nil && len(relationConfig.Union) > 0 {
+				return relationConfig.Union
+			}
+		}
+	}
+	ret = []*UsersetRewrite{{This: true}}
+	return
+}
+
+// Userset builds the subject '<namespace>:<object>#<relation>' for the subjects of a relation
+func Userset(namespace string, object string, relation string) string {
+	return namespace + ":" + object + "#" + relation
+}
+
+// ParseUserset splits '<namespace>:<object>#<relation>', the relation is optional for object references
+func ParseUserset(value string) (namespace string, object string, relation string, ok bool) {
+	colon := strings.Index(value, ":")
+	if colon <= 0 {
+		return
+	}
+	namespace = value[:colon]
+	object = value[colon+1:]
+	if hash := strings.LastIndex(object, "#"); hash >= 0 {
+		relation = object[hash+1:]
+		object = object[:hash]
+	}
+	ok = object != ""
+	return
+}
+
+func (o *RelationTupleQueryRepository) FindTuple(namespace string, object string, relation string,
+	subject string) (ret *RelationTuple, err error) {
+
+	var tuples []*RelationTuple
+	if tuples, err = o.FindAll(); err != nil {
+		return
+	}
+	for _, tuple := range tuples {
+		if tuple.Namespace == namespace && tuple.Object == object && tuple.Relation == relation &&
+			tuple.Subject == subject {
+			return tuple, nil
+		}
+	}
+	return
+}
+
+// UsersetTree is the expansion of a relation to its subjects
+type UsersetTree struct {
+	Operation string         `json:"operation"`
+	Userset   string         `json:"userset,omitempty"`
+	Subjects  []string       `json:"subjects,omitempty"`
+	Children  []*UsersetTree `json:"children,omitempty"`
+}
+
+// RelationEngine answers check, expand and list-objects queries over the tuples and the userset rewrites.
+type RelationEngine struct {
+	Tuples  *RelationTupleQueryRepository
+	Configs *RelationConfigs
+}
+
+func NewRelationEngine(tuples *RelationTupleQueryRepository, configs *RelationConfigs) (ret *RelationEngine) {
+	ret = &RelationEngine{Tuples: tuples, Configs: configs}
+	return
+}
+
+type relationIndex map[string][]string
+
+func (o *RelationEngine) index() (ret relationIndex, err error) {
+	var tuples []*RelationTuple
+	if tuples, err = o.Tuples.FindAll(); err != nil {
+		return
+	}
+	ret = relationIndex{}
+	for _, tuple := range tuples {
+		key := Userset(tuple.Namespace, tuple.Object, tuple.Relation)
+		ret[key] = append(ret[key], tuple.Subject)
+	}
+	return
+}
+
+// Check tells whether the subject, an account id or a userset, has the relation to the object
+func (o *RelationEngine) Check(namespace string, object string, relation string, subject string) (ret bool,
+	err error) {
+
+	var index relationIndex
+	if index, err = o.index(); err == nil {
+		ret = o.check(index, namespace, object, relation, subject, 0)
+	}
+	return
+}
+
+func (o *RelationEngine) check(index relationIndex, namespace string, object string, relation string,
+	subject string, depth int) bool {
+
+	if depth > maxRelationDepth {
+		return false
+	}
+
+	for _, rewrite := range o.Configs.rewrites(namespace, relation) {
+		if rewrite.This {
+			for _, item := range index[Userset(namespace, object, relation)] {
+				if item == subject {
+					return true
+				}
+				if itemNamespace, itemObject, itemRelation, ok := ParseUserset(item); ok && itemRelation != "" &&
+					o.check(index, itemNamespace, itemObject, itemRelation, subject, depth+1) {
+					return true
+				}
+			}
+		}
+		if rewrite.ComputedUserset != "" &&
+			o.check(index, namespace, object, rewrite.ComputedUserset, subject, depth+1) {
+			return true
+		}
+		if rewrite.TupleToUserset != nil {
+			for _, item := range index[Userset(namespace, object, rewrite.TupleToUserset.Tupleset)] {
+				if itemNamespace, itemObject, _, ok := ParseUserset(item); ok &&
+					o.check(index, itemNamespace, itemObject, rewrite.TupleToUserset.ComputedUserset, subject, depth+1) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (o *RelationEngine) Expand(namespace string, object string, relation string) (ret *UsersetTree, err error) {
+	var index relationIndex
+	if index, err = o.index(); err == nil {
+		ret = o.expand(index, namespace, object, relation, 0)
+	}
+	return
+}
+
+func (o *RelationEngine) expand(index relationIndex, namespace string, object string, relation string,
+	depth int) (ret *UsersetTree) {
+
+	ret = &UsersetTree{Operation: "union", Userset: Userset(namespace, object, relation)}
+	if depth > maxRelationDepth {
+		return
+	}
+
+	for _, rewrite := range o.Configs.rewrites(namespace, relation) {
+		if rewrite.This {
+			this := &UsersetTree{Operation: "this", Subjects: index[Userset(namespace, object, relation)]}
+			for _, item := range this.Subjects {
+				if itemNamespace, itemObject, itemRelation, ok := ParseUserset(item); ok && itemRelation != "" {
+					this.Children = append(this.Children,
+						o.expand(index, itemNamespace, itemObject, itemRelation, depth+1))
+				}
+			}
+			ret.Children = append(ret.Children, this)
+		}
+		if rewrite.ComputedUserset != "" {
+			computed := &UsersetTree{Operation: "computedUserset",
+				Children: []*UsersetTree{o.expand(index, namespace, object, rewrite.ComputedUserset, depth+1)}}
+			ret.Children = append(ret.Children, computed)
+		}
+		if rewrite.TupleToUserset != nil {
+			related := &UsersetTree{Operation: "tupleToUserset",
+				Userset: Userset(namespace, object, rewrite.TupleToUserset.Tupleset)}
+			for _, item := range index[related.Userset] {
+				if itemNamespace, itemObject, _, ok := ParseUserset(item); ok {
+					related.Children = append(related.Children,
+						o.expand(index, itemNamespace, itemObject, rewrite.TupleToUserset.ComputedUserset, depth+1))
+				}
+			}
+			ret.Children = append(ret.Children, related)
+		}
+	}
+	return
+}
+
+// ListObjects finds the objects of the namespace the subject has the relation to
+func (o *RelationEngine) ListObjects(namespace string, relation string, subject string) (ret []string, err error) {
+	var index relationIndex
+	if index, err = o.index(); err != nil {
+		return
+	}
+
+	candidates := map[string]bool{}
+	for key := range index {
+		if keyNamespace, keyObject, _, ok := ParseUserset(key); ok && keyNamespace == namespace {
+			candidates[keyObject] = true
+		}
+	}
+
+	ret = []string{}
+	for object := range candidates {
+		if o.check(index, namespace, object, relation, subject, 0) {
+			ret = append(ret, object)
+		}
+	}
+	sort.Strings(ret)
+	return
+}
+
+func (o *EsEngine) ImplementRelations(relations *RelationEngine) {
+	o.RelationTuple.ImplementRelations(relations)
+}
+
+func (o *RelationTupleAggregateEngine) ImplementRelations(relations *RelationEngine) {
+	o.AggregateExecutors.Initial.AddCreatePreparer(func(cmd *CreateRelationTuple, entity *RelationTuple) (err error) {
+		if cmd.Namespace == "" || cmd.Object == "" || cmd.Relation == "" || cmd.Subject == "" {
+			err = errors.New("namespace, object, relation and subject are required")
+			return
+		}
+		if _, parseErr := uuid.Parse(cmd.Subject); parseErr != nil {
+			if _, _, relation, ok := ParseUserset(cmd.Subject); !ok ||
+				(relation == "" && !relations.isTupleset(cmd.Namespace, cmd.Relation)) {
+				err = fmt.Errorf("subject '%v' is neither an account id nor a userset", cmd.Subject)
+				return
+			}
+		}
+		if err = relations.Configs.Validate(cmd.Namespace, cmd.Relation); err != nil {
+			return
+		}
+
+		var existing *RelationTuple
+		if existing, err = relations.Tuples.FindTuple(cmd.Namespace, cmd.Object, cmd.Relation,
+			cmd.Subject); err == nil && existing != nil {
+			err = errors.New("relation tuple exists already")
+		}
+		return
+	})
+}
+
+// isTupleset tells whether the relation links objects, its subjects may be plain object references
+func (o *RelationEngine) isTupleset(namespace string, relation string) bool {
+	if o.Configs == nil {
+		return true
+	}
+	for _, config := range o.Configs.Namespaces {
+		for _, relationConfig := range config.Relations {
+			for _, rewrite := range relationConfig.Union {
+				if rewrite.TupleToUserset != nil && rewrite.TupleToUserset.Tupleset == relation {
+					return true
+				}
+			}
+		}
+	}
+	return len(o.Configs.Namespaces) == 0
+}
+
+type RelationTupleBody struct {
+	Namespace string `json:"namespace"`
+	Object    string `json:"object"`
+	Relation  string `json:"relation"`
+	Subject   string `json:"subject"`
+}
+
+type RelationRouter struct {
+	PathPrefix string
+	Relations  *RelationEngine
+	CommandBus eventhorizon.CommandHandler
+	ctx        context.Context
+}
+
+func NewRelationRouter(pathPrefix string, newContext func(string) (ret context.Context),
+	commandBus eventhorizon.CommandHandler, relations *RelationEngine) (ret *RelationRouter) {
+	ret = &RelationRouter{
+		PathPrefix: pathPrefix + "/" + "relation",
+		Relations:  relations,
+		CommandBus: commandBus,
+		ctx:        newContext("relation"),
+	}
+	return
+}
+
+func (o *RelationRouter) Setup(router *mux.Router) (err error) {
+	router.Methods(http.MethodPost).Path(o.PathPrefix).
+		Name("WriteRelation").
+		HandlerFunc(o.Write)
+	router.Methods(http.MethodDelete).Path(o.PathPrefix).
+		Name("DeleteRelation").
+		HandlerFunc(o.Delete)
+	router.Methods(http.MethodPost).Path(o.PathPrefix + "/check").
+		Name("CheckRelation").
+		HandlerFunc(o.Check)
+	router.Methods(http.MethodGet).Path(o.PathPrefix + "/expand").
+		Name("ExpandRelation").
+		HandlerFunc(o.Expand)
+	router.Methods(http.MethodGet).Path(o.PathPrefix + "/objects").
+		Name("ListRelationObjects").
+		HandlerFunc(o.ListObjects)
+	return
+}
+
+func (o *RelationRouter) Write(w http.ResponseWriter, r *http.Request) {
+	body := &RelationTupleBody{}
+	if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+
+	create := &CreateRelationTuple{Id: uuid.New(), Namespace: body.Namespace, Object: body.Object,
+		Relation: body.Relation, Subject: body.Subject}
+	if err := o.CommandBus.HandleCommand(o.ctx, create); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, map[string]uuid.UUID{"id": create.Id})
+}
+
+func (o *RelationRouter) Delete(w http.ResponseWriter, r *http.Request) {
+	body := &RelationTupleBody{}
+	if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+
+	tuple, err := o.Relations.Tuples.FindTuple(body.Namespace, body.Object, body.Relation, body.Subject)
+	if err != nil || tuple == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if err = o.CommandBus.HandleCommand(o.ctx, &DeleteRelationTuple{Id: tuple.Id}); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (o *RelationRouter) Check(w http.ResponseWriter, r *http.Request) {
+	body := &RelationTupleBody{}
+	if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+
+	if allowed, err := o.Relations.Check(body.Namespace, body.Object, body.Relation, body.Subject); err == nil {
+		writeJSON(w, http.StatusOK, map[string]bool{"allowed": allowed})
+	} else {
+		writeError(w, http.StatusInternalServerError, "server_error", err)
+	}
+}
+
+func (o *RelationRouter) Expand(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if ret, err := o.Relations.Expand(query.Get("namespace"), query.Get("object"), query.Get("relation")); err == nil {
+		writeJSON(w, http.StatusOK, ret)
+	} else {
+		writeError(w, http.StatusInternalServerError, "server_error", err)
+	}
+}
+
+func (o *RelationRouter) ListObjects(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if ret, err := o.Relations.ListObjects(query.Get("namespace"), query.Get("relation"),
+		query.Get("subject")); err == nil {
+		writeJSON(w, http.StatusOK, ret)
+	} else {
+		writeError(w, http.StatusInternalServerError, "server_error", err)
+	}
+}
@@ -0,0 +1,186 @@
+package auth
+
+import (
+	"context"
+	"github.com/go-ee/utils/eh/app"
+	"github.com/go-ee/utils/eh/app/filestore"
+	"github.com/go-ee/utils/eh/app/memory"
+	"github.com/google/uuid"
+	"github.com/looplab/eventhorizon"
+	"reflect"
+	"testing"
+)
+
+var relationTestConfigs = &RelationConfigs{Namespaces: map[string]*NamespaceConfig{
+	"document": {Relations: map[string]*RelationConfig{
+		"parent": {},
+		"owner":  {},
+		"editor": {Union: []*UsersetRewrite{{This: true}, {ComputedUserset: "owner"}}},
+		"viewer": {Union: []*UsersetRewrite{{This: true}, {ComputedUserset: "editor"},
+			{TupleToUserset: &TupleToUserset{Tupleset: "parent", ComputedUserset: "viewer"}}}},
+	}},
+	"folder": {Relations: map[string]*RelationConfig{
+		"viewer": {},
+	}},
+	"group": {Relations: map[string]*RelationConfig{
+		"member": {},
+	}},
+}}
+
+// relationBackends are the read repositories of the relation tuples of all event store backends
+func relationBackends(t *testing.T) map[string]eventhorizon.ReadWriteRepo {
+	info := &app.AppInfo{AppName: "auth", WorkingFolder: t.TempDir()}
+	config := &app.ServerConfig{}
+	backends := map[string]*app.AppBase{
+		"memory": memory.NewAppMemory(info, config, false),
+		"file":   filestore.NewAppFileStore(info, config, false, t.TempDir()),
+	}
+
+	ret := map[string]eventhorizon.ReadWriteRepo{}
+	for name, backend := range backends {
+		repo, err := backend.Middleware.Repos(string(RelationTupleAggregateType),
+			func() eventhorizon.Entity { return NewRelationTupleDefault() })
+		if err != nil {
+			t.Fatal(err)
+		}
+		ret[name] = repo
+	}
+	return ret
+}
+
+func TestRelationStore(t *testing.T) {
+	for name, repo := range relationBackends(t) {
+		t.Run(name, func(t *testing.T) {
+			testRelationStore(t, repo)
+		})
+	}
+}
+
+// testRelationStore is the conformance of a backend, the stored tuples are written like by the projector
+func testRelationStore(t *testing.T, repo eventhorizon.ReadWriteRepo) {
+	ctx := context.Background()
+	alice, bob, carol := uuid.New().String(), uuid.New().String(), uuid.New().String()
+
+	save := func(namespace string, object string, relation string, subject string) (ret *RelationTuple) {
+		ret = &RelationTuple{Id: uuid.New(), Namespace: namespace, Object: object, Relation: relation,
+			Subject: subject}
+		if err := repo.Save(ctx, ret); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	save("document", "readme", "owner", alice)
+	save("document", "readme", "parent", "folder:docs")
+	shared := save("folder", "docs", "viewer", bob)
+	save("document", "plan", "viewer", Userset("group", "team", "member"))
+	save("group", "team", "member", carol)
+
+	relations := NewRelationEngine(NewRelationTupleQueryRepositoryFull(repo, ctx), relationTestConfigs)
+
+	for _, item := range []struct {
+		object, relation, subject string
+		expected                  bool
+	}{
+		{"readme", "owner", alice, true},
+		{"readme", "editor", alice, true},
+		{"readme", "viewer", alice, true},
+		{"readme", "viewer", bob, true},
+		{"readme", "editor", bob, false},
+		{"plan", "viewer", carol, true},
+		{"plan", "viewer", alice, false},
+		{"readme", "viewer", carol, false},
+	} {
+		if ret, err := relations.Check("document", item.object, item.relation, item.subject); err != nil {
+			t.Fatal(err)
+		} else if ret != item.expected {
+			t.Errorf("check %v#%v of %v is %v", item.object, item.relation, item.subject, ret)
+		}
+	}
+
+	if ret, err := relations.ListObjects("document", "viewer", bob); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(ret, []string{"readme"}) {
+		t.Errorf("objects of bob are %v", ret)
+	}
+
+	if tree, err := relations.Expand("document", "readme", "editor"); err != nil {
+		t.Fatal(err)
+	} else if !treeContains(tree, alice) {
+		t.Errorf("expansion of the editors misses the owner")
+	}
+
+	if ret, err := relations.Tuples.FindTuple("folder", "docs", "viewer", bob); err != nil || ret == nil ||
+		ret.Id != shared.Id {
+		t.Errorf("tuple of bob not found: %v", err)
+	}
+	if ret, err := relations.Tuples.FindTuple("folder", "docs", "viewer", alice); err != nil || ret != nil {
+		t.Errorf("tuple of alice found: %v", err)
+	}
+
+	if err := repo.Remove(ctx, shared.Id); err != nil {
+		t.Fatal(err)
+	}
+	if ret, err := relations.Check("document", "readme", "viewer", bob); err != nil || ret {
+		t.Errorf("deleted tuple still grants the relation: %v", err)
+	}
+}
+
+func treeContains(tree *UsersetTree, subject string) bool {
+	for _, item := range tree.Subjects {
+		if item == subject {
+			return true
+		}
+	}
+	for _, child := range tree.Children {
+		if treeContains(child, subject) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRelationTupleValidation(t *testing.T) {
+	for name, repo := range relationBackends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			subject := uuid.New().String()
+			if err := repo.Save(ctx, &RelationTuple{Id: uuid.New(), Namespace: "folder", Object: "docs",
+				Relation: "viewer", Subject: subject}); err != nil {
+				t.Fatal(err)
+			}
+
+			engine := &RelationTupleAggregateEngine{AggregateExecutors: NewRelationTupleAggregateExecutorsFull()}
+			if err := engine.AggregateExecutors.SetupCommandHandler(); err != nil {
+				t.Fatal(err)
+			}
+			engine.ImplementRelations(NewRelationEngine(NewRelationTupleQueryRepositoryFull(repo, ctx),
+				relationTestConfigs))
+
+			for _, item := range []struct {
+				cmd   *CreateRelationTuple
+				valid bool
+			}{
+				{&CreateRelationTuple{Namespace: "folder", Object: "docs", Relation: "viewer",
+					Subject: uuid.New().String()}, true},
+				{&CreateRelationTuple{Namespace: "folder", Object: "docs", Relation: "viewer",
+					Subject: subject}, false},
+				{&CreateRelationTuple{Namespace: "folder", Object: "docs", Relation: "owner",
+					Subject: uuid.New().String()}, false},
+				{&CreateRelationTuple{Namespace: "document", Object: "readme", Relation: "parent",
+					Subject: "folder:docs"}, true},
+				{&CreateRelationTuple{Namespace: "document", Object: "readme", Relation: "owner",
+					Subject: "folder:docs"}, false},
+				{&CreateRelationTuple{Namespace: "document", Object: "readme", Relation: "viewer",
+					Subject: Userset("group", "team", "member")}, true},
+			} {
+				events := &recordedEvents{}
+				err := engine.AggregateExecutors.Initial.CreateHandler(item.cmd, NewRelationTupleDefault(), events)
+				if item.valid && (err != nil || len(events.Types) != 1) {
+					t.Errorf("%v#%v@%v rejected: %v", item.cmd.Object, item.cmd.Relation, item.cmd.Subject, err)
+				} else if !item.valid && err == nil {
+					t.Errorf("%v#%v@%v accepted", item.cmd.Object, item.cmd.Relation, item.cmd.Subject)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+func (o *RoleQueryRepository) FindByName(name string) (ret *Role, err error) {
+	var roles []*Role
+	if roles, err = o.FindAll(); err != nil {
+		return
+	}
+	for _, role := range roles {
+		if role.Name == name {
+			return role, nil
+		}
+	}
+	return
+}
+
+// RoleHierarchy expands roles to the roles they inherit and the permissions of all of them.
+// Roles without definition are plain roles, they inherit nothing and grant the permission of their name only.
+type RoleHierarchy struct {
+	Roles *RoleQueryRepository
+}
+
+func NewRoleHierarchy(roles *RoleQueryRepository) (ret *RoleHierarchy) {
+	ret = &RoleHierarchy{Roles: roles}
+	return
+}
+
+// RoleTree is a role with the roles it inherits
+type RoleTree struct {
+	Role        string      `json:"role"`
+	Permissions []string    `json:"permissions,omitempty"`
+	Inherits    []*RoleTree `json:"inherits,omitempty"`
+}
+
+// Expand adds the inherited roles, the given roles keep their order in front
+func (o *RoleHierarchy) Expand(roles []string) (ret []string, err error) {
+	var definitions map[string]*Role
+	if definitions, err = o.definitions(); err == nil {
+		ret = expandRoles(definitions, roles)
+	}
+	return
+}
+
+// Permissions of the roles and of all inherited roles
+func (o *RoleHierarchy) Permissions(roles []string) (ret []string, err error) {
+	var definitions map[string]*Role
+	if definitions, err = o.definitions(); err != nil {
+		return
+	}
+
+	known := map[string]bool{}
+	for _, role := range expandRoles(definitions, roles) {
+		if definition := definitions[role]; definition != nil {
+			for _, permission := range definition.Permissions {
+				if !known[permission] {
+					known[permission] = true
+					ret = append(ret, permission)
+				}
+			}
+		}
+	}
+	return
+}
+
+// Inheritance maps each role of the expansion to the role it was inherited from, empty for the given roles
+func (o *RoleHierarchy) Inheritance(roles []string) (ret map[string]string, err error) {
+	var definitions map[string]*Role
+	if definitions, err = o.definitions(); err != nil {
+		return
+	}
+
+	ret = map[string]string{}
+	pending := append([]string{}, roles...)
+	for _, role := range roles {
+		ret[role] = ""
+	}
+	for len(pending) > 0 {
+		role := pending[0]
+		pending = pending[1:]
+		if definition := definitions[role]; definition != nil {
+			for _, inherited := range definition.Inherits {
+				if _, ok := ret[inherited]; !ok {
+					ret[inherited] = role
+					pending = append(pending, inherited)
+				}
+			}
+		}
+	}
+	return
+}
+
+func (o *RoleHierarchy) Tree(role string) (ret *RoleTree, err error) {
+	var definitions map[string]*Role
+	if definitions, err = o.definitions(); err == nil {
+		ret = roleTree(definitions, role, map[string]bool{})
+	}
+	return
+}
+
+func (o *RoleHierarchy) definitions() (ret map[string]*Role, err error) {
+	var roles []*Role
+	if roles, err = o.Roles.FindAll(); err != nil {
+		return
+	}
+	ret = map[string]*Role{}
+	for _, role := range roles {
+		ret[role.Name] = role
+	}
+	return
+}
+
+func expandRoles(definitions map[string]*Role, roles []string) (ret []string) {
+	known := map[string]bool{}
+	pending := append([]string{}, roles...)
+	for len(pending) > 0 {
+		role := pending[0]
+		pending = pending[1:]
+		if known[role] {
+			continue
+		}
+		known[role] = true
+		ret = append(ret, role)
+		if definition := definitions[role]; definition != nil {
+			pending = append(pending, definition.Inherits...)
+		}
+	}
+	return
+}
+
+func roleTree(definitions map[string]*Role, role string, path map[string]bool) (ret *RoleTree) {
+	ret = &RoleTree{Role: role}
+	definition := definitions[role]
+	if definition == nil || path[role] {
+		return
+	}
+
+	path[role] = true
+	ret.Permissions = definition.Permissions
+	for _, inherited := range definition.Inherits {
+		ret.Inherits = append(ret.Inherits, roleTree(definitions, inherited, path))
+	}
+	delete(path, role)
+	return
+}
+
+// checkInheritance rejects definitions that let the role inherit itself
+func (o *RoleHierarchy) checkInheritance(role string, inherits []string) (err error) {
+	var definitions map[string]*Role
+	if definitions, err = o.definitions(); err != nil {
+		return
+	}
+	definitions[role] = &Role{Name: role, Inherits: inherits}
+
+	for _, inherited := range inherits {
+		for _, reachable := range expandRoles(definitions, []string{inherited}) {
+			if reachable == role {
+				err = fmt.Errorf("role '%v' would inherit itself over '%v'", role, inherited)
+				return
+			}
+		}
+	}
+	return
+}
+
+func (o *EsEngine) ImplementRoles(hierarchy *RoleHierarchy) {
+	o.Role.ImplementRoles(hierarchy)
+}
+
+func (o *RoleAggregateEngine) ImplementRoles(hierarchy *RoleHierarchy) {
+	o.AggregateExecutors.Initial.AddCreatePreparer(func(cmd *CreateRole, entity *Role) (err error) {
+		if cmd.Name == "" {
+			err = errors.New("role name is required")
+		} else if existing, findErr := hierarchy.Roles.FindByName(cmd.Name); findErr != nil {
+			err = findErr
+		} else if existing != nil {
+			err = fmt.Errorf("role '%v' exists already", cmd.Name)
+		} else {
+			err = hierarchy.checkInheritance(cmd.Name, cmd.Inherits)
+		}
+		return
+	})
+
+	o.AggregateExecutors.Exist.AddUpdatePreparer(func(cmd *UpdateRole, entity *Role) (err error) {
+		if cmd.Name == "" {
+			cmd.Name = entity.Name
+		}
+		if cmd.Name != entity.Name {
+			err = errors.New("roles can not be renamed, other roles may inherit them")
+		} else {
+			err = hierarchy.checkInheritance(cmd.Name, cmd.Inherits)
+		}
+		return
+	})
+}
+
+type RoleTreeRouter struct {
+	PathPrefix string
+	Hierarchy  *RoleHierarchy
+}
+
+func NewRoleTreeRouter(pathPrefix string, hierarchy *RoleHierarchy) (ret *RoleTreeRouter) {
+	ret = &RoleTreeRouter{
+		PathPrefix: pathPrefix + "/" + "role",
+		Hierarchy:  hierarchy,
+	}
+	return
+}
+
+func (o *RoleTreeRouter) Setup(router *mux.Router) (err error) {
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefix).Path("/{id}/tree").
+		Name("RoleTree").
+		HandlerFunc(o.Tree)
+	return
+}
+
+// Tree shows the expanded inheritance of a role, the path variable is the id or the name of the role
+func (o *RoleTreeRouter) Tree(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["id"]
+	if id, err := uuid.Parse(name); err == nil {
+		var role *Role
+		if role, err = o.Hierarchy.Roles.FindById(id); err != nil || role == nil {
+			http.NotFound(w, r)
+			return
+		}
+		name = role.Name
+	}
+
+	if ret, err := o.Hierarchy.Tree(name); err == nil {
+		writeJSON(w, http.StatusOK, ret)
+	} else {
+		writeError(w, http.StatusInternalServerError, "server_error", err)
+	}
+}
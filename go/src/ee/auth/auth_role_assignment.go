@@ -0,0 +1,372 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-ee/utils"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/looplab/eventhorizon"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const PermissionManageRoles = "auth:roles"
+
+func (o *RoleAssignment) IsActive(now time.Time) bool {
+	return (o.ValidFrom == nil || !now.Before(*o.ValidFrom)) && (o.ValidUntil == nil || now.Before(*o.ValidUntil))
+}
+
+func (o *RoleAssignment) IsEnded(now time.Time) bool {
+	return o.ValidUntil != nil && !now.Before(*o.ValidUntil)
+}
+
+func (o *Account) FindRoleAssignment(assignmentId uuid.UUID) (ret *RoleAssignment) {
+	for _, assignment := range o.RoleAssignments {
+		if assignment.AssignmentId == assignmentId {
+			return assignment
+		}
+	}
+	return
+}
+
+func (o *Account) RemoveRoleAssignment(assignmentId uuid.UUID) {
+	assignments := o.RoleAssignments[:0]
+	for _, assignment := range o.RoleAssignments {
+		if assignment.AssignmentId != assignmentId {
+			assignments = append(assignments, assignment)
+		}
+	}
+	o.RoleAssignments = assignments
+}
+
+// ActiveRoles are the own roles of the account and the roles of assignments valid at the time
+func (o *Account) ActiveRoles(now time.Time) (ret []string) {
+	known := map[string]bool{}
+	for _, role := range o.Roles {
+		if !known[role] {
+			known[role] = true
+			ret = append(ret, role)
+		}
+	}
+	for _, assignment := range o.RoleAssignments {
+		if assignment.IsActive(now) && !known[assignment.Role] {
+			known[assignment.Role] = true
+			ret = append(ret, assignment.Role)
+		}
+	}
+	return
+}
+
+// RolesValidUntil is the end of the first active role assignment, nil if no assignment is active
+func (o *Account) RolesValidUntil(now time.Time) (ret *time.Time) {
+	for _, assignment := range o.RoleAssignments {
+		if assignment.IsActive(now) && assignment.ValidUntil != nil &&
+			(ret == nil || assignment.ValidUntil.Before(*ret)) {
+			ret = assignment.ValidUntil
+		}
+	}
+	return
+}
+
+func (o *EsEngine) ImplementRoleAssignments() {
+	o.Account.ImplementRoleAssignments()
+}
+
+func (o *AccountAggregateEngine) ImplementRoleAssignments() {
+	o.AggregateExecutors.Enabled.AddAssignRolePreparer(
+		func(cmd *AssignRoleAccount, entity *Account) (err error) {
+			now := time.Now()
+			if cmd.AssignmentId == uuid.Nil {
+				cmd.AssignmentId = uuid.New()
+			}
+			if cmd.ValidFrom == nil {
+				cmd.ValidFrom = utils.PtrTime(now)
+			}
+
+			// assignments without end are permanent
+			if cmd.Role == "" {
+				err = errors.New("role is required")
+			} else if cmd.ValidUntil != nil && (!cmd.ValidUntil.After(*cmd.ValidFrom) || !cmd.ValidUntil.After(now)) {
+				err = errors.New("end of the role assignment must be after its start and in the future")
+			} else if entity.FindRoleAssignment(cmd.AssignmentId) != nil {
+				err = fmt.Errorf("role assignment '%v' exists already", cmd.AssignmentId)
+			}
+			return
+		})
+
+	unassignPreparer := func(cmd *UnassignRoleAccount, entity *Account) (err error) {
+		if entity.FindRoleAssignment(cmd.AssignmentId) == nil {
+			err = fmt.Errorf("role assignment '%v' not found", cmd.AssignmentId)
+		}
+		return
+	}
+	o.AggregateExecutors.Enabled.AddUnassignRolePreparer(unassignPreparer)
+	o.AggregateExecutors.Disabled.AddUnassignRolePreparer(unassignPreparer)
+
+	expirePreparer := func(cmd *ExpireRoleAccount, entity *Account) (err error) {
+		if assignment := entity.FindRoleAssignment(cmd.AssignmentId); assignment == nil {
+			err = fmt.Errorf("role assignment '%v' not found", cmd.AssignmentId)
+		} else if !assignment.IsEnded(time.Now()) {
+			err = fmt.Errorf("role assignment '%v' is not ended", cmd.AssignmentId)
+		} else {
+			cmd.Role = assignment.Role
+		}
+		return
+	}
+	o.AggregateExecutors.Enabled.AddExpireRolePreparer(expirePreparer)
+	o.AggregateExecutors.Disabled.AddExpireRolePreparer(expirePreparer)
+
+	o.AggregateHandlers.Enabled.AssignedRoleHandler =
+		func(event eventhorizon.Event, eventData *AccountAssignedRole, entity *Account) (err error) {
+			entity.AddToRoleAssignments(&RoleAssignment{
+				AssignmentId:   eventData.AssignmentId,
+				Role:           eventData.Role,
+				ValidFrom:      eventData.ValidFrom,
+				ValidUntil:     eventData.ValidUntil,
+				AssignedBy:     eventData.AssignedBy,
+				GrantRequestId: eventData.GrantRequestId,
+			})
+			return
+		}
+
+	unassignedHandler := func(event eventhorizon.Event, eventData *AccountUnassignedRole, entity *Account) (err error) {
+		entity.RemoveRoleAssignment(eventData.AssignmentId)
+		return
+	}
+	o.AggregateHandlers.Enabled.UnassignedRoleHandler = unassignedHandler
+	o.AggregateHandlers.Disabled.UnassignedRoleHandler = unassignedHandler
+
+	expiredHandler := func(event eventhorizon.Event, eventData *AccountRoleExpired, entity *Account) (err error) {
+		entity.RemoveRoleAssignment(eventData.AssignmentId)
+		return
+	}
+	o.AggregateHandlers.Enabled.RoleExpiredHandler = expiredHandler
+	o.AggregateHandlers.Disabled.RoleExpiredHandler = expiredHandler
+}
+
+// RoleExpiryScheduler ends role assignments when their validity is over.
+type RoleExpiryScheduler struct {
+	Accounts   *AccountQueryRepository
+	CommandBus eventhorizon.CommandHandler
+	Interval   time.Duration
+	ctx        context.Context
+	stop       chan struct{}
+	mutex      sync.Mutex
+}
+
+func NewRoleExpiryScheduler(newContext func(string) (ret context.Context), commandBus eventhorizon.CommandHandler,
+	accounts *AccountQueryRepository, interval time.Duration) (ret *RoleExpiryScheduler) {
+	ret = &RoleExpiryScheduler{
+		Accounts:   accounts,
+		CommandBus: commandBus,
+		Interval:   interval,
+		ctx:        newContext("roleExpiry"),
+	}
+	return
+}
+
+func (o *RoleExpiryScheduler) Start() {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if o.stop != nil {
+		return
+	}
+
+	o.stop = make(chan struct{})
+	go func(stop chan struct{}) {
+		ticker := time.NewTicker(o.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				if err := o.ExpireDue(now); err != nil {
+					logrus.Warnf("expiry of role assignments failed: %v", err)
+				}
+			case <-stop:
+				return
+			}
+		}
+	}(o.stop)
+}
+
+func (o *RoleExpiryScheduler) Stop() {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if o.stop != nil {
+		close(o.stop)
+		o.stop = nil
+	}
+}
+
+// ExpireDue ends all role assignments over at the time, failures do not stop the expiry of other assignments.
+// Deleted accounts keep their assignments for a restore, they are ended after it.
+func (o *RoleExpiryScheduler) ExpireDue(now time.Time) (err error) {
+	var accounts []*Account
+	if accounts, err = o.Accounts.FindAll(); err != nil {
+		return
+	}
+
+	for _, account := range accounts {
+		if account.DeletedAt != nil {
+			continue
+		}
+		for _, assignment := range account.RoleAssignments {
+			if !assignment.IsEnded(now) {
+				continue
+			}
+			if expireErr := o.CommandBus.HandleCommand(o.ctx, &ExpireRoleAccount{Id: account.Id,
+				AssignmentId: assignment.AssignmentId, Role: assignment.Role}); expireErr != nil {
+				err = expireErr
+			}
+		}
+	}
+	return
+}
+
+type AssignRoleRequest struct {
+	Role       string     `json:"role"`
+	ValidFrom  *time.Time `json:"validFrom,omitempty"`
+	ValidUntil *time.Time `json:"validUntil,omitempty"`
+}
+
+// RoleAssignmentRouter assigns roles the principal holds to other accounts,
+// roles requiring approval are assigned over grant requests only.
+type RoleAssignmentRouter struct {
+	PathPrefix string
+	Accounts   *AccountQueryRepository
+	Policy     *RoleGrantPolicy
+	CommandBus eventhorizon.CommandHandler
+	ctx        context.Context
+}
+
+func NewRoleAssignmentRouter(pathPrefix string, newContext func(string) (ret context.Context),
+	commandBus eventhorizon.CommandHandler, accounts *AccountQueryRepository,
+	policy *RoleGrantPolicy) (ret *RoleAssignmentRouter) {
+	ret = &RoleAssignmentRouter{
+		PathPrefix: pathPrefix + "/" + "account",
+		Accounts:   accounts,
+		Policy:     policy,
+		CommandBus: commandBus,
+		ctx:        newContext("roleAssignment"),
+	}
+	return
+}
+
+func (o *RoleAssignmentRouter) Setup(router *mux.Router) (err error) {
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefix).Path("/{id}/roles").
+		Name("RoleAssignmentFindAll").
+		HandlerFunc(o.FindAll)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefix).Path("/{id}/roles").
+		Name("AssignRole").
+		HandlerFunc(o.Assign)
+	router.Methods(http.MethodDelete).PathPrefix(o.PathPrefix).Path("/{id}/roles/{assignmentId}").
+		Name("UnassignRole").
+		HandlerFunc(o.Unassign)
+	return
+}
+
+func (o *RoleAssignmentRouter) FindAll(w http.ResponseWriter, r *http.Request) {
+	if account, ok := o.account(w, r); ok {
+		ret := account.RoleAssignments
+		if ret == nil {
+			ret = []*RoleAssignment{}
+		}
+		writeJSON(w, http.StatusOK, ret)
+	}
+}
+
+func (o *RoleAssignmentRouter) Assign(w http.ResponseWriter, r *http.Request) {
+	account, ok := o.account(w, r)
+	if !ok {
+		return
+	}
+
+	request := &AssignRoleRequest{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+
+	principal := PrincipalFrom(r.Context())
+	if principal.AccountId == account.Id {
+		writeError(w, http.StatusForbidden, "access_denied", errors.New("roles can not be assigned to the own account"))
+		return
+	}
+	if err := principal.CheckGrantable([]string{request.Role}, nil); err != nil {
+		writeError(w, http.StatusForbidden, "access_denied", err)
+		return
+	}
+	if required, err := o.Policy.RequiresApproval(request.Role); err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", err)
+		return
+	} else if required {
+		writeError(w, http.StatusForbidden, "access_denied",
+			fmt.Errorf("role '%v' requires an approved grant request", request.Role))
+		return
+	}
+
+	assign := &AssignRoleAccount{
+		Id:           account.Id,
+		AssignmentId: uuid.New(),
+		Role:         request.Role,
+		ValidFrom:    request.ValidFrom,
+		ValidUntil:   request.ValidUntil,
+		AssignedBy:   principal.AccountId,
+	}
+	if err := o.CommandBus.HandleCommand(o.ctx, assign); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, &RoleAssignment{
+		AssignmentId: assign.AssignmentId,
+		Role:         assign.Role,
+		ValidFrom:    assign.ValidFrom,
+		ValidUntil:   assign.ValidUntil,
+		AssignedBy:   assign.AssignedBy,
+	})
+}
+
+func (o *RoleAssignmentRouter) Unassign(w http.ResponseWriter, r *http.Request) {
+	account, ok := o.account(w, r)
+	if !ok {
+		return
+	}
+
+	assignmentId, err := uuid.Parse(mux.Vars(r)["assignmentId"])
+	if err != nil || account.FindRoleAssignment(assignmentId) == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err = o.CommandBus.HandleCommand(o.ctx, &UnassignRoleAccount{Id: account.Id, AssignmentId: assignmentId}); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// account loads the account of the path, the permission to manage roles is checked by the authorizer
+func (o *RoleAssignmentRouter) account(w http.ResponseWriter, r *http.Request) (ret *Account, ok bool) {
+	if PrincipalFrom(r.Context()) == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", ErrUnauthenticated)
+		return
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	if ret, err = o.Accounts.FindById(id); err != nil || ret == nil {
+		http.NotFound(w, r)
+		return
+	}
+	ok = true
+	return
+}
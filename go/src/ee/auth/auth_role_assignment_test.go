@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"context"
+	"github.com/go-ee/utils"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/looplab/eventhorizon"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExpireDueSkipsDeletedAccounts(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryRepo(t, AccountAggregateType, func() eventhorizon.Entity { return NewAccountDefault() })
+
+	now := time.Now()
+	ended := func() []*RoleAssignment {
+		return []*RoleAssignment{{AssignmentId: uuid.New(), Role: "auditor", ValidUntil: utils.PtrTime(now)}}
+	}
+	active := &Account{Id: uuid.New(), RoleAssignments: ended()}
+	deleted := &Account{Id: uuid.New(), RoleAssignments: ended(), DeletedAt: utils.PtrTime(now)}
+	permanent := &Account{Id: uuid.New(), RoleAssignments: []*RoleAssignment{{AssignmentId: uuid.New(),
+		Role: "auditor"}}}
+	for _, account := range []*Account{active, deleted, permanent} {
+		if err := repo.Save(ctx, account); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var expired []uuid.UUID
+	commandBus := eventhorizon.CommandHandlerFunc(func(ctx context.Context, cmd eventhorizon.Command) error {
+		expired = append(expired, cmd.AggregateID())
+		return nil
+	})
+	scheduler := NewRoleExpiryScheduler(func(string) context.Context { return ctx }, commandBus,
+		NewAccountQueryRepositoryFull(repo, ctx), time.Minute)
+
+	if err := scheduler.ExpireDue(now.Add(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if len(expired) != 1 || expired[0] != active.Id {
+		t.Errorf("expired assignments of %v instead of %v", expired, active.Id)
+	}
+}
+
+func TestAssignRoleChecksPrincipal(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryRepo(t, AccountAggregateType, func() eventhorizon.Entity { return NewAccountDefault() })
+	principal := &Principal{AccountId: uuid.New(), Roles: []string{PermissionManageRoles, "auditor"}}
+	own := &Account{Id: principal.AccountId}
+	other := &Account{Id: uuid.New()}
+	for _, account := range []*Account{own, other} {
+		if err := repo.Save(ctx, account); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var assigned []string
+	commandBus := eventhorizon.CommandHandlerFunc(func(ctx context.Context, cmd eventhorizon.Command) error {
+		assigned = append(assigned, cmd.(*AssignRoleAccount).Role)
+		return nil
+	})
+	router := mux.NewRouter()
+	if err := NewRoleAssignmentRouter("/auth", func(string) context.Context { return ctx }, commandBus,
+		NewAccountQueryRepositoryFull(repo, ctx), NewRoleGrantPolicy(nil, nil)).Setup(router); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, item := range []struct {
+		name     string
+		account  *Account
+		role     string
+		expected int
+	}{
+		{"own account", own, "auditor", http.StatusForbidden},
+		{"role not held", other, "billing", http.StatusForbidden},
+		{"role requiring approval", other, PermissionManageRoles, http.StatusForbidden},
+		{"held role", other, "auditor", http.StatusCreated},
+	} {
+		request := httptest.NewRequest(http.MethodPost, "/auth/account/"+item.account.Id.String()+"/roles",
+			strings.NewReader(`{"role":"`+item.role+`"}`))
+		request = request.WithContext(WithPrincipal(request.Context(), principal))
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		if recorder.Code != item.expected {
+			t.Errorf("%v answered %v instead of %v", item.name, recorder.Code, item.expected)
+		}
+	}
+
+	if len(assigned) != 1 || assigned[0] != "auditor" {
+		t.Errorf("assigned roles %v", assigned)
+	}
+}
@@ -0,0 +1,466 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-ee/utils"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/looplab/eventhorizon"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const PermissionApproveRoles = "auth:roles:approve"
+
+// PrivilegedPermissions administrate the service, roles granting one of them require approval
+var PrivilegedPermissions = []string{PermissionManageAccounts, PermissionManageGroups, PermissionManageOrganizations,
+	PermissionManageRoles, PermissionApproveRoles, PermissionImpersonate, PermissionManagePolicies,
+	PermissionManageRelations, PermissionManageSessions}
+
+// RoleGrantPolicy lists the roles granted over approved grant requests only. Roles granting a privileged
+// permission and roles inheriting one of them require approval as well.
+type RoleGrantPolicy struct {
+	ApprovalRoles       []string
+	ApprovalPermissions []string
+	Hierarchy           *RoleHierarchy
+}
+
+func NewRoleGrantPolicy(approvalRoles []string, hierarchy *RoleHierarchy) (ret *RoleGrantPolicy) {
+	ret = &RoleGrantPolicy{ApprovalPermissions: PrivilegedPermissions, Hierarchy: hierarchy}
+	for _, role := range approvalRoles {
+		if role = strings.TrimSpace(role); role != "" {
+			ret.ApprovalRoles = append(ret.ApprovalRoles, role)
+		}
+	}
+	return
+}
+
+func (o *RoleGrantPolicy) RequiresApproval(role string) (ret bool, err error) {
+	roles := []string{role}
+	var permissions []string
+	if o.Hierarchy != nil {
+		if roles, err = o.Hierarchy.Expand(roles); err != nil {
+			return
+		}
+		if permissions, err = o.Hierarchy.Permissions(roles); err != nil {
+			return
+		}
+	}
+
+	for _, item := range roles {
+		for _, approvalRole := range o.ApprovalRoles {
+			if item == approvalRole {
+				ret = true
+				return
+			}
+		}
+	}
+	// roles named like a permission grant it
+	for _, pattern := range append(roles, permissions...) {
+		for _, permission := range o.ApprovalPermissions {
+			if matchPermission(pattern, permission) {
+				ret = true
+				return
+			}
+		}
+	}
+	return
+}
+
+// CheckGranted rejects the first role requiring approval which is not granted already
+func (o *RoleGrantPolicy) CheckGranted(roles []string, granted func(role string) bool) (err error) {
+	for _, role := range roles {
+		if granted != nil && granted(role) {
+			continue
+		}
+		var required bool
+		if required, err = o.RequiresApproval(role); err != nil {
+			return
+		} else if required {
+			err = fmt.Errorf("role '%v' requires an approved grant request", role)
+			return
+		}
+	}
+	return
+}
+
+// CheckGrantable rejects the first role the principal does not hold, nobody grants more than it has
+func (o *Principal) CheckGrantable(roles []string, granted func(role string) bool) (err error) {
+	for _, role := range roles {
+		if (granted == nil || !granted(role)) && !o.HasPermission(role) {
+			err = fmt.Errorf("role '%v' can not be granted", role)
+			return
+		}
+	}
+	return
+}
+
+// GrantGuard rejects roles in the commands of the generic routes the principal does not hold,
+// roles the account or group has already are kept.
+type GrantGuard struct {
+	// RoleRoutes are the routes of commands with roles
+	RoleRoutes map[string]bool
+	Accounts   *AccountQueryRepository
+	Groups     *GroupQueryRepository
+}
+
+func NewGrantGuard(accounts *AccountQueryRepository, groups *GroupQueryRepository) (ret *GrantGuard) {
+	ret = &GrantGuard{
+		RoleRoutes: map[string]bool{
+			"CreateAccount": true,
+			"UpdateAccount": true,
+			"CreateGroup":   true,
+			"UpdateGroup":   true,
+		},
+		Accounts: accounts,
+		Groups:   groups,
+	}
+	return
+}
+
+func (o *GrantGuard) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal := PrincipalFrom(r.Context())
+		route := mux.CurrentRoute(r)
+		if principal == nil || route == nil || !o.RoleRoutes[route.GetName()] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// the body is read for the roles and passed on unchanged
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_request", err)
+			return
+		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		command := &struct {
+			Roles []string `json:"roles"`
+		}{}
+		if len(body) > 0 {
+			if err = json.Unmarshal(body, command); err != nil {
+				writeError(w, http.StatusBadRequest, "invalid_request", err)
+				return
+			}
+		}
+
+		if err = principal.CheckGrantable(command.Roles, o.granted(route.GetName(), mux.Vars(r)["id"])); err != nil {
+			writeError(w, http.StatusForbidden, "access_denied", err)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// granted are the roles of the updated account or group
+func (o *GrantGuard) granted(routeName string, id string) (ret func(role string) bool) {
+	entityId, err := uuid.Parse(id)
+	if err != nil {
+		return
+	}
+
+	var roles []string
+	switch routeName {
+	case "UpdateAccount":
+		if account, findErr := o.Accounts.FindById(entityId); findErr == nil && account != nil {
+			roles = account.Roles
+		}
+	case "UpdateGroup":
+		if group, findErr := o.Groups.FindById(entityId); findErr == nil && group != nil {
+			roles = group.Roles
+		}
+	}
+	ret = func(role string) bool {
+		for _, item := range roles {
+			if item == role {
+				return true
+			}
+		}
+		return false
+	}
+	return
+}
+
+func (o *EsEngine) ImplementRoleGrants(requests *RoleGrantRequestQueryRepository, policy *RoleGrantPolicy) (err error) {
+	o.RoleGrantRequest.ImplementRoleGrants()
+	o.Account.ImplementRoleGrantPolicy(requests, policy)
+	o.Group.ImplementRoleGrantPolicy(policy)
+	err = o.RoleGrantRequest.RegisterForApproved(NewRoleGrantDispatcher(o.CommandBus))
+	return
+}
+
+func (o *RoleGrantRequestAggregateEngine) ImplementRoleGrants() {
+	o.AggregateExecutors.Initial.AddCreatePreparer(
+		func(cmd *CreateRoleGrantRequest, entity *RoleGrantRequest) (err error) {
+			if cmd.AccountId == uuid.Nil || cmd.Role == "" {
+				err = errors.New("account and role are required")
+			} else if cmd.RequestedBy == uuid.Nil {
+				err = errors.New("requester is required")
+			} else if cmd.ValidUntil != nil && !cmd.ValidUntil.After(time.Now()) {
+				err = errors.New("end of the role assignment must be in the future")
+			}
+			return
+		})
+
+	o.AggregateExecutors.Pending.AddUpdatePreparer(
+		func(cmd *UpdateRoleGrantRequest, entity *RoleGrantRequest) (err error) {
+			cmd.RequestedBy = entity.RequestedBy
+			return
+		})
+
+	o.AggregateExecutors.Pending.AddApprovePreparer(
+		func(cmd *ApproveRoleGrantRequest, entity *RoleGrantRequest) (err error) {
+			if cmd.DecidedBy == uuid.Nil {
+				err = errors.New("approver is required")
+			} else if cmd.DecidedBy == entity.RequestedBy {
+				err = errors.New("requester can not approve the own request")
+			} else if cmd.DecidedBy == entity.AccountId {
+				err = errors.New("the role can not be approved by its recipient")
+			} else {
+				cmd.AccountId = entity.AccountId
+				cmd.Role = entity.Role
+				cmd.ValidFrom = entity.ValidFrom
+				cmd.ValidUntil = entity.ValidUntil
+			}
+			return
+		})
+
+	o.AggregateExecutors.Pending.AddRejectPreparer(
+		func(cmd *RejectRoleGrantRequest, entity *RoleGrantRequest) (err error) {
+			if cmd.DecidedBy == uuid.Nil {
+				err = errors.New("decider is required")
+			}
+			return
+		})
+
+	o.AggregateHandlers.Pending.ApprovedHandler =
+		func(event eventhorizon.Event, eventData *RoleGrantRequestApproved, entity *RoleGrantRequest) (err error) {
+			entity.DecidedBy = eventData.DecidedBy
+			entity.DecidedAt = utils.PtrTime(event.Timestamp())
+			entity.Comment = eventData.Comment
+			return
+		}
+
+	o.AggregateHandlers.Pending.RejectedHandler =
+		func(event eventhorizon.Event, eventData *RoleGrantRequestRejected, entity *RoleGrantRequest) (err error) {
+			entity.DecidedBy = eventData.DecidedBy
+			entity.DecidedAt = utils.PtrTime(event.Timestamp())
+			entity.Comment = eventData.Comment
+			return
+		}
+}
+
+// ImplementRoleGrantPolicy rejects roles requiring approval unless they are assigned for an approved grant request
+func (o *AccountAggregateEngine) ImplementRoleGrantPolicy(requests *RoleGrantRequestQueryRepository,
+	policy *RoleGrantPolicy) {
+
+	o.AggregateExecutors.Initial.AddCreatePreparer(func(cmd *CreateAccount, entity *Account) (err error) {
+		err = policy.CheckGranted(cmd.Roles, nil)
+		return
+	})
+
+	o.AggregateExecutors.Exist.AddUpdatePreparer(func(cmd *UpdateAccount, entity *Account) (err error) {
+		err = policy.CheckGranted(cmd.Roles, entity.HasRole)
+		return
+	})
+
+	o.AggregateExecutors.Enabled.AddAssignRolePreparer(func(cmd *AssignRoleAccount, entity *Account) (err error) {
+		var required bool
+		if required, err = policy.RequiresApproval(cmd.Role); err != nil || !required {
+			return
+		}
+		if cmd.GrantRequestId == uuid.Nil {
+			err = fmt.Errorf("role '%v' requires an approved grant request", cmd.Role)
+			return
+		}
+
+		var request *RoleGrantRequest
+		if request, err = requests.FindById(cmd.GrantRequestId); err != nil || request == nil {
+			err = fmt.Errorf("grant request '%v' not found", cmd.GrantRequestId)
+		} else if request.AccountId != entity.Id || request.Role != cmd.Role {
+			err = fmt.Errorf("grant request '%v' is not for role '%v' of the account", cmd.GrantRequestId, cmd.Role)
+		} else if request.RequestedBy == cmd.AssignedBy {
+			err = errors.New("requester can not approve the own request")
+		}
+		return
+	})
+}
+
+// ImplementRoleGrantPolicy rejects roles requiring approval for groups, every member would get them without
+// a grant request. Roles a group has already are kept.
+func (o *GroupAggregateEngine) ImplementRoleGrantPolicy(policy *RoleGrantPolicy) {
+	o.AggregateExecutors.Initial.AddCreatePreparer(func(cmd *CreateGroup, entity *Group) (err error) {
+		err = policy.CheckGranted(cmd.Roles, nil)
+		return
+	})
+
+	o.AggregateExecutors.Exist.AddUpdatePreparer(func(cmd *UpdateGroup, entity *Group) (err error) {
+		err = policy.CheckGranted(cmd.Roles, func(role string) bool {
+			for _, item := range entity.Roles {
+				if item == role {
+					return true
+				}
+			}
+			return false
+		})
+		return
+	})
+}
+
+// RoleGrantDispatcher assigns the role of approved grant requests to the account.
+type RoleGrantDispatcher struct {
+	CommandBus eventhorizon.CommandHandler
+}
+
+func NewRoleGrantDispatcher(commandBus eventhorizon.CommandHandler) (ret *RoleGrantDispatcher) {
+	ret = &RoleGrantDispatcher{CommandBus: commandBus}
+	return
+}
+
+func (o *RoleGrantDispatcher) HandlerType() eventhorizon.EventHandlerType {
+	return "RoleGrantDispatcher"
+}
+
+func (o *RoleGrantDispatcher) HandleEvent(ctx context.Context, event eventhorizon.Event) (err error) {
+	approved, ok := event.Data().(*RoleGrantRequestApproved)
+	if !ok {
+		return
+	}
+
+	// the id of the grant request identifies the assignment, a repeated event is rejected as duplicate
+	err = o.CommandBus.HandleCommand(ctx, &AssignRoleAccount{
+		Id:             approved.AccountId,
+		AssignmentId:   event.AggregateID(),
+		Role:           approved.Role,
+		ValidFrom:      approved.ValidFrom,
+		ValidUntil:     approved.ValidUntil,
+		AssignedBy:     approved.DecidedBy,
+		GrantRequestId: event.AggregateID(),
+	})
+	return
+}
+
+type RoleGrantRequestBody struct {
+	AccountId  uuid.UUID  `json:"accountId"`
+	Role       string     `json:"role"`
+	Reason     string     `json:"reason"`
+	ValidFrom  *time.Time `json:"validFrom,omitempty"`
+	ValidUntil *time.Time `json:"validUntil,omitempty"`
+}
+
+type RoleGrantDecision struct {
+	Comment string `json:"comment,omitempty"`
+}
+
+// RoleGrantRouter takes requester and approver of grant requests from the principal of the request.
+type RoleGrantRouter struct {
+	PathPrefix string
+	CommandBus eventhorizon.CommandHandler
+	ctx        context.Context
+}
+
+func NewRoleGrantRouter(pathPrefix string, newContext func(string) (ret context.Context),
+	commandBus eventhorizon.CommandHandler) (ret *RoleGrantRouter) {
+	ret = &RoleGrantRouter{
+		PathPrefix: pathPrefix + "/" + "roleGrant",
+		CommandBus: commandBus,
+		ctx:        newContext("roleGrant"),
+	}
+	return
+}
+
+func (o *RoleGrantRouter) Setup(router *mux.Router) (err error) {
+	router.Methods(http.MethodPost).Path(o.PathPrefix).
+		Name("RequestRoleGrant").
+		HandlerFunc(o.Request)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefix).Path("/{id}/approve").
+		Name("ApproveRoleGrant").
+		HandlerFunc(o.Approve)
+	router.Methods(http.MethodPost).PathPrefix(o.PathPrefix).Path("/{id}/reject").
+		Name("RejectRoleGrant").
+		HandlerFunc(o.Reject)
+	return
+}
+
+func (o *RoleGrantRouter) Request(w http.ResponseWriter, r *http.Request) {
+	principal := PrincipalFrom(r.Context())
+	if principal == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", ErrUnauthenticated)
+		return
+	}
+
+	body := &RoleGrantRequestBody{}
+	if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+
+	if err := principal.CheckGrantable([]string{body.Role}, nil); err != nil {
+		writeError(w, http.StatusForbidden, "access_denied", err)
+		return
+	}
+
+	create := &CreateRoleGrantRequest{
+		Id:          uuid.New(),
+		AccountId:   body.AccountId,
+		Role:        body.Role,
+		Reason:      body.Reason,
+		ValidFrom:   body.ValidFrom,
+		ValidUntil:  body.ValidUntil,
+		RequestedBy: principal.AccountId,
+	}
+	if err := o.CommandBus.HandleCommand(o.ctx, create); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, map[string]uuid.UUID{"id": create.Id})
+}
+
+func (o *RoleGrantRouter) Approve(w http.ResponseWriter, r *http.Request) {
+	o.decide(w, r, func(id uuid.UUID, decidedBy uuid.UUID, comment string) eventhorizon.Command {
+		return &ApproveRoleGrantRequest{Id: id, DecidedBy: decidedBy, Comment: comment}
+	})
+}
+
+func (o *RoleGrantRouter) Reject(w http.ResponseWriter, r *http.Request) {
+	o.decide(w, r, func(id uuid.UUID, decidedBy uuid.UUID, comment string) eventhorizon.Command {
+		return &RejectRoleGrantRequest{Id: id, DecidedBy: decidedBy, Comment: comment}
+	})
+}
+
+func (o *RoleGrantRouter) decide(w http.ResponseWriter, r *http.Request,
+	command func(id uuid.UUID, decidedBy uuid.UUID, comment string) eventhorizon.Command) {
+
+	principal := PrincipalFrom(r.Context())
+	if principal == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", ErrUnauthenticated)
+		return
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	decision := &RoleGrantDecision{}
+	if r.ContentLength != 0 {
+		if err = json.NewDecoder(r.Body).Decode(decision); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_request", err)
+			return
+		}
+	}
+
+	if err = o.CommandBus.HandleCommand(o.ctx, command(id, principal.AccountId, decision.Comment)); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/looplab/eventhorizon"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRoleGrantPolicyRequiresApprovalForPrivilegedRoles(t *testing.T) {
+	ctx := context.Background()
+	roles := newMemoryRepo(t, RoleAggregateType, func() eventhorizon.Entity { return NewRoleDefault() })
+	for _, role := range []*Role{
+		{Id: uuid.New(), Name: "operator", Permissions: []string{"auth:*"}},
+		{Id: uuid.New(), Name: "lead", Inherits: []string{"operator"}},
+		{Id: uuid.New(), Name: "member", Permissions: []string{"docs:read"}},
+	} {
+		if err := roles.Save(ctx, role); err != nil {
+			t.Fatal(err)
+		}
+	}
+	policy := NewRoleGrantPolicy(nil, NewRoleHierarchy(NewRoleQueryRepositoryFull(roles, ctx)))
+
+	for _, item := range []struct {
+		roles    []string
+		granted  func(string) bool
+		accepted bool
+	}{
+		{[]string{PermissionManageOrganizations, PermissionApproveRoles}, nil, false},
+		{[]string{PermissionImpersonate}, nil, false},
+		{[]string{"lead"}, nil, false},
+		{[]string{"member"}, nil, true},
+		{[]string{PermissionManageAccounts}, func(string) bool { return true }, true},
+	} {
+		if err := policy.CheckGranted(item.roles, item.granted); (err == nil) != item.accepted {
+			t.Errorf("grant of %v answered %v", item.roles, err)
+		}
+	}
+}
+
+func TestGrantGuard(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryRepo(t, AccountAggregateType, func() eventhorizon.Entity { return NewAccountDefault() })
+	account := &Account{Id: uuid.New(), Roles: []string{"auditor"}}
+	if err := repo.Save(ctx, account); err != nil {
+		t.Fatal(err)
+	}
+
+	guard := NewGrantGuard(NewAccountQueryRepositoryFull(repo, ctx), nil)
+	principal := &Principal{AccountId: uuid.New(), Roles: []string{PermissionManageAccounts, "member"}}
+	var received []string
+	router := mux.NewRouter()
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
+		})
+	}, guard.Middleware)
+	served := func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		received = append(received, string(body))
+		w.WriteHeader(http.StatusNoContent)
+	}
+	router.Methods(http.MethodPost).Path("/auth/account/{id}").Name("CreateAccount").HandlerFunc(served)
+	router.Methods(http.MethodPut).Path("/auth/account/{id}").Name("UpdateAccount").HandlerFunc(served)
+
+	for _, item := range []struct {
+		name     string
+		method   string
+		id       uuid.UUID
+		body     string
+		expected int
+	}{
+		{"create with held role", http.MethodPost, uuid.New(), `{"roles":["member"]}`, http.StatusNoContent},
+		{"create with other role", http.MethodPost, uuid.New(), `{"roles":["auth:roles"]}`, http.StatusForbidden},
+		{"update keeping role", http.MethodPut, account.Id, `{"roles":["auditor","member"]}`, http.StatusNoContent},
+		{"update adding role", http.MethodPut, account.Id, `{"roles":["auditor","auth:roles"]}`, http.StatusForbidden},
+	} {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(item.method, "/auth/account/"+item.id.String(),
+			strings.NewReader(item.body)))
+		if recorder.Code != item.expected {
+			t.Errorf("%v answered %v instead of %v", item.name, recorder.Code, item.expected)
+		}
+	}
+
+	if len(received) != 2 || received[0] != `{"roles":["member"]}` {
+		t.Errorf("guarded routes received %v", received)
+	}
+}
@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"context"
+	"github.com/gorilla/mux"
+	"regexp"
+	"testing"
+)
+
+var routeVariable = regexp.MustCompile(`{[^}]*}`)
+
+// routers builds all routers of the service, their dependencies are not needed to set up the routes
+func routers(pathPrefix string) []interface{ Setup(*mux.Router) error } {
+	newContext := func(string) context.Context { return context.Background() }
+	return []interface{ Setup(*mux.Router) error }{
+		NewAccountRouter(pathPrefix, newContext, nil, nil),
+		NewAccountQueryRouter(nil),
+		NewGroupRouter(pathPrefix, newContext, nil, nil),
+		NewOrganizationRouter(pathPrefix, newContext, nil, nil),
+		NewRoleGrantRequestRouter(pathPrefix, newContext, nil, nil),
+		NewRoleRouter(pathPrefix, newContext, nil, nil),
+		NewRelationTupleRouter(pathPrefix, newContext, nil, nil),
+		NewInvitationRouter(pathPrefix, newContext, nil, nil),
+		NewPolicyRouter(pathPrefix, nil, nil),
+		NewApiKeyRouter(pathPrefix, newContext, nil, nil),
+		NewImpersonationRouter(pathPrefix, newContext, nil, nil, nil),
+		NewSessionRouter(pathPrefix, newContext, nil, nil, nil, nil),
+		NewProfileRouter(pathPrefix, newContext, nil, nil),
+		NewPasswordRouter(pathPrefix, newContext, nil),
+		NewEmailRouter(pathPrefix, newContext, nil, nil, nil, 0),
+		NewAttributeRouter(pathPrefix, newContext, nil, nil, nil),
+		NewMergeRouter(pathPrefix, newContext, nil),
+		NewExportRouter(pathPrefix, newContext, nil, nil, nil),
+		NewRoleAssignmentRouter(pathPrefix, newContext, nil, nil, nil),
+		NewRoleGrantRouter(pathPrefix, newContext, nil),
+		NewRoleTreeRouter(pathPrefix, nil),
+		NewRelationRouter(pathPrefix, newContext, nil, nil),
+		NewTenantRouter(pathPrefix, newContext, nil, nil, nil),
+		NewInviteRouter(pathPrefix, newContext, nil, nil, nil, 0),
+		NewRegistrationRouter(pathPrefix, newContext, nil, nil, RegistrationOpen),
+		NewDeviceRouter(pathPrefix, newContext, nil, nil),
+		NewOAuthRouter(pathPrefix, newContext, nil, nil, nil, nil),
+		NewCheckRouter(pathPrefix, nil, nil),
+		&FederationRouter{PathPrefix: pathPrefix + "/" + "federation"},
+	}
+}
+
+func setupRoutes(t *testing.T) (ret *mux.Router) {
+	ret = mux.NewRouter()
+	for _, router := range routers("/auth") {
+		if err := router.Setup(ret); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return
+}
+
+// a route registered later with the method and path of another one is never reached
+func TestRoutesAreUnique(t *testing.T) {
+	routes := map[string]string{}
+	names := map[string]bool{}
+	_ = setupRoutes(t).Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		template, _ := route.GetPathTemplate()
+		methods, _ := route.GetMethods()
+		for _, method := range methods {
+			key := method + " " + routeVariable.ReplaceAllString(template, "{}")
+			if name, ok := routes[key]; ok {
+				t.Errorf("route '%v' of %v is hidden by '%v'", route.GetName(), key, name)
+			}
+			routes[key] = route.GetName()
+		}
+		if names[route.GetName()] {
+			t.Errorf("route name '%v' is not unique", route.GetName())
+		}
+		names[route.GetName()] = true
+		return nil
+	})
+}
@@ -0,0 +1,355 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-ee/utils"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/looplab/eventhorizon"
+	"net"
+	"net/http"
+	"time"
+)
+
+const PermissionManageSessions = "auth:sessions"
+
+// the last seen time of a session is recorded at most once per interval
+const sessionTouchInterval = time.Minute
+
+func (o *Account) FindSession(sessionId uuid.UUID) (ret *Session) {
+	for _, session := range o.Sessions {
+		if session.SessionId == sessionId {
+			return session
+		}
+	}
+	return
+}
+
+func (o *Account) RemoveSession(sessionId uuid.UUID) {
+	sessions := o.Sessions[:0]
+	for _, session := range o.Sessions {
+		if session.SessionId != sessionId {
+			sessions = append(sessions, session)
+		}
+	}
+	o.Sessions = sessions
+}
+
+func (o *EsEngine) ImplementSessions() {
+	o.Account.ImplementSessions()
+}
+
+func (o *AccountAggregateEngine) ImplementSessions() {
+	o.AggregateExecutors.Enabled.AddStartSessionPreparer(
+		func(cmd *StartSessionAccount, entity *Account) (err error) {
+			if cmd.SessionId == uuid.Nil {
+				err = errors.New("session id is required")
+			} else if entity.FindSession(cmd.SessionId) != nil {
+				err = fmt.Errorf("session '%v' exists already", cmd.SessionId)
+			}
+			return
+		})
+
+	o.AggregateExecutors.Enabled.AddTouchSessionPreparer(
+		func(cmd *TouchSessionAccount, entity *Account) (err error) {
+			if entity.FindSession(cmd.SessionId) == nil {
+				err = fmt.Errorf("session '%v' not found", cmd.SessionId)
+			}
+			return
+		})
+
+	revokePreparer := func(cmd *RevokeSessionAccount, entity *Account) (err error) {
+		if entity.FindSession(cmd.SessionId) == nil {
+			err = fmt.Errorf("session '%v' not found", cmd.SessionId)
+		}
+		return
+	}
+	o.AggregateExecutors.Enabled.AddRevokeSessionPreparer(revokePreparer)
+	o.AggregateExecutors.Disabled.AddRevokeSessionPreparer(revokePreparer)
+
+	o.AggregateHandlers.Enabled.StartedSessionHandler =
+		func(event eventhorizon.Event, eventData *AccountStartedSession, entity *Account) (err error) {
+			entity.AddToSessions(&Session{
+				SessionId:  eventData.SessionId,
+				Device:     eventData.Device,
+				Ip:         eventData.Ip,
+				UserAgent:  eventData.UserAgent,
+				CreatedAt:  utils.PtrTime(event.Timestamp()),
+				LastSeenAt: utils.PtrTime(event.Timestamp()),
+			})
+			return
+		}
+
+	o.AggregateHandlers.Enabled.TouchedSessionHandler =
+		func(event eventhorizon.Event, eventData *AccountTouchedSession, entity *Account) (err error) {
+			if session := entity.FindSession(eventData.SessionId); session != nil {
+				session.LastSeenAt = utils.PtrTime(event.Timestamp())
+			}
+			return
+		}
+
+	revokedHandler := func(event eventhorizon.Event, eventData *AccountRevokedSession, entity *Account) (err error) {
+		entity.RemoveSession(eventData.SessionId)
+		return
+	}
+	o.AggregateHandlers.Enabled.RevokedSessionHandler = revokedHandler
+	o.AggregateHandlers.Disabled.RevokedSessionHandler = revokedHandler
+
+	revokedAllHandler := func(event eventhorizon.Event, entity *Account) (err error) {
+		entity.Sessions = nil
+		return
+	}
+	o.AggregateHandlers.Enabled.RevokedSessionsHandler = revokedAllHandler
+	o.AggregateHandlers.Disabled.RevokedSessionsHandler = revokedAllHandler
+}
+
+// Sessions issues tokens bound to a session of the account, revoked sessions invalidate their tokens.
+type Sessions struct {
+	Accounts   *AccountQueryRepository
+	CommandBus eventhorizon.CommandHandler
+	Tokens     *Tokens
+	ctx        context.Context
+}
+
+func NewSessions(newContext func(string) (ret context.Context), commandBus eventhorizon.CommandHandler,
+	accounts *AccountQueryRepository, tokens *Tokens) (ret *Sessions) {
+	ret = &Sessions{
+		Accounts:   accounts,
+		CommandBus: commandBus,
+		Tokens:     tokens,
+		ctx:        newContext("session"),
+	}
+	return
+}
+
+// passwordChangeTtl limits tokens restricted to the password change
+const passwordChangeTtl = 10 * time.Minute
+
+func (o *Sessions) Start(account *Account, device string, r *http.Request) (ret *TokenResponse, err error) {
+	return o.start(account, device, r, false)
+}
+
+// StartPasswordChange issues a short living token without roles, it allows the change of the own password only
+func (o *Sessions) StartPasswordChange(account *Account, device string, r *http.Request) (ret *TokenResponse, err error) {
+	return o.start(account, device, r, true)
+}
+
+func (o *Sessions) start(account *Account, device string, r *http.Request, passwordChange bool) (
+	ret *TokenResponse, err error) {
+
+	if device == "" {
+		device = r.UserAgent()
+	}
+
+	start := &StartSessionAccount{
+		Id:        account.Id,
+		SessionId: uuid.New(),
+		Device:    device,
+		Ip:        clientIp(r),
+		UserAgent: r.UserAgent(),
+	}
+	if err = o.CommandBus.HandleCommand(o.ctx, start); err != nil {
+		return
+	}
+
+	var claims *TokenClaims
+	if claims, err = o.Tokens.NewClaims(account); err == nil {
+		claims.SessionId = start.SessionId.String()
+		if passwordChange {
+			claims.Roles = nil
+			claims.Scope = ScopePasswordChange
+			claims.ExpiresAt = time.Now().Add(passwordChangeTtl).Unix()
+		}
+		ret, err = o.Tokens.Issue(claims)
+	}
+	return
+}
+
+func clientIp(r *http.Request) (ret string) {
+	var err error
+	if ret, _, err = net.SplitHostPort(r.RemoteAddr); err != nil {
+		ret = r.RemoteAddr
+	}
+	return
+}
+
+// resolveSession accepts tokens of active sessions only, touch records the activity of the session
+func (o *Authenticator) resolveSession(principal *Principal, claims *TokenClaims, touch bool) (err error) {
+	var sessionId uuid.UUID
+	if sessionId, err = uuid.Parse(claims.SessionId); err != nil {
+		err = errors.New("token is not bound to a session")
+		return
+	}
+
+	var account *Account
+	if account, err = o.Accounts.FindById(principal.AccountId); err != nil || account == nil {
+		err = errors.New("account of the session not found")
+		return
+	}
+
+	session := account.FindSession(sessionId)
+	if session == nil {
+		err = errors.New("session is revoked")
+	} else if account.Disabled {
+		err = errors.New("account is disabled")
+	} else {
+		principal.SessionId = sessionId
+		if touch && (session.LastSeenAt == nil || time.Since(*session.LastSeenAt) >= sessionTouchInterval) {
+			err = o.CommandBus.HandleCommand(o.ctx, &TouchSessionAccount{Id: account.Id, SessionId: sessionId})
+		}
+	}
+	return
+}
+
+type LoginRequest struct {
+	Username       string    `json:"username"`
+	Password       string    `json:"password"`
+	Device         string    `json:"device,omitempty"`
+	OrganizationId uuid.UUID `json:"organizationId,omitempty"`
+}
+
+type SessionRouter struct {
+	PathPrefix string
+	LoginPath  string
+	Accounts   *AccountQueryRepository
+	CommandBus eventhorizon.CommandHandler
+	Sessions   *Sessions
+	Passwords  *PasswordPolicy
+	ctx        context.Context
+}
+
+func NewSessionRouter(pathPrefix string, newContext func(string) (ret context.Context),
+	commandBus eventhorizon.CommandHandler, accounts *AccountQueryRepository, sessions *Sessions,
+	passwords *PasswordPolicy) (ret *SessionRouter) {
+	ret = &SessionRouter{
+		PathPrefix: pathPrefix + "/" + "account",
+		LoginPath:  pathPrefix + "/" + "login",
+		Accounts:   accounts,
+		CommandBus: commandBus,
+		Sessions:   sessions,
+		Passwords:  passwords,
+		ctx:        newContext("session"),
+	}
+	return
+}
+
+func (o *SessionRouter) Setup(router *mux.Router) (err error) {
+	router.Methods(http.MethodPost).Path(o.LoginPath).
+		Name("Login").
+		HandlerFunc(o.Login)
+	router.Methods(http.MethodGet).PathPrefix(o.PathPrefix).Path("/{id}/sessions").
+		Name("SessionFindAll").
+		HandlerFunc(o.FindAll)
+	router.Methods(http.MethodDelete).PathPrefix(o.PathPrefix).Path("/{id}/sessions").
+		Name("RevokeSessions").
+		HandlerFunc(o.RevokeAll)
+	router.Methods(http.MethodDelete).PathPrefix(o.PathPrefix).Path("/{id}/sessions/{sessionId}").
+		Name("RevokeSession").
+		HandlerFunc(o.Revoke)
+	return
+}
+
+func (o *SessionRouter) Login(w http.ResponseWriter, r *http.Request) {
+	request := &LoginRequest{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+
+	var account *Account
+	var err error
+	if request.OrganizationId != uuid.Nil {
+		account, err = o.Accounts.ForTenant(request.OrganizationId).FindByCredentials(request.Username, request.Password)
+	} else {
+		account, err = o.Accounts.FindByCredentials(request.Username, request.Password)
+	}
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "invalid_grant", err)
+		return
+	}
+
+	// an expired password is accepted once more to change it
+	var token *TokenResponse
+	if o.Passwords != nil && o.Passwords.IsExpired(account, time.Now()) {
+		token, err = o.Sessions.StartPasswordChange(account, request.Device, r)
+	} else {
+		token, err = o.Sessions.Start(account, request.Device, r)
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, token)
+}
+
+func (o *SessionRouter) FindAll(w http.ResponseWriter, r *http.Request) {
+	if account, ok := o.account(w, r); ok {
+		ret := account.Sessions
+		if ret == nil {
+			ret = []*Session{}
+		}
+		writeJSON(w, http.StatusOK, ret)
+	}
+}
+
+func (o *SessionRouter) Revoke(w http.ResponseWriter, r *http.Request) {
+	account, ok := o.account(w, r)
+	if !ok {
+		return
+	}
+
+	sessionId, err := uuid.Parse(mux.Vars(r)["sessionId"])
+	if err != nil || account.FindSession(sessionId) == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err = o.CommandBus.HandleCommand(o.ctx, &RevokeSessionAccount{Id: account.Id, SessionId: sessionId}); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (o *SessionRouter) RevokeAll(w http.ResponseWriter, r *http.Request) {
+	account, ok := o.account(w, r)
+	if !ok {
+		return
+	}
+
+	if err := o.CommandBus.HandleCommand(o.ctx, &RevokeSessionsAccount{Id: account.Id}); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// account loads the account of the path, sessions are accessible by the account itself and session managers
+func (o *SessionRouter) account(w http.ResponseWriter, r *http.Request) (ret *Account, ok bool) {
+	principal := PrincipalFrom(r.Context())
+	if principal == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", ErrUnauthenticated)
+		return
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	if id != principal.AccountId && !principal.HasPermission(PermissionManageSessions) {
+		writeError(w, http.StatusForbidden, "access_denied", errors.New("sessions of other accounts are not accessible"))
+		return
+	}
+
+	if ret, err = o.Accounts.FindById(id); err != nil || ret == nil {
+		http.NotFound(w, r)
+		return
+	}
+	ok = true
+	return
+}
@@ -13,26 +13,35 @@ import (
 )
 
 type AccountAggregateHandlers struct {
-	Initial        *AccountAggregateInitialHandler
-	Deleted        *AccountAggregateDeletedHandler
-	Disabled       *AccountAggregateDisabledHandler
-	Enabled        *AccountAggregateEnabledHandler
-	Exist          *AccountAggregateExistHandler
-	EventsPreparer func(eventhorizon.Event, *Account) (err error)
+	Initial         *AccountAggregateInitialHandler
+	PendingApproval *AccountAggregatePendingApprovalHandler
+	Deleted         *AccountAggregateDeletedHandler
+	Purged          *AccountAggregatePurgedHandler
+	Merged          *AccountAggregateMergedHandler
+	Disabled        *AccountAggregateDisabledHandler
+	Enabled         *AccountAggregateEnabledHandler
+	Exist           *AccountAggregateExistHandler
+	EventsPreparer  func(eventhorizon.Event, *Account) (err error)
 }
 
 func NewAccountAggregateHandlersFull() (ret *AccountAggregateHandlers) {
 	initial := NewAccountAggregateInitialHandlerDefault()
+	pendingApproval := NewAccountAggregatePendingApprovalHandlerDefault()
 	deleted := NewAccountAggregateDeletedHandlerDefault()
+	purged := NewAccountAggregatePurgedHandlerDefault()
+	merged := NewAccountAggregateMergedHandlerDefault()
 	disabled := NewAccountAggregateDisabledHandlerDefault()
 	enabled := NewAccountAggregateEnabledHandlerDefault()
 	exist := NewAccountAggregateExistHandlerDefault()
 	ret = &AccountAggregateHandlers{
-		Initial:  initial,
-		Deleted:  deleted,
-		Disabled: disabled,
-		Enabled:  enabled,
-		Exist:    exist,
+		Initial:         initial,
+		PendingApproval: pendingApproval,
+		Deleted:         deleted,
+		Purged:          purged,
+		Merged:          merged,
+		Disabled:        disabled,
+		Enabled:         enabled,
+		Exist:           exist,
 	}
 	return
 }
@@ -60,8 +69,14 @@ func (o *AccountAggregateHandlers) Apply(event eventhorizon.Event, account *Acco
 	switch currentAggregateState {
 	case AccountAggregateStateTypes().Initial().Name():
 		newAggregateState, err = o.Initial.Apply(event, account)
+	case AccountAggregateStateTypes().PendingApproval().Name():
+		newAggregateState, err = o.PendingApproval.Apply(event, account)
 	case AccountAggregateStateTypes().Deleted().Name():
 		newAggregateState, err = o.Deleted.Apply(event, account)
+	case AccountAggregateStateTypes().Purged().Name():
+		newAggregateState, err = o.Purged.Apply(event, account)
+	case AccountAggregateStateTypes().Merged().Name():
+		newAggregateState, err = o.Merged.Apply(event, account)
 	case AccountAggregateStateTypes().Disabled().Name():
 		newAggregateState, err = o.Disabled.Apply(event, account)
 	case AccountAggregateStateTypes().Enabled().Name():
@@ -82,9 +97,18 @@ func (o *AccountAggregateHandlers) SetupEventHandler() (err error) {
 	if err = o.Initial.SetupEventHandler(); err != nil {
 		return
 	}
+	if err = o.PendingApproval.SetupEventHandler(); err != nil {
+		return
+	}
 	if err = o.Deleted.SetupEventHandler(); err != nil {
 		return
 	}
+	if err = o.Purged.SetupEventHandler(); err != nil {
+		return
+	}
+	if err = o.Merged.SetupEventHandler(); err != nil {
+		return
+	}
 	if err = o.Disabled.SetupEventHandler(); err != nil {
 		return
 	}
@@ -99,7 +123,10 @@ func (o *AccountAggregateHandlers) SetupEventHandler() (err error) {
 
 type AccountAggregateExecutors struct {
 	Initial          *AccountAggregateInitialExecutor
+	PendingApproval  *AccountAggregatePendingApprovalExecutor
 	Deleted          *AccountAggregateDeletedExecutor
+	Purged           *AccountAggregatePurgedExecutor
+	Merged           *AccountAggregateMergedExecutor
 	Disabled         *AccountAggregateDisabledExecutor
 	Enabled          *AccountAggregateEnabledExecutor
 	Exist            *AccountAggregateExistExecutor
@@ -108,16 +135,22 @@ type AccountAggregateExecutors struct {
 
 func NewAccountAggregateExecutorsFull() (ret *AccountAggregateExecutors) {
 	initial := NewAccountAggregateInitialExecutorDefault()
+	pendingApproval := NewAccountAggregatePendingApprovalExecutorDefault()
 	deleted := NewAccountAggregateDeletedExecutorDefault()
+	purged := NewAccountAggregatePurgedExecutorDefault()
+	merged := NewAccountAggregateMergedExecutorDefault()
 	disabled := NewAccountAggregateDisabledExecutorDefault()
 	enabled := NewAccountAggregateEnabledExecutorDefault()
 	exist := NewAccountAggregateExistExecutorDefault()
 	ret = &AccountAggregateExecutors{
-		Initial:  initial,
-		Deleted:  deleted,
-		Disabled: disabled,
-		Enabled:  enabled,
-		Exist:    exist,
+		Initial:         initial,
+		PendingApproval: pendingApproval,
+		Deleted:         deleted,
+		Purged:          purged,
+		Merged:          merged,
+		Disabled:        disabled,
+		Enabled:         enabled,
+		Exist:           exist,
 	}
 	return
 }
@@ -145,8 +178,14 @@ func (o *AccountAggregateExecutors) Execute(cmd eventhorizon.Command, account *A
 	switch currentAggregateState {
 	case stateTypes.Initial().Name():
 		err = o.Initial.Execute(cmd, account, store)
+	case stateTypes.PendingApproval().Name():
+		err = o.PendingApproval.Execute(cmd, account, store)
 	case stateTypes.Deleted().Name():
 		err = o.Deleted.Execute(cmd, account, store)
+	case stateTypes.Purged().Name():
+		err = o.Purged.Execute(cmd, account, store)
+	case stateTypes.Merged().Name():
+		err = o.Merged.Execute(cmd, account, store)
 	case stateTypes.Disabled().Name():
 		err = o.Disabled.Execute(cmd, account, store)
 	case stateTypes.Enabled().Name():
@@ -163,9 +202,18 @@ func (o *AccountAggregateExecutors) SetupCommandHandler() (err error) {
 	if err = o.Initial.SetupCommandHandler(); err != nil {
 		return
 	}
+	if err = o.PendingApproval.SetupCommandHandler(); err != nil {
+		return
+	}
 	if err = o.Deleted.SetupCommandHandler(); err != nil {
 		return
 	}
+	if err = o.Purged.SetupCommandHandler(); err != nil {
+		return
+	}
+	if err = o.Merged.SetupCommandHandler(); err != nil {
+		return
+	}
 	if err = o.Disabled.SetupCommandHandler(); err != nil {
 		return
 	}
@@ -223,10 +271,22 @@ func (o *AccountAggregateStateType) IsInitial() bool {
 	return o.name == _accountAggregateStateTypes.Initial().name
 }
 
+func (o *AccountAggregateStateType) IsPendingApproval() bool {
+	return o.name == _accountAggregateStateTypes.PendingApproval().name
+}
+
 func (o *AccountAggregateStateType) IsDeleted() bool {
 	return o.name == _accountAggregateStateTypes.Deleted().name
 }
 
+func (o *AccountAggregateStateType) IsPurged() bool {
+	return o.name == _accountAggregateStateTypes.Purged().name
+}
+
+func (o *AccountAggregateStateType) IsMerged() bool {
+	return o.name == _accountAggregateStateTypes.Merged().name
+}
+
 func (o *AccountAggregateStateType) IsDisabled() bool {
 	return o.name == _accountAggregateStateTypes.Disabled().name
 }
@@ -279,10 +339,13 @@ type accountAggregateStateTypes struct {
 
 var _accountAggregateStateTypes = &accountAggregateStateTypes{values: []*AccountAggregateStateType{
 	{name: "Initial", ordinal: 0},
-	{name: "Deleted", ordinal: 1},
-	{name: "Disabled", ordinal: 2},
-	{name: "Enabled", ordinal: 3},
-	{name: "Exist", ordinal: 4}},
+	{name: "PendingApproval", ordinal: 1},
+	{name: "Deleted", ordinal: 2},
+	{name: "Purged", ordinal: 3},
+	{name: "Merged", ordinal: 4},
+	{name: "Disabled", ordinal: 5},
+	{name: "Enabled", ordinal: 6},
+	{name: "Exist", ordinal: 7}},
 }
 
 func AccountAggregateStateTypes() *accountAggregateStateTypes {
@@ -297,22 +360,34 @@ func (o *accountAggregateStateTypes) Initial() *AccountAggregateStateType {
 	return o.values[0]
 }
 
-func (o *accountAggregateStateTypes) Deleted() *AccountAggregateStateType {
+func (o *accountAggregateStateTypes) PendingApproval() *AccountAggregateStateType {
 	return o.values[1]
 }
 
-func (o *accountAggregateStateTypes) Disabled() *AccountAggregateStateType {
+func (o *accountAggregateStateTypes) Deleted() *AccountAggregateStateType {
 	return o.values[2]
 }
 
-func (o *accountAggregateStateTypes) Enabled() *AccountAggregateStateType {
+func (o *accountAggregateStateTypes) Purged() *AccountAggregateStateType {
 	return o.values[3]
 }
 
-func (o *accountAggregateStateTypes) Exist() *AccountAggregateStateType {
+func (o *accountAggregateStateTypes) Merged() *AccountAggregateStateType {
 	return o.values[4]
 }
 
+func (o *accountAggregateStateTypes) Disabled() *AccountAggregateStateType {
+	return o.values[5]
+}
+
+func (o *accountAggregateStateTypes) Enabled() *AccountAggregateStateType {
+	return o.values[6]
+}
+
+func (o *accountAggregateStateTypes) Exist() *AccountAggregateStateType {
+	return o.values[7]
+}
+
 func (o *accountAggregateStateTypes) ParseAccountAggregateStateType(name string) (ret *AccountAggregateStateType, ok bool) {
 	for _, lit := range o.Values() {
 		if strings.EqualFold(lit.Name(), name) {
@@ -332,3 +407,1729 @@ func (o *accountAggregateStateTypes) Literals() []enum.Literal {
 	}
 	return o.valuesAsLiterals
 }
+
+type GroupAggregateHandlers struct {
+	Initial        *GroupAggregateInitialHandler
+	Exist          *GroupAggregateExistHandler
+	Deleted        *GroupAggregateDeletedHandler
+	EventsPreparer func(eventhorizon.Event, *Group) (err error)
+}
+
+func NewGroupAggregateHandlersFull() (ret *GroupAggregateHandlers) {
+	initial := NewGroupAggregateInitialHandlerDefault()
+	exist := NewGroupAggregateExistHandlerDefault()
+	deleted := NewGroupAggregateDeletedHandlerDefault()
+	ret = &GroupAggregateHandlers{
+		Initial: initial,
+		Exist:   exist,
+		Deleted: deleted,
+	}
+	return
+}
+
+func (o *GroupAggregateHandlers) AddEventsPreparer(preparer func(eventhorizon.Event, *Group) (err error)) {
+	prevHandler := o.EventsPreparer
+	o.EventsPreparer = func(event eventhorizon.Event, entity *Group) (err error) {
+		if err = preparer(event, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(event, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *GroupAggregateHandlers) Apply(event eventhorizon.Event, group *Group) (err error) {
+
+	currentAggregateState := group.AggregateState
+	if currentAggregateState == "" {
+		currentAggregateState = GroupAggregateStateTypes().Initial().Name()
+	}
+
+	var newAggregateState *GroupAggregateStateType
+	switch currentAggregateState {
+	case GroupAggregateStateTypes().Initial().Name():
+		newAggregateState, err = o.Initial.Apply(event, group)
+	case GroupAggregateStateTypes().Exist().Name():
+		newAggregateState, err = o.Exist.Apply(event, group)
+	case GroupAggregateStateTypes().Deleted().Name():
+		newAggregateState, err = o.Deleted.Apply(event, group)
+	default:
+		err = errors.New(fmt.Sprintf("Not supported AggregateState '%v' for entity '%v", group.AggregateState, group))
+	}
+
+	if err == nil && newAggregateState.Name() != group.AggregateState {
+		group.AggregateState = newAggregateState.Name()
+	}
+	return
+}
+
+func (o *GroupAggregateHandlers) SetupEventHandler() (err error) {
+	if err = o.Initial.SetupEventHandler(); err != nil {
+		return
+	}
+	if err = o.Exist.SetupEventHandler(); err != nil {
+		return
+	}
+	if err = o.Deleted.SetupEventHandler(); err != nil {
+		return
+	}
+	return
+}
+
+type GroupAggregateExecutors struct {
+	Initial          *GroupAggregateInitialExecutor
+	Exist            *GroupAggregateExistExecutor
+	Deleted          *GroupAggregateDeletedExecutor
+	CommandsPreparer func(eventhorizon.Command, *Group) (err error)
+}
+
+func NewGroupAggregateExecutorsFull() (ret *GroupAggregateExecutors) {
+	initial := NewGroupAggregateInitialExecutorDefault()
+	exist := NewGroupAggregateExistExecutorDefault()
+	deleted := NewGroupAggregateDeletedExecutorDefault()
+	ret = &GroupAggregateExecutors{
+		Initial: initial,
+		Exist:   exist,
+		Deleted: deleted,
+	}
+	return
+}
+
+func (o *GroupAggregateExecutors) AddCommandsPreparer(preparer func(eventhorizon.Command, *Group) (err error)) {
+	prevHandler := o.CommandsPreparer
+	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *Group) (err error) {
+		if err = preparer(cmd, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(cmd, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *GroupAggregateExecutors) Execute(cmd eventhorizon.Command, group *Group, store eh.AggregateStoreEvent) (err error) {
+
+	stateTypes := GroupAggregateStateTypes()
+	currentAggregateState := group.AggregateState
+	if currentAggregateState == "" {
+		currentAggregateState = stateTypes.Initial().Name()
+	}
+
+	switch currentAggregateState {
+	case stateTypes.Initial().Name():
+		err = o.Initial.Execute(cmd, group, store)
+	case stateTypes.Exist().Name():
+		err = o.Exist.Execute(cmd, group, store)
+	case stateTypes.Deleted().Name():
+		err = o.Deleted.Execute(cmd, group, store)
+	default:
+		err = errors.New(fmt.Sprintf("Not supported state '%v' for entity '%v", group.AggregateState, group))
+	}
+	return
+}
+
+func (o *GroupAggregateExecutors) SetupCommandHandler() (err error) {
+	if err = o.Initial.SetupCommandHandler(); err != nil {
+		return
+	}
+	if err = o.Exist.SetupCommandHandler(); err != nil {
+		return
+	}
+	if err = o.Deleted.SetupCommandHandler(); err != nil {
+		return
+	}
+	return
+}
+
+type GroupAggregate struct {
+	*events.AggregateBase
+	Group              *Group
+	AggregateExecutors *GroupAggregateExecutors
+	AggregateHandlers  *GroupAggregateHandlers
+}
+
+func NewGroupAggregateFull(aggregateBase *events.AggregateBase, group *Group, aggregateExecutors *GroupAggregateExecutors,
+	aggregateHandlers *GroupAggregateHandlers) (ret *GroupAggregate) {
+	ret = &GroupAggregate{
+		AggregateBase:      aggregateBase,
+		Group:              group,
+		AggregateExecutors: aggregateExecutors,
+		AggregateHandlers:  aggregateHandlers,
+	}
+	return
+}
+
+func (o *GroupAggregate) ApplyEvent(ctx context.Context, event eventhorizon.Event) (err error) {
+	err = o.AggregateHandlers.Apply(event, o.Group)
+	return
+}
+
+func (o *GroupAggregate) HandleCommand(ctx context.Context, cmd eventhorizon.Command) (err error) {
+	err = o.AggregateExecutors.Execute(cmd, o.Group, o.AggregateBase)
+	return
+}
+
+type GroupAggregateStateType struct {
+	name    string
+	ordinal int
+}
+
+func (o *GroupAggregateStateType) Name() string {
+	return o.name
+}
+
+func (o *GroupAggregateStateType) Ordinal() int {
+	return o.ordinal
+}
+
+func (o *GroupAggregateStateType) IsInitial() bool {
+	return o.name == _groupAggregateStateTypes.Initial().name
+}
+
+func (o *GroupAggregateStateType) IsExist() bool {
+	return o.name == _groupAggregateStateTypes.Exist().name
+}
+
+func (o *GroupAggregateStateType) IsDeleted() bool {
+	return o.name == _groupAggregateStateTypes.Deleted().name
+}
+
+func (o *GroupAggregateStateType) MarshalJSON() (ret []byte, err error) {
+	ret = []byte(fmt.Sprintf("\"%v\"", o.name))
+	return
+}
+
+func (o *GroupAggregateStateType) UnmarshalJSON(data []byte) (err error) {
+	name := string(data)
+	//remove quotes
+	name = name[1 : len(name)-1]
+	if v, ok := GroupAggregateStateTypes().ParseGroupAggregateStateType(name); ok {
+		*o = *v
+	} else {
+		err = fmt.Errorf("invalid GroupAggregateStateType %q", name)
+	}
+	return
+}
+
+func (o *GroupAggregateStateType) GetBSON() (ret interface{}, err error) {
+	return o.name, nil
+}
+
+func (o *GroupAggregateStateType) SetBSON(raw bson.Raw) (err error) {
+	var lit string
+	if err = raw.Unmarshal(&lit); err == nil {
+		if v, ok := GroupAggregateStateTypes().ParseGroupAggregateStateType(lit); ok {
+			*o = *v
+		} else {
+			err = fmt.Errorf("invalid GroupAggregateStateType %q", lit)
+		}
+	}
+	return
+}
+
+type groupAggregateStateTypes struct {
+	values           []*GroupAggregateStateType
+	valuesAsLiterals []enum.Literal
+}
+
+var _groupAggregateStateTypes = &groupAggregateStateTypes{values: []*GroupAggregateStateType{
+	{name: "Initial", ordinal: 0},
+	{name: "Exist", ordinal: 1},
+	{name: "Deleted", ordinal: 2}},
+}
+
+func GroupAggregateStateTypes() *groupAggregateStateTypes {
+	return _groupAggregateStateTypes
+}
+
+func (o *groupAggregateStateTypes) Values() []*GroupAggregateStateType {
+	return o.values
+}
+
+func (o *groupAggregateStateTypes) Initial() *GroupAggregateStateType {
+	return o.values[0]
+}
+
+func (o *groupAggregateStateTypes) Exist() *GroupAggregateStateType {
+	return o.values[1]
+}
+
+func (o *groupAggregateStateTypes) Deleted() *GroupAggregateStateType {
+	return o.values[2]
+}
+
+func (o *groupAggregateStateTypes) ParseGroupAggregateStateType(name string) (ret *GroupAggregateStateType, ok bool) {
+	for _, lit := range o.Values() {
+		if strings.EqualFold(lit.Name(), name) {
+			return lit, true
+		}
+	}
+	return nil, false
+}
+
+// we have to convert the instances to Literal interface, because it is not a other way in Go
+func (o *groupAggregateStateTypes) Literals() []enum.Literal {
+	if o.valuesAsLiterals == nil {
+		o.valuesAsLiterals = make([]enum.Literal, len(o.values))
+		for i, item := range o.values {
+			o.valuesAsLiterals[i] = item
+		}
+	}
+	return o.valuesAsLiterals
+}
+
+type OrganizationAggregateHandlers struct {
+	Initial        *OrganizationAggregateInitialHandler
+	Exist          *OrganizationAggregateExistHandler
+	Deleted        *OrganizationAggregateDeletedHandler
+	EventsPreparer func(eventhorizon.Event, *Organization) (err error)
+}
+
+func NewOrganizationAggregateHandlersFull() (ret *OrganizationAggregateHandlers) {
+	initial := NewOrganizationAggregateInitialHandlerDefault()
+	exist := NewOrganizationAggregateExistHandlerDefault()
+	deleted := NewOrganizationAggregateDeletedHandlerDefault()
+	ret = &OrganizationAggregateHandlers{
+		Initial: initial,
+		Exist:   exist,
+		Deleted: deleted,
+	}
+	return
+}
+
+func (o *OrganizationAggregateHandlers) AddEventsPreparer(preparer func(eventhorizon.Event, *Organization) (err error)) {
+	prevHandler := o.EventsPreparer
+	o.EventsPreparer = func(event eventhorizon.Event, entity *Organization) (err error) {
+		if err = preparer(event, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(event, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *OrganizationAggregateHandlers) Apply(event eventhorizon.Event, organization *Organization) (err error) {
+
+	currentAggregateState := organization.AggregateState
+	if currentAggregateState == "" {
+		currentAggregateState = OrganizationAggregateStateTypes().Initial().Name()
+	}
+
+	var newAggregateState *OrganizationAggregateStateType
+	switch currentAggregateState {
+	case OrganizationAggregateStateTypes().Initial().Name():
+		newAggregateState, err = o.Initial.Apply(event, organization)
+	case OrganizationAggregateStateTypes().Exist().Name():
+		newAggregateState, err = o.Exist.Apply(event, organization)
+	case OrganizationAggregateStateTypes().Deleted().Name():
+		newAggregateState, err = o.Deleted.Apply(event, organization)
+	default:
+		err = errors.New(fmt.Sprintf("Not supported AggregateState '%v' for entity '%v", organization.AggregateState, organization))
+	}
+
+	if err == nil && newAggregateState.Name() != organization.AggregateState {
+		organization.AggregateState = newAggregateState.Name()
+	}
+	return
+}
+
+func (o *OrganizationAggregateHandlers) SetupEventHandler() (err error) {
+	if err = o.Initial.SetupEventHandler(); err != nil {
+		return
+	}
+	if err = o.Exist.SetupEventHandler(); err != nil {
+		return
+	}
+	if err = o.Deleted.SetupEventHandler(); err != nil {
+		return
+	}
+	return
+}
+
+type OrganizationAggregateExecutors struct {
+	Initial          *OrganizationAggregateInitialExecutor
+	Exist            *OrganizationAggregateExistExecutor
+	Deleted          *OrganizationAggregateDeletedExecutor
+	CommandsPreparer func(eventhorizon.Command, *Organization) (err error)
+}
+
+func NewOrganizationAggregateExecutorsFull() (ret *OrganizationAggregateExecutors) {
+	initial := NewOrganizationAggregateInitialExecutorDefault()
+	exist := NewOrganizationAggregateExistExecutorDefault()
+	deleted := NewOrganizationAggregateDeletedExecutorDefault()
+	ret = &OrganizationAggregateExecutors{
+		Initial: initial,
+		Exist:   exist,
+		Deleted: deleted,
+	}
+	return
+}
+
+func (o *OrganizationAggregateExecutors) AddCommandsPreparer(preparer func(eventhorizon.Command, *Organization) (err error)) {
+	prevHandler := o.CommandsPreparer
+	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *Organization) (err error) {
+		if err = preparer(cmd, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(cmd, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *OrganizationAggregateExecutors) Execute(cmd eventhorizon.Command, organization *Organization, store eh.AggregateStoreEvent) (err error) {
+
+	stateTypes := OrganizationAggregateStateTypes()
+	currentAggregateState := organization.AggregateState
+	if currentAggregateState == "" {
+		currentAggregateState = stateTypes.Initial().Name()
+	}
+
+	switch currentAggregateState {
+	case stateTypes.Initial().Name():
+		err = o.Initial.Execute(cmd, organization, store)
+	case stateTypes.Exist().Name():
+		err = o.Exist.Execute(cmd, organization, store)
+	case stateTypes.Deleted().Name():
+		err = o.Deleted.Execute(cmd, organization, store)
+	default:
+		err = errors.New(fmt.Sprintf("Not supported state '%v' for entity '%v", organization.AggregateState, organization))
+	}
+	return
+}
+
+func (o *OrganizationAggregateExecutors) SetupCommandHandler() (err error) {
+	if err = o.Initial.SetupCommandHandler(); err != nil {
+		return
+	}
+	if err = o.Exist.SetupCommandHandler(); err != nil {
+		return
+	}
+	if err = o.Deleted.SetupCommandHandler(); err != nil {
+		return
+	}
+	return
+}
+
+type OrganizationAggregate struct {
+	*events.AggregateBase
+	Organization       *Organization
+	AggregateExecutors *OrganizationAggregateExecutors
+	AggregateHandlers  *OrganizationAggregateHandlers
+}
+
+func NewOrganizationAggregateFull(aggregateBase *events.AggregateBase, organization *Organization, aggregateExecutors *OrganizationAggregateExecutors,
+	aggregateHandlers *OrganizationAggregateHandlers) (ret *OrganizationAggregate) {
+	ret = &OrganizationAggregate{
+		AggregateBase:      aggregateBase,
+		Organization:       organization,
+		AggregateExecutors: aggregateExecutors,
+		AggregateHandlers:  aggregateHandlers,
+	}
+	return
+}
+
+func (o *OrganizationAggregate) ApplyEvent(ctx context.Context, event eventhorizon.Event) (err error) {
+	err = o.AggregateHandlers.Apply(event, o.Organization)
+	return
+}
+
+func (o *OrganizationAggregate) HandleCommand(ctx context.Context, cmd eventhorizon.Command) (err error) {
+	err = o.AggregateExecutors.Execute(cmd, o.Organization, o.AggregateBase)
+	return
+}
+
+type OrganizationAggregateStateType struct {
+	name    string
+	ordinal int
+}
+
+func (o *OrganizationAggregateStateType) Name() string {
+	return o.name
+}
+
+func (o *OrganizationAggregateStateType) Ordinal() int {
+	return o.ordinal
+}
+
+func (o *OrganizationAggregateStateType) IsInitial() bool {
+	return o.name == _organizationAggregateStateTypes.Initial().name
+}
+
+func (o *OrganizationAggregateStateType) IsExist() bool {
+	return o.name == _organizationAggregateStateTypes.Exist().name
+}
+
+func (o *OrganizationAggregateStateType) IsDeleted() bool {
+	return o.name == _organizationAggregateStateTypes.Deleted().name
+}
+
+func (o *OrganizationAggregateStateType) MarshalJSON() (ret []byte, err error) {
+	ret = []byte(fmt.Sprintf("\"%v\"", o.name))
+	return
+}
+
+func (o *OrganizationAggregateStateType) UnmarshalJSON(data []byte) (err error) {
+	name := string(data)
+	//remove quotes
+	name = name[1 : len(name)-1]
+	if v, ok := OrganizationAggregateStateTypes().ParseOrganizationAggregateStateType(name); ok {
+		*o = *v
+	} else {
+		err = fmt.Errorf("invalid OrganizationAggregateStateType %q", name)
+	}
+	return
+}
+
+func (o *OrganizationAggregateStateType) GetBSON() (ret interface{}, err error) {
+	return o.name, nil
+}
+
+func (o *OrganizationAggregateStateType) SetBSON(raw bson.Raw) (err error) {
+	var lit string
+	if err = raw.Unmarshal(&lit); err == nil {
+		if v, ok := OrganizationAggregateStateTypes().ParseOrganizationAggregateStateType(lit); ok {
+			*o = *v
+		} else {
+			err = fmt.Errorf("invalid OrganizationAggregateStateType %q", lit)
+		}
+	}
+	return
+}
+
+type organizationAggregateStateTypes struct {
+	values           []*OrganizationAggregateStateType
+	valuesAsLiterals []enum.Literal
+}
+
+var _organizationAggregateStateTypes = &organizationAggregateStateTypes{values: []*OrganizationAggregateStateType{
+	{name: "Initial", ordinal: 0},
+	{name: "Exist", ordinal: 1},
+	{name: "Deleted", ordinal: 2}},
+}
+
+func OrganizationAggregateStateTypes() *organizationAggregateStateTypes {
+	return _organizationAggregateStateTypes
+}
+
+func (o *organizationAggregateStateTypes) Values() []*OrganizationAggregateStateType {
+	return o.values
+}
+
+func (o *organizationAggregateStateTypes) Initial() *OrganizationAggregateStateType {
+	return o.values[0]
+}
+
+func (o *organizationAggregateStateTypes) Exist() *OrganizationAggregateStateType {
+	return o.values[1]
+}
+
+func (o *organizationAggregateStateTypes) Deleted() *OrganizationAggregateStateType {
+	return o.values[2]
+}
+
+func (o *organizationAggregateStateTypes) ParseOrganizationAggregateStateType(name string) (ret *OrganizationAggregateStateType, ok bool) {
+	for _, lit := range o.Values() {
+		if strings.EqualFold(lit.Name(), name) {
+			return lit, true
+		}
+	}
+	return nil, false
+}
+
+// we have to convert the instances to Literal interface, because it is not a other way in Go
+func (o *organizationAggregateStateTypes) Literals() []enum.Literal {
+	if o.valuesAsLiterals == nil {
+		o.valuesAsLiterals = make([]enum.Literal, len(o.values))
+		for i, item := range o.values {
+			o.valuesAsLiterals[i] = item
+		}
+	}
+	return o.valuesAsLiterals
+}
+
+type RoleGrantRequestAggregateHandlers struct {
+	Initial        *RoleGrantRequestAggregateInitialHandler
+	Pending        *RoleGrantRequestAggregatePendingHandler
+	Approved       *RoleGrantRequestAggregateApprovedHandler
+	Rejected       *RoleGrantRequestAggregateRejectedHandler
+	Deleted        *RoleGrantRequestAggregateDeletedHandler
+	EventsPreparer func(eventhorizon.Event, *RoleGrantRequest) (err error)
+}
+
+func NewRoleGrantRequestAggregateHandlersFull() (ret *RoleGrantRequestAggregateHandlers) {
+	initial := NewRoleGrantRequestAggregateInitialHandlerDefault()
+	pending := NewRoleGrantRequestAggregatePendingHandlerDefault()
+	approved := NewRoleGrantRequestAggregateApprovedHandlerDefault()
+	rejected := NewRoleGrantRequestAggregateRejectedHandlerDefault()
+	deleted := NewRoleGrantRequestAggregateDeletedHandlerDefault()
+	ret = &RoleGrantRequestAggregateHandlers{
+		Initial:  initial,
+		Pending:  pending,
+		Approved: approved,
+		Rejected: rejected,
+		Deleted:  deleted,
+	}
+	return
+}
+
+func (o *RoleGrantRequestAggregateHandlers) AddEventsPreparer(preparer func(eventhorizon.Event, *RoleGrantRequest) (err error)) {
+	prevHandler := o.EventsPreparer
+	o.EventsPreparer = func(event eventhorizon.Event, entity *RoleGrantRequest) (err error) {
+		if err = preparer(event, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(event, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *RoleGrantRequestAggregateHandlers) Apply(event eventhorizon.Event, roleGrantRequest *RoleGrantRequest) (err error) {
+
+	currentAggregateState := roleGrantRequest.AggregateState
+	if currentAggregateState == "" {
+		currentAggregateState = RoleGrantRequestAggregateStateTypes().Initial().Name()
+	}
+
+	var newAggregateState *RoleGrantRequestAggregateStateType
+	switch currentAggregateState {
+	case RoleGrantRequestAggregateStateTypes().Initial().Name():
+		newAggregateState, err = o.Initial.Apply(event, roleGrantRequest)
+	case RoleGrantRequestAggregateStateTypes().Pending().Name():
+		newAggregateState, err = o.Pending.Apply(event, roleGrantRequest)
+	case RoleGrantRequestAggregateStateTypes().Approved().Name():
+		newAggregateState, err = o.Approved.Apply(event, roleGrantRequest)
+	case RoleGrantRequestAggregateStateTypes().Rejected().Name():
+		newAggregateState, err = o.Rejected.Apply(event, roleGrantRequest)
+	case RoleGrantRequestAggregateStateTypes().Deleted().Name():
+		newAggregateState, err = o.Deleted.Apply(event, roleGrantRequest)
+	default:
+		err = errors.New(fmt.Sprintf("Not supported AggregateState '%v' for entity '%v", roleGrantRequest.AggregateState, roleGrantRequest))
+	}
+
+	if err == nil && newAggregateState.Name() != roleGrantRequest.AggregateState {
+		roleGrantRequest.AggregateState = newAggregateState.Name()
+	}
+	return
+}
+
+func (o *RoleGrantRequestAggregateHandlers) SetupEventHandler() (err error) {
+	if err = o.Initial.SetupEventHandler(); err != nil {
+		return
+	}
+	if err = o.Pending.SetupEventHandler(); err != nil {
+		return
+	}
+	if err = o.Approved.SetupEventHandler(); err != nil {
+		return
+	}
+	if err = o.Rejected.SetupEventHandler(); err != nil {
+		return
+	}
+	if err = o.Deleted.SetupEventHandler(); err != nil {
+		return
+	}
+	return
+}
+
+type RoleGrantRequestAggregateExecutors struct {
+	Initial          *RoleGrantRequestAggregateInitialExecutor
+	Pending          *RoleGrantRequestAggregatePendingExecutor
+	Approved         *RoleGrantRequestAggregateApprovedExecutor
+	Rejected         *RoleGrantRequestAggregateRejectedExecutor
+	Deleted          *RoleGrantRequestAggregateDeletedExecutor
+	CommandsPreparer func(eventhorizon.Command, *RoleGrantRequest) (err error)
+}
+
+func NewRoleGrantRequestAggregateExecutorsFull() (ret *RoleGrantRequestAggregateExecutors) {
+	initial := NewRoleGrantRequestAggregateInitialExecutorDefault()
+	pending := NewRoleGrantRequestAggregatePendingExecutorDefault()
+	approved := NewRoleGrantRequestAggregateApprovedExecutorDefault()
+	rejected := NewRoleGrantRequestAggregateRejectedExecutorDefault()
+	deleted := NewRoleGrantRequestAggregateDeletedExecutorDefault()
+	ret = &RoleGrantRequestAggregateExecutors{
+		Initial:  initial,
+		Pending:  pending,
+		Approved: approved,
+		Rejected: rejected,
+		Deleted:  deleted,
+	}
+	return
+}
+
+func (o *RoleGrantRequestAggregateExecutors) AddCommandsPreparer(preparer func(eventhorizon.Command, *RoleGrantRequest) (err error)) {
+	prevHandler := o.CommandsPreparer
+	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *RoleGrantRequest) (err error) {
+		if err = preparer(cmd, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(cmd, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *RoleGrantRequestAggregateExecutors) Execute(cmd eventhorizon.Command, roleGrantRequest *RoleGrantRequest, store eh.AggregateStoreEvent) (err error) {
+
+	stateTypes := RoleGrantRequestAggregateStateTypes()
+	currentAggregateState := roleGrantRequest.AggregateState
+	if currentAggregateState == "" {
+		currentAggregateState = stateTypes.Initial().Name()
+	}
+
+	switch currentAggregateState {
+	case stateTypes.Initial().Name():
+		err = o.Initial.Execute(cmd, roleGrantRequest, store)
+	case stateTypes.Pending().Name():
+		err = o.Pending.Execute(cmd, roleGrantRequest, store)
+	case stateTypes.Approved().Name():
+		err = o.Approved.Execute(cmd, roleGrantRequest, store)
+	case stateTypes.Rejected().Name():
+		err = o.Rejected.Execute(cmd, roleGrantRequest, store)
+	case stateTypes.Deleted().Name():
+		err = o.Deleted.Execute(cmd, roleGrantRequest, store)
+	default:
+		err = errors.New(fmt.Sprintf("Not supported state '%v' for entity '%v", roleGrantRequest.AggregateState, roleGrantRequest))
+	}
+	return
+}
+
+func (o *RoleGrantRequestAggregateExecutors) SetupCommandHandler() (err error) {
+	if err = o.Initial.SetupCommandHandler(); err != nil {
+		return
+	}
+	if err = o.Pending.SetupCommandHandler(); err != nil {
+		return
+	}
+	if err = o.Approved.SetupCommandHandler(); err != nil {
+		return
+	}
+	if err = o.Rejected.SetupCommandHandler(); err != nil {
+		return
+	}
+	if err = o.Deleted.SetupCommandHandler(); err != nil {
+		return
+	}
+	return
+}
+
+type RoleGrantRequestAggregate struct {
+	*events.AggregateBase
+	RoleGrantRequest   *RoleGrantRequest
+	AggregateExecutors *RoleGrantRequestAggregateExecutors
+	AggregateHandlers  *RoleGrantRequestAggregateHandlers
+}
+
+func NewRoleGrantRequestAggregateFull(aggregateBase *events.AggregateBase, roleGrantRequest *RoleGrantRequest, aggregateExecutors *RoleGrantRequestAggregateExecutors,
+	aggregateHandlers *RoleGrantRequestAggregateHandlers) (ret *RoleGrantRequestAggregate) {
+	ret = &RoleGrantRequestAggregate{
+		AggregateBase:      aggregateBase,
+		RoleGrantRequest:   roleGrantRequest,
+		AggregateExecutors: aggregateExecutors,
+		AggregateHandlers:  aggregateHandlers,
+	}
+	return
+}
+
+func (o *RoleGrantRequestAggregate) ApplyEvent(ctx context.Context, event eventhorizon.Event) (err error) {
+	err = o.AggregateHandlers.Apply(event, o.RoleGrantRequest)
+	return
+}
+
+func (o *RoleGrantRequestAggregate) HandleCommand(ctx context.Context, cmd eventhorizon.Command) (err error) {
+	err = o.AggregateExecutors.Execute(cmd, o.RoleGrantRequest, o.AggregateBase)
+	return
+}
+
+type RoleGrantRequestAggregateStateType struct {
+	name    string
+	ordinal int
+}
+
+func (o *RoleGrantRequestAggregateStateType) Name() string {
+	return o.name
+}
+
+func (o *RoleGrantRequestAggregateStateType) Ordinal() int {
+	return o.ordinal
+}
+
+func (o *RoleGrantRequestAggregateStateType) IsInitial() bool {
+	return o.name == _roleGrantRequestAggregateStateTypes.Initial().name
+}
+
+func (o *RoleGrantRequestAggregateStateType) IsPending() bool {
+	return o.name == _roleGrantRequestAggregateStateTypes.Pending().name
+}
+
+func (o *RoleGrantRequestAggregateStateType) IsApproved() bool {
+	return o.name == _roleGrantRequestAggregateStateTypes.Approved().name
+}
+
+func (o *RoleGrantRequestAggregateStateType) IsRejected() bool {
+	return o.name == _roleGrantRequestAggregateStateTypes.Rejected().name
+}
+
+func (o *RoleGrantRequestAggregateStateType) IsDeleted() bool {
+	return o.name == _roleGrantRequestAggregateStateTypes.Deleted().name
+}
+
+func (o *RoleGrantRequestAggregateStateType) MarshalJSON() (ret []byte, err error) {
+	ret = []byte(fmt.Sprintf("\"%v\"", o.name))
+	return
+}
+
+func (o *RoleGrantRequestAggregateStateType) UnmarshalJSON(data []byte) (err error) {
+	name := string(data)
+	//remove quotes
+	name = name[1 : len(name)-1]
+	if v, ok := RoleGrantRequestAggregateStateTypes().ParseRoleGrantRequestAggregateStateType(name); ok {
+		*o = *v
+	} else {
+		err = fmt.Errorf("invalid RoleGrantRequestAggregateStateType %q", name)
+	}
+	return
+}
+
+func (o *RoleGrantRequestAggregateStateType) GetBSON() (ret interface{}, err error) {
+	return o.name, nil
+}
+
+func (o *RoleGrantRequestAggregateStateType) SetBSON(raw bson.Raw) (err error) {
+	var lit string
+	if err = raw.Unmarshal(&lit); err == nil {
+		if v, ok := RoleGrantRequestAggregateStateTypes().ParseRoleGrantRequestAggregateStateType(lit); ok {
+			*o = *v
+		} else {
+			err = fmt.Errorf("invalid RoleGrantRequestAggregateStateType %q", lit)
+		}
+	}
+	return
+}
+
+type roleGrantRequestAggregateStateTypes struct {
+	values           []*RoleGrantRequestAggregateStateType
+	valuesAsLiterals []enum.Literal
+}
+
+var _roleGrantRequestAggregateStateTypes = &roleGrantRequestAggregateStateTypes{values: []*RoleGrantRequestAggregateStateType{
+	{name: "Initial", ordinal: 0},
+	{name: "Pending", ordinal: 1},
+	{name: "Approved", ordinal: 2},
+	{name: "Rejected", ordinal: 3},
+	{name: "Deleted", ordinal: 4}},
+}
+
+func RoleGrantRequestAggregateStateTypes() *roleGrantRequestAggregateStateTypes {
+	return _roleGrantRequestAggregateStateTypes
+}
+
+func (o *roleGrantRequestAggregateStateTypes) Values() []*RoleGrantRequestAggregateStateType {
+	return o.values
+}
+
+func (o *roleGrantRequestAggregateStateTypes) Initial() *RoleGrantRequestAggregateStateType {
+	return o.values[0]
+}
+
+func (o *roleGrantRequestAggregateStateTypes) Pending() *RoleGrantRequestAggregateStateType {
+	return o.values[1]
+}
+
+func (o *roleGrantRequestAggregateStateTypes) Approved() *RoleGrantRequestAggregateStateType {
+	return o.values[2]
+}
+
+func (o *roleGrantRequestAggregateStateTypes) Rejected() *RoleGrantRequestAggregateStateType {
+	return o.values[3]
+}
+
+func (o *roleGrantRequestAggregateStateTypes) Deleted() *RoleGrantRequestAggregateStateType {
+	return o.values[4]
+}
+
+func (o *roleGrantRequestAggregateStateTypes) ParseRoleGrantRequestAggregateStateType(name string) (ret *RoleGrantRequestAggregateStateType, ok bool) {
+	for _, lit := range o.Values() {
+		if strings.EqualFold(lit.Name(), name) {
+			return lit, true
+		}
+	}
+	return nil, false
+}
+
+// we have to convert the instances to Literal interface, because it is not a other way in Go
+func (o *roleGrantRequestAggregateStateTypes) Literals() []enum.Literal {
+	if o.valuesAsLiterals == nil {
+		o.valuesAsLiterals = make([]enum.Literal, len(o.values))
+		for i, item := range o.values {
+			o.valuesAsLiterals[i] = item
+		}
+	}
+	return o.valuesAsLiterals
+}
+
+type RoleAggregateHandlers struct {
+	Initial        *RoleAggregateInitialHandler
+	Exist          *RoleAggregateExistHandler
+	Deleted        *RoleAggregateDeletedHandler
+	EventsPreparer func(eventhorizon.Event, *Role) (err error)
+}
+
+func NewRoleAggregateHandlersFull() (ret *RoleAggregateHandlers) {
+	initial := NewRoleAggregateInitialHandlerDefault()
+	exist := NewRoleAggregateExistHandlerDefault()
+	deleted := NewRoleAggregateDeletedHandlerDefault()
+	ret = &RoleAggregateHandlers{
+		Initial: initial,
+		Exist:   exist,
+		Deleted: deleted,
+	}
+	return
+}
+
+func (o *RoleAggregateHandlers) AddEventsPreparer(preparer func(eventhorizon.Event, *Role) (err error)) {
+	prevHandler := o.EventsPreparer
+	o.EventsPreparer = func(event eventhorizon.Event, entity *Role) (err error) {
+		if err = preparer(event, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(event, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *RoleAggregateHandlers) Apply(event eventhorizon.Event, role *Role) (err error) {
+
+	currentAggregateState := role.AggregateState
+	if currentAggregateState == "" {
+		currentAggregateState = RoleAggregateStateTypes().Initial().Name()
+	}
+
+	var newAggregateState *RoleAggregateStateType
+	switch currentAggregateState {
+	case RoleAggregateStateTypes().Initial().Name():
+		newAggregateState, err = o.Initial.Apply(event, role)
+	case RoleAggregateStateTypes().Exist().Name():
+		newAggregateState, err = o.Exist.Apply(event, role)
+	case RoleAggregateStateTypes().Deleted().Name():
+		newAggregateState, err = o.Deleted.Apply(event, role)
+	default:
+		err = errors.New(fmt.Sprintf("Not supported AggregateState '%v' for entity '%v", role.AggregateState, role))
+	}
+
+	if err == nil && newAggregateState.Name() != role.AggregateState {
+		role.AggregateState = newAggregateState.Name()
+	}
+	return
+}
+
+func (o *RoleAggregateHandlers) SetupEventHandler() (err error) {
+	if err = o.Initial.SetupEventHandler(); err != nil {
+		return
+	}
+	if err = o.Exist.SetupEventHandler(); err != nil {
+		return
+	}
+	if err = o.Deleted.SetupEventHandler(); err != nil {
+		return
+	}
+	return
+}
+
+type RoleAggregateExecutors struct {
+	Initial          *RoleAggregateInitialExecutor
+	Exist            *RoleAggregateExistExecutor
+	Deleted          *RoleAggregateDeletedExecutor
+	CommandsPreparer func(eventhorizon.Command, *Role) (err error)
+}
+
+func NewRoleAggregateExecutorsFull() (ret *RoleAggregateExecutors) {
+	initial := NewRoleAggregateInitialExecutorDefault()
+	exist := NewRoleAggregateExistExecutorDefault()
+	deleted := NewRoleAggregateDeletedExecutorDefault()
+	ret = &RoleAggregateExecutors{
+		Initial: initial,
+		Exist:   exist,
+		Deleted: deleted,
+	}
+	return
+}
+
+func (o *RoleAggregateExecutors) AddCommandsPreparer(preparer func(eventhorizon.Command, *Role) (err error)) {
+	prevHandler := o.CommandsPreparer
+	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *Role) (err error) {
+		if err = preparer(cmd, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(cmd, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *RoleAggregateExecutors) Execute(cmd eventhorizon.Command, role *Role, store eh.AggregateStoreEvent) (err error) {
+
+	stateTypes := RoleAggregateStateTypes()
+	currentAggregateState := role.AggregateState
+	if currentAggregateState == "" {
+		currentAggregateState = stateTypes.Initial().Name()
+	}
+
+	switch currentAggregateState {
+	case stateTypes.Initial().Name():
+		err = o.Initial.Execute(cmd, role, store)
+	case stateTypes.Exist().Name():
+		err = o.Exist.Execute(cmd, role, store)
+	case stateTypes.Deleted().Name():
+		err = o.Deleted.Execute(cmd, role, store)
+	default:
+		err = errors.New(fmt.Sprintf("Not supported state '%v' for entity '%v", role.AggregateState, role))
+	}
+	return
+}
+
+func (o *RoleAggregateExecutors) SetupCommandHandler() (err error) {
+	if err = o.Initial.SetupCommandHandler(); err != nil {
+		return
+	}
+	if err = o.Exist.SetupCommandHandler(); err != nil {
+		return
+	}
+	if err = o.Deleted.SetupCommandHandler(); err != nil {
+		return
+	}
+	return
+}
+
+type RoleAggregate struct {
+	*events.AggregateBase
+	Role               *Role
+	AggregateExecutors *RoleAggregateExecutors
+	AggregateHandlers  *RoleAggregateHandlers
+}
+
+func NewRoleAggregateFull(aggregateBase *events.AggregateBase, role *Role, aggregateExecutors *RoleAggregateExecutors,
+	aggregateHandlers *RoleAggregateHandlers) (ret *RoleAggregate) {
+	ret = &RoleAggregate{
+		AggregateBase:      aggregateBase,
+		Role:               role,
+		AggregateExecutors: aggregateExecutors,
+		AggregateHandlers:  aggregateHandlers,
+	}
+	return
+}
+
+func (o *RoleAggregate) ApplyEvent(ctx context.Context, event eventhorizon.Event) (err error) {
+	err = o.AggregateHandlers.Apply(event, o.Role)
+	return
+}
+
+func (o *RoleAggregate) HandleCommand(ctx context.Context, cmd eventhorizon.Command) (err error) {
+	err = o.AggregateExecutors.Execute(cmd, o.Role, o.AggregateBase)
+	return
+}
+
+type RoleAggregateStateType struct {
+	name    string
+	ordinal int
+}
+
+func (o *RoleAggregateStateType) Name() string {
+	return o.name
+}
+
+func (o *RoleAggregateStateType) Ordinal() int {
+	return o.ordinal
+}
+
+func (o *RoleAggregateStateType) IsInitial() bool {
+	return o.name == _roleAggregateStateTypes.Initial().name
+}
+
+func (o *RoleAggregateStateType) IsExist() bool {
+	return o.name == _roleAggregateStateTypes.Exist().name
+}
+
+func (o *RoleAggregateStateType) IsDeleted() bool {
+	return o.name == _roleAggregateStateTypes.Deleted().name
+}
+
+func (o *RoleAggregateStateType) MarshalJSON() (ret []byte, err error) {
+	ret = []byte(fmt.Sprintf("\"%v\"", o.name))
+	return
+}
+
+func (o *RoleAggregateStateType) UnmarshalJSON(data []byte) (err error) {
+	name := string(data)
+	//remove quotes
+	name = name[1 : len(name)-1]
+	if v, ok := RoleAggregateStateTypes().ParseRoleAggregateStateType(name); ok {
+		*o = *v
+	} else {
+		err = fmt.Errorf("invalid RoleAggregateStateType %q", name)
+	}
+	return
+}
+
+func (o *RoleAggregateStateType) GetBSON() (ret interface{}, err error) {
+	return o.name, nil
+}
+
+func (o *RoleAggregateStateType) SetBSON(raw bson.Raw) (err error) {
+	var lit string
+	if err = raw.Unmarshal(&lit); err == nil {
+		if v, ok := RoleAggregateStateTypes().ParseRoleAggregateStateType(lit); ok {
+			*o = *v
+		} else {
+			err = fmt.Errorf("invalid RoleAggregateStateType %q", lit)
+		}
+	}
+	return
+}
+
+type roleAggregateStateTypes struct {
+	values           []*RoleAggregateStateType
+	valuesAsLiterals []enum.Literal
+}
+
+var _roleAggregateStateTypes = &roleAggregateStateTypes{values: []*RoleAggregateStateType{
+	{name: "Initial", ordinal: 0},
+	{name: "Exist", ordinal: 1},
+	{name: "Deleted", ordinal: 2}},
+}
+
+func RoleAggregateStateTypes() *roleAggregateStateTypes {
+	return _roleAggregateStateTypes
+}
+
+func (o *roleAggregateStateTypes) Values() []*RoleAggregateStateType {
+	return o.values
+}
+
+func (o *roleAggregateStateTypes) Initial() *RoleAggregateStateType {
+	return o.values[0]
+}
+
+func (o *roleAggregateStateTypes) Exist() *RoleAggregateStateType {
+	return o.values[1]
+}
+
+func (o *roleAggregateStateTypes) Deleted() *RoleAggregateStateType {
+	return o.values[2]
+}
+
+func (o *roleAggregateStateTypes) ParseRoleAggregateStateType(name string) (ret *RoleAggregateStateType, ok bool) {
+	for _, lit := range o.Values() {
+		if strings.EqualFold(lit.Name(), name) {
+			return lit, true
+		}
+	}
+	return nil, false
+}
+
+// we have to convert the instances to Literal interface, because it is not a other way in Go
+func (o *roleAggregateStateTypes) Literals() []enum.Literal {
+	if o.valuesAsLiterals == nil {
+		o.valuesAsLiterals = make([]enum.Literal, len(o.values))
+		for i, item := range o.values {
+			o.valuesAsLiterals[i] = item
+		}
+	}
+	return o.valuesAsLiterals
+}
+
+type RelationTupleAggregateHandlers struct {
+	Initial        *RelationTupleAggregateInitialHandler
+	Exist          *RelationTupleAggregateExistHandler
+	Deleted        *RelationTupleAggregateDeletedHandler
+	EventsPreparer func(eventhorizon.Event, *RelationTuple) (err error)
+}
+
+func NewRelationTupleAggregateHandlersFull() (ret *RelationTupleAggregateHandlers) {
+	initial := NewRelationTupleAggregateInitialHandlerDefault()
+	exist := NewRelationTupleAggregateExistHandlerDefault()
+	deleted := NewRelationTupleAggregateDeletedHandlerDefault()
+	ret = &RelationTupleAggregateHandlers{
+		Initial: initial,
+		Exist:   exist,
+		Deleted: deleted,
+	}
+	return
+}
+
+func (o *RelationTupleAggregateHandlers) AddEventsPreparer(preparer func(eventhorizon.Event, *RelationTuple) (err error)) {
+	prevHandler := o.EventsPreparer
+	o.EventsPreparer = func(event eventhorizon.Event, entity *RelationTuple) (err error) {
+		if err = preparer(event, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(event, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *RelationTupleAggregateHandlers) Apply(event eventhorizon.Event, relationTuple *RelationTuple) (err error) {
+
+	currentAggregateState := relationTuple.AggregateState
+	if currentAggregateState == "" {
+		currentAggregateState = RelationTupleAggregateStateTypes().Initial().Name()
+	}
+
+	var newAggregateState *RelationTupleAggregateStateType
+	switch currentAggregateState {
+	case RelationTupleAggregateStateTypes().Initial().Name():
+		newAggregateState, err = o.Initial.Apply(event, relationTuple)
+	case RelationTupleAggregateStateTypes().Exist().Name():
+		newAggregateState, err = o.Exist.Apply(event, relationTuple)
+	case RelationTupleAggregateStateTypes().Deleted().Name():
+		newAggregateState, err = o.Deleted.Apply(event, relationTuple)
+	default:
+		err = errors.New(fmt.Sprintf("Not supported AggregateState '%v' for entity '%v", relationTuple.AggregateState, relationTuple))
+	}
+
+	if err == nil && newAggregateState.Name() != relationTuple.AggregateState {
+		relationTuple.AggregateState = newAggregateState.Name()
+	}
+	return
+}
+
+func (o *RelationTupleAggregateHandlers) SetupEventHandler() (err error) {
+	if err = o.Initial.SetupEventHandler(); err != nil {
+		return
+	}
+	if err = o.Exist.SetupEventHandler(); err != nil {
+		return
+	}
+	if err = o.Deleted.SetupEventHandler(); err != nil {
+		return
+	}
+	return
+}
+
+type RelationTupleAggregateExecutors struct {
+	Initial          *RelationTupleAggregateInitialExecutor
+	Exist            *RelationTupleAggregateExistExecutor
+	Deleted          *RelationTupleAggregateDeletedExecutor
+	CommandsPreparer func(eventhorizon.Command, *RelationTuple) (err error)
+}
+
+func NewRelationTupleAggregateExecutorsFull() (ret *RelationTupleAggregateExecutors) {
+	initial := NewRelationTupleAggregateInitialExecutorDefault()
+	exist := NewRelationTupleAggregateExistExecutorDefault()
+	deleted := NewRelationTupleAggregateDeletedExecutorDefault()
+	ret = &RelationTupleAggregateExecutors{
+		Initial: initial,
+		Exist:   exist,
+		Deleted: deleted,
+	}
+	return
+}
+
+func (o *RelationTupleAggregateExecutors) AddCommandsPreparer(preparer func(eventhorizon.Command, *RelationTuple) (err error)) {
+	prevHandler := o.CommandsPreparer
+	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *RelationTuple) (err error) {
+		if err = preparer(cmd, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(cmd, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *RelationTupleAggregateExecutors) Execute(cmd eventhorizon.Command, relationTuple *RelationTuple, store eh.AggregateStoreEvent) (err error) {
+
+	stateTypes := RelationTupleAggregateStateTypes()
+	currentAggregateState := relationTuple.AggregateState
+	if currentAggregateState == "" {
+		currentAggregateState = stateTypes.Initial().Name()
+	}
+
+	switch currentAggregateState {
+	case stateTypes.Initial().Name():
+		err = o.Initial.Execute(cmd, relationTuple, store)
+	case stateTypes.Exist().Name():
+		err = o.Exist.Execute(cmd, relationTuple, store)
+	case stateTypes.Deleted().Name():
+		err = o.Deleted.Execute(cmd, relationTuple, store)
+	default:
+		err = errors.New(fmt.Sprintf("Not supported state '%v' for entity '%v", relationTuple.AggregateState, relationTuple))
+	}
+	return
+}
+
+func (o *RelationTupleAggregateExecutors) SetupCommandHandler() (err error) {
+	if err = o.Initial.SetupCommandHandler(); err != nil {
+		return
+	}
+	if err = o.Exist.SetupCommandHandler(); err != nil {
+		return
+	}
+	if err = o.Deleted.SetupCommandHandler(); err != nil {
+		return
+	}
+	return
+}
+
+type RelationTupleAggregate struct {
+	*events.AggregateBase
+	RelationTuple      *RelationTuple
+	AggregateExecutors *RelationTupleAggregateExecutors
+	AggregateHandlers  *RelationTupleAggregateHandlers
+}
+
+func NewRelationTupleAggregateFull(aggregateBase *events.AggregateBase, relationTuple *RelationTuple, aggregateExecutors *RelationTupleAggregateExecutors,
+	aggregateHandlers *RelationTupleAggregateHandlers) (ret *RelationTupleAggregate) {
+	ret = &RelationTupleAggregate{
+		AggregateBase:      aggregateBase,
+		RelationTuple:      relationTuple,
+		AggregateExecutors: aggregateExecutors,
+		AggregateHandlers:  aggregateHandlers,
+	}
+	return
+}
+
+func (o *RelationTupleAggregate) ApplyEvent(ctx context.Context, event eventhorizon.Event) (err error) {
+	err = o.AggregateHandlers.Apply(event, o.RelationTuple)
+	return
+}
+
+func (o *RelationTupleAggregate) HandleCommand(ctx context.Context, cmd eventhorizon.Command) (err error) {
+	err = o.AggregateExecutors.Execute(cmd, o.RelationTuple, o.AggregateBase)
+	return
+}
+
+type RelationTupleAggregateStateType struct {
+	name    string
+	ordinal int
+}
+
+func (o *RelationTupleAggregateStateType) Name() string {
+	return o.name
+}
+
+func (o *RelationTupleAggregateStateType) Ordinal() int {
+	return o.ordinal
+}
+
+func (o *RelationTupleAggregateStateType) IsInitial() bool {
+	return o.name == _relationTupleAggregateStateTypes.Initial().name
+}
+
+func (o *RelationTupleAggregateStateType) IsExist() bool {
+	return o.name == _relationTupleAggregateStateTypes.Exist().name
+}
+
+func (o *RelationTupleAggregateStateType) IsDeleted() bool {
+	return o.name == _relationTupleAggregateStateTypes.Deleted().name
+}
+
+func (o *RelationTupleAggregateStateType) MarshalJSON() (ret []byte, err error) {
+	ret = []byte(fmt.Sprintf("\"%v\"", o.name))
+	return
+}
+
+func (o *RelationTupleAggregateStateType) UnmarshalJSON(data []byte) (err error) {
+	name := string(data)
+	//remove quotes
+	name = name[1 : len(name)-1]
+	if v, ok := RelationTupleAggregateStateTypes().ParseRelationTupleAggregateStateType(name); ok {
+		*o = *v
+	} else {
+		err = fmt.Errorf("invalid RelationTupleAggregateStateType %q", name)
+	}
+	return
+}
+
+func (o *RelationTupleAggregateStateType) GetBSON() (ret interface{}, err error) {
+	return o.name, nil
+}
+
+func (o *RelationTupleAggregateStateType) SetBSON(raw bson.Raw) (err error) {
+	var lit string
+	if err = raw.Unmarshal(&lit); err == nil {
+		if v, ok := RelationTupleAggregateStateTypes().ParseRelationTupleAggregateStateType(lit); ok {
+			*o = *v
+		} else {
+			err = fmt.Errorf("invalid RelationTupleAggregateStateType %q", lit)
+		}
+	}
+	return
+}
+
+type relationTupleAggregateStateTypes struct {
+	values           []*RelationTupleAggregateStateType
+	valuesAsLiterals []enum.Literal
+}
+
+var _relationTupleAggregateStateTypes = &relationTupleAggregateStateTypes{values: []*RelationTupleAggregateStateType{
+	{name: "Initial", ordinal: 0},
+	{name: "Exist", ordinal: 1},
+	{name: "Deleted", ordinal: 2}},
+}
+
+func RelationTupleAggregateStateTypes() *relationTupleAggregateStateTypes {
+	return _relationTupleAggregateStateTypes
+}
+
+func (o *relationTupleAggregateStateTypes) Values() []*RelationTupleAggregateStateType {
+	return o.values
+}
+
+func (o *relationTupleAggregateStateTypes) Initial() *RelationTupleAggregateStateType {
+	return o.values[0]
+}
+
+func (o *relationTupleAggregateStateTypes) Exist() *RelationTupleAggregateStateType {
+	return o.values[1]
+}
+
+func (o *relationTupleAggregateStateTypes) Deleted() *RelationTupleAggregateStateType {
+	return o.values[2]
+}
+
+func (o *relationTupleAggregateStateTypes) ParseRelationTupleAggregateStateType(name string) (ret *RelationTupleAggregateStateType, ok bool) {
+	for _, lit := range o.Values() {
+		if strings.EqualFold(lit.Name(), name) {
+			return lit, true
+		}
+	}
+	return nil, false
+}
+
+// we have to convert the instances to Literal interface, because it is not a other way in Go
+func (o *relationTupleAggregateStateTypes) Literals() []enum.Literal {
+	if o.valuesAsLiterals == nil {
+		o.valuesAsLiterals = make([]enum.Literal, len(o.values))
+		for i, item := range o.values {
+			o.valuesAsLiterals[i] = item
+		}
+	}
+	return o.valuesAsLiterals
+}
+
+type InvitationAggregateHandlers struct {
+	Initial        *InvitationAggregateInitialHandler
+	Pending        *InvitationAggregatePendingHandler
+	Accepted       *InvitationAggregateAcceptedHandler
+	Revoked        *InvitationAggregateRevokedHandler
+	Deleted        *InvitationAggregateDeletedHandler
+	EventsPreparer func(eventhorizon.Event, *Invitation) (err error)
+}
+
+func NewInvitationAggregateHandlersFull() (ret *InvitationAggregateHandlers) {
+	initial := NewInvitationAggregateInitialHandlerDefault()
+	pending := NewInvitationAggregatePendingHandlerDefault()
+	accepted := NewInvitationAggregateAcceptedHandlerDefault()
+	revoked := NewInvitationAggregateRevokedHandlerDefault()
+	deleted := NewInvitationAggregateDeletedHandlerDefault()
+	ret = &InvitationAggregateHandlers{
+		Initial:  initial,
+		Pending:  pending,
+		Accepted: accepted,
+		Revoked:  revoked,
+		Deleted:  deleted,
+	}
+	return
+}
+
+func (o *InvitationAggregateHandlers) AddEventsPreparer(preparer func(eventhorizon.Event, *Invitation) (err error)) {
+	prevHandler := o.EventsPreparer
+	o.EventsPreparer = func(event eventhorizon.Event, entity *Invitation) (err error) {
+		if err = preparer(event, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(event, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *InvitationAggregateHandlers) Apply(event eventhorizon.Event, invitation *Invitation) (err error) {
+
+	currentAggregateState := invitation.AggregateState
+	if currentAggregateState == "" {
+		currentAggregateState = InvitationAggregateStateTypes().Initial().Name()
+	}
+
+	var newAggregateState *InvitationAggregateStateType
+	switch currentAggregateState {
+	case InvitationAggregateStateTypes().Initial().Name():
+		newAggregateState, err = o.Initial.Apply(event, invitation)
+	case InvitationAggregateStateTypes().Pending().Name():
+		newAggregateState, err = o.Pending.Apply(event, invitation)
+	case InvitationAggregateStateTypes().Accepted().Name():
+		newAggregateState, err = o.Accepted.Apply(event, invitation)
+	case InvitationAggregateStateTypes().Revoked().Name():
+		newAggregateState, err = o.Revoked.Apply(event, invitation)
+	case InvitationAggregateStateTypes().Deleted().Name():
+		newAggregateState, err = o.Deleted.Apply(event, invitation)
+	default:
+		err = errors.New(fmt.Sprintf("Not supported AggregateState '%v' for entity '%v", invitation.AggregateState, invitation))
+	}
+
+	if err == nil && newAggregateState.Name() != invitation.AggregateState {
+		invitation.AggregateState = newAggregateState.Name()
+	}
+	return
+}
+
+func (o *InvitationAggregateHandlers) SetupEventHandler() (err error) {
+	if err = o.Initial.SetupEventHandler(); err != nil {
+		return
+	}
+	if err = o.Pending.SetupEventHandler(); err != nil {
+		return
+	}
+	if err = o.Accepted.SetupEventHandler(); err != nil {
+		return
+	}
+	if err = o.Revoked.SetupEventHandler(); err != nil {
+		return
+	}
+	if err = o.Deleted.SetupEventHandler(); err != nil {
+		return
+	}
+	return
+}
+
+type InvitationAggregateExecutors struct {
+	Initial          *InvitationAggregateInitialExecutor
+	Pending          *InvitationAggregatePendingExecutor
+	Accepted         *InvitationAggregateAcceptedExecutor
+	Revoked          *InvitationAggregateRevokedExecutor
+	Deleted          *InvitationAggregateDeletedExecutor
+	CommandsPreparer func(eventhorizon.Command, *Invitation) (err error)
+}
+
+func NewInvitationAggregateExecutorsFull() (ret *InvitationAggregateExecutors) {
+	initial := NewInvitationAggregateInitialExecutorDefault()
+	pending := NewInvitationAggregatePendingExecutorDefault()
+	accepted := NewInvitationAggregateAcceptedExecutorDefault()
+	revoked := NewInvitationAggregateRevokedExecutorDefault()
+	deleted := NewInvitationAggregateDeletedExecutorDefault()
+	ret = &InvitationAggregateExecutors{
+		Initial:  initial,
+		Pending:  pending,
+		Accepted: accepted,
+		Revoked:  revoked,
+		Deleted:  deleted,
+	}
+	return
+}
+
+func (o *InvitationAggregateExecutors) AddCommandsPreparer(preparer func(eventhorizon.Command, *Invitation) (err error)) {
+	prevHandler := o.CommandsPreparer
+	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *Invitation) (err error) {
+		if err = preparer(cmd, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(cmd, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *InvitationAggregateExecutors) Execute(cmd eventhorizon.Command, invitation *Invitation, store eh.AggregateStoreEvent) (err error) {
+
+	stateTypes := InvitationAggregateStateTypes()
+	currentAggregateState := invitation.AggregateState
+	if currentAggregateState == "" {
+		currentAggregateState = stateTypes.Initial().Name()
+	}
+
+	switch currentAggregateState {
+	case stateTypes.Initial().Name():
+		err = o.Initial.Execute(cmd, invitation, store)
+	case stateTypes.Pending().Name():
+		err = o.Pending.Execute(cmd, invitation, store)
+	case stateTypes.Accepted().Name():
+		err = o.Accepted.Execute(cmd, invitation, store)
+	case stateTypes.Revoked().Name():
+		err = o.Revoked.Execute(cmd, invitation, store)
+	case stateTypes.Deleted().Name():
+		err = o.Deleted.Execute(cmd, invitation, store)
+	default:
+		err = errors.New(fmt.Sprintf("Not supported state '%v' for entity '%v", invitation.AggregateState, invitation))
+	}
+	return
+}
+
+func (o *InvitationAggregateExecutors) SetupCommandHandler() (err error) {
+	if err = o.Initial.SetupCommandHandler(); err != nil {
+		return
+	}
+	if err = o.Pending.SetupCommandHandler(); err != nil {
+		return
+	}
+	if err = o.Accepted.SetupCommandHandler(); err != nil {
+		return
+	}
+	if err = o.Revoked.SetupCommandHandler(); err != nil {
+		return
+	}
+	if err = o.Deleted.SetupCommandHandler(); err != nil {
+		return
+	}
+	return
+}
+
+type InvitationAggregate struct {
+	*events.AggregateBase
+	Invitation         *Invitation
+	AggregateExecutors *InvitationAggregateExecutors
+	AggregateHandlers  *InvitationAggregateHandlers
+}
+
+func NewInvitationAggregateFull(aggregateBase *events.AggregateBase, invitation *Invitation, aggregateExecutors *InvitationAggregateExecutors,
+	aggregateHandlers *InvitationAggregateHandlers) (ret *InvitationAggregate) {
+	ret = &InvitationAggregate{
+		AggregateBase:      aggregateBase,
+		Invitation:         invitation,
+		AggregateExecutors: aggregateExecutors,
+		AggregateHandlers:  aggregateHandlers,
+	}
+	return
+}
+
+func (o *InvitationAggregate) ApplyEvent(ctx context.Context, event eventhorizon.Event) (err error) {
+	err = o.AggregateHandlers.Apply(event, o.Invitation)
+	return
+}
+
+func (o *InvitationAggregate) HandleCommand(ctx context.Context, cmd eventhorizon.Command) (err error) {
+	err = o.AggregateExecutors.Execute(cmd, o.Invitation, o.AggregateBase)
+	return
+}
+
+type InvitationAggregateStateType struct {
+	name    string
+	ordinal int
+}
+
+func (o *InvitationAggregateStateType) Name() string {
+	return o.name
+}
+
+func (o *InvitationAggregateStateType) Ordinal() int {
+	return o.ordinal
+}
+
+func (o *InvitationAggregateStateType) IsInitial() bool {
+	return o.name == _invitationAggregateStateTypes.Initial().name
+}
+
+func (o *InvitationAggregateStateType) IsPending() bool {
+	return o.name == _invitationAggregateStateTypes.Pending().name
+}
+
+func (o *InvitationAggregateStateType) IsAccepted() bool {
+	return o.name == _invitationAggregateStateTypes.Accepted().name
+}
+
+func (o *InvitationAggregateStateType) IsRevoked() bool {
+	return o.name == _invitationAggregateStateTypes.Revoked().name
+}
+
+func (o *InvitationAggregateStateType) IsDeleted() bool {
+	return o.name == _invitationAggregateStateTypes.Deleted().name
+}
+
+func (o *InvitationAggregateStateType) MarshalJSON() (ret []byte, err error) {
+	ret = []byte(fmt.Sprintf("\"%v\"", o.name))
+	return
+}
+
+func (o *InvitationAggregateStateType) UnmarshalJSON(data []byte) (err error) {
+	name := string(data)
+	//remove quotes
+	name = name[1 : len(name)-1]
+	if v, ok := InvitationAggregateStateTypes().ParseInvitationAggregateStateType(name); ok {
+		*o = *v
+	} else {
+		err = fmt.Errorf("invalid InvitationAggregateStateType %q", name)
+	}
+	return
+}
+
+func (o *InvitationAggregateStateType) GetBSON() (ret interface{}, err error) {
+	return o.name, nil
+}
+
+func (o *InvitationAggregateStateType) SetBSON(raw bson.Raw) (err error) {
+	var lit string
+	if err = raw.Unmarshal(&lit); err == nil {
+		if v, ok := InvitationAggregateStateTypes().ParseInvitationAggregateStateType(lit); ok {
+			*o = *v
+		} else {
+			err = fmt.Errorf("invalid InvitationAggregateStateType %q", lit)
+		}
+	}
+	return
+}
+
+type invitationAggregateStateTypes struct {
+	values           []*InvitationAggregateStateType
+	valuesAsLiterals []enum.Literal
+}
+
+var _invitationAggregateStateTypes = &invitationAggregateStateTypes{values: []*InvitationAggregateStateType{
+	{name: "Initial", ordinal: 0},
+	{name: "Pending", ordinal: 1},
+	{name: "Accepted", ordinal: 2},
+	{name: "Revoked", ordinal: 3},
+	{name: "Deleted", ordinal: 4}},
+}
+
+func InvitationAggregateStateTypes() *invitationAggregateStateTypes {
+	return _invitationAggregateStateTypes
+}
+
+func (o *invitationAggregateStateTypes) Values() []*InvitationAggregateStateType {
+	return o.values
+}
+
+func (o *invitationAggregateStateTypes) Initial() *InvitationAggregateStateType {
+	return o.values[0]
+}
+
+func (o *invitationAggregateStateTypes) Pending() *InvitationAggregateStateType {
+	return o.values[1]
+}
+
+func (o *invitationAggregateStateTypes) Accepted() *InvitationAggregateStateType {
+	return o.values[2]
+}
+
+func (o *invitationAggregateStateTypes) Revoked() *InvitationAggregateStateType {
+	return o.values[3]
+}
+
+func (o *invitationAggregateStateTypes) Deleted() *InvitationAggregateStateType {
+	return o.values[4]
+}
+
+func (o *invitationAggregateStateTypes) ParseInvitationAggregateStateType(name string) (ret *InvitationAggregateStateType, ok bool) {
+	for _, lit := range o.Values() {
+		if strings.EqualFold(lit.Name(), name) {
+			return lit, true
+		}
+	}
+	return nil, false
+}
+
+// we have to convert the instances to Literal interface, because it is not a other way in Go
+func (o *invitationAggregateStateTypes) Literals() []enum.Literal {
+	if o.valuesAsLiterals == nil {
+		o.valuesAsLiterals = make([]enum.Literal, len(o.values))
+		for i, item := range o.values {
+			o.valuesAsLiterals[i] = item
+		}
+	}
+	return o.valuesAsLiterals
+}
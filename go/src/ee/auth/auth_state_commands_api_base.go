@@ -11,6 +11,7 @@ import (
 type AccountAggregateInitialExecutor struct {
 	CommandsPreparer func(eventhorizon.Command, *Account) (err error)
 	CreateHandler    func(*CreateAccount, *Account, eh.AggregateStoreEvent) (err error)
+	RegisterHandler  func(*RegisterAccount, *Account, eh.AggregateStoreEvent) (err error)
 }
 
 func NewAccountAggregateInitialExecutorDefault() (ret *AccountAggregateInitialExecutor) {
@@ -40,6 +41,16 @@ func (o *AccountAggregateInitialExecutor) AddCreatePreparer(preparer func(*Creat
 	}
 }
 
+func (o *AccountAggregateInitialExecutor) AddRegisterPreparer(preparer func(*RegisterAccount, *Account) (err error)) {
+	prevHandler := o.RegisterHandler
+	o.RegisterHandler = func(command *RegisterAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
 func (o *AccountAggregateInitialExecutor) StateType() (ret *AccountAggregateStateType) {
 	ret = AccountAggregateStateTypes().Initial()
 	return
@@ -55,6 +66,8 @@ func (o *AccountAggregateInitialExecutor) Execute(cmd eventhorizon.Command, acco
 	switch cmd.CommandType() {
 	case CreateAccountCommand:
 		err = o.CreateHandler(cmd.(*CreateAccount), account, store)
+	case RegisterAccountCommand:
+		err = o.RegisterHandler(cmd.(*RegisterAccount), account, store)
 	default:
 		err = errors.New(fmt.Sprintf("Not supported command type '%v' in state 'Initial' for entity '%v", cmd.CommandType(), account))
 	}
@@ -64,26 +77,43 @@ func (o *AccountAggregateInitialExecutor) Execute(cmd eventhorizon.Command, acco
 func (o *AccountAggregateInitialExecutor) SetupCommandHandler() (err error) {
 	o.CreateHandler = func(command *CreateAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
 		store.AppendEvent(AccountCreatedEvent, &AccountCreated{
-			Name:     command.Name,
-			Username: command.Username,
-			Password: command.Password,
-			Email:    command.Email,
-			Roles:    command.Roles}, time.Now())
+			Name:           command.Name,
+			Username:       command.Username,
+			Password:       command.Password,
+			Email:          command.Email,
+			Roles:          command.Roles,
+			OrganizationId: command.OrganizationId,
+			Service:        command.Service,
+			BreakGlass:     command.BreakGlass,
+			Attributes:     command.Attributes}, time.Now())
+		return
+	}
+	o.RegisterHandler = func(command *RegisterAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountRegisteredEvent, &AccountRegistered{
+			Name:            command.Name,
+			Username:        command.Username,
+			Password:        command.Password,
+			Email:           command.Email,
+			Roles:           command.Roles,
+			OrganizationId:  command.OrganizationId,
+			PendingApproval: command.PendingApproval}, time.Now())
 		return
 	}
 	return
 }
 
-type AccountAggregateDeletedExecutor struct {
-	CommandsPreparer func(eventhorizon.Command, *Account) (err error)
+type AccountAggregatePendingApprovalExecutor struct {
+	CommandsPreparer           func(eventhorizon.Command, *Account) (err error)
+	ApproveRegistrationHandler func(*ApproveRegistrationAccount, *Account, eh.AggregateStoreEvent) (err error)
+	RejectRegistrationHandler  func(*RejectRegistrationAccount, *Account, eh.AggregateStoreEvent) (err error)
 }
 
-func NewAccountAggregateDeletedExecutorDefault() (ret *AccountAggregateDeletedExecutor) {
-	ret = &AccountAggregateDeletedExecutor{}
+func NewAccountAggregatePendingApprovalExecutorDefault() (ret *AccountAggregatePendingApprovalExecutor) {
+	ret = &AccountAggregatePendingApprovalExecutor{}
 	return
 }
 
-func (o *AccountAggregateDeletedExecutor) AddCommandsPreparer(preparer func(eventhorizon.Command, *Account) (err error)) {
+func (o *AccountAggregatePendingApprovalExecutor) AddCommandsPreparer(preparer func(eventhorizon.Command, *Account) (err error)) {
 	prevHandler := o.CommandsPreparer
 	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *Account) (err error) {
 		if err = preparer(cmd, entity); err == nil {
@@ -95,37 +125,77 @@ func (o *AccountAggregateDeletedExecutor) AddCommandsPreparer(preparer func(even
 	}
 }
 
-func (o *AccountAggregateDeletedExecutor) StateType() (ret *AccountAggregateStateType) {
-	ret = AccountAggregateStateTypes().Deleted()
+func (o *AccountAggregatePendingApprovalExecutor) AddApproveRegistrationPreparer(preparer func(*ApproveRegistrationAccount, *Account) (err error)) {
+	prevHandler := o.ApproveRegistrationHandler
+	o.ApproveRegistrationHandler = func(command *ApproveRegistrationAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregatePendingApprovalExecutor) AddRejectRegistrationPreparer(preparer func(*RejectRegistrationAccount, *Account) (err error)) {
+	prevHandler := o.RejectRegistrationHandler
+	o.RejectRegistrationHandler = func(command *RejectRegistrationAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregatePendingApprovalExecutor) StateType() (ret *AccountAggregateStateType) {
+	ret = AccountAggregateStateTypes().PendingApproval()
 	return
 }
 
-func (o *AccountAggregateDeletedExecutor) Execute(cmd eventhorizon.Command, account *Account, store eh.AggregateStoreEvent) (err error) {
+func (o *AccountAggregatePendingApprovalExecutor) Execute(cmd eventhorizon.Command, account *Account, store eh.AggregateStoreEvent) (err error) {
 	if o.CommandsPreparer != nil {
 		if err = o.CommandsPreparer(cmd, account); err != nil {
 			return
 		}
 	}
-	err = errors.New(fmt.Sprintf("Not supported command type '%v' in state 'Deleted' for entity '%v", cmd.CommandType(), account))
+
+	switch cmd.CommandType() {
+	case ApproveRegistrationAccountCommand:
+		err = o.ApproveRegistrationHandler(cmd.(*ApproveRegistrationAccount), account, store)
+	case RejectRegistrationAccountCommand:
+		err = o.RejectRegistrationHandler(cmd.(*RejectRegistrationAccount), account, store)
+	default:
+		err = errors.New(fmt.Sprintf("Not supported command type '%v' in state 'PendingApproval' for entity '%v", cmd.CommandType(), account))
+	}
 	return
 }
 
-func (o *AccountAggregateDeletedExecutor) SetupCommandHandler() (err error) {
+func (o *AccountAggregatePendingApprovalExecutor) SetupCommandHandler() (err error) {
+	o.ApproveRegistrationHandler = func(command *ApproveRegistrationAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountRegistrationApprovedEvent, &AccountRegistrationApproved{
+			ApprovedBy: command.ApprovedBy}, time.Now())
+		return
+	}
+	o.RejectRegistrationHandler = func(command *RejectRegistrationAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountRegistrationRejectedEvent, &AccountRegistrationRejected{
+			RejectedBy: command.RejectedBy,
+			Reason:     command.Reason}, time.Now())
+		return
+	}
 	return
 }
 
-type AccountAggregateDisabledExecutor struct {
-	CommandsPreparer                func(eventhorizon.Command, *Account) (err error)
-	EnableHandler                   func(*EnableAccount, *Account, eh.AggregateStoreEvent) (err error)
-	SendDisabledConfirmationHandler func(*SendDisabledConfirmationAccount, *Account, eh.AggregateStoreEvent) (err error)
+type AccountAggregateDeletedExecutor struct {
+	CommandsPreparer func(eventhorizon.Command, *Account) (err error)
+	ForgetHandler    func(*ForgetAccount, *Account, eh.AggregateStoreEvent) (err error)
+	PurgeHandler     func(*PurgeAccount, *Account, eh.AggregateStoreEvent) (err error)
+	RestoreHandler   func(*RestoreAccount, *Account, eh.AggregateStoreEvent) (err error)
 }
 
-func NewAccountAggregateDisabledExecutorDefault() (ret *AccountAggregateDisabledExecutor) {
-	ret = &AccountAggregateDisabledExecutor{}
+func NewAccountAggregateDeletedExecutorDefault() (ret *AccountAggregateDeletedExecutor) {
+	ret = &AccountAggregateDeletedExecutor{}
 	return
 }
 
-func (o *AccountAggregateDisabledExecutor) AddCommandsPreparer(preparer func(eventhorizon.Command, *Account) (err error)) {
+func (o *AccountAggregateDeletedExecutor) AddCommandsPreparer(preparer func(eventhorizon.Command, *Account) (err error)) {
 	prevHandler := o.CommandsPreparer
 	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *Account) (err error) {
 		if err = preparer(cmd, entity); err == nil {
@@ -137,9 +207,9 @@ func (o *AccountAggregateDisabledExecutor) AddCommandsPreparer(preparer func(eve
 	}
 }
 
-func (o *AccountAggregateDisabledExecutor) AddEnablePreparer(preparer func(*EnableAccount, *Account) (err error)) {
-	prevHandler := o.EnableHandler
-	o.EnableHandler = func(command *EnableAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+func (o *AccountAggregateDeletedExecutor) AddForgetPreparer(preparer func(*ForgetAccount, *Account) (err error)) {
+	prevHandler := o.ForgetHandler
+	o.ForgetHandler = func(command *ForgetAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
 		if err = preparer(command, entity); err == nil {
 			err = prevHandler(command, entity, store)
 		}
@@ -147,9 +217,9 @@ func (o *AccountAggregateDisabledExecutor) AddEnablePreparer(preparer func(*Enab
 	}
 }
 
-func (o *AccountAggregateDisabledExecutor) AddSendDisabledConfirmationPreparer(preparer func(*SendDisabledConfirmationAccount, *Account) (err error)) {
-	prevHandler := o.SendDisabledConfirmationHandler
-	o.SendDisabledConfirmationHandler = func(command *SendDisabledConfirmationAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+func (o *AccountAggregateDeletedExecutor) AddPurgePreparer(preparer func(*PurgeAccount, *Account) (err error)) {
+	prevHandler := o.PurgeHandler
+	o.PurgeHandler = func(command *PurgeAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
 		if err = preparer(command, entity); err == nil {
 			err = prevHandler(command, entity, store)
 		}
@@ -157,12 +227,22 @@ func (o *AccountAggregateDisabledExecutor) AddSendDisabledConfirmationPreparer(p
 	}
 }
 
-func (o *AccountAggregateDisabledExecutor) StateType() (ret *AccountAggregateStateType) {
-	ret = AccountAggregateStateTypes().Disabled()
+func (o *AccountAggregateDeletedExecutor) AddRestorePreparer(preparer func(*RestoreAccount, *Account) (err error)) {
+	prevHandler := o.RestoreHandler
+	o.RestoreHandler = func(command *RestoreAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateDeletedExecutor) StateType() (ret *AccountAggregateStateType) {
+	ret = AccountAggregateStateTypes().Deleted()
 	return
 }
 
-func (o *AccountAggregateDisabledExecutor) Execute(cmd eventhorizon.Command, account *Account, store eh.AggregateStoreEvent) (err error) {
+func (o *AccountAggregateDeletedExecutor) Execute(cmd eventhorizon.Command, account *Account, store eh.AggregateStoreEvent) (err error) {
 	if o.CommandsPreparer != nil {
 		if err = o.CommandsPreparer(cmd, account); err != nil {
 			return
@@ -170,41 +250,44 @@ func (o *AccountAggregateDisabledExecutor) Execute(cmd eventhorizon.Command, acc
 	}
 
 	switch cmd.CommandType() {
-	case EnableAccountCommand:
-		err = o.EnableHandler(cmd.(*EnableAccount), account, store)
-	case SendDisabledConfirmationAccountCommand:
-		err = o.SendDisabledConfirmationHandler(cmd.(*SendDisabledConfirmationAccount), account, store)
+	case ForgetAccountCommand:
+		err = o.ForgetHandler(cmd.(*ForgetAccount), account, store)
+	case PurgeAccountCommand:
+		err = o.PurgeHandler(cmd.(*PurgeAccount), account, store)
+	case RestoreAccountCommand:
+		err = o.RestoreHandler(cmd.(*RestoreAccount), account, store)
 	default:
-		err = errors.New(fmt.Sprintf("Not supported command type '%v' in state 'Disabled' for entity '%v", cmd.CommandType(), account))
+		err = errors.New(fmt.Sprintf("Not supported command type '%v' in state 'Deleted' for entity '%v", cmd.CommandType(), account))
 	}
 	return
 }
 
-func (o *AccountAggregateDisabledExecutor) SetupCommandHandler() (err error) {
-	o.EnableHandler = func(command *EnableAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
-		store.AppendEvent(AccountEnabledEvent, nil, time.Now())
+func (o *AccountAggregateDeletedExecutor) SetupCommandHandler() (err error) {
+	o.ForgetHandler = func(command *ForgetAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountForgottenEvent, nil, time.Now())
 		return
 	}
-	o.SendDisabledConfirmationHandler = func(command *SendDisabledConfirmationAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
-		store.AppendEvent(AccountSentDisabledConfirmationEvent, nil, time.Now())
+	o.PurgeHandler = func(command *PurgeAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountPurgedEvent, nil, time.Now())
+		return
+	}
+	o.RestoreHandler = func(command *RestoreAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountRestoredEvent, nil, time.Now())
 		return
 	}
 	return
 }
 
-type AccountAggregateEnabledExecutor struct {
-	CommandsPreparer               func(eventhorizon.Command, *Account) (err error)
-	DeleteHandler                  func(*DeleteAccount, *Account, eh.AggregateStoreEvent) (err error)
-	DisableHandler                 func(*DisableAccount, *Account, eh.AggregateStoreEvent) (err error)
-	SendEnabledConfirmationHandler func(*SendEnabledConfirmationAccount, *Account, eh.AggregateStoreEvent) (err error)
+type AccountAggregatePurgedExecutor struct {
+	CommandsPreparer func(eventhorizon.Command, *Account) (err error)
 }
 
-func NewAccountAggregateEnabledExecutorDefault() (ret *AccountAggregateEnabledExecutor) {
-	ret = &AccountAggregateEnabledExecutor{}
+func NewAccountAggregatePurgedExecutorDefault() (ret *AccountAggregatePurgedExecutor) {
+	ret = &AccountAggregatePurgedExecutor{}
 	return
 }
 
-func (o *AccountAggregateEnabledExecutor) AddCommandsPreparer(preparer func(eventhorizon.Command, *Account) (err error)) {
+func (o *AccountAggregatePurgedExecutor) AddCommandsPreparer(preparer func(eventhorizon.Command, *Account) (err error)) {
 	prevHandler := o.CommandsPreparer
 	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *Account) (err error) {
 		if err = preparer(cmd, entity); err == nil {
@@ -216,29 +299,50 @@ func (o *AccountAggregateEnabledExecutor) AddCommandsPreparer(preparer func(even
 	}
 }
 
-func (o *AccountAggregateEnabledExecutor) AddDeletePreparer(preparer func(*DeleteAccount, *Account) (err error)) {
-	prevHandler := o.DeleteHandler
-	o.DeleteHandler = func(command *DeleteAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
-		if err = preparer(command, entity); err == nil {
-			err = prevHandler(command, entity, store)
+func (o *AccountAggregatePurgedExecutor) StateType() (ret *AccountAggregateStateType) {
+	ret = AccountAggregateStateTypes().Purged()
+	return
+}
+
+func (o *AccountAggregatePurgedExecutor) Execute(cmd eventhorizon.Command, account *Account, store eh.AggregateStoreEvent) (err error) {
+	if o.CommandsPreparer != nil {
+		if err = o.CommandsPreparer(cmd, account); err != nil {
+			return
 		}
-		return
 	}
+	err = errors.New(fmt.Sprintf("Not supported command type '%v' in state 'Purged' for entity '%v", cmd.CommandType(), account))
+	return
 }
 
-func (o *AccountAggregateEnabledExecutor) AddDisablePreparer(preparer func(*DisableAccount, *Account) (err error)) {
-	prevHandler := o.DisableHandler
-	o.DisableHandler = func(command *DisableAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
-		if err = preparer(command, entity); err == nil {
-			err = prevHandler(command, entity, store)
+func (o *AccountAggregatePurgedExecutor) SetupCommandHandler() (err error) {
+	return
+}
+
+type AccountAggregateMergedExecutor struct {
+	CommandsPreparer func(eventhorizon.Command, *Account) (err error)
+	ForgetHandler    func(*ForgetAccount, *Account, eh.AggregateStoreEvent) (err error)
+}
+
+func NewAccountAggregateMergedExecutorDefault() (ret *AccountAggregateMergedExecutor) {
+	ret = &AccountAggregateMergedExecutor{}
+	return
+}
+
+func (o *AccountAggregateMergedExecutor) AddCommandsPreparer(preparer func(eventhorizon.Command, *Account) (err error)) {
+	prevHandler := o.CommandsPreparer
+	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *Account) (err error) {
+		if err = preparer(cmd, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(cmd, entity)
+			}
 		}
 		return
 	}
 }
 
-func (o *AccountAggregateEnabledExecutor) AddSendEnabledConfirmationPreparer(preparer func(*SendEnabledConfirmationAccount, *Account) (err error)) {
-	prevHandler := o.SendEnabledConfirmationHandler
-	o.SendEnabledConfirmationHandler = func(command *SendEnabledConfirmationAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+func (o *AccountAggregateMergedExecutor) AddForgetPreparer(preparer func(*ForgetAccount, *Account) (err error)) {
+	prevHandler := o.ForgetHandler
+	o.ForgetHandler = func(command *ForgetAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
 		if err = preparer(command, entity); err == nil {
 			err = prevHandler(command, entity, store)
 		}
@@ -246,12 +350,12 @@ func (o *AccountAggregateEnabledExecutor) AddSendEnabledConfirmationPreparer(pre
 	}
 }
 
-func (o *AccountAggregateEnabledExecutor) StateType() (ret *AccountAggregateStateType) {
-	ret = AccountAggregateStateTypes().Enabled()
+func (o *AccountAggregateMergedExecutor) StateType() (ret *AccountAggregateStateType) {
+	ret = AccountAggregateStateTypes().Merged()
 	return
 }
 
-func (o *AccountAggregateEnabledExecutor) Execute(cmd eventhorizon.Command, account *Account, store eh.AggregateStoreEvent) (err error) {
+func (o *AccountAggregateMergedExecutor) Execute(cmd eventhorizon.Command, account *Account, store eh.AggregateStoreEvent) (err error) {
 	if o.CommandsPreparer != nil {
 		if err = o.CommandsPreparer(cmd, account); err != nil {
 			return
@@ -259,46 +363,46 @@ func (o *AccountAggregateEnabledExecutor) Execute(cmd eventhorizon.Command, acco
 	}
 
 	switch cmd.CommandType() {
-	case DeleteAccountCommand:
-		err = o.DeleteHandler(cmd.(*DeleteAccount), account, store)
-	case DisableAccountCommand:
-		err = o.DisableHandler(cmd.(*DisableAccount), account, store)
-	case SendEnabledConfirmationAccountCommand:
-		err = o.SendEnabledConfirmationHandler(cmd.(*SendEnabledConfirmationAccount), account, store)
+	case ForgetAccountCommand:
+		err = o.ForgetHandler(cmd.(*ForgetAccount), account, store)
 	default:
-		err = errors.New(fmt.Sprintf("Not supported command type '%v' in state 'Enabled' for entity '%v", cmd.CommandType(), account))
+		err = errors.New(fmt.Sprintf("Not supported command type '%v' in state 'Merged' for entity '%v", cmd.CommandType(), account))
 	}
 	return
 }
 
-func (o *AccountAggregateEnabledExecutor) SetupCommandHandler() (err error) {
-	o.DeleteHandler = func(command *DeleteAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
-		store.AppendEvent(AccountDeletedEvent, nil, time.Now())
-		return
-	}
-	o.DisableHandler = func(command *DisableAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
-		store.AppendEvent(AccountDisabledEvent, nil, time.Now())
-		return
-	}
-	o.SendEnabledConfirmationHandler = func(command *SendEnabledConfirmationAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
-		store.AppendEvent(AccountSentEnabledConfirmationEvent, nil, time.Now())
+func (o *AccountAggregateMergedExecutor) SetupCommandHandler() (err error) {
+	o.ForgetHandler = func(command *ForgetAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountForgottenEvent, nil, time.Now())
 		return
 	}
 	return
 }
 
-type AccountAggregateExistExecutor struct {
-	CommandsPreparer func(eventhorizon.Command, *Account) (err error)
-	DeleteHandler    func(*DeleteAccount, *Account, eh.AggregateStoreEvent) (err error)
-	UpdateHandler    func(*UpdateAccount, *Account, eh.AggregateStoreEvent) (err error)
+type AccountAggregateDisabledExecutor struct {
+	CommandsPreparer                func(eventhorizon.Command, *Account) (err error)
+	ChangeAttributesHandler         func(*ChangeAttributesAccount, *Account, eh.AggregateStoreEvent) (err error)
+	EnableHandler                   func(*EnableAccount, *Account, eh.AggregateStoreEvent) (err error)
+	EndImpersonationHandler         func(*EndImpersonationAccount, *Account, eh.AggregateStoreEvent) (err error)
+	ExpireRoleHandler               func(*ExpireRoleAccount, *Account, eh.AggregateStoreEvent) (err error)
+	ForgetHandler                   func(*ForgetAccount, *Account, eh.AggregateStoreEvent) (err error)
+	LinkIdentityHandler             func(*LinkIdentityAccount, *Account, eh.AggregateStoreEvent) (err error)
+	MergeHandler                    func(*MergeAccount, *Account, eh.AggregateStoreEvent) (err error)
+	MergeIntoHandler                func(*MergeIntoAccount, *Account, eh.AggregateStoreEvent) (err error)
+	RevokeApiKeyHandler             func(*RevokeApiKeyAccount, *Account, eh.AggregateStoreEvent) (err error)
+	RevokeSessionHandler            func(*RevokeSessionAccount, *Account, eh.AggregateStoreEvent) (err error)
+	RevokeSessionsHandler           func(*RevokeSessionsAccount, *Account, eh.AggregateStoreEvent) (err error)
+	SendDisabledConfirmationHandler func(*SendDisabledConfirmationAccount, *Account, eh.AggregateStoreEvent) (err error)
+	UnassignRoleHandler             func(*UnassignRoleAccount, *Account, eh.AggregateStoreEvent) (err error)
+	UnlinkIdentityHandler           func(*UnlinkIdentityAccount, *Account, eh.AggregateStoreEvent) (err error)
 }
 
-func NewAccountAggregateExistExecutorDefault() (ret *AccountAggregateExistExecutor) {
-	ret = &AccountAggregateExistExecutor{}
+func NewAccountAggregateDisabledExecutorDefault() (ret *AccountAggregateDisabledExecutor) {
+	ret = &AccountAggregateDisabledExecutor{}
 	return
 }
 
-func (o *AccountAggregateExistExecutor) AddCommandsPreparer(preparer func(eventhorizon.Command, *Account) (err error)) {
+func (o *AccountAggregateDisabledExecutor) AddCommandsPreparer(preparer func(eventhorizon.Command, *Account) (err error)) {
 	prevHandler := o.CommandsPreparer
 	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *Account) (err error) {
 		if err = preparer(cmd, entity); err == nil {
@@ -310,9 +414,9 @@ func (o *AccountAggregateExistExecutor) AddCommandsPreparer(preparer func(eventh
 	}
 }
 
-func (o *AccountAggregateExistExecutor) AddDeletePreparer(preparer func(*DeleteAccount, *Account) (err error)) {
-	prevHandler := o.DeleteHandler
-	o.DeleteHandler = func(command *DeleteAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+func (o *AccountAggregateDisabledExecutor) AddChangeAttributesPreparer(preparer func(*ChangeAttributesAccount, *Account) (err error)) {
+	prevHandler := o.ChangeAttributesHandler
+	o.ChangeAttributesHandler = func(command *ChangeAttributesAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
 		if err = preparer(command, entity); err == nil {
 			err = prevHandler(command, entity, store)
 		}
@@ -320,9 +424,9 @@ func (o *AccountAggregateExistExecutor) AddDeletePreparer(preparer func(*DeleteA
 	}
 }
 
-func (o *AccountAggregateExistExecutor) AddUpdatePreparer(preparer func(*UpdateAccount, *Account) (err error)) {
-	prevHandler := o.UpdateHandler
-	o.UpdateHandler = func(command *UpdateAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+func (o *AccountAggregateDisabledExecutor) AddEnablePreparer(preparer func(*EnableAccount, *Account) (err error)) {
+	prevHandler := o.EnableHandler
+	o.EnableHandler = func(command *EnableAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
 		if err = preparer(command, entity); err == nil {
 			err = prevHandler(command, entity, store)
 		}
@@ -330,42 +434,2300 @@ func (o *AccountAggregateExistExecutor) AddUpdatePreparer(preparer func(*UpdateA
 	}
 }
 
-func (o *AccountAggregateExistExecutor) StateType() (ret *AccountAggregateStateType) {
-	ret = AccountAggregateStateTypes().Exist()
-	return
+func (o *AccountAggregateDisabledExecutor) AddEndImpersonationPreparer(preparer func(*EndImpersonationAccount, *Account) (err error)) {
+	prevHandler := o.EndImpersonationHandler
+	o.EndImpersonationHandler = func(command *EndImpersonationAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
 }
 
-func (o *AccountAggregateExistExecutor) Execute(cmd eventhorizon.Command, account *Account, store eh.AggregateStoreEvent) (err error) {
-	if o.CommandsPreparer != nil {
-		if err = o.CommandsPreparer(cmd, account); err != nil {
-			return
+func (o *AccountAggregateDisabledExecutor) AddExpireRolePreparer(preparer func(*ExpireRoleAccount, *Account) (err error)) {
+	prevHandler := o.ExpireRoleHandler
+	o.ExpireRoleHandler = func(command *ExpireRoleAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
 		}
+		return
 	}
+}
 
-	switch cmd.CommandType() {
-	case DeleteAccountCommand:
-		err = o.DeleteHandler(cmd.(*DeleteAccount), account, store)
-	case UpdateAccountCommand:
-		err = o.UpdateHandler(cmd.(*UpdateAccount), account, store)
-	default:
-		err = errors.New(fmt.Sprintf("Not supported command type '%v' in state 'Exist' for entity '%v", cmd.CommandType(), account))
+func (o *AccountAggregateDisabledExecutor) AddForgetPreparer(preparer func(*ForgetAccount, *Account) (err error)) {
+	prevHandler := o.ForgetHandler
+	o.ForgetHandler = func(command *ForgetAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
 	}
-	return
 }
 
-func (o *AccountAggregateExistExecutor) SetupCommandHandler() (err error) {
-	o.DeleteHandler = func(command *DeleteAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
-		store.AppendEvent(AccountDeletedEvent, nil, time.Now())
+func (o *AccountAggregateDisabledExecutor) AddLinkIdentityPreparer(preparer func(*LinkIdentityAccount, *Account) (err error)) {
+	prevHandler := o.LinkIdentityHandler
+	o.LinkIdentityHandler = func(command *LinkIdentityAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
 		return
 	}
-	o.UpdateHandler = func(command *UpdateAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
-		store.AppendEvent(AccountUpdatedEvent, &AccountUpdated{
-			Name:     command.Name,
-			Username: command.Username,
-			Password: command.Password,
-			Email:    command.Email,
-			Roles:    command.Roles}, time.Now())
+}
+
+func (o *AccountAggregateDisabledExecutor) AddMergePreparer(preparer func(*MergeAccount, *Account) (err error)) {
+	prevHandler := o.MergeHandler
+	o.MergeHandler = func(command *MergeAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateDisabledExecutor) AddMergeIntoPreparer(preparer func(*MergeIntoAccount, *Account) (err error)) {
+	prevHandler := o.MergeIntoHandler
+	o.MergeIntoHandler = func(command *MergeIntoAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateDisabledExecutor) AddRevokeApiKeyPreparer(preparer func(*RevokeApiKeyAccount, *Account) (err error)) {
+	prevHandler := o.RevokeApiKeyHandler
+	o.RevokeApiKeyHandler = func(command *RevokeApiKeyAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateDisabledExecutor) AddRevokeSessionPreparer(preparer func(*RevokeSessionAccount, *Account) (err error)) {
+	prevHandler := o.RevokeSessionHandler
+	o.RevokeSessionHandler = func(command *RevokeSessionAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateDisabledExecutor) AddRevokeSessionsPreparer(preparer func(*RevokeSessionsAccount, *Account) (err error)) {
+	prevHandler := o.RevokeSessionsHandler
+	o.RevokeSessionsHandler = func(command *RevokeSessionsAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateDisabledExecutor) AddSendDisabledConfirmationPreparer(preparer func(*SendDisabledConfirmationAccount, *Account) (err error)) {
+	prevHandler := o.SendDisabledConfirmationHandler
+	o.SendDisabledConfirmationHandler = func(command *SendDisabledConfirmationAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
 		return
 	}
+}
+
+func (o *AccountAggregateDisabledExecutor) AddUnassignRolePreparer(preparer func(*UnassignRoleAccount, *Account) (err error)) {
+	prevHandler := o.UnassignRoleHandler
+	o.UnassignRoleHandler = func(command *UnassignRoleAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateDisabledExecutor) AddUnlinkIdentityPreparer(preparer func(*UnlinkIdentityAccount, *Account) (err error)) {
+	prevHandler := o.UnlinkIdentityHandler
+	o.UnlinkIdentityHandler = func(command *UnlinkIdentityAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateDisabledExecutor) StateType() (ret *AccountAggregateStateType) {
+	ret = AccountAggregateStateTypes().Disabled()
+	return
+}
+
+func (o *AccountAggregateDisabledExecutor) Execute(cmd eventhorizon.Command, account *Account, store eh.AggregateStoreEvent) (err error) {
+	if o.CommandsPreparer != nil {
+		if err = o.CommandsPreparer(cmd, account); err != nil {
+			return
+		}
+	}
+
+	switch cmd.CommandType() {
+	case ChangeAttributesAccountCommand:
+		err = o.ChangeAttributesHandler(cmd.(*ChangeAttributesAccount), account, store)
+	case EnableAccountCommand:
+		err = o.EnableHandler(cmd.(*EnableAccount), account, store)
+	case EndImpersonationAccountCommand:
+		err = o.EndImpersonationHandler(cmd.(*EndImpersonationAccount), account, store)
+	case ExpireRoleAccountCommand:
+		err = o.ExpireRoleHandler(cmd.(*ExpireRoleAccount), account, store)
+	case ForgetAccountCommand:
+		err = o.ForgetHandler(cmd.(*ForgetAccount), account, store)
+	case LinkIdentityAccountCommand:
+		err = o.LinkIdentityHandler(cmd.(*LinkIdentityAccount), account, store)
+	case MergeAccountCommand:
+		err = o.MergeHandler(cmd.(*MergeAccount), account, store)
+	case MergeIntoAccountCommand:
+		err = o.MergeIntoHandler(cmd.(*MergeIntoAccount), account, store)
+	case RevokeApiKeyAccountCommand:
+		err = o.RevokeApiKeyHandler(cmd.(*RevokeApiKeyAccount), account, store)
+	case RevokeSessionAccountCommand:
+		err = o.RevokeSessionHandler(cmd.(*RevokeSessionAccount), account, store)
+	case RevokeSessionsAccountCommand:
+		err = o.RevokeSessionsHandler(cmd.(*RevokeSessionsAccount), account, store)
+	case SendDisabledConfirmationAccountCommand:
+		err = o.SendDisabledConfirmationHandler(cmd.(*SendDisabledConfirmationAccount), account, store)
+	case UnassignRoleAccountCommand:
+		err = o.UnassignRoleHandler(cmd.(*UnassignRoleAccount), account, store)
+	case UnlinkIdentityAccountCommand:
+		err = o.UnlinkIdentityHandler(cmd.(*UnlinkIdentityAccount), account, store)
+	default:
+		err = errors.New(fmt.Sprintf("Not supported command type '%v' in state 'Disabled' for entity '%v", cmd.CommandType(), account))
+	}
+	return
+}
+
+func (o *AccountAggregateDisabledExecutor) SetupCommandHandler() (err error) {
+	o.ChangeAttributesHandler = func(command *ChangeAttributesAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountAttributesChangedEvent, &AccountAttributesChanged{
+			Attributes: command.Attributes}, time.Now())
+		return
+	}
+	o.EnableHandler = func(command *EnableAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountEnabledEvent, nil, time.Now())
+		return
+	}
+	o.EndImpersonationHandler = func(command *EndImpersonationAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(ImpersonationEndedEvent, &ImpersonationEnded{
+			ImpersonationId: command.ImpersonationId,
+			ActorId:         command.ActorId}, time.Now())
+		return
+	}
+	o.ExpireRoleHandler = func(command *ExpireRoleAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountRoleExpiredEvent, &AccountRoleExpired{
+			AssignmentId: command.AssignmentId,
+			Role:         command.Role}, time.Now())
+		return
+	}
+	o.ForgetHandler = func(command *ForgetAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountForgottenEvent, nil, time.Now())
+		return
+	}
+	o.LinkIdentityHandler = func(command *LinkIdentityAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountLinkedIdentityEvent, &AccountLinkedIdentity{
+			Issuer:  command.Issuer,
+			Subject: command.Subject,
+			Email:   command.Email}, time.Now())
+		return
+	}
+	o.MergeHandler = func(command *MergeAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountMergedEvent, &AccountMerged{
+			SourceId:        command.SourceId,
+			Roles:           command.Roles,
+			RoleAssignments: command.RoleAssignments,
+			Identities:      command.Identities,
+			ApiKeys:         command.ApiKeys}, time.Now())
+		return
+	}
+	o.MergeIntoHandler = func(command *MergeIntoAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountMergedIntoEvent, &AccountMergedInto{
+			TargetId: command.TargetId}, time.Now())
+		return
+	}
+	o.RevokeApiKeyHandler = func(command *RevokeApiKeyAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountRevokedApiKeyEvent, &AccountRevokedApiKey{
+			KeyId: command.KeyId}, time.Now())
+		return
+	}
+	o.RevokeSessionHandler = func(command *RevokeSessionAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountRevokedSessionEvent, &AccountRevokedSession{
+			SessionId: command.SessionId}, time.Now())
+		return
+	}
+	o.RevokeSessionsHandler = func(command *RevokeSessionsAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountRevokedSessionsEvent, nil, time.Now())
+		return
+	}
+	o.SendDisabledConfirmationHandler = func(command *SendDisabledConfirmationAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountSentDisabledConfirmationEvent, nil, time.Now())
+		return
+	}
+	o.UnassignRoleHandler = func(command *UnassignRoleAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountUnassignedRoleEvent, &AccountUnassignedRole{
+			AssignmentId: command.AssignmentId}, time.Now())
+		return
+	}
+	o.UnlinkIdentityHandler = func(command *UnlinkIdentityAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountUnlinkedIdentityEvent, &AccountUnlinkedIdentity{
+			Issuer:  command.Issuer,
+			Subject: command.Subject}, time.Now())
+		return
+	}
+	return
+}
+
+type AccountAggregateEnabledExecutor struct {
+	CommandsPreparer               func(eventhorizon.Command, *Account) (err error)
+	AssignRoleHandler              func(*AssignRoleAccount, *Account, eh.AggregateStoreEvent) (err error)
+	ChangeAttributesHandler        func(*ChangeAttributesAccount, *Account, eh.AggregateStoreEvent) (err error)
+	ChangePasswordHandler          func(*ChangePasswordAccount, *Account, eh.AggregateStoreEvent) (err error)
+	ConfirmEmailChangeHandler      func(*ConfirmEmailChangeAccount, *Account, eh.AggregateStoreEvent) (err error)
+	CreateApiKeyHandler            func(*CreateApiKeyAccount, *Account, eh.AggregateStoreEvent) (err error)
+	DeleteHandler                  func(*DeleteAccount, *Account, eh.AggregateStoreEvent) (err error)
+	DisableHandler                 func(*DisableAccount, *Account, eh.AggregateStoreEvent) (err error)
+	EndImpersonationHandler        func(*EndImpersonationAccount, *Account, eh.AggregateStoreEvent) (err error)
+	ExpireRoleHandler              func(*ExpireRoleAccount, *Account, eh.AggregateStoreEvent) (err error)
+	ForgetHandler                  func(*ForgetAccount, *Account, eh.AggregateStoreEvent) (err error)
+	ImpersonateHandler             func(*ImpersonateAccount, *Account, eh.AggregateStoreEvent) (err error)
+	LinkIdentityHandler            func(*LinkIdentityAccount, *Account, eh.AggregateStoreEvent) (err error)
+	MergeHandler                   func(*MergeAccount, *Account, eh.AggregateStoreEvent) (err error)
+	MergeIntoHandler               func(*MergeIntoAccount, *Account, eh.AggregateStoreEvent) (err error)
+	RequestEmailChangeHandler      func(*RequestEmailChangeAccount, *Account, eh.AggregateStoreEvent) (err error)
+	RevokeApiKeyHandler            func(*RevokeApiKeyAccount, *Account, eh.AggregateStoreEvent) (err error)
+	RevokeSessionHandler           func(*RevokeSessionAccount, *Account, eh.AggregateStoreEvent) (err error)
+	RevokeSessionsHandler          func(*RevokeSessionsAccount, *Account, eh.AggregateStoreEvent) (err error)
+	SendEnabledConfirmationHandler func(*SendEnabledConfirmationAccount, *Account, eh.AggregateStoreEvent) (err error)
+	StartSessionHandler            func(*StartSessionAccount, *Account, eh.AggregateStoreEvent) (err error)
+	TouchSessionHandler            func(*TouchSessionAccount, *Account, eh.AggregateStoreEvent) (err error)
+	UnassignRoleHandler            func(*UnassignRoleAccount, *Account, eh.AggregateStoreEvent) (err error)
+	UnlinkIdentityHandler          func(*UnlinkIdentityAccount, *Account, eh.AggregateStoreEvent) (err error)
+	UseApiKeyHandler               func(*UseApiKeyAccount, *Account, eh.AggregateStoreEvent) (err error)
+	WarnInactivityHandler          func(*WarnInactivityAccount, *Account, eh.AggregateStoreEvent) (err error)
+}
+
+func NewAccountAggregateEnabledExecutorDefault() (ret *AccountAggregateEnabledExecutor) {
+	ret = &AccountAggregateEnabledExecutor{}
+	return
+}
+
+func (o *AccountAggregateEnabledExecutor) AddCommandsPreparer(preparer func(eventhorizon.Command, *Account) (err error)) {
+	prevHandler := o.CommandsPreparer
+	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *Account) (err error) {
+		if err = preparer(cmd, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(cmd, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateEnabledExecutor) AddAssignRolePreparer(preparer func(*AssignRoleAccount, *Account) (err error)) {
+	prevHandler := o.AssignRoleHandler
+	o.AssignRoleHandler = func(command *AssignRoleAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateEnabledExecutor) AddChangeAttributesPreparer(preparer func(*ChangeAttributesAccount, *Account) (err error)) {
+	prevHandler := o.ChangeAttributesHandler
+	o.ChangeAttributesHandler = func(command *ChangeAttributesAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateEnabledExecutor) AddChangePasswordPreparer(preparer func(*ChangePasswordAccount, *Account) (err error)) {
+	prevHandler := o.ChangePasswordHandler
+	o.ChangePasswordHandler = func(command *ChangePasswordAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateEnabledExecutor) AddConfirmEmailChangePreparer(preparer func(*ConfirmEmailChangeAccount, *Account) (err error)) {
+	prevHandler := o.ConfirmEmailChangeHandler
+	o.ConfirmEmailChangeHandler = func(command *ConfirmEmailChangeAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateEnabledExecutor) AddCreateApiKeyPreparer(preparer func(*CreateApiKeyAccount, *Account) (err error)) {
+	prevHandler := o.CreateApiKeyHandler
+	o.CreateApiKeyHandler = func(command *CreateApiKeyAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateEnabledExecutor) AddDeletePreparer(preparer func(*DeleteAccount, *Account) (err error)) {
+	prevHandler := o.DeleteHandler
+	o.DeleteHandler = func(command *DeleteAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateEnabledExecutor) AddDisablePreparer(preparer func(*DisableAccount, *Account) (err error)) {
+	prevHandler := o.DisableHandler
+	o.DisableHandler = func(command *DisableAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateEnabledExecutor) AddEndImpersonationPreparer(preparer func(*EndImpersonationAccount, *Account) (err error)) {
+	prevHandler := o.EndImpersonationHandler
+	o.EndImpersonationHandler = func(command *EndImpersonationAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateEnabledExecutor) AddExpireRolePreparer(preparer func(*ExpireRoleAccount, *Account) (err error)) {
+	prevHandler := o.ExpireRoleHandler
+	o.ExpireRoleHandler = func(command *ExpireRoleAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateEnabledExecutor) AddForgetPreparer(preparer func(*ForgetAccount, *Account) (err error)) {
+	prevHandler := o.ForgetHandler
+	o.ForgetHandler = func(command *ForgetAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateEnabledExecutor) AddImpersonatePreparer(preparer func(*ImpersonateAccount, *Account) (err error)) {
+	prevHandler := o.ImpersonateHandler
+	o.ImpersonateHandler = func(command *ImpersonateAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateEnabledExecutor) AddLinkIdentityPreparer(preparer func(*LinkIdentityAccount, *Account) (err error)) {
+	prevHandler := o.LinkIdentityHandler
+	o.LinkIdentityHandler = func(command *LinkIdentityAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateEnabledExecutor) AddMergePreparer(preparer func(*MergeAccount, *Account) (err error)) {
+	prevHandler := o.MergeHandler
+	o.MergeHandler = func(command *MergeAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateEnabledExecutor) AddMergeIntoPreparer(preparer func(*MergeIntoAccount, *Account) (err error)) {
+	prevHandler := o.MergeIntoHandler
+	o.MergeIntoHandler = func(command *MergeIntoAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateEnabledExecutor) AddRequestEmailChangePreparer(preparer func(*RequestEmailChangeAccount, *Account) (err error)) {
+	prevHandler := o.RequestEmailChangeHandler
+	o.RequestEmailChangeHandler = func(command *RequestEmailChangeAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateEnabledExecutor) AddRevokeApiKeyPreparer(preparer func(*RevokeApiKeyAccount, *Account) (err error)) {
+	prevHandler := o.RevokeApiKeyHandler
+	o.RevokeApiKeyHandler = func(command *RevokeApiKeyAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateEnabledExecutor) AddRevokeSessionPreparer(preparer func(*RevokeSessionAccount, *Account) (err error)) {
+	prevHandler := o.RevokeSessionHandler
+	o.RevokeSessionHandler = func(command *RevokeSessionAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateEnabledExecutor) AddRevokeSessionsPreparer(preparer func(*RevokeSessionsAccount, *Account) (err error)) {
+	prevHandler := o.RevokeSessionsHandler
+	o.RevokeSessionsHandler = func(command *RevokeSessionsAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateEnabledExecutor) AddSendEnabledConfirmationPreparer(preparer func(*SendEnabledConfirmationAccount, *Account) (err error)) {
+	prevHandler := o.SendEnabledConfirmationHandler
+	o.SendEnabledConfirmationHandler = func(command *SendEnabledConfirmationAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateEnabledExecutor) AddStartSessionPreparer(preparer func(*StartSessionAccount, *Account) (err error)) {
+	prevHandler := o.StartSessionHandler
+	o.StartSessionHandler = func(command *StartSessionAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateEnabledExecutor) AddTouchSessionPreparer(preparer func(*TouchSessionAccount, *Account) (err error)) {
+	prevHandler := o.TouchSessionHandler
+	o.TouchSessionHandler = func(command *TouchSessionAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateEnabledExecutor) AddUnassignRolePreparer(preparer func(*UnassignRoleAccount, *Account) (err error)) {
+	prevHandler := o.UnassignRoleHandler
+	o.UnassignRoleHandler = func(command *UnassignRoleAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateEnabledExecutor) AddUnlinkIdentityPreparer(preparer func(*UnlinkIdentityAccount, *Account) (err error)) {
+	prevHandler := o.UnlinkIdentityHandler
+	o.UnlinkIdentityHandler = func(command *UnlinkIdentityAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateEnabledExecutor) AddUseApiKeyPreparer(preparer func(*UseApiKeyAccount, *Account) (err error)) {
+	prevHandler := o.UseApiKeyHandler
+	o.UseApiKeyHandler = func(command *UseApiKeyAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateEnabledExecutor) AddWarnInactivityPreparer(preparer func(*WarnInactivityAccount, *Account) (err error)) {
+	prevHandler := o.WarnInactivityHandler
+	o.WarnInactivityHandler = func(command *WarnInactivityAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateEnabledExecutor) StateType() (ret *AccountAggregateStateType) {
+	ret = AccountAggregateStateTypes().Enabled()
+	return
+}
+
+func (o *AccountAggregateEnabledExecutor) Execute(cmd eventhorizon.Command, account *Account, store eh.AggregateStoreEvent) (err error) {
+	if o.CommandsPreparer != nil {
+		if err = o.CommandsPreparer(cmd, account); err != nil {
+			return
+		}
+	}
+
+	switch cmd.CommandType() {
+	case AssignRoleAccountCommand:
+		err = o.AssignRoleHandler(cmd.(*AssignRoleAccount), account, store)
+	case ChangeAttributesAccountCommand:
+		err = o.ChangeAttributesHandler(cmd.(*ChangeAttributesAccount), account, store)
+	case ChangePasswordAccountCommand:
+		err = o.ChangePasswordHandler(cmd.(*ChangePasswordAccount), account, store)
+	case ConfirmEmailChangeAccountCommand:
+		err = o.ConfirmEmailChangeHandler(cmd.(*ConfirmEmailChangeAccount), account, store)
+	case CreateApiKeyAccountCommand:
+		err = o.CreateApiKeyHandler(cmd.(*CreateApiKeyAccount), account, store)
+	case DeleteAccountCommand:
+		err = o.DeleteHandler(cmd.(*DeleteAccount), account, store)
+	case DisableAccountCommand:
+		err = o.DisableHandler(cmd.(*DisableAccount), account, store)
+	case EndImpersonationAccountCommand:
+		err = o.EndImpersonationHandler(cmd.(*EndImpersonationAccount), account, store)
+	case ExpireRoleAccountCommand:
+		err = o.ExpireRoleHandler(cmd.(*ExpireRoleAccount), account, store)
+	case ForgetAccountCommand:
+		err = o.ForgetHandler(cmd.(*ForgetAccount), account, store)
+	case ImpersonateAccountCommand:
+		err = o.ImpersonateHandler(cmd.(*ImpersonateAccount), account, store)
+	case LinkIdentityAccountCommand:
+		err = o.LinkIdentityHandler(cmd.(*LinkIdentityAccount), account, store)
+	case MergeAccountCommand:
+		err = o.MergeHandler(cmd.(*MergeAccount), account, store)
+	case MergeIntoAccountCommand:
+		err = o.MergeIntoHandler(cmd.(*MergeIntoAccount), account, store)
+	case RequestEmailChangeAccountCommand:
+		err = o.RequestEmailChangeHandler(cmd.(*RequestEmailChangeAccount), account, store)
+	case RevokeApiKeyAccountCommand:
+		err = o.RevokeApiKeyHandler(cmd.(*RevokeApiKeyAccount), account, store)
+	case RevokeSessionAccountCommand:
+		err = o.RevokeSessionHandler(cmd.(*RevokeSessionAccount), account, store)
+	case RevokeSessionsAccountCommand:
+		err = o.RevokeSessionsHandler(cmd.(*RevokeSessionsAccount), account, store)
+	case SendEnabledConfirmationAccountCommand:
+		err = o.SendEnabledConfirmationHandler(cmd.(*SendEnabledConfirmationAccount), account, store)
+	case StartSessionAccountCommand:
+		err = o.StartSessionHandler(cmd.(*StartSessionAccount), account, store)
+	case TouchSessionAccountCommand:
+		err = o.TouchSessionHandler(cmd.(*TouchSessionAccount), account, store)
+	case UnassignRoleAccountCommand:
+		err = o.UnassignRoleHandler(cmd.(*UnassignRoleAccount), account, store)
+	case UnlinkIdentityAccountCommand:
+		err = o.UnlinkIdentityHandler(cmd.(*UnlinkIdentityAccount), account, store)
+	case UseApiKeyAccountCommand:
+		err = o.UseApiKeyHandler(cmd.(*UseApiKeyAccount), account, store)
+	case WarnInactivityAccountCommand:
+		err = o.WarnInactivityHandler(cmd.(*WarnInactivityAccount), account, store)
+	default:
+		err = errors.New(fmt.Sprintf("Not supported command type '%v' in state 'Enabled' for entity '%v", cmd.CommandType(), account))
+	}
+	return
+}
+
+func (o *AccountAggregateEnabledExecutor) SetupCommandHandler() (err error) {
+	o.AssignRoleHandler = func(command *AssignRoleAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountAssignedRoleEvent, &AccountAssignedRole{
+			AssignmentId:   command.AssignmentId,
+			Role:           command.Role,
+			ValidFrom:      command.ValidFrom,
+			ValidUntil:     command.ValidUntil,
+			AssignedBy:     command.AssignedBy,
+			GrantRequestId: command.GrantRequestId}, time.Now())
+		return
+	}
+	o.ChangeAttributesHandler = func(command *ChangeAttributesAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountAttributesChangedEvent, &AccountAttributesChanged{
+			Attributes: command.Attributes}, time.Now())
+		return
+	}
+	o.ChangePasswordHandler = func(command *ChangePasswordAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountPasswordChangedEvent, &AccountPasswordChanged{
+			Password: command.Password}, time.Now())
+		return
+	}
+	o.ConfirmEmailChangeHandler = func(command *ConfirmEmailChangeAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountEmailChangeConfirmedEvent, &AccountEmailChangeConfirmed{
+			Email: command.Email}, time.Now())
+		return
+	}
+	o.CreateApiKeyHandler = func(command *CreateApiKeyAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountCreatedApiKeyEvent, &AccountCreatedApiKey{
+			KeyId:     command.KeyId,
+			Name:      command.Name,
+			Scopes:    command.Scopes,
+			Hash:      command.Hash,
+			ExpiresAt: command.ExpiresAt}, time.Now())
+		return
+	}
+	o.DeleteHandler = func(command *DeleteAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountDeletedEvent, nil, time.Now())
+		return
+	}
+	o.DisableHandler = func(command *DisableAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountDisabledEvent, &AccountDisabled{
+			DisabledReason: command.DisabledReason}, time.Now())
+		return
+	}
+	o.EndImpersonationHandler = func(command *EndImpersonationAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(ImpersonationEndedEvent, &ImpersonationEnded{
+			ImpersonationId: command.ImpersonationId,
+			ActorId:         command.ActorId}, time.Now())
+		return
+	}
+	o.ExpireRoleHandler = func(command *ExpireRoleAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountRoleExpiredEvent, &AccountRoleExpired{
+			AssignmentId: command.AssignmentId,
+			Role:         command.Role}, time.Now())
+		return
+	}
+	o.ForgetHandler = func(command *ForgetAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountForgottenEvent, nil, time.Now())
+		return
+	}
+	o.ImpersonateHandler = func(command *ImpersonateAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(ImpersonationStartedEvent, &ImpersonationStarted{
+			ImpersonationId: command.ImpersonationId,
+			ActorId:         command.ActorId,
+			Reason:          command.Reason,
+			ExpiresAt:       command.ExpiresAt}, time.Now())
+		return
+	}
+	o.LinkIdentityHandler = func(command *LinkIdentityAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountLinkedIdentityEvent, &AccountLinkedIdentity{
+			Issuer:  command.Issuer,
+			Subject: command.Subject,
+			Email:   command.Email}, time.Now())
+		return
+	}
+	o.MergeHandler = func(command *MergeAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountMergedEvent, &AccountMerged{
+			SourceId:        command.SourceId,
+			Roles:           command.Roles,
+			RoleAssignments: command.RoleAssignments,
+			Identities:      command.Identities,
+			ApiKeys:         command.ApiKeys}, time.Now())
+		return
+	}
+	o.MergeIntoHandler = func(command *MergeIntoAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountMergedIntoEvent, &AccountMergedInto{
+			TargetId: command.TargetId}, time.Now())
+		return
+	}
+	o.RequestEmailChangeHandler = func(command *RequestEmailChangeAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountEmailChangeRequestedEvent, &AccountEmailChangeRequested{
+			PendingEmail:         command.PendingEmail,
+			EmailChangeTokenHash: command.EmailChangeTokenHash,
+			EmailChangeExpiresAt: command.EmailChangeExpiresAt}, time.Now())
+		return
+	}
+	o.RevokeApiKeyHandler = func(command *RevokeApiKeyAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountRevokedApiKeyEvent, &AccountRevokedApiKey{
+			KeyId: command.KeyId}, time.Now())
+		return
+	}
+	o.RevokeSessionHandler = func(command *RevokeSessionAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountRevokedSessionEvent, &AccountRevokedSession{
+			SessionId: command.SessionId}, time.Now())
+		return
+	}
+	o.RevokeSessionsHandler = func(command *RevokeSessionsAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountRevokedSessionsEvent, nil, time.Now())
+		return
+	}
+	o.SendEnabledConfirmationHandler = func(command *SendEnabledConfirmationAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountSentEnabledConfirmationEvent, nil, time.Now())
+		return
+	}
+	o.StartSessionHandler = func(command *StartSessionAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountStartedSessionEvent, &AccountStartedSession{
+			SessionId: command.SessionId,
+			Device:    command.Device,
+			Ip:        command.Ip,
+			UserAgent: command.UserAgent}, time.Now())
+		return
+	}
+	o.TouchSessionHandler = func(command *TouchSessionAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountTouchedSessionEvent, &AccountTouchedSession{
+			SessionId: command.SessionId}, time.Now())
+		return
+	}
+	o.UnassignRoleHandler = func(command *UnassignRoleAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountUnassignedRoleEvent, &AccountUnassignedRole{
+			AssignmentId: command.AssignmentId}, time.Now())
+		return
+	}
+	o.UnlinkIdentityHandler = func(command *UnlinkIdentityAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountUnlinkedIdentityEvent, &AccountUnlinkedIdentity{
+			Issuer:  command.Issuer,
+			Subject: command.Subject}, time.Now())
+		return
+	}
+	o.UseApiKeyHandler = func(command *UseApiKeyAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountUsedApiKeyEvent, &AccountUsedApiKey{
+			KeyId: command.KeyId}, time.Now())
+		return
+	}
+	o.WarnInactivityHandler = func(command *WarnInactivityAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountInactivityWarnedEvent, nil, time.Now())
+		return
+	}
+	return
+}
+
+type AccountAggregateExistExecutor struct {
+	CommandsPreparer func(eventhorizon.Command, *Account) (err error)
+	DeleteHandler    func(*DeleteAccount, *Account, eh.AggregateStoreEvent) (err error)
+	UpdateHandler    func(*UpdateAccount, *Account, eh.AggregateStoreEvent) (err error)
+}
+
+func NewAccountAggregateExistExecutorDefault() (ret *AccountAggregateExistExecutor) {
+	ret = &AccountAggregateExistExecutor{}
+	return
+}
+
+func (o *AccountAggregateExistExecutor) AddCommandsPreparer(preparer func(eventhorizon.Command, *Account) (err error)) {
+	prevHandler := o.CommandsPreparer
+	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *Account) (err error) {
+		if err = preparer(cmd, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(cmd, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateExistExecutor) AddDeletePreparer(preparer func(*DeleteAccount, *Account) (err error)) {
+	prevHandler := o.DeleteHandler
+	o.DeleteHandler = func(command *DeleteAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateExistExecutor) AddUpdatePreparer(preparer func(*UpdateAccount, *Account) (err error)) {
+	prevHandler := o.UpdateHandler
+	o.UpdateHandler = func(command *UpdateAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *AccountAggregateExistExecutor) StateType() (ret *AccountAggregateStateType) {
+	ret = AccountAggregateStateTypes().Exist()
+	return
+}
+
+func (o *AccountAggregateExistExecutor) Execute(cmd eventhorizon.Command, account *Account, store eh.AggregateStoreEvent) (err error) {
+	if o.CommandsPreparer != nil {
+		if err = o.CommandsPreparer(cmd, account); err != nil {
+			return
+		}
+	}
+
+	switch cmd.CommandType() {
+	case DeleteAccountCommand:
+		err = o.DeleteHandler(cmd.(*DeleteAccount), account, store)
+	case UpdateAccountCommand:
+		err = o.UpdateHandler(cmd.(*UpdateAccount), account, store)
+	default:
+		err = errors.New(fmt.Sprintf("Not supported command type '%v' in state 'Exist' for entity '%v", cmd.CommandType(), account))
+	}
+	return
+}
+
+func (o *AccountAggregateExistExecutor) SetupCommandHandler() (err error) {
+	o.DeleteHandler = func(command *DeleteAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountDeletedEvent, nil, time.Now())
+		return
+	}
+	o.UpdateHandler = func(command *UpdateAccount, entity *Account, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(AccountUpdatedEvent, &AccountUpdated{
+			Name:           command.Name,
+			Username:       command.Username,
+			Password:       command.Password,
+			Email:          command.Email,
+			Roles:          command.Roles,
+			OrganizationId: command.OrganizationId,
+			Service:        command.Service,
+			BreakGlass:     command.BreakGlass,
+			Attributes:     command.Attributes}, time.Now())
+		return
+	}
+	return
+}
+
+type GroupAggregateInitialExecutor struct {
+	CommandsPreparer func(eventhorizon.Command, *Group) (err error)
+	CreateHandler    func(*CreateGroup, *Group, eh.AggregateStoreEvent) (err error)
+}
+
+func NewGroupAggregateInitialExecutorDefault() (ret *GroupAggregateInitialExecutor) {
+	ret = &GroupAggregateInitialExecutor{}
+	return
+}
+
+func (o *GroupAggregateInitialExecutor) AddCommandsPreparer(preparer func(eventhorizon.Command, *Group) (err error)) {
+	prevHandler := o.CommandsPreparer
+	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *Group) (err error) {
+		if err = preparer(cmd, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(cmd, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *GroupAggregateInitialExecutor) AddCreatePreparer(preparer func(*CreateGroup, *Group) (err error)) {
+	prevHandler := o.CreateHandler
+	o.CreateHandler = func(command *CreateGroup, entity *Group, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *GroupAggregateInitialExecutor) StateType() (ret *GroupAggregateStateType) {
+	ret = GroupAggregateStateTypes().Initial()
+	return
+}
+
+func (o *GroupAggregateInitialExecutor) Execute(cmd eventhorizon.Command, group *Group, store eh.AggregateStoreEvent) (err error) {
+	if o.CommandsPreparer != nil {
+		if err = o.CommandsPreparer(cmd, group); err != nil {
+			return
+		}
+	}
+
+	switch cmd.CommandType() {
+	case CreateGroupCommand:
+		err = o.CreateHandler(cmd.(*CreateGroup), group, store)
+	default:
+		err = errors.New(fmt.Sprintf("Not supported command type '%v' in state 'Initial' for entity '%v", cmd.CommandType(), group))
+	}
+	return
+}
+
+func (o *GroupAggregateInitialExecutor) SetupCommandHandler() (err error) {
+	o.CreateHandler = func(command *CreateGroup, entity *Group, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(GroupCreatedEvent, &GroupCreated{
+			Name:        command.Name,
+			Description: command.Description,
+			Roles:       command.Roles}, time.Now())
+		return
+	}
+	return
+}
+
+type GroupAggregateExistExecutor struct {
+	CommandsPreparer      func(eventhorizon.Command, *Group) (err error)
+	AddMemberHandler      func(*AddMemberGroup, *Group, eh.AggregateStoreEvent) (err error)
+	AddSubgroupHandler    func(*AddSubgroupGroup, *Group, eh.AggregateStoreEvent) (err error)
+	DeleteHandler         func(*DeleteGroup, *Group, eh.AggregateStoreEvent) (err error)
+	RemoveMemberHandler   func(*RemoveMemberGroup, *Group, eh.AggregateStoreEvent) (err error)
+	RemoveSubgroupHandler func(*RemoveSubgroupGroup, *Group, eh.AggregateStoreEvent) (err error)
+	UpdateHandler         func(*UpdateGroup, *Group, eh.AggregateStoreEvent) (err error)
+}
+
+func NewGroupAggregateExistExecutorDefault() (ret *GroupAggregateExistExecutor) {
+	ret = &GroupAggregateExistExecutor{}
+	return
+}
+
+func (o *GroupAggregateExistExecutor) AddCommandsPreparer(preparer func(eventhorizon.Command, *Group) (err error)) {
+	prevHandler := o.CommandsPreparer
+	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *Group) (err error) {
+		if err = preparer(cmd, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(cmd, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *GroupAggregateExistExecutor) AddAddMemberPreparer(preparer func(*AddMemberGroup, *Group) (err error)) {
+	prevHandler := o.AddMemberHandler
+	o.AddMemberHandler = func(command *AddMemberGroup, entity *Group, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *GroupAggregateExistExecutor) AddAddSubgroupPreparer(preparer func(*AddSubgroupGroup, *Group) (err error)) {
+	prevHandler := o.AddSubgroupHandler
+	o.AddSubgroupHandler = func(command *AddSubgroupGroup, entity *Group, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *GroupAggregateExistExecutor) AddDeletePreparer(preparer func(*DeleteGroup, *Group) (err error)) {
+	prevHandler := o.DeleteHandler
+	o.DeleteHandler = func(command *DeleteGroup, entity *Group, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *GroupAggregateExistExecutor) AddRemoveMemberPreparer(preparer func(*RemoveMemberGroup, *Group) (err error)) {
+	prevHandler := o.RemoveMemberHandler
+	o.RemoveMemberHandler = func(command *RemoveMemberGroup, entity *Group, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *GroupAggregateExistExecutor) AddRemoveSubgroupPreparer(preparer func(*RemoveSubgroupGroup, *Group) (err error)) {
+	prevHandler := o.RemoveSubgroupHandler
+	o.RemoveSubgroupHandler = func(command *RemoveSubgroupGroup, entity *Group, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *GroupAggregateExistExecutor) AddUpdatePreparer(preparer func(*UpdateGroup, *Group) (err error)) {
+	prevHandler := o.UpdateHandler
+	o.UpdateHandler = func(command *UpdateGroup, entity *Group, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *GroupAggregateExistExecutor) StateType() (ret *GroupAggregateStateType) {
+	ret = GroupAggregateStateTypes().Exist()
+	return
+}
+
+func (o *GroupAggregateExistExecutor) Execute(cmd eventhorizon.Command, group *Group, store eh.AggregateStoreEvent) (err error) {
+	if o.CommandsPreparer != nil {
+		if err = o.CommandsPreparer(cmd, group); err != nil {
+			return
+		}
+	}
+
+	switch cmd.CommandType() {
+	case AddMemberGroupCommand:
+		err = o.AddMemberHandler(cmd.(*AddMemberGroup), group, store)
+	case AddSubgroupGroupCommand:
+		err = o.AddSubgroupHandler(cmd.(*AddSubgroupGroup), group, store)
+	case DeleteGroupCommand:
+		err = o.DeleteHandler(cmd.(*DeleteGroup), group, store)
+	case RemoveMemberGroupCommand:
+		err = o.RemoveMemberHandler(cmd.(*RemoveMemberGroup), group, store)
+	case RemoveSubgroupGroupCommand:
+		err = o.RemoveSubgroupHandler(cmd.(*RemoveSubgroupGroup), group, store)
+	case UpdateGroupCommand:
+		err = o.UpdateHandler(cmd.(*UpdateGroup), group, store)
+	default:
+		err = errors.New(fmt.Sprintf("Not supported command type '%v' in state 'Exist' for entity '%v", cmd.CommandType(), group))
+	}
+	return
+}
+
+func (o *GroupAggregateExistExecutor) SetupCommandHandler() (err error) {
+	o.AddMemberHandler = func(command *AddMemberGroup, entity *Group, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(GroupAddedMemberEvent, &GroupAddedMember{
+			AccountId: command.AccountId}, time.Now())
+		return
+	}
+	o.AddSubgroupHandler = func(command *AddSubgroupGroup, entity *Group, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(GroupAddedSubgroupEvent, &GroupAddedSubgroup{
+			GroupId: command.GroupId}, time.Now())
+		return
+	}
+	o.DeleteHandler = func(command *DeleteGroup, entity *Group, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(GroupDeletedEvent, nil, time.Now())
+		return
+	}
+	o.RemoveMemberHandler = func(command *RemoveMemberGroup, entity *Group, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(GroupRemovedMemberEvent, &GroupRemovedMember{
+			AccountId: command.AccountId}, time.Now())
+		return
+	}
+	o.RemoveSubgroupHandler = func(command *RemoveSubgroupGroup, entity *Group, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(GroupRemovedSubgroupEvent, &GroupRemovedSubgroup{
+			GroupId: command.GroupId}, time.Now())
+		return
+	}
+	o.UpdateHandler = func(command *UpdateGroup, entity *Group, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(GroupUpdatedEvent, &GroupUpdated{
+			Name:        command.Name,
+			Description: command.Description,
+			Roles:       command.Roles}, time.Now())
+		return
+	}
+	return
+}
+
+type GroupAggregateDeletedExecutor struct {
+	CommandsPreparer func(eventhorizon.Command, *Group) (err error)
+}
+
+func NewGroupAggregateDeletedExecutorDefault() (ret *GroupAggregateDeletedExecutor) {
+	ret = &GroupAggregateDeletedExecutor{}
+	return
+}
+
+func (o *GroupAggregateDeletedExecutor) AddCommandsPreparer(preparer func(eventhorizon.Command, *Group) (err error)) {
+	prevHandler := o.CommandsPreparer
+	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *Group) (err error) {
+		if err = preparer(cmd, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(cmd, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *GroupAggregateDeletedExecutor) StateType() (ret *GroupAggregateStateType) {
+	ret = GroupAggregateStateTypes().Deleted()
+	return
+}
+
+func (o *GroupAggregateDeletedExecutor) Execute(cmd eventhorizon.Command, group *Group, store eh.AggregateStoreEvent) (err error) {
+	if o.CommandsPreparer != nil {
+		if err = o.CommandsPreparer(cmd, group); err != nil {
+			return
+		}
+	}
+	err = errors.New(fmt.Sprintf("Not supported command type '%v' in state 'Deleted' for entity '%v", cmd.CommandType(), group))
+	return
+}
+
+func (o *GroupAggregateDeletedExecutor) SetupCommandHandler() (err error) {
+	return
+}
+
+type OrganizationAggregateInitialExecutor struct {
+	CommandsPreparer func(eventhorizon.Command, *Organization) (err error)
+	CreateHandler    func(*CreateOrganization, *Organization, eh.AggregateStoreEvent) (err error)
+}
+
+func NewOrganizationAggregateInitialExecutorDefault() (ret *OrganizationAggregateInitialExecutor) {
+	ret = &OrganizationAggregateInitialExecutor{}
+	return
+}
+
+func (o *OrganizationAggregateInitialExecutor) AddCommandsPreparer(preparer func(eventhorizon.Command, *Organization) (err error)) {
+	prevHandler := o.CommandsPreparer
+	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *Organization) (err error) {
+		if err = preparer(cmd, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(cmd, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *OrganizationAggregateInitialExecutor) AddCreatePreparer(preparer func(*CreateOrganization, *Organization) (err error)) {
+	prevHandler := o.CreateHandler
+	o.CreateHandler = func(command *CreateOrganization, entity *Organization, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *OrganizationAggregateInitialExecutor) StateType() (ret *OrganizationAggregateStateType) {
+	ret = OrganizationAggregateStateTypes().Initial()
+	return
+}
+
+func (o *OrganizationAggregateInitialExecutor) Execute(cmd eventhorizon.Command, organization *Organization, store eh.AggregateStoreEvent) (err error) {
+	if o.CommandsPreparer != nil {
+		if err = o.CommandsPreparer(cmd, organization); err != nil {
+			return
+		}
+	}
+
+	switch cmd.CommandType() {
+	case CreateOrganizationCommand:
+		err = o.CreateHandler(cmd.(*CreateOrganization), organization, store)
+	default:
+		err = errors.New(fmt.Sprintf("Not supported command type '%v' in state 'Initial' for entity '%v", cmd.CommandType(), organization))
+	}
+	return
+}
+
+func (o *OrganizationAggregateInitialExecutor) SetupCommandHandler() (err error) {
+	o.CreateHandler = func(command *CreateOrganization, entity *Organization, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(OrganizationCreatedEvent, &OrganizationCreated{
+			Name:        command.Name,
+			Description: command.Description}, time.Now())
+		return
+	}
+	return
+}
+
+type OrganizationAggregateExistExecutor struct {
+	CommandsPreparer func(eventhorizon.Command, *Organization) (err error)
+	DeleteHandler    func(*DeleteOrganization, *Organization, eh.AggregateStoreEvent) (err error)
+	UpdateHandler    func(*UpdateOrganization, *Organization, eh.AggregateStoreEvent) (err error)
+}
+
+func NewOrganizationAggregateExistExecutorDefault() (ret *OrganizationAggregateExistExecutor) {
+	ret = &OrganizationAggregateExistExecutor{}
+	return
+}
+
+func (o *OrganizationAggregateExistExecutor) AddCommandsPreparer(preparer func(eventhorizon.Command, *Organization) (err error)) {
+	prevHandler := o.CommandsPreparer
+	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *Organization) (err error) {
+		if err = preparer(cmd, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(cmd, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *OrganizationAggregateExistExecutor) AddDeletePreparer(preparer func(*DeleteOrganization, *Organization) (err error)) {
+	prevHandler := o.DeleteHandler
+	o.DeleteHandler = func(command *DeleteOrganization, entity *Organization, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *OrganizationAggregateExistExecutor) AddUpdatePreparer(preparer func(*UpdateOrganization, *Organization) (err error)) {
+	prevHandler := o.UpdateHandler
+	o.UpdateHandler = func(command *UpdateOrganization, entity *Organization, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *OrganizationAggregateExistExecutor) StateType() (ret *OrganizationAggregateStateType) {
+	ret = OrganizationAggregateStateTypes().Exist()
+	return
+}
+
+func (o *OrganizationAggregateExistExecutor) Execute(cmd eventhorizon.Command, organization *Organization, store eh.AggregateStoreEvent) (err error) {
+	if o.CommandsPreparer != nil {
+		if err = o.CommandsPreparer(cmd, organization); err != nil {
+			return
+		}
+	}
+
+	switch cmd.CommandType() {
+	case DeleteOrganizationCommand:
+		err = o.DeleteHandler(cmd.(*DeleteOrganization), organization, store)
+	case UpdateOrganizationCommand:
+		err = o.UpdateHandler(cmd.(*UpdateOrganization), organization, store)
+	default:
+		err = errors.New(fmt.Sprintf("Not supported command type '%v' in state 'Exist' for entity '%v", cmd.CommandType(), organization))
+	}
+	return
+}
+
+func (o *OrganizationAggregateExistExecutor) SetupCommandHandler() (err error) {
+	o.DeleteHandler = func(command *DeleteOrganization, entity *Organization, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(OrganizationDeletedEvent, nil, time.Now())
+		return
+	}
+	o.UpdateHandler = func(command *UpdateOrganization, entity *Organization, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(OrganizationUpdatedEvent, &OrganizationUpdated{
+			Name:        command.Name,
+			Description: command.Description}, time.Now())
+		return
+	}
+	return
+}
+
+type OrganizationAggregateDeletedExecutor struct {
+	CommandsPreparer func(eventhorizon.Command, *Organization) (err error)
+}
+
+func NewOrganizationAggregateDeletedExecutorDefault() (ret *OrganizationAggregateDeletedExecutor) {
+	ret = &OrganizationAggregateDeletedExecutor{}
+	return
+}
+
+func (o *OrganizationAggregateDeletedExecutor) AddCommandsPreparer(preparer func(eventhorizon.Command, *Organization) (err error)) {
+	prevHandler := o.CommandsPreparer
+	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *Organization) (err error) {
+		if err = preparer(cmd, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(cmd, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *OrganizationAggregateDeletedExecutor) StateType() (ret *OrganizationAggregateStateType) {
+	ret = OrganizationAggregateStateTypes().Deleted()
+	return
+}
+
+func (o *OrganizationAggregateDeletedExecutor) Execute(cmd eventhorizon.Command, organization *Organization, store eh.AggregateStoreEvent) (err error) {
+	if o.CommandsPreparer != nil {
+		if err = o.CommandsPreparer(cmd, organization); err != nil {
+			return
+		}
+	}
+	err = errors.New(fmt.Sprintf("Not supported command type '%v' in state 'Deleted' for entity '%v", cmd.CommandType(), organization))
+	return
+}
+
+func (o *OrganizationAggregateDeletedExecutor) SetupCommandHandler() (err error) {
+	return
+}
+
+type RoleGrantRequestAggregateInitialExecutor struct {
+	CommandsPreparer func(eventhorizon.Command, *RoleGrantRequest) (err error)
+	CreateHandler    func(*CreateRoleGrantRequest, *RoleGrantRequest, eh.AggregateStoreEvent) (err error)
+}
+
+func NewRoleGrantRequestAggregateInitialExecutorDefault() (ret *RoleGrantRequestAggregateInitialExecutor) {
+	ret = &RoleGrantRequestAggregateInitialExecutor{}
+	return
+}
+
+func (o *RoleGrantRequestAggregateInitialExecutor) AddCommandsPreparer(preparer func(eventhorizon.Command, *RoleGrantRequest) (err error)) {
+	prevHandler := o.CommandsPreparer
+	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *RoleGrantRequest) (err error) {
+		if err = preparer(cmd, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(cmd, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *RoleGrantRequestAggregateInitialExecutor) AddCreatePreparer(preparer func(*CreateRoleGrantRequest, *RoleGrantRequest) (err error)) {
+	prevHandler := o.CreateHandler
+	o.CreateHandler = func(command *CreateRoleGrantRequest, entity *RoleGrantRequest, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *RoleGrantRequestAggregateInitialExecutor) StateType() (ret *RoleGrantRequestAggregateStateType) {
+	ret = RoleGrantRequestAggregateStateTypes().Initial()
+	return
+}
+
+func (o *RoleGrantRequestAggregateInitialExecutor) Execute(cmd eventhorizon.Command, roleGrantRequest *RoleGrantRequest, store eh.AggregateStoreEvent) (err error) {
+	if o.CommandsPreparer != nil {
+		if err = o.CommandsPreparer(cmd, roleGrantRequest); err != nil {
+			return
+		}
+	}
+
+	switch cmd.CommandType() {
+	case CreateRoleGrantRequestCommand:
+		err = o.CreateHandler(cmd.(*CreateRoleGrantRequest), roleGrantRequest, store)
+	default:
+		err = errors.New(fmt.Sprintf("Not supported command type '%v' in state 'Initial' for entity '%v", cmd.CommandType(), roleGrantRequest))
+	}
+	return
+}
+
+func (o *RoleGrantRequestAggregateInitialExecutor) SetupCommandHandler() (err error) {
+	o.CreateHandler = func(command *CreateRoleGrantRequest, entity *RoleGrantRequest, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(RoleGrantRequestCreatedEvent, &RoleGrantRequestCreated{
+			AccountId:   command.AccountId,
+			Role:        command.Role,
+			Reason:      command.Reason,
+			ValidFrom:   command.ValidFrom,
+			ValidUntil:  command.ValidUntil,
+			RequestedBy: command.RequestedBy}, time.Now())
+		return
+	}
+	return
+}
+
+type RoleGrantRequestAggregatePendingExecutor struct {
+	CommandsPreparer func(eventhorizon.Command, *RoleGrantRequest) (err error)
+	ApproveHandler   func(*ApproveRoleGrantRequest, *RoleGrantRequest, eh.AggregateStoreEvent) (err error)
+	DeleteHandler    func(*DeleteRoleGrantRequest, *RoleGrantRequest, eh.AggregateStoreEvent) (err error)
+	RejectHandler    func(*RejectRoleGrantRequest, *RoleGrantRequest, eh.AggregateStoreEvent) (err error)
+	UpdateHandler    func(*UpdateRoleGrantRequest, *RoleGrantRequest, eh.AggregateStoreEvent) (err error)
+}
+
+func NewRoleGrantRequestAggregatePendingExecutorDefault() (ret *RoleGrantRequestAggregatePendingExecutor) {
+	ret = &RoleGrantRequestAggregatePendingExecutor{}
+	return
+}
+
+func (o *RoleGrantRequestAggregatePendingExecutor) AddCommandsPreparer(preparer func(eventhorizon.Command, *RoleGrantRequest) (err error)) {
+	prevHandler := o.CommandsPreparer
+	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *RoleGrantRequest) (err error) {
+		if err = preparer(cmd, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(cmd, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *RoleGrantRequestAggregatePendingExecutor) AddApprovePreparer(preparer func(*ApproveRoleGrantRequest, *RoleGrantRequest) (err error)) {
+	prevHandler := o.ApproveHandler
+	o.ApproveHandler = func(command *ApproveRoleGrantRequest, entity *RoleGrantRequest, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *RoleGrantRequestAggregatePendingExecutor) AddDeletePreparer(preparer func(*DeleteRoleGrantRequest, *RoleGrantRequest) (err error)) {
+	prevHandler := o.DeleteHandler
+	o.DeleteHandler = func(command *DeleteRoleGrantRequest, entity *RoleGrantRequest, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *RoleGrantRequestAggregatePendingExecutor) AddRejectPreparer(preparer func(*RejectRoleGrantRequest, *RoleGrantRequest) (err error)) {
+	prevHandler := o.RejectHandler
+	o.RejectHandler = func(command *RejectRoleGrantRequest, entity *RoleGrantRequest, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *RoleGrantRequestAggregatePendingExecutor) AddUpdatePreparer(preparer func(*UpdateRoleGrantRequest, *RoleGrantRequest) (err error)) {
+	prevHandler := o.UpdateHandler
+	o.UpdateHandler = func(command *UpdateRoleGrantRequest, entity *RoleGrantRequest, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *RoleGrantRequestAggregatePendingExecutor) StateType() (ret *RoleGrantRequestAggregateStateType) {
+	ret = RoleGrantRequestAggregateStateTypes().Pending()
+	return
+}
+
+func (o *RoleGrantRequestAggregatePendingExecutor) Execute(cmd eventhorizon.Command, roleGrantRequest *RoleGrantRequest, store eh.AggregateStoreEvent) (err error) {
+	if o.CommandsPreparer != nil {
+		if err = o.CommandsPreparer(cmd, roleGrantRequest); err != nil {
+			return
+		}
+	}
+
+	switch cmd.CommandType() {
+	case ApproveRoleGrantRequestCommand:
+		err = o.ApproveHandler(cmd.(*ApproveRoleGrantRequest), roleGrantRequest, store)
+	case DeleteRoleGrantRequestCommand:
+		err = o.DeleteHandler(cmd.(*DeleteRoleGrantRequest), roleGrantRequest, store)
+	case RejectRoleGrantRequestCommand:
+		err = o.RejectHandler(cmd.(*RejectRoleGrantRequest), roleGrantRequest, store)
+	case UpdateRoleGrantRequestCommand:
+		err = o.UpdateHandler(cmd.(*UpdateRoleGrantRequest), roleGrantRequest, store)
+	default:
+		err = errors.New(fmt.Sprintf("Not supported command type '%v' in state 'Pending' for entity '%v", cmd.CommandType(), roleGrantRequest))
+	}
+	return
+}
+
+func (o *RoleGrantRequestAggregatePendingExecutor) SetupCommandHandler() (err error) {
+	o.ApproveHandler = func(command *ApproveRoleGrantRequest, entity *RoleGrantRequest, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(RoleGrantRequestApprovedEvent, &RoleGrantRequestApproved{
+			DecidedBy:  command.DecidedBy,
+			Comment:    command.Comment,
+			AccountId:  command.AccountId,
+			Role:       command.Role,
+			ValidFrom:  command.ValidFrom,
+			ValidUntil: command.ValidUntil}, time.Now())
+		return
+	}
+	o.DeleteHandler = func(command *DeleteRoleGrantRequest, entity *RoleGrantRequest, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(RoleGrantRequestDeletedEvent, nil, time.Now())
+		return
+	}
+	o.RejectHandler = func(command *RejectRoleGrantRequest, entity *RoleGrantRequest, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(RoleGrantRequestRejectedEvent, &RoleGrantRequestRejected{
+			DecidedBy: command.DecidedBy,
+			Comment:   command.Comment}, time.Now())
+		return
+	}
+	o.UpdateHandler = func(command *UpdateRoleGrantRequest, entity *RoleGrantRequest, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(RoleGrantRequestUpdatedEvent, &RoleGrantRequestUpdated{
+			AccountId:   command.AccountId,
+			Role:        command.Role,
+			Reason:      command.Reason,
+			ValidFrom:   command.ValidFrom,
+			ValidUntil:  command.ValidUntil,
+			RequestedBy: command.RequestedBy}, time.Now())
+		return
+	}
+	return
+}
+
+type RoleGrantRequestAggregateApprovedExecutor struct {
+	CommandsPreparer func(eventhorizon.Command, *RoleGrantRequest) (err error)
+}
+
+func NewRoleGrantRequestAggregateApprovedExecutorDefault() (ret *RoleGrantRequestAggregateApprovedExecutor) {
+	ret = &RoleGrantRequestAggregateApprovedExecutor{}
+	return
+}
+
+func (o *RoleGrantRequestAggregateApprovedExecutor) AddCommandsPreparer(preparer func(eventhorizon.Command, *RoleGrantRequest) (err error)) {
+	prevHandler := o.CommandsPreparer
+	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *RoleGrantRequest) (err error) {
+		if err = preparer(cmd, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(cmd, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *RoleGrantRequestAggregateApprovedExecutor) StateType() (ret *RoleGrantRequestAggregateStateType) {
+	ret = RoleGrantRequestAggregateStateTypes().Approved()
+	return
+}
+
+func (o *RoleGrantRequestAggregateApprovedExecutor) Execute(cmd eventhorizon.Command, roleGrantRequest *RoleGrantRequest, store eh.AggregateStoreEvent) (err error) {
+	if o.CommandsPreparer != nil {
+		if err = o.CommandsPreparer(cmd, roleGrantRequest); err != nil {
+			return
+		}
+	}
+	err = errors.New(fmt.Sprintf("Not supported command type '%v' in state 'Approved' for entity '%v", cmd.CommandType(), roleGrantRequest))
+	return
+}
+
+func (o *RoleGrantRequestAggregateApprovedExecutor) SetupCommandHandler() (err error) {
+	return
+}
+
+type RoleGrantRequestAggregateRejectedExecutor struct {
+	CommandsPreparer func(eventhorizon.Command, *RoleGrantRequest) (err error)
+}
+
+func NewRoleGrantRequestAggregateRejectedExecutorDefault() (ret *RoleGrantRequestAggregateRejectedExecutor) {
+	ret = &RoleGrantRequestAggregateRejectedExecutor{}
+	return
+}
+
+func (o *RoleGrantRequestAggregateRejectedExecutor) AddCommandsPreparer(preparer func(eventhorizon.Command, *RoleGrantRequest) (err error)) {
+	prevHandler := o.CommandsPreparer
+	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *RoleGrantRequest) (err error) {
+		if err = preparer(cmd, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(cmd, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *RoleGrantRequestAggregateRejectedExecutor) StateType() (ret *RoleGrantRequestAggregateStateType) {
+	ret = RoleGrantRequestAggregateStateTypes().Rejected()
+	return
+}
+
+func (o *RoleGrantRequestAggregateRejectedExecutor) Execute(cmd eventhorizon.Command, roleGrantRequest *RoleGrantRequest, store eh.AggregateStoreEvent) (err error) {
+	if o.CommandsPreparer != nil {
+		if err = o.CommandsPreparer(cmd, roleGrantRequest); err != nil {
+			return
+		}
+	}
+	err = errors.New(fmt.Sprintf("Not supported command type '%v' in state 'Rejected' for entity '%v", cmd.CommandType(), roleGrantRequest))
+	return
+}
+
+func (o *RoleGrantRequestAggregateRejectedExecutor) SetupCommandHandler() (err error) {
+	return
+}
+
+type RoleGrantRequestAggregateDeletedExecutor struct {
+	CommandsPreparer func(eventhorizon.Command, *RoleGrantRequest) (err error)
+}
+
+func NewRoleGrantRequestAggregateDeletedExecutorDefault() (ret *RoleGrantRequestAggregateDeletedExecutor) {
+	ret = &RoleGrantRequestAggregateDeletedExecutor{}
+	return
+}
+
+func (o *RoleGrantRequestAggregateDeletedExecutor) AddCommandsPreparer(preparer func(eventhorizon.Command, *RoleGrantRequest) (err error)) {
+	prevHandler := o.CommandsPreparer
+	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *RoleGrantRequest) (err error) {
+		if err = preparer(cmd, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(cmd, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *RoleGrantRequestAggregateDeletedExecutor) StateType() (ret *RoleGrantRequestAggregateStateType) {
+	ret = RoleGrantRequestAggregateStateTypes().Deleted()
+	return
+}
+
+func (o *RoleGrantRequestAggregateDeletedExecutor) Execute(cmd eventhorizon.Command, roleGrantRequest *RoleGrantRequest, store eh.AggregateStoreEvent) (err error) {
+	if o.CommandsPreparer != nil {
+		if err = o.CommandsPreparer(cmd, roleGrantRequest); err != nil {
+			return
+		}
+	}
+	err = errors.New(fmt.Sprintf("Not supported command type '%v' in state 'Deleted' for entity '%v", cmd.CommandType(), roleGrantRequest))
+	return
+}
+
+func (o *RoleGrantRequestAggregateDeletedExecutor) SetupCommandHandler() (err error) {
+	return
+}
+
+type RoleAggregateInitialExecutor struct {
+	CommandsPreparer func(eventhorizon.Command, *Role) (err error)
+	CreateHandler    func(*CreateRole, *Role, eh.AggregateStoreEvent) (err error)
+}
+
+func NewRoleAggregateInitialExecutorDefault() (ret *RoleAggregateInitialExecutor) {
+	ret = &RoleAggregateInitialExecutor{}
+	return
+}
+
+func (o *RoleAggregateInitialExecutor) AddCommandsPreparer(preparer func(eventhorizon.Command, *Role) (err error)) {
+	prevHandler := o.CommandsPreparer
+	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *Role) (err error) {
+		if err = preparer(cmd, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(cmd, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *RoleAggregateInitialExecutor) AddCreatePreparer(preparer func(*CreateRole, *Role) (err error)) {
+	prevHandler := o.CreateHandler
+	o.CreateHandler = func(command *CreateRole, entity *Role, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *RoleAggregateInitialExecutor) StateType() (ret *RoleAggregateStateType) {
+	ret = RoleAggregateStateTypes().Initial()
+	return
+}
+
+func (o *RoleAggregateInitialExecutor) Execute(cmd eventhorizon.Command, role *Role, store eh.AggregateStoreEvent) (err error) {
+	if o.CommandsPreparer != nil {
+		if err = o.CommandsPreparer(cmd, role); err != nil {
+			return
+		}
+	}
+
+	switch cmd.CommandType() {
+	case CreateRoleCommand:
+		err = o.CreateHandler(cmd.(*CreateRole), role, store)
+	default:
+		err = errors.New(fmt.Sprintf("Not supported command type '%v' in state 'Initial' for entity '%v", cmd.CommandType(), role))
+	}
+	return
+}
+
+func (o *RoleAggregateInitialExecutor) SetupCommandHandler() (err error) {
+	o.CreateHandler = func(command *CreateRole, entity *Role, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(RoleCreatedEvent, &RoleCreated{
+			Name:        command.Name,
+			Description: command.Description,
+			Inherits:    command.Inherits,
+			Permissions: command.Permissions}, time.Now())
+		return
+	}
+	return
+}
+
+type RoleAggregateExistExecutor struct {
+	CommandsPreparer func(eventhorizon.Command, *Role) (err error)
+	DeleteHandler    func(*DeleteRole, *Role, eh.AggregateStoreEvent) (err error)
+	UpdateHandler    func(*UpdateRole, *Role, eh.AggregateStoreEvent) (err error)
+}
+
+func NewRoleAggregateExistExecutorDefault() (ret *RoleAggregateExistExecutor) {
+	ret = &RoleAggregateExistExecutor{}
+	return
+}
+
+func (o *RoleAggregateExistExecutor) AddCommandsPreparer(preparer func(eventhorizon.Command, *Role) (err error)) {
+	prevHandler := o.CommandsPreparer
+	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *Role) (err error) {
+		if err = preparer(cmd, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(cmd, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *RoleAggregateExistExecutor) AddDeletePreparer(preparer func(*DeleteRole, *Role) (err error)) {
+	prevHandler := o.DeleteHandler
+	o.DeleteHandler = func(command *DeleteRole, entity *Role, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *RoleAggregateExistExecutor) AddUpdatePreparer(preparer func(*UpdateRole, *Role) (err error)) {
+	prevHandler := o.UpdateHandler
+	o.UpdateHandler = func(command *UpdateRole, entity *Role, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *RoleAggregateExistExecutor) StateType() (ret *RoleAggregateStateType) {
+	ret = RoleAggregateStateTypes().Exist()
+	return
+}
+
+func (o *RoleAggregateExistExecutor) Execute(cmd eventhorizon.Command, role *Role, store eh.AggregateStoreEvent) (err error) {
+	if o.CommandsPreparer != nil {
+		if err = o.CommandsPreparer(cmd, role); err != nil {
+			return
+		}
+	}
+
+	switch cmd.CommandType() {
+	case DeleteRoleCommand:
+		err = o.DeleteHandler(cmd.(*DeleteRole), role, store)
+	case UpdateRoleCommand:
+		err = o.UpdateHandler(cmd.(*UpdateRole), role, store)
+	default:
+		err = errors.New(fmt.Sprintf("Not supported command type '%v' in state 'Exist' for entity '%v", cmd.CommandType(), role))
+	}
+	return
+}
+
+func (o *RoleAggregateExistExecutor) SetupCommandHandler() (err error) {
+	o.DeleteHandler = func(command *DeleteRole, entity *Role, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(RoleDeletedEvent, nil, time.Now())
+		return
+	}
+	o.UpdateHandler = func(command *UpdateRole, entity *Role, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(RoleUpdatedEvent, &RoleUpdated{
+			Name:        command.Name,
+			Description: command.Description,
+			Inherits:    command.Inherits,
+			Permissions: command.Permissions}, time.Now())
+		return
+	}
+	return
+}
+
+type RoleAggregateDeletedExecutor struct {
+	CommandsPreparer func(eventhorizon.Command, *Role) (err error)
+}
+
+func NewRoleAggregateDeletedExecutorDefault() (ret *RoleAggregateDeletedExecutor) {
+	ret = &RoleAggregateDeletedExecutor{}
+	return
+}
+
+func (o *RoleAggregateDeletedExecutor) AddCommandsPreparer(preparer func(eventhorizon.Command, *Role) (err error)) {
+	prevHandler := o.CommandsPreparer
+	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *Role) (err error) {
+		if err = preparer(cmd, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(cmd, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *RoleAggregateDeletedExecutor) StateType() (ret *RoleAggregateStateType) {
+	ret = RoleAggregateStateTypes().Deleted()
+	return
+}
+
+func (o *RoleAggregateDeletedExecutor) Execute(cmd eventhorizon.Command, role *Role, store eh.AggregateStoreEvent) (err error) {
+	if o.CommandsPreparer != nil {
+		if err = o.CommandsPreparer(cmd, role); err != nil {
+			return
+		}
+	}
+	err = errors.New(fmt.Sprintf("Not supported command type '%v' in state 'Deleted' for entity '%v", cmd.CommandType(), role))
+	return
+}
+
+func (o *RoleAggregateDeletedExecutor) SetupCommandHandler() (err error) {
+	return
+}
+
+type RelationTupleAggregateInitialExecutor struct {
+	CommandsPreparer func(eventhorizon.Command, *RelationTuple) (err error)
+	CreateHandler    func(*CreateRelationTuple, *RelationTuple, eh.AggregateStoreEvent) (err error)
+}
+
+func NewRelationTupleAggregateInitialExecutorDefault() (ret *RelationTupleAggregateInitialExecutor) {
+	ret = &RelationTupleAggregateInitialExecutor{}
+	return
+}
+
+func (o *RelationTupleAggregateInitialExecutor) AddCommandsPreparer(preparer func(eventhorizon.Command, *RelationTuple) (err error)) {
+	prevHandler := o.CommandsPreparer
+	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *RelationTuple) (err error) {
+		if err = preparer(cmd, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(cmd, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *RelationTupleAggregateInitialExecutor) AddCreatePreparer(preparer func(*CreateRelationTuple, *RelationTuple) (err error)) {
+	prevHandler := o.CreateHandler
+	o.CreateHandler = func(command *CreateRelationTuple, entity *RelationTuple, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *RelationTupleAggregateInitialExecutor) StateType() (ret *RelationTupleAggregateStateType) {
+	ret = RelationTupleAggregateStateTypes().Initial()
+	return
+}
+
+func (o *RelationTupleAggregateInitialExecutor) Execute(cmd eventhorizon.Command, relationTuple *RelationTuple, store eh.AggregateStoreEvent) (err error) {
+	if o.CommandsPreparer != nil {
+		if err = o.CommandsPreparer(cmd, relationTuple); err != nil {
+			return
+		}
+	}
+
+	switch cmd.CommandType() {
+	case CreateRelationTupleCommand:
+		err = o.CreateHandler(cmd.(*CreateRelationTuple), relationTuple, store)
+	default:
+		err = errors.New(fmt.Sprintf("Not supported command type '%v' in state 'Initial' for entity '%v", cmd.CommandType(), relationTuple))
+	}
+	return
+}
+
+func (o *RelationTupleAggregateInitialExecutor) SetupCommandHandler() (err error) {
+	o.CreateHandler = func(command *CreateRelationTuple, entity *RelationTuple, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(RelationTupleCreatedEvent, &RelationTupleCreated{
+			Namespace: command.Namespace,
+			Object:    command.Object,
+			Relation:  command.Relation,
+			Subject:   command.Subject}, time.Now())
+		return
+	}
+	return
+}
+
+type RelationTupleAggregateExistExecutor struct {
+	CommandsPreparer func(eventhorizon.Command, *RelationTuple) (err error)
+	DeleteHandler    func(*DeleteRelationTuple, *RelationTuple, eh.AggregateStoreEvent) (err error)
+}
+
+func NewRelationTupleAggregateExistExecutorDefault() (ret *RelationTupleAggregateExistExecutor) {
+	ret = &RelationTupleAggregateExistExecutor{}
+	return
+}
+
+func (o *RelationTupleAggregateExistExecutor) AddCommandsPreparer(preparer func(eventhorizon.Command, *RelationTuple) (err error)) {
+	prevHandler := o.CommandsPreparer
+	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *RelationTuple) (err error) {
+		if err = preparer(cmd, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(cmd, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *RelationTupleAggregateExistExecutor) AddDeletePreparer(preparer func(*DeleteRelationTuple, *RelationTuple) (err error)) {
+	prevHandler := o.DeleteHandler
+	o.DeleteHandler = func(command *DeleteRelationTuple, entity *RelationTuple, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *RelationTupleAggregateExistExecutor) StateType() (ret *RelationTupleAggregateStateType) {
+	ret = RelationTupleAggregateStateTypes().Exist()
+	return
+}
+
+func (o *RelationTupleAggregateExistExecutor) Execute(cmd eventhorizon.Command, relationTuple *RelationTuple, store eh.AggregateStoreEvent) (err error) {
+	if o.CommandsPreparer != nil {
+		if err = o.CommandsPreparer(cmd, relationTuple); err != nil {
+			return
+		}
+	}
+
+	switch cmd.CommandType() {
+	case DeleteRelationTupleCommand:
+		err = o.DeleteHandler(cmd.(*DeleteRelationTuple), relationTuple, store)
+	default:
+		err = errors.New(fmt.Sprintf("Not supported command type '%v' in state 'Exist' for entity '%v", cmd.CommandType(), relationTuple))
+	}
+	return
+}
+
+func (o *RelationTupleAggregateExistExecutor) SetupCommandHandler() (err error) {
+	o.DeleteHandler = func(command *DeleteRelationTuple, entity *RelationTuple, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(RelationTupleDeletedEvent, nil, time.Now())
+		return
+	}
+	return
+}
+
+type RelationTupleAggregateDeletedExecutor struct {
+	CommandsPreparer func(eventhorizon.Command, *RelationTuple) (err error)
+}
+
+func NewRelationTupleAggregateDeletedExecutorDefault() (ret *RelationTupleAggregateDeletedExecutor) {
+	ret = &RelationTupleAggregateDeletedExecutor{}
+	return
+}
+
+func (o *RelationTupleAggregateDeletedExecutor) AddCommandsPreparer(preparer func(eventhorizon.Command, *RelationTuple) (err error)) {
+	prevHandler := o.CommandsPreparer
+	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *RelationTuple) (err error) {
+		if err = preparer(cmd, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(cmd, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *RelationTupleAggregateDeletedExecutor) StateType() (ret *RelationTupleAggregateStateType) {
+	ret = RelationTupleAggregateStateTypes().Deleted()
+	return
+}
+
+func (o *RelationTupleAggregateDeletedExecutor) Execute(cmd eventhorizon.Command, relationTuple *RelationTuple, store eh.AggregateStoreEvent) (err error) {
+	if o.CommandsPreparer != nil {
+		if err = o.CommandsPreparer(cmd, relationTuple); err != nil {
+			return
+		}
+	}
+	err = errors.New(fmt.Sprintf("Not supported command type '%v' in state 'Deleted' for entity '%v", cmd.CommandType(), relationTuple))
+	return
+}
+
+func (o *RelationTupleAggregateDeletedExecutor) SetupCommandHandler() (err error) {
+	return
+}
+
+type InvitationAggregateInitialExecutor struct {
+	CommandsPreparer func(eventhorizon.Command, *Invitation) (err error)
+	CreateHandler    func(*CreateInvitation, *Invitation, eh.AggregateStoreEvent) (err error)
+}
+
+func NewInvitationAggregateInitialExecutorDefault() (ret *InvitationAggregateInitialExecutor) {
+	ret = &InvitationAggregateInitialExecutor{}
+	return
+}
+
+func (o *InvitationAggregateInitialExecutor) AddCommandsPreparer(preparer func(eventhorizon.Command, *Invitation) (err error)) {
+	prevHandler := o.CommandsPreparer
+	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *Invitation) (err error) {
+		if err = preparer(cmd, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(cmd, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *InvitationAggregateInitialExecutor) AddCreatePreparer(preparer func(*CreateInvitation, *Invitation) (err error)) {
+	prevHandler := o.CreateHandler
+	o.CreateHandler = func(command *CreateInvitation, entity *Invitation, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *InvitationAggregateInitialExecutor) StateType() (ret *InvitationAggregateStateType) {
+	ret = InvitationAggregateStateTypes().Initial()
+	return
+}
+
+func (o *InvitationAggregateInitialExecutor) Execute(cmd eventhorizon.Command, invitation *Invitation, store eh.AggregateStoreEvent) (err error) {
+	if o.CommandsPreparer != nil {
+		if err = o.CommandsPreparer(cmd, invitation); err != nil {
+			return
+		}
+	}
+
+	switch cmd.CommandType() {
+	case CreateInvitationCommand:
+		err = o.CreateHandler(cmd.(*CreateInvitation), invitation, store)
+	default:
+		err = errors.New(fmt.Sprintf("Not supported command type '%v' in state 'Initial' for entity '%v", cmd.CommandType(), invitation))
+	}
+	return
+}
+
+func (o *InvitationAggregateInitialExecutor) SetupCommandHandler() (err error) {
+	o.CreateHandler = func(command *CreateInvitation, entity *Invitation, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(InvitationCreatedEvent, &InvitationCreated{
+			Email:          command.Email,
+			Roles:          command.Roles,
+			OrganizationId: command.OrganizationId,
+			TokenHash:      command.TokenHash,
+			ExpiresAt:      command.ExpiresAt,
+			InvitedBy:      command.InvitedBy}, time.Now())
+		return
+	}
+	return
+}
+
+type InvitationAggregatePendingExecutor struct {
+	CommandsPreparer func(eventhorizon.Command, *Invitation) (err error)
+	AcceptHandler    func(*AcceptInvitation, *Invitation, eh.AggregateStoreEvent) (err error)
+	DeleteHandler    func(*DeleteInvitation, *Invitation, eh.AggregateStoreEvent) (err error)
+	ResendHandler    func(*ResendInvitation, *Invitation, eh.AggregateStoreEvent) (err error)
+	RevokeHandler    func(*RevokeInvitation, *Invitation, eh.AggregateStoreEvent) (err error)
+}
+
+func NewInvitationAggregatePendingExecutorDefault() (ret *InvitationAggregatePendingExecutor) {
+	ret = &InvitationAggregatePendingExecutor{}
+	return
+}
+
+func (o *InvitationAggregatePendingExecutor) AddCommandsPreparer(preparer func(eventhorizon.Command, *Invitation) (err error)) {
+	prevHandler := o.CommandsPreparer
+	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *Invitation) (err error) {
+		if err = preparer(cmd, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(cmd, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *InvitationAggregatePendingExecutor) AddAcceptPreparer(preparer func(*AcceptInvitation, *Invitation) (err error)) {
+	prevHandler := o.AcceptHandler
+	o.AcceptHandler = func(command *AcceptInvitation, entity *Invitation, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *InvitationAggregatePendingExecutor) AddDeletePreparer(preparer func(*DeleteInvitation, *Invitation) (err error)) {
+	prevHandler := o.DeleteHandler
+	o.DeleteHandler = func(command *DeleteInvitation, entity *Invitation, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *InvitationAggregatePendingExecutor) AddResendPreparer(preparer func(*ResendInvitation, *Invitation) (err error)) {
+	prevHandler := o.ResendHandler
+	o.ResendHandler = func(command *ResendInvitation, entity *Invitation, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *InvitationAggregatePendingExecutor) AddRevokePreparer(preparer func(*RevokeInvitation, *Invitation) (err error)) {
+	prevHandler := o.RevokeHandler
+	o.RevokeHandler = func(command *RevokeInvitation, entity *Invitation, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *InvitationAggregatePendingExecutor) StateType() (ret *InvitationAggregateStateType) {
+	ret = InvitationAggregateStateTypes().Pending()
+	return
+}
+
+func (o *InvitationAggregatePendingExecutor) Execute(cmd eventhorizon.Command, invitation *Invitation, store eh.AggregateStoreEvent) (err error) {
+	if o.CommandsPreparer != nil {
+		if err = o.CommandsPreparer(cmd, invitation); err != nil {
+			return
+		}
+	}
+
+	switch cmd.CommandType() {
+	case AcceptInvitationCommand:
+		err = o.AcceptHandler(cmd.(*AcceptInvitation), invitation, store)
+	case DeleteInvitationCommand:
+		err = o.DeleteHandler(cmd.(*DeleteInvitation), invitation, store)
+	case ResendInvitationCommand:
+		err = o.ResendHandler(cmd.(*ResendInvitation), invitation, store)
+	case RevokeInvitationCommand:
+		err = o.RevokeHandler(cmd.(*RevokeInvitation), invitation, store)
+	default:
+		err = errors.New(fmt.Sprintf("Not supported command type '%v' in state 'Pending' for entity '%v", cmd.CommandType(), invitation))
+	}
+	return
+}
+
+func (o *InvitationAggregatePendingExecutor) SetupCommandHandler() (err error) {
+	o.AcceptHandler = func(command *AcceptInvitation, entity *Invitation, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(InvitationAcceptedEvent, &InvitationAccepted{
+			AccountId: command.AccountId}, time.Now())
+		return
+	}
+	o.DeleteHandler = func(command *DeleteInvitation, entity *Invitation, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(InvitationDeletedEvent, nil, time.Now())
+		return
+	}
+	o.ResendHandler = func(command *ResendInvitation, entity *Invitation, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(InvitationResentEvent, &InvitationResent{
+			TokenHash: command.TokenHash,
+			ExpiresAt: command.ExpiresAt}, time.Now())
+		return
+	}
+	o.RevokeHandler = func(command *RevokeInvitation, entity *Invitation, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(InvitationRevokedEvent, nil, time.Now())
+		return
+	}
+	return
+}
+
+type InvitationAggregateAcceptedExecutor struct {
+	CommandsPreparer func(eventhorizon.Command, *Invitation) (err error)
+	DeleteHandler    func(*DeleteInvitation, *Invitation, eh.AggregateStoreEvent) (err error)
+}
+
+func NewInvitationAggregateAcceptedExecutorDefault() (ret *InvitationAggregateAcceptedExecutor) {
+	ret = &InvitationAggregateAcceptedExecutor{}
+	return
+}
+
+func (o *InvitationAggregateAcceptedExecutor) AddCommandsPreparer(preparer func(eventhorizon.Command, *Invitation) (err error)) {
+	prevHandler := o.CommandsPreparer
+	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *Invitation) (err error) {
+		if err = preparer(cmd, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(cmd, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *InvitationAggregateAcceptedExecutor) AddDeletePreparer(preparer func(*DeleteInvitation, *Invitation) (err error)) {
+	prevHandler := o.DeleteHandler
+	o.DeleteHandler = func(command *DeleteInvitation, entity *Invitation, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *InvitationAggregateAcceptedExecutor) StateType() (ret *InvitationAggregateStateType) {
+	ret = InvitationAggregateStateTypes().Accepted()
+	return
+}
+
+func (o *InvitationAggregateAcceptedExecutor) Execute(cmd eventhorizon.Command, invitation *Invitation, store eh.AggregateStoreEvent) (err error) {
+	if o.CommandsPreparer != nil {
+		if err = o.CommandsPreparer(cmd, invitation); err != nil {
+			return
+		}
+	}
+
+	switch cmd.CommandType() {
+	case DeleteInvitationCommand:
+		err = o.DeleteHandler(cmd.(*DeleteInvitation), invitation, store)
+	default:
+		err = errors.New(fmt.Sprintf("Not supported command type '%v' in state 'Accepted' for entity '%v", cmd.CommandType(), invitation))
+	}
+	return
+}
+
+func (o *InvitationAggregateAcceptedExecutor) SetupCommandHandler() (err error) {
+	o.DeleteHandler = func(command *DeleteInvitation, entity *Invitation, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(InvitationDeletedEvent, nil, time.Now())
+		return
+	}
+	return
+}
+
+type InvitationAggregateRevokedExecutor struct {
+	CommandsPreparer func(eventhorizon.Command, *Invitation) (err error)
+	DeleteHandler    func(*DeleteInvitation, *Invitation, eh.AggregateStoreEvent) (err error)
+}
+
+func NewInvitationAggregateRevokedExecutorDefault() (ret *InvitationAggregateRevokedExecutor) {
+	ret = &InvitationAggregateRevokedExecutor{}
+	return
+}
+
+func (o *InvitationAggregateRevokedExecutor) AddCommandsPreparer(preparer func(eventhorizon.Command, *Invitation) (err error)) {
+	prevHandler := o.CommandsPreparer
+	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *Invitation) (err error) {
+		if err = preparer(cmd, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(cmd, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *InvitationAggregateRevokedExecutor) AddDeletePreparer(preparer func(*DeleteInvitation, *Invitation) (err error)) {
+	prevHandler := o.DeleteHandler
+	o.DeleteHandler = func(command *DeleteInvitation, entity *Invitation, store eh.AggregateStoreEvent) (err error) {
+		if err = preparer(command, entity); err == nil {
+			err = prevHandler(command, entity, store)
+		}
+		return
+	}
+}
+
+func (o *InvitationAggregateRevokedExecutor) StateType() (ret *InvitationAggregateStateType) {
+	ret = InvitationAggregateStateTypes().Revoked()
+	return
+}
+
+func (o *InvitationAggregateRevokedExecutor) Execute(cmd eventhorizon.Command, invitation *Invitation, store eh.AggregateStoreEvent) (err error) {
+	if o.CommandsPreparer != nil {
+		if err = o.CommandsPreparer(cmd, invitation); err != nil {
+			return
+		}
+	}
+
+	switch cmd.CommandType() {
+	case DeleteInvitationCommand:
+		err = o.DeleteHandler(cmd.(*DeleteInvitation), invitation, store)
+	default:
+		err = errors.New(fmt.Sprintf("Not supported command type '%v' in state 'Revoked' for entity '%v", cmd.CommandType(), invitation))
+	}
+	return
+}
+
+func (o *InvitationAggregateRevokedExecutor) SetupCommandHandler() (err error) {
+	o.DeleteHandler = func(command *DeleteInvitation, entity *Invitation, store eh.AggregateStoreEvent) (err error) {
+		store.AppendEvent(InvitationDeletedEvent, nil, time.Now())
+		return
+	}
+	return
+}
+
+type InvitationAggregateDeletedExecutor struct {
+	CommandsPreparer func(eventhorizon.Command, *Invitation) (err error)
+}
+
+func NewInvitationAggregateDeletedExecutorDefault() (ret *InvitationAggregateDeletedExecutor) {
+	ret = &InvitationAggregateDeletedExecutor{}
+	return
+}
+
+func (o *InvitationAggregateDeletedExecutor) AddCommandsPreparer(preparer func(eventhorizon.Command, *Invitation) (err error)) {
+	prevHandler := o.CommandsPreparer
+	o.CommandsPreparer = func(cmd eventhorizon.Command, entity *Invitation) (err error) {
+		if err = preparer(cmd, entity); err == nil {
+			if prevHandler != nil {
+				err = prevHandler(cmd, entity)
+			}
+		}
+		return
+	}
+}
+
+func (o *InvitationAggregateDeletedExecutor) StateType() (ret *InvitationAggregateStateType) {
+	ret = InvitationAggregateStateTypes().Deleted()
+	return
+}
+
+func (o *InvitationAggregateDeletedExecutor) Execute(cmd eventhorizon.Command, invitation *Invitation, store eh.AggregateStoreEvent) (err error) {
+	if o.CommandsPreparer != nil {
+		if err = o.CommandsPreparer(cmd, invitation); err != nil {
+			return
+		}
+	}
+	err = errors.New(fmt.Sprintf("Not supported command type '%v' in state 'Deleted' for entity '%v", cmd.CommandType(), invitation))
+	return
+}
+
+func (o *InvitationAggregateDeletedExecutor) SetupCommandHandler() (err error) {
 	return
 }
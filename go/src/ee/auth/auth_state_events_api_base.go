@@ -7,7 +7,8 @@ import (
 )
 
 type AccountAggregateInitialHandler struct {
-	CreatedHandler func(eventhorizon.Event, *AccountCreated, *Account) (err error)
+	CreatedHandler    func(eventhorizon.Event, *AccountCreated, *Account) (err error)
+	RegisteredHandler func(eventhorizon.Event, *AccountRegistered, *Account) (err error)
 }
 
 func NewAccountAggregateInitialHandlerDefault() (ret *AccountAggregateInitialHandler) {
@@ -30,6 +31,13 @@ func (o *AccountAggregateInitialHandler) Apply(event eventhorizon.Event, account
 		} else if !(account.Disabled) {
 			ret = AccountAggregateStateTypes().Enabled()
 		}
+	case AccountRegisteredEvent:
+		err = o.RegisteredHandler(event, event.Data().(*AccountRegistered), account)
+		if account.PendingApproval {
+			ret = AccountAggregateStateTypes().PendingApproval()
+		} else if !(account.PendingApproval) {
+			ret = AccountAggregateStateTypes().Enabled()
+		}
 	default:
 		err = errors.New(fmt.Sprintf("Not supported event type '%v' for entity '%v", event.EventType(), account))
 	}
@@ -52,12 +60,86 @@ func (o *AccountAggregateInitialHandler) SetupEventHandler() (err error) {
 		entity.Password = eventData.Password
 		entity.Email = eventData.Email
 		entity.Roles = eventData.Roles
+		entity.OrganizationId = eventData.OrganizationId
+		entity.Service = eventData.Service
+		entity.BreakGlass = eventData.BreakGlass
+		entity.Attributes = eventData.Attributes
+		return
+	}
+
+	//register event object factory
+	eventhorizon.RegisterEventData(AccountRegisteredEvent, func() eventhorizon.EventData {
+		return &AccountRegistered{}
+	})
+
+	//default handler implementation
+	o.RegisteredHandler = func(event eventhorizon.Event, eventData *AccountRegistered, entity *Account) (err error) {
+
+		return
+	}
+	return
+}
+
+type AccountAggregatePendingApprovalHandler struct {
+	RegistrationApprovedHandler func(eventhorizon.Event, *AccountRegistrationApproved, *Account) (err error)
+	RegistrationRejectedHandler func(eventhorizon.Event, *AccountRegistrationRejected, *Account) (err error)
+}
+
+func NewAccountAggregatePendingApprovalHandlerDefault() (ret *AccountAggregatePendingApprovalHandler) {
+	ret = &AccountAggregatePendingApprovalHandler{}
+	return
+}
+
+func (o *AccountAggregatePendingApprovalHandler) StateType() (ret *AccountAggregateStateType) {
+	ret = AccountAggregateStateTypes().PendingApproval()
+	return
+}
+
+func (o *AccountAggregatePendingApprovalHandler) Apply(event eventhorizon.Event, account *Account) (ret *AccountAggregateStateType, err error) {
+
+	switch event.EventType() {
+	case AccountRegistrationApprovedEvent:
+		err = o.RegistrationApprovedHandler(event, event.Data().(*AccountRegistrationApproved), account)
+		ret = AccountAggregateStateTypes().Enabled()
+	case AccountRegistrationRejectedEvent:
+		err = o.RegistrationRejectedHandler(event, event.Data().(*AccountRegistrationRejected), account)
+		ret = AccountAggregateStateTypes().Purged()
+	default:
+		err = errors.New(fmt.Sprintf("Not supported event type '%v' for entity '%v", event.EventType(), account))
+	}
+	return
+}
+
+func (o *AccountAggregatePendingApprovalHandler) SetupEventHandler() (err error) {
+
+	//register event object factory
+	eventhorizon.RegisterEventData(AccountRegistrationApprovedEvent, func() eventhorizon.EventData {
+		return &AccountRegistrationApproved{}
+	})
+
+	//default handler implementation
+	o.RegistrationApprovedHandler = func(event eventhorizon.Event, eventData *AccountRegistrationApproved, entity *Account) (err error) {
+
+		return
+	}
+
+	//register event object factory
+	eventhorizon.RegisterEventData(AccountRegistrationRejectedEvent, func() eventhorizon.EventData {
+		return &AccountRegistrationRejected{}
+	})
+
+	//default handler implementation
+	o.RegistrationRejectedHandler = func(event eventhorizon.Event, eventData *AccountRegistrationRejected, entity *Account) (err error) {
+
 		return
 	}
 	return
 }
 
 type AccountAggregateDeletedHandler struct {
+	ForgottenHandler func(eventhorizon.Event, *Account) (err error)
+	PurgedHandler    func(eventhorizon.Event, *Account) (err error)
+	RestoredHandler  func(eventhorizon.Event, *Account) (err error)
 }
 
 func NewAccountAggregateDeletedHandlerDefault() (ret *AccountAggregateDeletedHandler) {
@@ -72,15 +154,116 @@ func (o *AccountAggregateDeletedHandler) StateType() (ret *AccountAggregateState
 
 func (o *AccountAggregateDeletedHandler) Apply(event eventhorizon.Event, account *Account) (ret *AccountAggregateStateType, err error) {
 
+	switch event.EventType() {
+	case AccountForgottenEvent:
+		err = o.ForgottenHandler(event, account)
+		ret = AccountAggregateStateTypes().Deleted()
+	case AccountPurgedEvent:
+		err = o.PurgedHandler(event, account)
+		ret = AccountAggregateStateTypes().Purged()
+	case AccountRestoredEvent:
+		err = o.RestoredHandler(event, account)
+		ret = AccountAggregateStateTypes().Disabled()
+	default:
+		err = errors.New(fmt.Sprintf("Not supported event type '%v' for entity '%v", event.EventType(), account))
+	}
 	return
 }
 
 func (o *AccountAggregateDeletedHandler) SetupEventHandler() (err error) {
+
+	//default handler implementation
+	o.ForgottenHandler = func(event eventhorizon.Event, entity *Account) (err error) {
+
+		return
+	}
+
+	//default handler implementation
+	o.PurgedHandler = func(event eventhorizon.Event, entity *Account) (err error) {
+
+		return
+	}
+
+	//default handler implementation
+	o.RestoredHandler = func(event eventhorizon.Event, entity *Account) (err error) {
+
+		return
+	}
+	return
+}
+
+type AccountAggregatePurgedHandler struct {
+}
+
+func NewAccountAggregatePurgedHandlerDefault() (ret *AccountAggregatePurgedHandler) {
+	ret = &AccountAggregatePurgedHandler{}
+	return
+}
+
+func (o *AccountAggregatePurgedHandler) StateType() (ret *AccountAggregateStateType) {
+	ret = AccountAggregateStateTypes().Purged()
+	return
+}
+
+func (o *AccountAggregatePurgedHandler) Apply(event eventhorizon.Event, account *Account) (ret *AccountAggregateStateType, err error) {
+
+	return
+}
+
+func (o *AccountAggregatePurgedHandler) SetupEventHandler() (err error) {
+	return
+}
+
+type AccountAggregateMergedHandler struct {
+	ForgottenHandler func(eventhorizon.Event, *Account) (err error)
+}
+
+func NewAccountAggregateMergedHandlerDefault() (ret *AccountAggregateMergedHandler) {
+	ret = &AccountAggregateMergedHandler{}
+	return
+}
+
+func (o *AccountAggregateMergedHandler) StateType() (ret *AccountAggregateStateType) {
+	ret = AccountAggregateStateTypes().Merged()
+	return
+}
+
+func (o *AccountAggregateMergedHandler) Apply(event eventhorizon.Event, account *Account) (ret *AccountAggregateStateType, err error) {
+
+	switch event.EventType() {
+	case AccountForgottenEvent:
+		err = o.ForgottenHandler(event, account)
+		ret = AccountAggregateStateTypes().Merged()
+	default:
+		err = errors.New(fmt.Sprintf("Not supported event type '%v' for entity '%v", event.EventType(), account))
+	}
+	return
+}
+
+func (o *AccountAggregateMergedHandler) SetupEventHandler() (err error) {
+
+	//default handler implementation
+	o.ForgottenHandler = func(event eventhorizon.Event, entity *Account) (err error) {
+
+		return
+	}
 	return
 }
 
 type AccountAggregateDisabledHandler struct {
-	EnabledHandler func(eventhorizon.Event, *Account) (err error)
+	AttributesChangedHandler  func(eventhorizon.Event, *AccountAttributesChanged, *Account) (err error)
+	EnabledHandler            func(eventhorizon.Event, *Account) (err error)
+	ForgottenHandler          func(eventhorizon.Event, *Account) (err error)
+	ImpersonationEndedHandler func(eventhorizon.Event, *ImpersonationEnded, *Account) (err error)
+	LinkedIdentityHandler     func(eventhorizon.Event, *AccountLinkedIdentity, *Account) (err error)
+	MergedHandler             func(eventhorizon.Event, *AccountMerged, *Account) (err error)
+	MergedIntoHandler         func(eventhorizon.Event, *AccountMergedInto, *Account) (err error)
+	RevokedApiKeyHandler      func(eventhorizon.Event, *AccountRevokedApiKey, *Account) (err error)
+	RevokedSessionHandler     func(eventhorizon.Event, *AccountRevokedSession, *Account) (err error)
+	RevokedSessionsHandler    func(eventhorizon.Event, *Account) (err error)
+	RoleExpiredHandler        func(eventhorizon.Event, *AccountRoleExpired, *Account) (err error)
+	UnassignedRoleHandler     func(eventhorizon.Event, *AccountUnassignedRole, *Account) (err error)
+	UnlinkedIdentityHandler   func(eventhorizon.Event, *AccountUnlinkedIdentity, *Account) (err error)
 }
 
 func NewAccountAggregateDisabledHandlerDefault() (ret *AccountAggregateDisabledHandler) {
@@ -96,9 +279,45 @@ func (o *AccountAggregateDisabledHandler) StateType() (ret *AccountAggregateStat
 func (o *AccountAggregateDisabledHandler) Apply(event eventhorizon.Event, account *Account) (ret *AccountAggregateStateType, err error) {
 
 	switch event.EventType() {
+	case AccountAttributesChangedEvent:
+		err = o.AttributesChangedHandler(event, event.Data().(*AccountAttributesChanged), account)
+		ret = AccountAggregateStateTypes().Disabled()
 	case AccountEnabledEvent:
 		err = o.EnabledHandler(event, account)
 		ret = AccountAggregateStateTypes().Enabled()
+	case AccountForgottenEvent:
+		err = o.ForgottenHandler(event, account)
+		ret = AccountAggregateStateTypes().Deleted()
+	case ImpersonationEndedEvent:
+		err = o.ImpersonationEndedHandler(event, event.Data().(*ImpersonationEnded), account)
+		ret = AccountAggregateStateTypes().Disabled()
+	case AccountLinkedIdentityEvent:
+		err = o.LinkedIdentityHandler(event, event.Data().(*AccountLinkedIdentity), account)
+		ret = AccountAggregateStateTypes().Disabled()
+	case AccountMergedEvent:
+		err = o.MergedHandler(event, event.Data().(*AccountMerged), account)
+		ret = AccountAggregateStateTypes().Disabled()
+	case AccountMergedIntoEvent:
+		err = o.MergedIntoHandler(event, event.Data().(*AccountMergedInto), account)
+		ret = AccountAggregateStateTypes().Merged()
+	case AccountRevokedApiKeyEvent:
+		err = o.RevokedApiKeyHandler(event, event.Data().(*AccountRevokedApiKey), account)
+		ret = AccountAggregateStateTypes().Disabled()
+	case AccountRevokedSessionEvent:
+		err = o.RevokedSessionHandler(event, event.Data().(*AccountRevokedSession), account)
+		ret = AccountAggregateStateTypes().Disabled()
+	case AccountRevokedSessionsEvent:
+		err = o.RevokedSessionsHandler(event, account)
+		ret = AccountAggregateStateTypes().Disabled()
+	case AccountRoleExpiredEvent:
+		err = o.RoleExpiredHandler(event, event.Data().(*AccountRoleExpired), account)
+		ret = AccountAggregateStateTypes().Disabled()
+	case AccountUnassignedRoleEvent:
+		err = o.UnassignedRoleHandler(event, event.Data().(*AccountUnassignedRole), account)
+		ret = AccountAggregateStateTypes().Disabled()
+	case AccountUnlinkedIdentityEvent:
+		err = o.UnlinkedIdentityHandler(event, event.Data().(*AccountUnlinkedIdentity), account)
+		ret = AccountAggregateStateTypes().Disabled()
 	default:
 		err = errors.New(fmt.Sprintf("Not supported event type '%v' for entity '%v", event.EventType(), account))
 	}
@@ -107,18 +326,164 @@ func (o *AccountAggregateDisabledHandler) Apply(event eventhorizon.Event, accoun
 
 func (o *AccountAggregateDisabledHandler) SetupEventHandler() (err error) {
 
+	//register event object factory
+	eventhorizon.RegisterEventData(AccountAttributesChangedEvent, func() eventhorizon.EventData {
+		return &AccountAttributesChanged{}
+	})
+
+	//default handler implementation
+	o.AttributesChangedHandler = func(event eventhorizon.Event, eventData *AccountAttributesChanged, entity *Account) (err error) {
+
+		entity.Attributes = eventData.Attributes
+		return
+	}
+
 	//default handler implementation
 	o.EnabledHandler = func(event eventhorizon.Event, entity *Account) (err error) {
 
 		entity.Disabled = false
+		entity.DisabledReason = ""
+		return
+	}
+
+	//default handler implementation
+	o.ForgottenHandler = func(event eventhorizon.Event, entity *Account) (err error) {
+
+		return
+	}
+
+	//register event object factory
+	eventhorizon.RegisterEventData(ImpersonationEndedEvent, func() eventhorizon.EventData {
+		return &ImpersonationEnded{}
+	})
+
+	//default handler implementation
+	o.ImpersonationEndedHandler = func(event eventhorizon.Event, eventData *ImpersonationEnded, entity *Account) (err error) {
+
+		return
+	}
+
+	//register event object factory
+	eventhorizon.RegisterEventData(AccountLinkedIdentityEvent, func() eventhorizon.EventData {
+		return &AccountLinkedIdentity{}
+	})
+
+	//default handler implementation
+	o.LinkedIdentityHandler = func(event eventhorizon.Event, eventData *AccountLinkedIdentity, entity *Account) (err error) {
+
+		return
+	}
+
+	//register event object factory
+	eventhorizon.RegisterEventData(AccountMergedEvent, func() eventhorizon.EventData {
+		return &AccountMerged{}
+	})
+
+	//default handler implementation
+	o.MergedHandler = func(event eventhorizon.Event, eventData *AccountMerged, entity *Account) (err error) {
+
+		return
+	}
+
+	//register event object factory
+	eventhorizon.RegisterEventData(AccountMergedIntoEvent, func() eventhorizon.EventData {
+		return &AccountMergedInto{}
+	})
+
+	//default handler implementation
+	o.MergedIntoHandler = func(event eventhorizon.Event, eventData *AccountMergedInto, entity *Account) (err error) {
+
+		return
+	}
+
+	//register event object factory
+	eventhorizon.RegisterEventData(AccountRevokedApiKeyEvent, func() eventhorizon.EventData {
+		return &AccountRevokedApiKey{}
+	})
+
+	//default handler implementation
+	o.RevokedApiKeyHandler = func(event eventhorizon.Event, eventData *AccountRevokedApiKey, entity *Account) (err error) {
+
+		return
+	}
+
+	//register event object factory
+	eventhorizon.RegisterEventData(AccountRevokedSessionEvent, func() eventhorizon.EventData {
+		return &AccountRevokedSession{}
+	})
+
+	//default handler implementation
+	o.RevokedSessionHandler = func(event eventhorizon.Event, eventData *AccountRevokedSession, entity *Account) (err error) {
+
+		return
+	}
+
+	//default handler implementation
+	o.RevokedSessionsHandler = func(event eventhorizon.Event, entity *Account) (err error) {
+
+		return
+	}
+
+	//register event object factory
+	eventhorizon.RegisterEventData(AccountRoleExpiredEvent, func() eventhorizon.EventData {
+		return &AccountRoleExpired{}
+	})
+
+	//default handler implementation
+	o.RoleExpiredHandler = func(event eventhorizon.Event, eventData *AccountRoleExpired, entity *Account) (err error) {
+
+		return
+	}
+
+	//register event object factory
+	eventhorizon.RegisterEventData(AccountUnassignedRoleEvent, func() eventhorizon.EventData {
+		return &AccountUnassignedRole{}
+	})
+
+	//default handler implementation
+	o.UnassignedRoleHandler = func(event eventhorizon.Event, eventData *AccountUnassignedRole, entity *Account) (err error) {
+
+		return
+	}
+
+	//register event object factory
+	eventhorizon.RegisterEventData(AccountUnlinkedIdentityEvent, func() eventhorizon.EventData {
+		return &AccountUnlinkedIdentity{}
+	})
+
+	//default handler implementation
+	o.UnlinkedIdentityHandler = func(event eventhorizon.Event, eventData *AccountUnlinkedIdentity, entity *Account) (err error) {
+
 		return
 	}
 	return
 }
 
 type AccountAggregateEnabledHandler struct {
-	DeletedHandler  func(eventhorizon.Event, *Account) (err error)
-	DisabledHandler func(eventhorizon.Event, *Account) (err error)
+	AssignedRoleHandler         func(eventhorizon.Event, *AccountAssignedRole, *Account) (err error)
+	AttributesChangedHandler    func(eventhorizon.Event, *AccountAttributesChanged, *Account) (err error)
+	CreatedApiKeyHandler        func(eventhorizon.Event, *AccountCreatedApiKey, *Account) (err error)
+	DeletedHandler              func(eventhorizon.Event, *Account) (err error)
+	DisabledHandler             func(eventhorizon.Event, *AccountDisabled, *Account) (err error)
+	EmailChangeConfirmedHandler func(eventhorizon.Event, *AccountEmailChangeConfirmed, *Account) (err error)
+	EmailChangeRequestedHandler func(eventhorizon.Event, *AccountEmailChangeRequested, *Account) (err error)
+	ForgottenHandler            func(eventhorizon.Event, *Account) (err error)
+	ImpersonationEndedHandler   func(eventhorizon.Event, *ImpersonationEnded, *Account) (err error)
+	ImpersonationStartedHandler func(eventhorizon.Event, *ImpersonationStarted, *Account) (err error)
+	InactivityWarnedHandler     func(eventhorizon.Event, *Account) (err error)
+	LinkedIdentityHandler       func(eventhorizon.Event, *AccountLinkedIdentity, *Account) (err error)
+	MergedHandler               func(eventhorizon.Event, *AccountMerged, *Account) (err error)
+	MergedIntoHandler           func(eventhorizon.Event, *AccountMergedInto, *Account) (err error)
+	PasswordChangedHandler      func(eventhorizon.Event, *AccountPasswordChanged, *Account) (err error)
+	RevokedApiKeyHandler        func(eventhorizon.Event, *AccountRevokedApiKey, *Account) (err error)
+	RevokedSessionHandler       func(eventhorizon.Event, *AccountRevokedSession, *Account) (err error)
+	RevokedSessionsHandler      func(eventhorizon.Event, *Account) (err error)
+	RoleExpiredHandler          func(eventhorizon.Event, *AccountRoleExpired, *Account) (err error)
+	StartedSessionHandler       func(eventhorizon.Event, *AccountStartedSession, *Account) (err error)
+	TouchedSessionHandler       func(eventhorizon.Event, *AccountTouchedSession, *Account) (err error)
+	UnassignedRoleHandler       func(eventhorizon.Event, *AccountUnassignedRole, *Account) (err error)
+	UnlinkedIdentityHandler     func(eventhorizon.Event, *AccountUnlinkedIdentity, *Account) (err error)
+	UsedApiKeyHandler           func(eventhorizon.Event, *AccountUsedApiKey, *Account) (err error)
 }
 
 func NewAccountAggregateEnabledHandlerDefault() (ret *AccountAggregateEnabledHandler) {
@@ -134,12 +499,78 @@ func (o *AccountAggregateEnabledHandler) StateType() (ret *AccountAggregateState
 func (o *AccountAggregateEnabledHandler) Apply(event eventhorizon.Event, account *Account) (ret *AccountAggregateStateType, err error) {
 
 	switch event.EventType() {
+	case AccountAssignedRoleEvent:
+		err = o.AssignedRoleHandler(event, event.Data().(*AccountAssignedRole), account)
+		ret = AccountAggregateStateTypes().Enabled()
+	case AccountAttributesChangedEvent:
+		err = o.AttributesChangedHandler(event, event.Data().(*AccountAttributesChanged), account)
+		ret = AccountAggregateStateTypes().Enabled()
+	case AccountCreatedApiKeyEvent:
+		err = o.CreatedApiKeyHandler(event, event.Data().(*AccountCreatedApiKey), account)
+		ret = AccountAggregateStateTypes().Enabled()
 	case AccountDeletedEvent:
 		err = o.DeletedHandler(event, account)
 		ret = AccountAggregateStateTypes().Deleted()
 	case AccountDisabledEvent:
-		err = o.DisabledHandler(event, account)
+		err = o.DisabledHandler(event, event.Data().(*AccountDisabled), account)
 		ret = AccountAggregateStateTypes().Disabled()
+	case AccountEmailChangeConfirmedEvent:
+		err = o.EmailChangeConfirmedHandler(event, event.Data().(*AccountEmailChangeConfirmed), account)
+		ret = AccountAggregateStateTypes().Enabled()
+	case AccountEmailChangeRequestedEvent:
+		err = o.EmailChangeRequestedHandler(event, event.Data().(*AccountEmailChangeRequested), account)
+		ret = AccountAggregateStateTypes().Enabled()
+	case AccountForgottenEvent:
+		err = o.ForgottenHandler(event, account)
+		ret = AccountAggregateStateTypes().Deleted()
+	case ImpersonationEndedEvent:
+		err = o.ImpersonationEndedHandler(event, event.Data().(*ImpersonationEnded), account)
+		ret = AccountAggregateStateTypes().Enabled()
+	case ImpersonationStartedEvent:
+		err = o.ImpersonationStartedHandler(event, event.Data().(*ImpersonationStarted), account)
+		ret = AccountAggregateStateTypes().Enabled()
+	case AccountInactivityWarnedEvent:
+		err = o.InactivityWarnedHandler(event, account)
+		ret = AccountAggregateStateTypes().Enabled()
+	case AccountLinkedIdentityEvent:
+		err = o.LinkedIdentityHandler(event, event.Data().(*AccountLinkedIdentity), account)
+		ret = AccountAggregateStateTypes().Enabled()
+	case AccountMergedEvent:
+		err = o.MergedHandler(event, event.Data().(*AccountMerged), account)
+		ret = AccountAggregateStateTypes().Enabled()
+	case AccountMergedIntoEvent:
+		err = o.MergedIntoHandler(event, event.Data().(*AccountMergedInto), account)
+		ret = AccountAggregateStateTypes().Merged()
+	case AccountPasswordChangedEvent:
+		err = o.PasswordChangedHandler(event, event.Data().(*AccountPasswordChanged), account)
+		ret = AccountAggregateStateTypes().Enabled()
+	case AccountRevokedApiKeyEvent:
+		err = o.RevokedApiKeyHandler(event, event.Data().(*AccountRevokedApiKey), account)
+		ret = AccountAggregateStateTypes().Enabled()
+	case AccountRevokedSessionEvent:
+		err = o.RevokedSessionHandler(event, event.Data().(*AccountRevokedSession), account)
+		ret = AccountAggregateStateTypes().Enabled()
+	case AccountRevokedSessionsEvent:
+		err = o.RevokedSessionsHandler(event, account)
+		ret = AccountAggregateStateTypes().Enabled()
+	case AccountRoleExpiredEvent:
+		err = o.RoleExpiredHandler(event, event.Data().(*AccountRoleExpired), account)
+		ret = AccountAggregateStateTypes().Enabled()
+	case AccountStartedSessionEvent:
+		err = o.StartedSessionHandler(event, event.Data().(*AccountStartedSession), account)
+		ret = AccountAggregateStateTypes().Enabled()
+	case AccountTouchedSessionEvent:
+		err = o.TouchedSessionHandler(event, event.Data().(*AccountTouchedSession), account)
+		ret = AccountAggregateStateTypes().Enabled()
+	case AccountUnassignedRoleEvent:
+		err = o.UnassignedRoleHandler(event, event.Data().(*AccountUnassignedRole), account)
+		ret = AccountAggregateStateTypes().Enabled()
+	case AccountUnlinkedIdentityEvent:
+		err = o.UnlinkedIdentityHandler(event, event.Data().(*AccountUnlinkedIdentity), account)
+		ret = AccountAggregateStateTypes().Enabled()
+	case AccountUsedApiKeyEvent:
+		err = o.UsedApiKeyHandler(event, event.Data().(*AccountUsedApiKey), account)
+		ret = AccountAggregateStateTypes().Enabled()
 	default:
 		err = errors.New(fmt.Sprintf("Not supported event type '%v' for entity '%v", event.EventType(), account))
 	}
@@ -148,38 +579,226 @@ func (o *AccountAggregateEnabledHandler) Apply(event eventhorizon.Event, account
 
 func (o *AccountAggregateEnabledHandler) SetupEventHandler() (err error) {
 
+	//register event object factory
+	eventhorizon.RegisterEventData(AccountAssignedRoleEvent, func() eventhorizon.EventData {
+		return &AccountAssignedRole{}
+	})
+
 	//default handler implementation
-	o.DeletedHandler = func(event eventhorizon.Event, entity *Account) (err error) {
+	o.AssignedRoleHandler = func(event eventhorizon.Event, eventData *AccountAssignedRole, entity *Account) (err error) {
 
-		*entity = *NewAccountDefault()
 		return
 	}
 
 	//default handler implementation
-	o.DisabledHandler = func(event eventhorizon.Event, entity *Account) (err error) {
+	o.AttributesChangedHandler = func(event eventhorizon.Event, eventData *AccountAttributesChanged, entity *Account) (err error) {
 
-		entity.Disabled = true
+		entity.Attributes = eventData.Attributes
 		return
 	}
-	return
-}
 
-type AccountAggregateExistHandler struct {
-	DeletedHandler func(eventhorizon.Event, *Account) (err error)
-	UpdatedHandler func(eventhorizon.Event, *AccountUpdated, *Account) (err error)
-}
+	//register event object factory
+	eventhorizon.RegisterEventData(AccountCreatedApiKeyEvent, func() eventhorizon.EventData {
+		return &AccountCreatedApiKey{}
+	})
 
-func NewAccountAggregateExistHandlerDefault() (ret *AccountAggregateExistHandler) {
-	ret = &AccountAggregateExistHandler{}
-	return
-}
+	//default handler implementation
+	o.CreatedApiKeyHandler = func(event eventhorizon.Event, eventData *AccountCreatedApiKey, entity *Account) (err error) {
 
-func (o *AccountAggregateExistHandler) StateType() (ret *AccountAggregateStateType) {
-	ret = AccountAggregateStateTypes().Exist()
-	return
-}
+		return
+	}
 
-func (o *AccountAggregateExistHandler) Apply(event eventhorizon.Event, account *Account) (ret *AccountAggregateStateType, err error) {
+	//default handler implementation
+	o.DeletedHandler = func(event eventhorizon.Event, entity *Account) (err error) {
+
+		*entity = *NewAccountDefault()
+		return
+	}
+
+	//register event object factory
+	eventhorizon.RegisterEventData(AccountDisabledEvent, func() eventhorizon.EventData {
+		return &AccountDisabled{}
+	})
+
+	//default handler implementation
+	o.DisabledHandler = func(event eventhorizon.Event, eventData *AccountDisabled, entity *Account) (err error) {
+
+		entity.Disabled = true
+		entity.DisabledReason = eventData.DisabledReason
+		return
+	}
+
+	//register event object factory
+	eventhorizon.RegisterEventData(AccountEmailChangeConfirmedEvent, func() eventhorizon.EventData {
+		return &AccountEmailChangeConfirmed{}
+	})
+
+	//default handler implementation
+	o.EmailChangeConfirmedHandler = func(event eventhorizon.Event, eventData *AccountEmailChangeConfirmed, entity *Account) (err error) {
+
+		return
+	}
+
+	//register event object factory
+	eventhorizon.RegisterEventData(AccountEmailChangeRequestedEvent, func() eventhorizon.EventData {
+		return &AccountEmailChangeRequested{}
+	})
+
+	//default handler implementation
+	o.EmailChangeRequestedHandler = func(event eventhorizon.Event, eventData *AccountEmailChangeRequested, entity *Account) (err error) {
+
+		entity.PendingEmail = eventData.PendingEmail
+		entity.EmailChangeTokenHash = eventData.EmailChangeTokenHash
+		entity.EmailChangeExpiresAt = eventData.EmailChangeExpiresAt
+		return
+	}
+
+	//default handler implementation
+	o.ForgottenHandler = func(event eventhorizon.Event, entity *Account) (err error) {
+
+		return
+	}
+
+	//default handler implementation
+	o.ImpersonationEndedHandler = func(event eventhorizon.Event, eventData *ImpersonationEnded, entity *Account) (err error) {
+
+		return
+	}
+
+	//register event object factory
+	eventhorizon.RegisterEventData(ImpersonationStartedEvent, func() eventhorizon.EventData {
+		return &ImpersonationStarted{}
+	})
+
+	//default handler implementation
+	o.ImpersonationStartedHandler = func(event eventhorizon.Event, eventData *ImpersonationStarted, entity *Account) (err error) {
+
+		return
+	}
+
+	//default handler implementation
+	o.InactivityWarnedHandler = func(event eventhorizon.Event, entity *Account) (err error) {
+
+		entity.SentInactivityWarning = true
+		return
+	}
+
+	//default handler implementation
+	o.LinkedIdentityHandler = func(event eventhorizon.Event, eventData *AccountLinkedIdentity, entity *Account) (err error) {
+
+		return
+	}
+
+	//default handler implementation
+	o.MergedHandler = func(event eventhorizon.Event, eventData *AccountMerged, entity *Account) (err error) {
+
+		return
+	}
+
+	//default handler implementation
+	o.MergedIntoHandler = func(event eventhorizon.Event, eventData *AccountMergedInto, entity *Account) (err error) {
+
+		return
+	}
+
+	//register event object factory
+	eventhorizon.RegisterEventData(AccountPasswordChangedEvent, func() eventhorizon.EventData {
+		return &AccountPasswordChanged{}
+	})
+
+	//default handler implementation
+	o.PasswordChangedHandler = func(event eventhorizon.Event, eventData *AccountPasswordChanged, entity *Account) (err error) {
+
+		return
+	}
+
+	//default handler implementation
+	o.RevokedApiKeyHandler = func(event eventhorizon.Event, eventData *AccountRevokedApiKey, entity *Account) (err error) {
+
+		return
+	}
+
+	//default handler implementation
+	o.RevokedSessionHandler = func(event eventhorizon.Event, eventData *AccountRevokedSession, entity *Account) (err error) {
+
+		return
+	}
+
+	//default handler implementation
+	o.RevokedSessionsHandler = func(event eventhorizon.Event, entity *Account) (err error) {
+
+		return
+	}
+
+	//default handler implementation
+	o.RoleExpiredHandler = func(event eventhorizon.Event, eventData *AccountRoleExpired, entity *Account) (err error) {
+
+		return
+	}
+
+	//register event object factory
+	eventhorizon.RegisterEventData(AccountStartedSessionEvent, func() eventhorizon.EventData {
+		return &AccountStartedSession{}
+	})
+
+	//default handler implementation
+	o.StartedSessionHandler = func(event eventhorizon.Event, eventData *AccountStartedSession, entity *Account) (err error) {
+
+		return
+	}
+
+	//register event object factory
+	eventhorizon.RegisterEventData(AccountTouchedSessionEvent, func() eventhorizon.EventData {
+		return &AccountTouchedSession{}
+	})
+
+	//default handler implementation
+	o.TouchedSessionHandler = func(event eventhorizon.Event, eventData *AccountTouchedSession, entity *Account) (err error) {
+
+		return
+	}
+
+	//default handler implementation
+	o.UnassignedRoleHandler = func(event eventhorizon.Event, eventData *AccountUnassignedRole, entity *Account) (err error) {
+
+		return
+	}
+
+	//default handler implementation
+	o.UnlinkedIdentityHandler = func(event eventhorizon.Event, eventData *AccountUnlinkedIdentity, entity *Account) (err error) {
+
+		return
+	}
+
+	//register event object factory
+	eventhorizon.RegisterEventData(AccountUsedApiKeyEvent, func() eventhorizon.EventData {
+		return &AccountUsedApiKey{}
+	})
+
+	//default handler implementation
+	o.UsedApiKeyHandler = func(event eventhorizon.Event, eventData *AccountUsedApiKey, entity *Account) (err error) {
+
+		return
+	}
+	return
+}
+
+type AccountAggregateExistHandler struct {
+	DeletedHandler func(eventhorizon.Event, *Account) (err error)
+	UpdatedHandler func(eventhorizon.Event, *AccountUpdated, *Account) (err error)
+}
+
+func NewAccountAggregateExistHandlerDefault() (ret *AccountAggregateExistHandler) {
+	ret = &AccountAggregateExistHandler{}
+	return
+}
+
+func (o *AccountAggregateExistHandler) StateType() (ret *AccountAggregateStateType) {
+	ret = AccountAggregateStateTypes().Exist()
+	return
+}
+
+func (o *AccountAggregateExistHandler) Apply(event eventhorizon.Event, account *Account) (ret *AccountAggregateStateType, err error) {
 
 	switch event.EventType() {
 	case AccountDeletedEvent:
@@ -215,7 +834,965 @@ func (o *AccountAggregateExistHandler) SetupEventHandler() (err error) {
 		entity.Password = eventData.Password
 		entity.Email = eventData.Email
 		entity.Roles = eventData.Roles
+		entity.OrganizationId = eventData.OrganizationId
+		entity.Service = eventData.Service
+		entity.BreakGlass = eventData.BreakGlass
+		entity.Attributes = eventData.Attributes
+		return
+	}
+	return
+}
+
+type GroupAggregateInitialHandler struct {
+	CreatedHandler func(eventhorizon.Event, *GroupCreated, *Group) (err error)
+}
+
+func NewGroupAggregateInitialHandlerDefault() (ret *GroupAggregateInitialHandler) {
+	ret = &GroupAggregateInitialHandler{}
+	return
+}
+
+func (o *GroupAggregateInitialHandler) StateType() (ret *GroupAggregateStateType) {
+	ret = GroupAggregateStateTypes().Initial()
+	return
+}
+
+func (o *GroupAggregateInitialHandler) Apply(event eventhorizon.Event, group *Group) (ret *GroupAggregateStateType, err error) {
+
+	switch event.EventType() {
+	case GroupCreatedEvent:
+		err = o.CreatedHandler(event, event.Data().(*GroupCreated), group)
+		ret = GroupAggregateStateTypes().Exist()
+	default:
+		err = errors.New(fmt.Sprintf("Not supported event type '%v' for entity '%v", event.EventType(), group))
+	}
+	return
+}
+
+func (o *GroupAggregateInitialHandler) SetupEventHandler() (err error) {
+
+	//register event object factory
+	eventhorizon.RegisterEventData(GroupCreatedEvent, func() eventhorizon.EventData {
+		return &GroupCreated{}
+	})
+
+	//default handler implementation
+	o.CreatedHandler = func(event eventhorizon.Event, eventData *GroupCreated, entity *Group) (err error) {
+
+		entity.Id = event.AggregateID()
+		entity.Name = eventData.Name
+		entity.Description = eventData.Description
+		entity.Roles = eventData.Roles
+		return
+	}
+	return
+}
+
+type GroupAggregateExistHandler struct {
+	AddedMemberHandler     func(eventhorizon.Event, *GroupAddedMember, *Group) (err error)
+	AddedSubgroupHandler   func(eventhorizon.Event, *GroupAddedSubgroup, *Group) (err error)
+	DeletedHandler         func(eventhorizon.Event, *Group) (err error)
+	RemovedMemberHandler   func(eventhorizon.Event, *GroupRemovedMember, *Group) (err error)
+	RemovedSubgroupHandler func(eventhorizon.Event, *GroupRemovedSubgroup, *Group) (err error)
+	UpdatedHandler         func(eventhorizon.Event, *GroupUpdated, *Group) (err error)
+}
+
+func NewGroupAggregateExistHandlerDefault() (ret *GroupAggregateExistHandler) {
+	ret = &GroupAggregateExistHandler{}
+	return
+}
+
+func (o *GroupAggregateExistHandler) StateType() (ret *GroupAggregateStateType) {
+	ret = GroupAggregateStateTypes().Exist()
+	return
+}
+
+func (o *GroupAggregateExistHandler) Apply(event eventhorizon.Event, group *Group) (ret *GroupAggregateStateType, err error) {
+
+	switch event.EventType() {
+	case GroupAddedMemberEvent:
+		err = o.AddedMemberHandler(event, event.Data().(*GroupAddedMember), group)
+		ret = GroupAggregateStateTypes().Exist()
+	case GroupAddedSubgroupEvent:
+		err = o.AddedSubgroupHandler(event, event.Data().(*GroupAddedSubgroup), group)
+		ret = GroupAggregateStateTypes().Exist()
+	case GroupDeletedEvent:
+		err = o.DeletedHandler(event, group)
+		ret = GroupAggregateStateTypes().Deleted()
+	case GroupRemovedMemberEvent:
+		err = o.RemovedMemberHandler(event, event.Data().(*GroupRemovedMember), group)
+		ret = GroupAggregateStateTypes().Exist()
+	case GroupRemovedSubgroupEvent:
+		err = o.RemovedSubgroupHandler(event, event.Data().(*GroupRemovedSubgroup), group)
+		ret = GroupAggregateStateTypes().Exist()
+	case GroupUpdatedEvent:
+		err = o.UpdatedHandler(event, event.Data().(*GroupUpdated), group)
+		ret = GroupAggregateStateTypes().Exist()
+	default:
+		err = errors.New(fmt.Sprintf("Not supported event type '%v' for entity '%v", event.EventType(), group))
+	}
+	return
+}
+
+func (o *GroupAggregateExistHandler) SetupEventHandler() (err error) {
+
+	//register event object factory
+	eventhorizon.RegisterEventData(GroupAddedMemberEvent, func() eventhorizon.EventData {
+		return &GroupAddedMember{}
+	})
+
+	//default handler implementation
+	o.AddedMemberHandler = func(event eventhorizon.Event, eventData *GroupAddedMember, entity *Group) (err error) {
+
+		return
+	}
+
+	//register event object factory
+	eventhorizon.RegisterEventData(GroupAddedSubgroupEvent, func() eventhorizon.EventData {
+		return &GroupAddedSubgroup{}
+	})
+
+	//default handler implementation
+	o.AddedSubgroupHandler = func(event eventhorizon.Event, eventData *GroupAddedSubgroup, entity *Group) (err error) {
+
+		return
+	}
+
+	//default handler implementation
+	o.DeletedHandler = func(event eventhorizon.Event, entity *Group) (err error) {
+
+		*entity = *NewGroupDefault()
+		return
+	}
+
+	//register event object factory
+	eventhorizon.RegisterEventData(GroupRemovedMemberEvent, func() eventhorizon.EventData {
+		return &GroupRemovedMember{}
+	})
+
+	//default handler implementation
+	o.RemovedMemberHandler = func(event eventhorizon.Event, eventData *GroupRemovedMember, entity *Group) (err error) {
+
+		return
+	}
+
+	//register event object factory
+	eventhorizon.RegisterEventData(GroupRemovedSubgroupEvent, func() eventhorizon.EventData {
+		return &GroupRemovedSubgroup{}
+	})
+
+	//default handler implementation
+	o.RemovedSubgroupHandler = func(event eventhorizon.Event, eventData *GroupRemovedSubgroup, entity *Group) (err error) {
+
+		return
+	}
+
+	//register event object factory
+	eventhorizon.RegisterEventData(GroupUpdatedEvent, func() eventhorizon.EventData {
+		return &GroupUpdated{}
+	})
+
+	//default handler implementation
+	o.UpdatedHandler = func(event eventhorizon.Event, eventData *GroupUpdated, entity *Group) (err error) {
+
+		entity.Name = eventData.Name
+		entity.Description = eventData.Description
+		entity.Roles = eventData.Roles
 		return
 	}
 	return
 }
+
+type GroupAggregateDeletedHandler struct {
+}
+
+func NewGroupAggregateDeletedHandlerDefault() (ret *GroupAggregateDeletedHandler) {
+	ret = &GroupAggregateDeletedHandler{}
+	return
+}
+
+func (o *GroupAggregateDeletedHandler) StateType() (ret *GroupAggregateStateType) {
+	ret = GroupAggregateStateTypes().Deleted()
+	return
+}
+
+func (o *GroupAggregateDeletedHandler) Apply(event eventhorizon.Event, group *Group) (ret *GroupAggregateStateType, err error) {
+
+	return
+}
+
+func (o *GroupAggregateDeletedHandler) SetupEventHandler() (err error) {
+	return
+}
+
+type OrganizationAggregateInitialHandler struct {
+	CreatedHandler func(eventhorizon.Event, *OrganizationCreated, *Organization) (err error)
+}
+
+func NewOrganizationAggregateInitialHandlerDefault() (ret *OrganizationAggregateInitialHandler) {
+	ret = &OrganizationAggregateInitialHandler{}
+	return
+}
+
+func (o *OrganizationAggregateInitialHandler) StateType() (ret *OrganizationAggregateStateType) {
+	ret = OrganizationAggregateStateTypes().Initial()
+	return
+}
+
+func (o *OrganizationAggregateInitialHandler) Apply(event eventhorizon.Event, organization *Organization) (ret *OrganizationAggregateStateType, err error) {
+
+	switch event.EventType() {
+	case OrganizationCreatedEvent:
+		err = o.CreatedHandler(event, event.Data().(*OrganizationCreated), organization)
+		ret = OrganizationAggregateStateTypes().Exist()
+	default:
+		err = errors.New(fmt.Sprintf("Not supported event type '%v' for entity '%v", event.EventType(), organization))
+	}
+	return
+}
+
+func (o *OrganizationAggregateInitialHandler) SetupEventHandler() (err error) {
+
+	//register event object factory
+	eventhorizon.RegisterEventData(OrganizationCreatedEvent, func() eventhorizon.EventData {
+		return &OrganizationCreated{}
+	})
+
+	//default handler implementation
+	o.CreatedHandler = func(event eventhorizon.Event, eventData *OrganizationCreated, entity *Organization) (err error) {
+
+		entity.Id = event.AggregateID()
+		entity.Name = eventData.Name
+		entity.Description = eventData.Description
+		return
+	}
+	return
+}
+
+type OrganizationAggregateExistHandler struct {
+	DeletedHandler func(eventhorizon.Event, *Organization) (err error)
+	UpdatedHandler func(eventhorizon.Event, *OrganizationUpdated, *Organization) (err error)
+}
+
+func NewOrganizationAggregateExistHandlerDefault() (ret *OrganizationAggregateExistHandler) {
+	ret = &OrganizationAggregateExistHandler{}
+	return
+}
+
+func (o *OrganizationAggregateExistHandler) StateType() (ret *OrganizationAggregateStateType) {
+	ret = OrganizationAggregateStateTypes().Exist()
+	return
+}
+
+func (o *OrganizationAggregateExistHandler) Apply(event eventhorizon.Event, organization *Organization) (ret *OrganizationAggregateStateType, err error) {
+
+	switch event.EventType() {
+	case OrganizationDeletedEvent:
+		err = o.DeletedHandler(event, organization)
+		ret = OrganizationAggregateStateTypes().Deleted()
+	case OrganizationUpdatedEvent:
+		err = o.UpdatedHandler(event, event.Data().(*OrganizationUpdated), organization)
+		ret = OrganizationAggregateStateTypes().Exist()
+	default:
+		err = errors.New(fmt.Sprintf("Not supported event type '%v' for entity '%v", event.EventType(), organization))
+	}
+	return
+}
+
+func (o *OrganizationAggregateExistHandler) SetupEventHandler() (err error) {
+
+	//default handler implementation
+	o.DeletedHandler = func(event eventhorizon.Event, entity *Organization) (err error) {
+
+		*entity = *NewOrganizationDefault()
+		return
+	}
+
+	//register event object factory
+	eventhorizon.RegisterEventData(OrganizationUpdatedEvent, func() eventhorizon.EventData {
+		return &OrganizationUpdated{}
+	})
+
+	//default handler implementation
+	o.UpdatedHandler = func(event eventhorizon.Event, eventData *OrganizationUpdated, entity *Organization) (err error) {
+
+		entity.Name = eventData.Name
+		entity.Description = eventData.Description
+		return
+	}
+	return
+}
+
+type OrganizationAggregateDeletedHandler struct {
+}
+
+func NewOrganizationAggregateDeletedHandlerDefault() (ret *OrganizationAggregateDeletedHandler) {
+	ret = &OrganizationAggregateDeletedHandler{}
+	return
+}
+
+func (o *OrganizationAggregateDeletedHandler) StateType() (ret *OrganizationAggregateStateType) {
+	ret = OrganizationAggregateStateTypes().Deleted()
+	return
+}
+
+func (o *OrganizationAggregateDeletedHandler) Apply(event eventhorizon.Event, organization *Organization) (ret *OrganizationAggregateStateType, err error) {
+
+	return
+}
+
+func (o *OrganizationAggregateDeletedHandler) SetupEventHandler() (err error) {
+	return
+}
+
+type RoleGrantRequestAggregateInitialHandler struct {
+	CreatedHandler func(eventhorizon.Event, *RoleGrantRequestCreated, *RoleGrantRequest) (err error)
+}
+
+func NewRoleGrantRequestAggregateInitialHandlerDefault() (ret *RoleGrantRequestAggregateInitialHandler) {
+	ret = &RoleGrantRequestAggregateInitialHandler{}
+	return
+}
+
+func (o *RoleGrantRequestAggregateInitialHandler) StateType() (ret *RoleGrantRequestAggregateStateType) {
+	ret = RoleGrantRequestAggregateStateTypes().Initial()
+	return
+}
+
+func (o *RoleGrantRequestAggregateInitialHandler) Apply(event eventhorizon.Event, roleGrantRequest *RoleGrantRequest) (ret *RoleGrantRequestAggregateStateType, err error) {
+
+	switch event.EventType() {
+	case RoleGrantRequestCreatedEvent:
+		err = o.CreatedHandler(event, event.Data().(*RoleGrantRequestCreated), roleGrantRequest)
+		ret = RoleGrantRequestAggregateStateTypes().Pending()
+	default:
+		err = errors.New(fmt.Sprintf("Not supported event type '%v' for entity '%v", event.EventType(), roleGrantRequest))
+	}
+	return
+}
+
+func (o *RoleGrantRequestAggregateInitialHandler) SetupEventHandler() (err error) {
+
+	//register event object factory
+	eventhorizon.RegisterEventData(RoleGrantRequestCreatedEvent, func() eventhorizon.EventData {
+		return &RoleGrantRequestCreated{}
+	})
+
+	//default handler implementation
+	o.CreatedHandler = func(event eventhorizon.Event, eventData *RoleGrantRequestCreated, entity *RoleGrantRequest) (err error) {
+
+		entity.Id = event.AggregateID()
+		entity.AccountId = eventData.AccountId
+		entity.Role = eventData.Role
+		entity.Reason = eventData.Reason
+		entity.ValidFrom = eventData.ValidFrom
+		entity.ValidUntil = eventData.ValidUntil
+		entity.RequestedBy = eventData.RequestedBy
+		return
+	}
+	return
+}
+
+type RoleGrantRequestAggregatePendingHandler struct {
+	ApprovedHandler func(eventhorizon.Event, *RoleGrantRequestApproved, *RoleGrantRequest) (err error)
+	DeletedHandler  func(eventhorizon.Event, *RoleGrantRequest) (err error)
+	RejectedHandler func(eventhorizon.Event, *RoleGrantRequestRejected, *RoleGrantRequest) (err error)
+	UpdatedHandler  func(eventhorizon.Event, *RoleGrantRequestUpdated, *RoleGrantRequest) (err error)
+}
+
+func NewRoleGrantRequestAggregatePendingHandlerDefault() (ret *RoleGrantRequestAggregatePendingHandler) {
+	ret = &RoleGrantRequestAggregatePendingHandler{}
+	return
+}
+
+func (o *RoleGrantRequestAggregatePendingHandler) StateType() (ret *RoleGrantRequestAggregateStateType) {
+	ret = RoleGrantRequestAggregateStateTypes().Pending()
+	return
+}
+
+func (o *RoleGrantRequestAggregatePendingHandler) Apply(event eventhorizon.Event, roleGrantRequest *RoleGrantRequest) (ret *RoleGrantRequestAggregateStateType, err error) {
+
+	switch event.EventType() {
+	case RoleGrantRequestApprovedEvent:
+		err = o.ApprovedHandler(event, event.Data().(*RoleGrantRequestApproved), roleGrantRequest)
+		ret = RoleGrantRequestAggregateStateTypes().Approved()
+	case RoleGrantRequestDeletedEvent:
+		err = o.DeletedHandler(event, roleGrantRequest)
+		ret = RoleGrantRequestAggregateStateTypes().Deleted()
+	case RoleGrantRequestRejectedEvent:
+		err = o.RejectedHandler(event, event.Data().(*RoleGrantRequestRejected), roleGrantRequest)
+		ret = RoleGrantRequestAggregateStateTypes().Rejected()
+	case RoleGrantRequestUpdatedEvent:
+		err = o.UpdatedHandler(event, event.Data().(*RoleGrantRequestUpdated), roleGrantRequest)
+		ret = RoleGrantRequestAggregateStateTypes().Pending()
+	default:
+		err = errors.New(fmt.Sprintf("Not supported event type '%v' for entity '%v", event.EventType(), roleGrantRequest))
+	}
+	return
+}
+
+func (o *RoleGrantRequestAggregatePendingHandler) SetupEventHandler() (err error) {
+
+	//register event object factory
+	eventhorizon.RegisterEventData(RoleGrantRequestApprovedEvent, func() eventhorizon.EventData {
+		return &RoleGrantRequestApproved{}
+	})
+
+	//default handler implementation
+	o.ApprovedHandler = func(event eventhorizon.Event, eventData *RoleGrantRequestApproved, entity *RoleGrantRequest) (err error) {
+
+		return
+	}
+
+	//default handler implementation
+	o.DeletedHandler = func(event eventhorizon.Event, entity *RoleGrantRequest) (err error) {
+
+		*entity = *NewRoleGrantRequestDefault()
+		return
+	}
+
+	//register event object factory
+	eventhorizon.RegisterEventData(RoleGrantRequestRejectedEvent, func() eventhorizon.EventData {
+		return &RoleGrantRequestRejected{}
+	})
+
+	//default handler implementation
+	o.RejectedHandler = func(event eventhorizon.Event, eventData *RoleGrantRequestRejected, entity *RoleGrantRequest) (err error) {
+
+		return
+	}
+
+	//register event object factory
+	eventhorizon.RegisterEventData(RoleGrantRequestUpdatedEvent, func() eventhorizon.EventData {
+		return &RoleGrantRequestUpdated{}
+	})
+
+	//default handler implementation
+	o.UpdatedHandler = func(event eventhorizon.Event, eventData *RoleGrantRequestUpdated, entity *RoleGrantRequest) (err error) {
+
+		entity.AccountId = eventData.AccountId
+		entity.Role = eventData.Role
+		entity.Reason = eventData.Reason
+		entity.ValidFrom = eventData.ValidFrom
+		entity.ValidUntil = eventData.ValidUntil
+		entity.RequestedBy = eventData.RequestedBy
+		return
+	}
+	return
+}
+
+type RoleGrantRequestAggregateApprovedHandler struct {
+}
+
+func NewRoleGrantRequestAggregateApprovedHandlerDefault() (ret *RoleGrantRequestAggregateApprovedHandler) {
+	ret = &RoleGrantRequestAggregateApprovedHandler{}
+	return
+}
+
+func (o *RoleGrantRequestAggregateApprovedHandler) StateType() (ret *RoleGrantRequestAggregateStateType) {
+	ret = RoleGrantRequestAggregateStateTypes().Approved()
+	return
+}
+
+func (o *RoleGrantRequestAggregateApprovedHandler) Apply(event eventhorizon.Event, roleGrantRequest *RoleGrantRequest) (ret *RoleGrantRequestAggregateStateType, err error) {
+
+	return
+}
+
+func (o *RoleGrantRequestAggregateApprovedHandler) SetupEventHandler() (err error) {
+	return
+}
+
+type RoleGrantRequestAggregateRejectedHandler struct {
+}
+
+func NewRoleGrantRequestAggregateRejectedHandlerDefault() (ret *RoleGrantRequestAggregateRejectedHandler) {
+	ret = &RoleGrantRequestAggregateRejectedHandler{}
+	return
+}
+
+func (o *RoleGrantRequestAggregateRejectedHandler) StateType() (ret *RoleGrantRequestAggregateStateType) {
+	ret = RoleGrantRequestAggregateStateTypes().Rejected()
+	return
+}
+
+func (o *RoleGrantRequestAggregateRejectedHandler) Apply(event eventhorizon.Event, roleGrantRequest *RoleGrantRequest) (ret *RoleGrantRequestAggregateStateType, err error) {
+
+	return
+}
+
+func (o *RoleGrantRequestAggregateRejectedHandler) SetupEventHandler() (err error) {
+	return
+}
+
+type RoleGrantRequestAggregateDeletedHandler struct {
+}
+
+func NewRoleGrantRequestAggregateDeletedHandlerDefault() (ret *RoleGrantRequestAggregateDeletedHandler) {
+	ret = &RoleGrantRequestAggregateDeletedHandler{}
+	return
+}
+
+func (o *RoleGrantRequestAggregateDeletedHandler) StateType() (ret *RoleGrantRequestAggregateStateType) {
+	ret = RoleGrantRequestAggregateStateTypes().Deleted()
+	return
+}
+
+func (o *RoleGrantRequestAggregateDeletedHandler) Apply(event eventhorizon.Event, roleGrantRequest *RoleGrantRequest) (ret *RoleGrantRequestAggregateStateType, err error) {
+
+	return
+}
+
+func (o *RoleGrantRequestAggregateDeletedHandler) SetupEventHandler() (err error) {
+	return
+}
+
+type RoleAggregateInitialHandler struct {
+	CreatedHandler func(eventhorizon.Event, *RoleCreated, *Role) (err error)
+}
+
+func NewRoleAggregateInitialHandlerDefault() (ret *RoleAggregateInitialHandler) {
+	ret = &RoleAggregateInitialHandler{}
+	return
+}
+
+func (o *RoleAggregateInitialHandler) StateType() (ret *RoleAggregateStateType) {
+	ret = RoleAggregateStateTypes().Initial()
+	return
+}
+
+func (o *RoleAggregateInitialHandler) Apply(event eventhorizon.Event, role *Role) (ret *RoleAggregateStateType, err error) {
+
+	switch event.EventType() {
+	case RoleCreatedEvent:
+		err = o.CreatedHandler(event, event.Data().(*RoleCreated), role)
+		ret = RoleAggregateStateTypes().Exist()
+	default:
+		err = errors.New(fmt.Sprintf("Not supported event type '%v' for entity '%v", event.EventType(), role))
+	}
+	return
+}
+
+func (o *RoleAggregateInitialHandler) SetupEventHandler() (err error) {
+
+	//register event object factory
+	eventhorizon.RegisterEventData(RoleCreatedEvent, func() eventhorizon.EventData {
+		return &RoleCreated{}
+	})
+
+	//default handler implementation
+	o.CreatedHandler = func(event eventhorizon.Event, eventData *RoleCreated, entity *Role) (err error) {
+
+		entity.Id = event.AggregateID()
+		entity.Name = eventData.Name
+		entity.Description = eventData.Description
+		entity.Inherits = eventData.Inherits
+		entity.Permissions = eventData.Permissions
+		return
+	}
+	return
+}
+
+type RoleAggregateExistHandler struct {
+	DeletedHandler func(eventhorizon.Event, *Role) (err error)
+	UpdatedHandler func(eventhorizon.Event, *RoleUpdated, *Role) (err error)
+}
+
+func NewRoleAggregateExistHandlerDefault() (ret *RoleAggregateExistHandler) {
+	ret = &RoleAggregateExistHandler{}
+	return
+}
+
+func (o *RoleAggregateExistHandler) StateType() (ret *RoleAggregateStateType) {
+	ret = RoleAggregateStateTypes().Exist()
+	return
+}
+
+func (o *RoleAggregateExistHandler) Apply(event eventhorizon.Event, role *Role) (ret *RoleAggregateStateType, err error) {
+
+	switch event.EventType() {
+	case RoleDeletedEvent:
+		err = o.DeletedHandler(event, role)
+		ret = RoleAggregateStateTypes().Deleted()
+	case RoleUpdatedEvent:
+		err = o.UpdatedHandler(event, event.Data().(*RoleUpdated), role)
+		ret = RoleAggregateStateTypes().Exist()
+	default:
+		err = errors.New(fmt.Sprintf("Not supported event type '%v' for entity '%v", event.EventType(), role))
+	}
+	return
+}
+
+func (o *RoleAggregateExistHandler) SetupEventHandler() (err error) {
+
+	//default handler implementation
+	o.DeletedHandler = func(event eventhorizon.Event, entity *Role) (err error) {
+
+		*entity = *NewRoleDefault()
+		return
+	}
+
+	//register event object factory
+	eventhorizon.RegisterEventData(RoleUpdatedEvent, func() eventhorizon.EventData {
+		return &RoleUpdated{}
+	})
+
+	//default handler implementation
+	o.UpdatedHandler = func(event eventhorizon.Event, eventData *RoleUpdated, entity *Role) (err error) {
+
+		entity.Name = eventData.Name
+		entity.Description = eventData.Description
+		entity.Inherits = eventData.Inherits
+		entity.Permissions = eventData.Permissions
+		return
+	}
+	return
+}
+
+type RoleAggregateDeletedHandler struct {
+}
+
+func NewRoleAggregateDeletedHandlerDefault() (ret *RoleAggregateDeletedHandler) {
+	ret = &RoleAggregateDeletedHandler{}
+	return
+}
+
+func (o *RoleAggregateDeletedHandler) StateType() (ret *RoleAggregateStateType) {
+	ret = RoleAggregateStateTypes().Deleted()
+	return
+}
+
+func (o *RoleAggregateDeletedHandler) Apply(event eventhorizon.Event, role *Role) (ret *RoleAggregateStateType, err error) {
+
+	return
+}
+
+func (o *RoleAggregateDeletedHandler) SetupEventHandler() (err error) {
+	return
+}
+
+type RelationTupleAggregateInitialHandler struct {
+	CreatedHandler func(eventhorizon.Event, *RelationTupleCreated, *RelationTuple) (err error)
+}
+
+func NewRelationTupleAggregateInitialHandlerDefault() (ret *RelationTupleAggregateInitialHandler) {
+	ret = &RelationTupleAggregateInitialHandler{}
+	return
+}
+
+func (o *RelationTupleAggregateInitialHandler) StateType() (ret *RelationTupleAggregateStateType) {
+	ret = RelationTupleAggregateStateTypes().Initial()
+	return
+}
+
+func (o *RelationTupleAggregateInitialHandler) Apply(event eventhorizon.Event, relationTuple *RelationTuple) (ret *RelationTupleAggregateStateType, err error) {
+
+	switch event.EventType() {
+	case RelationTupleCreatedEvent:
+		err = o.CreatedHandler(event, event.Data().(*RelationTupleCreated), relationTuple)
+		ret = RelationTupleAggregateStateTypes().Exist()
+	default:
+		err = errors.New(fmt.Sprintf("Not supported event type '%v' for entity '%v", event.EventType(), relationTuple))
+	}
+	return
+}
+
+func (o *RelationTupleAggregateInitialHandler) SetupEventHandler() (err error) {
+
+	//register event object factory
+	eventhorizon.RegisterEventData(RelationTupleCreatedEvent, func() eventhorizon.EventData {
+		return &RelationTupleCreated{}
+	})
+
+	//default handler implementation
+	o.CreatedHandler = func(event eventhorizon.Event, eventData *RelationTupleCreated, entity *RelationTuple) (err error) {
+
+		entity.Id = event.AggregateID()
+		entity.Namespace = eventData.Namespace
+		entity.Object = eventData.Object
+		entity.Relation = eventData.Relation
+		entity.Subject = eventData.Subject
+		return
+	}
+	return
+}
+
+type RelationTupleAggregateExistHandler struct {
+	DeletedHandler func(eventhorizon.Event, *RelationTuple) (err error)
+}
+
+func NewRelationTupleAggregateExistHandlerDefault() (ret *RelationTupleAggregateExistHandler) {
+	ret = &RelationTupleAggregateExistHandler{}
+	return
+}
+
+func (o *RelationTupleAggregateExistHandler) StateType() (ret *RelationTupleAggregateStateType) {
+	ret = RelationTupleAggregateStateTypes().Exist()
+	return
+}
+
+func (o *RelationTupleAggregateExistHandler) Apply(event eventhorizon.Event, relationTuple *RelationTuple) (ret *RelationTupleAggregateStateType, err error) {
+
+	switch event.EventType() {
+	case RelationTupleDeletedEvent:
+		err = o.DeletedHandler(event, relationTuple)
+		ret = RelationTupleAggregateStateTypes().Deleted()
+	default:
+		err = errors.New(fmt.Sprintf("Not supported event type '%v' for entity '%v", event.EventType(), relationTuple))
+	}
+	return
+}
+
+func (o *RelationTupleAggregateExistHandler) SetupEventHandler() (err error) {
+
+	//default handler implementation
+	o.DeletedHandler = func(event eventhorizon.Event, entity *RelationTuple) (err error) {
+
+		*entity = *NewRelationTupleDefault()
+		return
+	}
+	return
+}
+
+type RelationTupleAggregateDeletedHandler struct {
+}
+
+func NewRelationTupleAggregateDeletedHandlerDefault() (ret *RelationTupleAggregateDeletedHandler) {
+	ret = &RelationTupleAggregateDeletedHandler{}
+	return
+}
+
+func (o *RelationTupleAggregateDeletedHandler) StateType() (ret *RelationTupleAggregateStateType) {
+	ret = RelationTupleAggregateStateTypes().Deleted()
+	return
+}
+
+func (o *RelationTupleAggregateDeletedHandler) Apply(event eventhorizon.Event, relationTuple *RelationTuple) (ret *RelationTupleAggregateStateType, err error) {
+
+	return
+}
+
+func (o *RelationTupleAggregateDeletedHandler) SetupEventHandler() (err error) {
+	return
+}
+
+type InvitationAggregateInitialHandler struct {
+	CreatedHandler func(eventhorizon.Event, *InvitationCreated, *Invitation) (err error)
+}
+
+func NewInvitationAggregateInitialHandlerDefault() (ret *InvitationAggregateInitialHandler) {
+	ret = &InvitationAggregateInitialHandler{}
+	return
+}
+
+func (o *InvitationAggregateInitialHandler) StateType() (ret *InvitationAggregateStateType) {
+	ret = InvitationAggregateStateTypes().Initial()
+	return
+}
+
+func (o *InvitationAggregateInitialHandler) Apply(event eventhorizon.Event, invitation *Invitation) (ret *InvitationAggregateStateType, err error) {
+
+	switch event.EventType() {
+	case InvitationCreatedEvent:
+		err = o.CreatedHandler(event, event.Data().(*InvitationCreated), invitation)
+		ret = InvitationAggregateStateTypes().Pending()
+	default:
+		err = errors.New(fmt.Sprintf("Not supported event type '%v' for entity '%v", event.EventType(), invitation))
+	}
+	return
+}
+
+func (o *InvitationAggregateInitialHandler) SetupEventHandler() (err error) {
+
+	//register event object factory
+	eventhorizon.RegisterEventData(InvitationCreatedEvent, func() eventhorizon.EventData {
+		return &InvitationCreated{}
+	})
+
+	//default handler implementation
+	o.CreatedHandler = func(event eventhorizon.Event, eventData *InvitationCreated, entity *Invitation) (err error) {
+
+		entity.Id = event.AggregateID()
+		entity.Email = eventData.Email
+		entity.Roles = eventData.Roles
+		entity.OrganizationId = eventData.OrganizationId
+		entity.TokenHash = eventData.TokenHash
+		entity.ExpiresAt = eventData.ExpiresAt
+		entity.InvitedBy = eventData.InvitedBy
+		return
+	}
+	return
+}
+
+type InvitationAggregatePendingHandler struct {
+	AcceptedHandler func(eventhorizon.Event, *InvitationAccepted, *Invitation) (err error)
+	DeletedHandler  func(eventhorizon.Event, *Invitation) (err error)
+	ResentHandler   func(eventhorizon.Event, *InvitationResent, *Invitation) (err error)
+	RevokedHandler  func(eventhorizon.Event, *Invitation) (err error)
+}
+
+func NewInvitationAggregatePendingHandlerDefault() (ret *InvitationAggregatePendingHandler) {
+	ret = &InvitationAggregatePendingHandler{}
+	return
+}
+
+func (o *InvitationAggregatePendingHandler) StateType() (ret *InvitationAggregateStateType) {
+	ret = InvitationAggregateStateTypes().Pending()
+	return
+}
+
+func (o *InvitationAggregatePendingHandler) Apply(event eventhorizon.Event, invitation *Invitation) (ret *InvitationAggregateStateType, err error) {
+
+	switch event.EventType() {
+	case InvitationAcceptedEvent:
+		err = o.AcceptedHandler(event, event.Data().(*InvitationAccepted), invitation)
+		ret = InvitationAggregateStateTypes().Accepted()
+	case InvitationDeletedEvent:
+		err = o.DeletedHandler(event, invitation)
+		ret = InvitationAggregateStateTypes().Deleted()
+	case InvitationResentEvent:
+		err = o.ResentHandler(event, event.Data().(*InvitationResent), invitation)
+		ret = InvitationAggregateStateTypes().Pending()
+	case InvitationRevokedEvent:
+		err = o.RevokedHandler(event, invitation)
+		ret = InvitationAggregateStateTypes().Revoked()
+	default:
+		err = errors.New(fmt.Sprintf("Not supported event type '%v' for entity '%v", event.EventType(), invitation))
+	}
+	return
+}
+
+func (o *InvitationAggregatePendingHandler) SetupEventHandler() (err error) {
+
+	//register event object factory
+	eventhorizon.RegisterEventData(InvitationAcceptedEvent, func() eventhorizon.EventData {
+		return &InvitationAccepted{}
+	})
+
+	//default handler implementation
+	o.AcceptedHandler = func(event eventhorizon.Event, eventData *InvitationAccepted, entity *Invitation) (err error) {
+
+		return
+	}
+
+	//default handler implementation
+	o.DeletedHandler = func(event eventhorizon.Event, entity *Invitation) (err error) {
+
+		*entity = *NewInvitationDefault()
+		return
+	}
+
+	//register event object factory
+	eventhorizon.RegisterEventData(InvitationResentEvent, func() eventhorizon.EventData {
+		return &InvitationResent{}
+	})
+
+	//default handler implementation
+	o.ResentHandler = func(event eventhorizon.Event, eventData *InvitationResent, entity *Invitation) (err error) {
+
+		return
+	}
+
+	//default handler implementation
+	o.RevokedHandler = func(event eventhorizon.Event, entity *Invitation) (err error) {
+
+		return
+	}
+	return
+}
+
+type InvitationAggregateAcceptedHandler struct {
+	DeletedHandler func(eventhorizon.Event, *Invitation) (err error)
+}
+
+func NewInvitationAggregateAcceptedHandlerDefault() (ret *InvitationAggregateAcceptedHandler) {
+	ret = &InvitationAggregateAcceptedHandler{}
+	return
+}
+
+func (o *InvitationAggregateAcceptedHandler) StateType() (ret *InvitationAggregateStateType) {
+	ret = InvitationAggregateStateTypes().Accepted()
+	return
+}
+
+func (o *InvitationAggregateAcceptedHandler) Apply(event eventhorizon.Event, invitation *Invitation) (ret *InvitationAggregateStateType, err error) {
+
+	switch event.EventType() {
+	case InvitationDeletedEvent:
+		err = o.DeletedHandler(event, invitation)
+		ret = InvitationAggregateStateTypes().Deleted()
+	default:
+		err = errors.New(fmt.Sprintf("Not supported event type '%v' for entity '%v", event.EventType(), invitation))
+	}
+	return
+}
+
+func (o *InvitationAggregateAcceptedHandler) SetupEventHandler() (err error) {
+
+	//default handler implementation
+	o.DeletedHandler = func(event eventhorizon.Event, entity *Invitation) (err error) {
+
+		*entity = *NewInvitationDefault()
+		return
+	}
+	return
+}
+
+type InvitationAggregateRevokedHandler struct {
+	DeletedHandler func(eventhorizon.Event, *Invitation) (err error)
+}
+
+func NewInvitationAggregateRevokedHandlerDefault() (ret *InvitationAggregateRevokedHandler) {
+	ret = &InvitationAggregateRevokedHandler{}
+	return
+}
+
+func (o *InvitationAggregateRevokedHandler) StateType() (ret *InvitationAggregateStateType) {
+	ret = InvitationAggregateStateTypes().Revoked()
+	return
+}
+
+func (o *InvitationAggregateRevokedHandler) Apply(event eventhorizon.Event, invitation *Invitation) (ret *InvitationAggregateStateType, err error) {
+
+	switch event.EventType() {
+	case InvitationDeletedEvent:
+		err = o.DeletedHandler(event, invitation)
+		ret = InvitationAggregateStateTypes().Deleted()
+	default:
+		err = errors.New(fmt.Sprintf("Not supported event type '%v' for entity '%v", event.EventType(), invitation))
+	}
+	return
+}
+
+func (o *InvitationAggregateRevokedHandler) SetupEventHandler() (err error) {
+
+	//default handler implementation
+	o.DeletedHandler = func(event eventhorizon.Event, entity *Invitation) (err error) {
+
+		*entity = *NewInvitationDefault()
+		return
+	}
+	return
+}
+
+type InvitationAggregateDeletedHandler struct {
+}
+
+func NewInvitationAggregateDeletedHandlerDefault() (ret *InvitationAggregateDeletedHandler) {
+	ret = &InvitationAggregateDeletedHandler{}
+	return
+}
+
+func (o *InvitationAggregateDeletedHandler) StateType() (ret *InvitationAggregateStateType) {
+	ret = InvitationAggregateStateTypes().Deleted()
+	return
+}
+
+func (o *InvitationAggregateDeletedHandler) Apply(event eventhorizon.Event, invitation *Invitation) (ret *InvitationAggregateStateType, err error) {
+
+	return
+}
+
+func (o *InvitationAggregateDeletedHandler) SetupEventHandler() (err error) {
+	return
+}
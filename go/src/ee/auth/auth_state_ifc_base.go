@@ -12,3 +12,51 @@ type AccountAggregateExecutor interface {
 type AccountAggregateHandler interface {
 	Apply(event eventhorizon.Event, account *Account) (err error)
 }
+
+type GroupAggregateExecutor interface {
+	Execute(cmd eventhorizon.Command, group *Group, store eh.AggregateStoreEvent) (err error)
+}
+
+type GroupAggregateHandler interface {
+	Apply(event eventhorizon.Event, group *Group) (err error)
+}
+
+type OrganizationAggregateExecutor interface {
+	Execute(cmd eventhorizon.Command, organization *Organization, store eh.AggregateStoreEvent) (err error)
+}
+
+type OrganizationAggregateHandler interface {
+	Apply(event eventhorizon.Event, organization *Organization) (err error)
+}
+
+type RoleGrantRequestAggregateExecutor interface {
+	Execute(cmd eventhorizon.Command, roleGrantRequest *RoleGrantRequest, store eh.AggregateStoreEvent) (err error)
+}
+
+type RoleGrantRequestAggregateHandler interface {
+	Apply(event eventhorizon.Event, roleGrantRequest *RoleGrantRequest) (err error)
+}
+
+type RoleAggregateExecutor interface {
+	Execute(cmd eventhorizon.Command, role *Role, store eh.AggregateStoreEvent) (err error)
+}
+
+type RoleAggregateHandler interface {
+	Apply(event eventhorizon.Event, role *Role) (err error)
+}
+
+type RelationTupleAggregateExecutor interface {
+	Execute(cmd eventhorizon.Command, relationTuple *RelationTuple, store eh.AggregateStoreEvent) (err error)
+}
+
+type RelationTupleAggregateHandler interface {
+	Apply(event eventhorizon.Event, relationTuple *RelationTuple) (err error)
+}
+
+type InvitationAggregateExecutor interface {
+	Execute(cmd eventhorizon.Command, invitation *Invitation, store eh.AggregateStoreEvent) (err error)
+}
+
+type InvitationAggregateHandler interface {
+	Apply(event eventhorizon.Event, invitation *Invitation) (err error)
+}
@@ -22,6 +22,152 @@ func NewAccountDefaultByPropNames(intSalt int) (ret *Account) {
 	ret.Password = fmt.Sprintf("Password %v", intSalt)
 	ret.Email = fmt.Sprintf("Email %v", intSalt)
 	ret.Roles = []string{}
+	ret.OrganizationId = uuid.New()
+	ret.DisabledReason = fmt.Sprintf("DisabledReason %v", intSalt)
+	ret.EnabledAt = utils.PtrTime(time.Now())
+	ret.LastLoginAt = utils.PtrTime(time.Now())
+	ret.Attributes = make(map[string]interface{})
+	ret.PendingEmail = fmt.Sprintf("PendingEmail %v", intSalt)
+	ret.EmailChangeTokenHash = fmt.Sprintf("EmailChangeTokenHash %v", intSalt)
+	ret.EmailChangeExpiresAt = utils.PtrTime(time.Now())
+	ret.Identities = []*ExternalIdentity{}
+	ret.ApiKeys = []*ApiKey{}
+	ret.Impersonations = []*Impersonation{}
+	ret.Sessions = []*Session{}
+	ret.RoleAssignments = []*RoleAssignment{}
+	ret.PasswordChangedAt = utils.PtrTime(time.Now())
+	ret.PasswordHistory = []string{}
+	ret.MergedInto = uuid.New()
+	ret.MergedAt = utils.PtrTime(time.Now())
+	ret.Id = uuid.New()
+	ret.AggregateState = fmt.Sprintf("AggregateState %v", intSalt)
+	ret.DeletedAt = utils.PtrTime(time.Now())
+	return
+}
+
+func NewGroupDefaultsByPropNames(count int) []*Group {
+	items := make([]*Group, count)
+	for i := 0; i < count; i++ {
+		items[i] = NewGroupDefaultByPropNames(i)
+	}
+	return items
+}
+
+func NewGroupDefaultByPropNames(intSalt int) (ret *Group) {
+	ret = NewGroupDefault()
+	ret.Name = fmt.Sprintf("Name %v", intSalt)
+	ret.Description = fmt.Sprintf("Description %v", intSalt)
+	ret.Roles = []string{}
+	ret.Members = []uuid.UUID{}
+	ret.Subgroups = []uuid.UUID{}
+	ret.Id = uuid.New()
+	ret.AggregateState = fmt.Sprintf("AggregateState %v", intSalt)
+	ret.DeletedAt = utils.PtrTime(time.Now())
+	return
+}
+
+func NewOrganizationDefaultsByPropNames(count int) []*Organization {
+	items := make([]*Organization, count)
+	for i := 0; i < count; i++ {
+		items[i] = NewOrganizationDefaultByPropNames(i)
+	}
+	return items
+}
+
+func NewOrganizationDefaultByPropNames(intSalt int) (ret *Organization) {
+	ret = NewOrganizationDefault()
+	ret.Name = fmt.Sprintf("Name %v", intSalt)
+	ret.Description = fmt.Sprintf("Description %v", intSalt)
+	ret.Id = uuid.New()
+	ret.AggregateState = fmt.Sprintf("AggregateState %v", intSalt)
+	ret.DeletedAt = utils.PtrTime(time.Now())
+	return
+}
+
+func NewRoleGrantRequestDefaultsByPropNames(count int) []*RoleGrantRequest {
+	items := make([]*RoleGrantRequest, count)
+	for i := 0; i < count; i++ {
+		items[i] = NewRoleGrantRequestDefaultByPropNames(i)
+	}
+	return items
+}
+
+func NewRoleGrantRequestDefaultByPropNames(intSalt int) (ret *RoleGrantRequest) {
+	ret = NewRoleGrantRequestDefault()
+	ret.AccountId = uuid.New()
+	ret.Role = fmt.Sprintf("Role %v", intSalt)
+	ret.Reason = fmt.Sprintf("Reason %v", intSalt)
+	ret.ValidFrom = utils.PtrTime(time.Now())
+	ret.ValidUntil = utils.PtrTime(time.Now())
+	ret.RequestedBy = uuid.New()
+	ret.DecidedBy = uuid.New()
+	ret.DecidedAt = utils.PtrTime(time.Now())
+	ret.Comment = fmt.Sprintf("Comment %v", intSalt)
+	ret.Id = uuid.New()
+	ret.AggregateState = fmt.Sprintf("AggregateState %v", intSalt)
+	ret.DeletedAt = utils.PtrTime(time.Now())
+	return
+}
+
+func NewRoleDefaultsByPropNames(count int) []*Role {
+	items := make([]*Role, count)
+	for i := 0; i < count; i++ {
+		items[i] = NewRoleDefaultByPropNames(i)
+	}
+	return items
+}
+
+func NewRoleDefaultByPropNames(intSalt int) (ret *Role) {
+	ret = NewRoleDefault()
+	ret.Name = fmt.Sprintf("Name %v", intSalt)
+	ret.Description = fmt.Sprintf("Description %v", intSalt)
+	ret.Inherits = []string{}
+	ret.Permissions = []string{}
+	ret.Id = uuid.New()
+	ret.AggregateState = fmt.Sprintf("AggregateState %v", intSalt)
+	ret.DeletedAt = utils.PtrTime(time.Now())
+	return
+}
+
+func NewRelationTupleDefaultsByPropNames(count int) []*RelationTuple {
+	items := make([]*RelationTuple, count)
+	for i := 0; i < count; i++ {
+		items[i] = NewRelationTupleDefaultByPropNames(i)
+	}
+	return items
+}
+
+func NewRelationTupleDefaultByPropNames(intSalt int) (ret *RelationTuple) {
+	ret = NewRelationTupleDefault()
+	ret.Namespace = fmt.Sprintf("Namespace %v", intSalt)
+	ret.Object = fmt.Sprintf("Object %v", intSalt)
+	ret.Relation = fmt.Sprintf("Relation %v", intSalt)
+	ret.Subject = fmt.Sprintf("Subject %v", intSalt)
+	ret.Id = uuid.New()
+	ret.AggregateState = fmt.Sprintf("AggregateState %v", intSalt)
+	ret.DeletedAt = utils.PtrTime(time.Now())
+	return
+}
+
+func NewInvitationDefaultsByPropNames(count int) []*Invitation {
+	items := make([]*Invitation, count)
+	for i := 0; i < count; i++ {
+		items[i] = NewInvitationDefaultByPropNames(i)
+	}
+	return items
+}
+
+func NewInvitationDefaultByPropNames(intSalt int) (ret *Invitation) {
+	ret = NewInvitationDefault()
+	ret.Email = fmt.Sprintf("Email %v", intSalt)
+	ret.Roles = []string{}
+	ret.OrganizationId = uuid.New()
+	ret.TokenHash = fmt.Sprintf("TokenHash %v", intSalt)
+	ret.ExpiresAt = utils.PtrTime(time.Now())
+	ret.InvitedBy = uuid.New()
+	ret.SentAt = utils.PtrTime(time.Now())
+	ret.AcceptedAt = utils.PtrTime(time.Now())
+	ret.AccountId = uuid.New()
 	ret.Id = uuid.New()
 	ret.AggregateState = fmt.Sprintf("AggregateState %v", intSalt)
 	ret.DeletedAt = utils.PtrTime(time.Now())
@@ -57,3 +203,96 @@ func NewPersonNameDefaultByPropNames(intSalt int) (ret *PersonName) {
 	ret.Last = fmt.Sprintf("Last %v", intSalt)
 	return
 }
+
+func NewExternalIdentityDefaultsByPropNames(count int) []*ExternalIdentity {
+	items := make([]*ExternalIdentity, count)
+	for i := 0; i < count; i++ {
+		items[i] = NewExternalIdentityDefaultByPropNames(i)
+	}
+	return items
+}
+
+func NewExternalIdentityDefaultByPropNames(intSalt int) (ret *ExternalIdentity) {
+	ret = NewExternalIdentityDefault()
+	ret.Issuer = fmt.Sprintf("Issuer %v", intSalt)
+	ret.Subject = fmt.Sprintf("Subject %v", intSalt)
+	ret.Email = fmt.Sprintf("Email %v", intSalt)
+	ret.LinkedAt = utils.PtrTime(time.Now())
+	return
+}
+
+func NewApiKeyDefaultsByPropNames(count int) []*ApiKey {
+	items := make([]*ApiKey, count)
+	for i := 0; i < count; i++ {
+		items[i] = NewApiKeyDefaultByPropNames(i)
+	}
+	return items
+}
+
+func NewApiKeyDefaultByPropNames(intSalt int) (ret *ApiKey) {
+	ret = NewApiKeyDefault()
+	ret.KeyId = uuid.New()
+	ret.Name = fmt.Sprintf("Name %v", intSalt)
+	ret.Scopes = []string{}
+	ret.Hash = fmt.Sprintf("Hash %v", intSalt)
+	ret.CreatedAt = utils.PtrTime(time.Now())
+	ret.ExpiresAt = utils.PtrTime(time.Now())
+	ret.LastUsedAt = utils.PtrTime(time.Now())
+	return
+}
+
+func NewImpersonationDefaultsByPropNames(count int) []*Impersonation {
+	items := make([]*Impersonation, count)
+	for i := 0; i < count; i++ {
+		items[i] = NewImpersonationDefaultByPropNames(i)
+	}
+	return items
+}
+
+func NewImpersonationDefaultByPropNames(intSalt int) (ret *Impersonation) {
+	ret = NewImpersonationDefault()
+	ret.ImpersonationId = uuid.New()
+	ret.ActorId = uuid.New()
+	ret.Reason = fmt.Sprintf("Reason %v", intSalt)
+	ret.StartedAt = utils.PtrTime(time.Now())
+	ret.ExpiresAt = utils.PtrTime(time.Now())
+	return
+}
+
+func NewSessionDefaultsByPropNames(count int) []*Session {
+	items := make([]*Session, count)
+	for i := 0; i < count; i++ {
+		items[i] = NewSessionDefaultByPropNames(i)
+	}
+	return items
+}
+
+func NewSessionDefaultByPropNames(intSalt int) (ret *Session) {
+	ret = NewSessionDefault()
+	ret.SessionId = uuid.New()
+	ret.Device = fmt.Sprintf("Device %v", intSalt)
+	ret.Ip = fmt.Sprintf("Ip %v", intSalt)
+	ret.UserAgent = fmt.Sprintf("UserAgent %v", intSalt)
+	ret.CreatedAt = utils.PtrTime(time.Now())
+	ret.LastSeenAt = utils.PtrTime(time.Now())
+	return
+}
+
+func NewRoleAssignmentDefaultsByPropNames(count int) []*RoleAssignment {
+	items := make([]*RoleAssignment, count)
+	for i := 0; i < count; i++ {
+		items[i] = NewRoleAssignmentDefaultByPropNames(i)
+	}
+	return items
+}
+
+func NewRoleAssignmentDefaultByPropNames(intSalt int) (ret *RoleAssignment) {
+	ret = NewRoleAssignmentDefault()
+	ret.AssignmentId = uuid.New()
+	ret.Role = fmt.Sprintf("Role %v", intSalt)
+	ret.ValidFrom = utils.PtrTime(time.Now())
+	ret.ValidUntil = utils.PtrTime(time.Now())
+	ret.AssignedBy = uuid.New()
+	ret.GrantRequestId = uuid.New()
+	return
+}
@@ -0,0 +1,208 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/google/uuid"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+type TokenClaims struct {
+	jwt.StandardClaims
+	Username   string                 `json:"username,omitempty"`
+	Email      string                 `json:"email,omitempty"`
+	Roles      []string               `json:"roles,omitempty"`
+	Actor      *Actor                 `json:"act,omitempty"`
+	SessionId  string                 `json:"sid,omitempty"`
+	Tenant     string                 `json:"tenant,omitempty"`
+	Scope      string                 `json:"scope,omitempty"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// ScopePasswordChange restricts a token to the change of the own password
+const ScopePasswordChange = "password_change"
+
+// Actor is the party acting on behalf of the subject of a token, see RFC 8693
+type Actor struct {
+	Subject  string `json:"sub"`
+	Username string `json:"username,omitempty"`
+}
+
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Scope       string `json:"scope,omitempty"`
+}
+
+type Tokens struct {
+	Issuer          string
+	Ttl             time.Duration
+	Roles           RoleResolver
+	Hierarchy       *RoleHierarchy
+	AttributeClaims []string
+	signKey         *rsa.PrivateKey
+	verifyKey       *rsa.PublicKey
+}
+
+func NewTokens(issuer string, ttl time.Duration, signKey *rsa.PrivateKey) (ret *Tokens) {
+	ret = &Tokens{
+		Issuer:    issuer,
+		Ttl:       ttl,
+		signKey:   signKey,
+		verifyKey: &signKey.PublicKey,
+	}
+	return
+}
+
+// NewTokensFromFolder loads the signing key '<issuer>_tokens.rsa' from the folder and creates it on first start.
+func NewTokensFromFolder(folder string, issuer string, ttl time.Duration) (ret *Tokens, err error) {
+	keyFile := filepath.Join(folder, issuer+"_tokens.rsa")
+
+	var signKey *rsa.PrivateKey
+	var keyBytes []byte
+	if keyBytes, err = ioutil.ReadFile(keyFile); err == nil {
+		signKey, err = jwt.ParseRSAPrivateKeyFromPEM(keyBytes)
+	} else if os.IsNotExist(err) {
+		signKey, err = generateSignKey(keyFile)
+	}
+
+	if err == nil {
+		ret = NewTokens(issuer, ttl, signKey)
+	}
+	return
+}
+
+func generateSignKey(keyFile string) (ret *rsa.PrivateKey, err error) {
+	if ret, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+		return
+	}
+	if err = os.MkdirAll(filepath.Dir(keyFile), 0700); err != nil {
+		return
+	}
+	keyBytes := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(ret)})
+	err = ioutil.WriteFile(keyFile, keyBytes, 0600)
+	return
+}
+
+// EffectiveRoles resolves the roles of the account, without a resolver the active roles of the account only,
+// and adds the roles they inherit
+func (o *Tokens) EffectiveRoles(account *Account) (ret []string, err error) {
+	if o.Roles == nil {
+		ret = account.ActiveRoles(time.Now())
+	} else if ret, err = o.Roles.EffectiveRoles(account); err != nil {
+		return
+	}
+
+	if o.Hierarchy != nil {
+		ret, err = o.Hierarchy.Expand(ret)
+	}
+	return
+}
+
+// EffectivePermissions are the permissions of the roles and of the roles they inherit
+func (o *Tokens) EffectivePermissions(roles []string) (ret []string, err error) {
+	if o.Hierarchy != nil {
+		ret, err = o.Hierarchy.Permissions(roles)
+	}
+	return
+}
+
+func (o *Tokens) NewClaims(account *Account) (ret *TokenClaims, err error) {
+	var roles []string
+	if roles, err = o.EffectiveRoles(account); err != nil {
+		return
+	}
+
+	now := time.Now()
+	ret = &TokenClaims{
+		StandardClaims: jwt.StandardClaims{
+			Id:        uuid.New().String(),
+			Issuer:    o.Issuer,
+			Subject:   account.Id.String(),
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(o.Ttl).Unix(),
+		},
+		Username: account.Username,
+		Email:    account.Email,
+		Roles:    roles,
+	}
+	if account.OrganizationId != uuid.Nil {
+		ret.Tenant = account.OrganizationId.String()
+	}
+	for _, name := range o.AttributeClaims {
+		if value, ok := account.Attributes[name]; ok {
+			if ret.Attributes == nil {
+				ret.Attributes = map[string]interface{}{}
+			}
+			ret.Attributes[name] = value
+		}
+	}
+	limitToRoleAssignments(ret, account, now)
+	return
+}
+
+// limitToRoleAssignments lets tokens expire at the latest with the first active role assignment
+func limitToRoleAssignments(claims *TokenClaims, account *Account, now time.Time) {
+	if until := account.RolesValidUntil(now); until != nil && until.Unix() < claims.ExpiresAt {
+		claims.ExpiresAt = until.Unix()
+	}
+}
+
+func (o *Tokens) Issue(claims *TokenClaims) (ret *TokenResponse, err error) {
+	var token string
+	if token, err = jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(o.signKey); err == nil {
+		ret = &TokenResponse{
+			AccessToken: token,
+			TokenType:   "Bearer",
+			ExpiresIn:   claims.ExpiresAt - time.Now().Unix(),
+			Scope:       claims.Scope,
+		}
+	}
+	return
+}
+
+func (o *Tokens) Parse(token string) (ret *TokenClaims, err error) {
+	claims := &TokenClaims{}
+	var parsed *jwt.Token
+	if parsed, err = jwt.ParseWithClaims(token, claims, o.verifyKeyFor); err != nil {
+		return
+	}
+	if !parsed.Valid {
+		err = errors.New("invalid token")
+	} else if claims.Issuer != o.Issuer {
+		err = fmt.Errorf("unexpected token issuer '%v'", claims.Issuer)
+	} else {
+		ret = claims
+	}
+	return
+}
+
+func (o *Tokens) verifyKeyFor(token *jwt.Token) (ret interface{}, err error) {
+	if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+		err = fmt.Errorf("unexpected signing method '%v'", token.Header["alg"])
+	} else {
+		ret = o.verifyKey
+	}
+	return
+}
+
+func (o *TokenClaims) AccountId() (ret uuid.UUID, err error) {
+	ret, err = uuid.Parse(o.Subject)
+	return
+}
+
+func (o *TokenClaims) OrganizationId() (ret uuid.UUID, err error) {
+	if o.Tenant != "" {
+		ret, err = uuid.Parse(o.Tenant)
+	}
+	return
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"ee/auth"
 	appAuth "ee/auth/app"
 	"github.com/go-ee/utils/eh/app"
 	"github.com/go-ee/utils/eh/app/filestore"
@@ -12,13 +13,17 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 )
 
 func main() {
 	const productName = "Auth"
 
-	var name, serverAddress, mongoUrl, targetFile, workingFolder, folderEventStore string
-	var debug, secure bool
+	var name, serverAddress, mongoUrl, targetFile, workingFolder, folderEventStore, federationConfig string
+	var clientConfig, permissions, policies, relations, approvalRoles, registration, attributeClaims string
+	var debug bool
+	var passwordMaxAge, dormancyPeriod, dormancyWarning, deletionRetention time.Duration
 	var serverPort int
 
 	commonFlags := []cli.Flag{
@@ -28,11 +33,6 @@ func main() {
 			Usage:       "name of the app, used for backend data",
 			Value:       "Auth",
 			Destination: &name,
-		}, &cli.BoolFlag{
-			Name:        "secure",
-			Aliases:     []string{"s"},
-			Usage:       "activate secure mode",
-			Destination: &secure,
 		}, &cli.StringFlag{
 			Name:        "address",
 			Aliases:     []string{"a"},
@@ -50,6 +50,66 @@ func main() {
 			Usage:       "working folder",
 			Value:       "",
 			Destination: &workingFolder,
+		}, &cli.StringFlag{
+			Name:        "federation",
+			Usage:       "JSON file with the external identity providers",
+			Value:       "",
+			Destination: &federationConfig,
+		}, &cli.StringFlag{
+			Name:        "clients",
+			Usage:       "JSON file with the oauth clients allowed to introspect and revoke tokens and to check permissions",
+			Value:       "",
+			Destination: &clientConfig,
+		}, &cli.StringFlag{
+			Name:        "permissions",
+			Usage:       "JSON file with the permissions granted by roles",
+			Value:       "",
+			Destination: &permissions,
+		}, &cli.StringFlag{
+			Name:        "policies",
+			Usage:       "JSON file with the access policies, reloaded on changes",
+			Value:       "",
+			Destination: &policies,
+		}, &cli.StringFlag{
+			Name:        "relations",
+			Usage:       "JSON file with the relations of namespaces and their userset rewrites",
+			Value:       "",
+			Destination: &relations,
+		}, &cli.StringFlag{
+			Name:        "approvalRoles",
+			Usage:       "comma separated roles granted over approved grant requests only, in addition to the auth permissions",
+			Value:       "admin",
+			Destination: &approvalRoles,
+		}, &cli.StringFlag{
+			Name:        "registration",
+			Usage:       "self-registration of accounts: open, approval or closed",
+			Value:       "approval",
+			Destination: &registration,
+		}, &cli.StringFlag{
+			Name:        "attributeClaims",
+			Usage:       "comma separated custom attributes of accounts mapped into the claims of tokens",
+			Value:       "",
+			Destination: &attributeClaims,
+		}, &cli.DurationFlag{
+			Name:        "passwordMaxAge",
+			Usage:       "maximum age of passwords, e.g. 2160h, expired passwords must be changed on login, 0 disables the expiry",
+			Value:       0,
+			Destination: &passwordMaxAge,
+		}, &cli.DurationFlag{
+			Name:        "dormancyPeriod",
+			Usage:       "inactivity after that accounts are disabled, e.g. 2160h, 0 disables the check",
+			Value:       0,
+			Destination: &dormancyPeriod,
+		}, &cli.DurationFlag{
+			Name:        "dormancyWarning",
+			Usage:       "time before the disabling of dormant accounts to warn their owners",
+			Value:       7 * 24 * time.Hour,
+			Destination: &dormancyWarning,
+		}, &cli.DurationFlag{
+			Name:        "deletionRetention",
+			Usage:       "time deleted accounts can be restored, 0 keeps them restorable until they are purged",
+			Value:       30 * 24 * time.Hour,
+			Destination: &deletionRetention,
 		}, &cli.BoolFlag{
 			Name:        "debug",
 			Aliases:     []string{"d"},
@@ -92,7 +152,23 @@ func main() {
 					}, &app.ServerConfig{
 						ServerAddress: serverAddress,
 						ServerPort:    serverPort,
-					}, secure, mongoUrl))
+					}, false, mongoUrl))
+				Auth.FederationConfigFile = federationConfig
+				Auth.ClientConfigFile = clientConfig
+				Auth.PermissionsFile = permissions
+				Auth.PoliciesFile = policies
+				Auth.RelationsFile = relations
+				Auth.ApprovalRoles = strings.Split(approvalRoles, ",")
+				Auth.PasswordMaxAge = passwordMaxAge
+				Auth.DormancyPeriod = dormancyPeriod
+				Auth.DormancyWarning = dormancyWarning
+				Auth.DeletionRetention = deletionRetention
+				if attributeClaims != "" {
+					Auth.AttributeClaims = strings.Split(attributeClaims, ",")
+				}
+				if Auth.Registration, err = auth.ParseRegistrationMode(registration); err != nil {
+					return
+				}
 				err = Auth.Start()
 				return
 			},
@@ -108,7 +184,23 @@ func main() {
 				}, &app.ServerConfig{
 					ServerAddress: serverAddress,
 					ServerPort:    serverPort,
-				}, secure))
+				}, false))
+				Auth.FederationConfigFile = federationConfig
+				Auth.ClientConfigFile = clientConfig
+				Auth.PermissionsFile = permissions
+				Auth.PoliciesFile = policies
+				Auth.RelationsFile = relations
+				Auth.ApprovalRoles = strings.Split(approvalRoles, ",")
+				Auth.PasswordMaxAge = passwordMaxAge
+				Auth.DormancyPeriod = dormancyPeriod
+				Auth.DormancyWarning = dormancyWarning
+				Auth.DeletionRetention = deletionRetention
+				if attributeClaims != "" {
+					Auth.AttributeClaims = strings.Split(attributeClaims, ",")
+				}
+				if Auth.Registration, err = auth.ParseRegistrationMode(registration); err != nil {
+					return
+				}
 				err = Auth.Start()
 				return
 			},
@@ -131,7 +223,23 @@ func main() {
 					}, &app.ServerConfig{
 						ServerAddress: serverAddress,
 						ServerPort:    serverPort,
-					}, secure, folderEventStore))
+					}, false, folderEventStore))
+				Auth.FederationConfigFile = federationConfig
+				Auth.ClientConfigFile = clientConfig
+				Auth.PermissionsFile = permissions
+				Auth.PoliciesFile = policies
+				Auth.RelationsFile = relations
+				Auth.ApprovalRoles = strings.Split(approvalRoles, ",")
+				Auth.PasswordMaxAge = passwordMaxAge
+				Auth.DormancyPeriod = dormancyPeriod
+				Auth.DormancyWarning = dormancyWarning
+				Auth.DeletionRetention = deletionRetention
+				if attributeClaims != "" {
+					Auth.AttributeClaims = strings.Split(attributeClaims, ",")
+				}
+				if Auth.Registration, err = auth.ParseRegistrationMode(registration); err != nil {
+					return
+				}
 				err = Auth.Start()
 				return
 			},
@@ -0,0 +1,204 @@
+// Command mockidp is a minimal OpenID Connect provider for local federation tests,
+// every authorization request is approved for the configured user.
+package main
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/go-ee/utils/lg"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+type user struct {
+	Subject           string `json:"sub"`
+	Email             string `json:"email,omitempty"`
+	EmailVerified     bool   `json:"email_verified"`
+	Name              string `json:"name,omitempty"`
+	PreferredUsername string `json:"preferred_username,omitempty"`
+}
+
+type provider struct {
+	issuer       string
+	clientId     string
+	clientSecret string
+	user         *user
+	codes        map[string]bool
+	tokens       map[string]bool
+	mutex        sync.Mutex
+}
+
+func main() {
+	var serverPort int
+	var issuer, clientId, clientSecret, subject, email, name string
+
+	runner := cli.NewApp()
+	runner.Usage = "Mock OpenID Connect provider for local federation tests"
+	runner.Version = "1.0"
+
+	lg.LogrusTimeAsTimestampFormatter()
+
+	runner.Flags = []cli.Flag{
+		&cli.IntFlag{
+			Name:        "port",
+			Usage:       "server port",
+			Value:       7071,
+			Destination: &serverPort,
+		}, &cli.StringFlag{
+			Name:        "issuer",
+			Usage:       "issuer url, must match the url the provider is reachable at",
+			Value:       "http://localhost:7071",
+			Destination: &issuer,
+		}, &cli.StringFlag{
+			Name:        "clientId",
+			Value:       "auth",
+			Destination: &clientId,
+		}, &cli.StringFlag{
+			Name:        "clientSecret",
+			Value:       "secret",
+			Destination: &clientSecret,
+		}, &cli.StringFlag{
+			Name:        "subject",
+			Value:       "mock-user",
+			Destination: &subject,
+		}, &cli.StringFlag{
+			Name:        "email",
+			Value:       "mock.user@example.com",
+			Destination: &email,
+		}, &cli.StringFlag{
+			Name:        "name",
+			Value:       "Mock User",
+			Destination: &name,
+		},
+	}
+
+	runner.Action = func(c *cli.Context) (err error) {
+		o := &provider{
+			issuer:       strings.TrimSuffix(issuer, "/"),
+			clientId:     clientId,
+			clientSecret: clientSecret,
+			user: &user{
+				Subject:           subject,
+				Email:             email,
+				EmailVerified:     true,
+				Name:              name,
+				PreferredUsername: subject,
+			},
+			codes:  map[string]bool{},
+			tokens: map[string]bool{},
+		}
+
+		http.HandleFunc("/.well-known/openid-configuration", o.discovery)
+		http.HandleFunc("/authorize", o.authorize)
+		http.HandleFunc("/token", o.token)
+		http.HandleFunc("/userinfo", o.userInfo)
+
+		logrus.Infof("mock identity provider %v listening on port %v", o.issuer, serverPort)
+		err = http.ListenAndServe(fmt.Sprintf(":%v", serverPort), nil)
+		return
+	}
+
+	if err := runner.Run(os.Args); err != nil {
+		logrus.Infof("%v", err)
+	}
+}
+
+func (o *provider) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                 o.issuer,
+		"authorization_endpoint": o.issuer + "/authorize",
+		"token_endpoint":         o.issuer + "/token",
+		"userinfo_endpoint":      o.issuer + "/userinfo",
+	})
+}
+
+func (o *provider) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("client_id") != o.clientId {
+		http.Error(w, "unknown client", http.StatusBadRequest)
+		return
+	}
+
+	redirect, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || redirect.Host == "" {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+
+	code := o.issue(o.codes)
+	params := redirect.Query()
+	params.Set("code", code)
+	params.Set("state", query.Get("state"))
+	redirect.RawQuery = params.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (o *provider) token(w http.ResponseWriter, r *http.Request) {
+	clientId, clientSecret, ok := r.BasicAuth()
+	if ok {
+		clientId, _ = url.QueryUnescape(clientId)
+		clientSecret, _ = url.QueryUnescape(clientSecret)
+	} else {
+		clientId, clientSecret = r.PostFormValue("client_id"), r.PostFormValue("client_secret")
+	}
+	if clientId != o.clientId || clientSecret != o.clientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	if r.PostFormValue("grant_type") != "authorization_code" || !o.redeem(o.codes, r.PostFormValue("code")) {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": o.issue(o.tokens),
+		"token_type":   "Bearer",
+		"expires_in":   300,
+	})
+}
+
+func (o *provider) userInfo(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	o.mutex.Lock()
+	known := o.tokens[token]
+	o.mutex.Unlock()
+
+	if !known {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	writeJSON(w, http.StatusOK, o.user)
+}
+
+func (o *provider) issue(store map[string]bool) (ret string) {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	ret = base64.RawURLEncoding.EncodeToString(buf)
+
+	o.mutex.Lock()
+	store[ret] = true
+	o.mutex.Unlock()
+	return
+}
+
+func (o *provider) redeem(store map[string]bool, value string) (ret bool) {
+	o.mutex.Lock()
+	ret = store[value]
+	delete(store, value)
+	o.mutex.Unlock()
+	return
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}